@@ -38,6 +38,12 @@ var (
 	ErrInvalidStateRoot     = errors.New("invalid block state root")
 	ErrInvalidGasUsed       = errors.New("invalid block gas used")
 	ErrInvalidReceiptsRoot  = errors.New("invalid block receipts root")
+	ErrInvalidBaseFee       = errors.New("invalid block base fee")
+	ErrHeaderNotFound       = errors.New("header not found")
+	ErrNotCanonical         = errors.New("header is not part of the canonical chain")
+	ErrFinalizedRollback    = errors.New("finalized header can't be moved backwards")
+	ErrSafeBelowFinalized   = errors.New("safe header can't be below the finalized header")
+	ErrReorgBelowFinalized  = errors.New("reorg below the finalized header")
 )
 
 // Blockchain is a blockchain reference
@@ -69,6 +75,9 @@ type Blockchain struct {
 	currentHeader     atomic.Value // The current header
 	currentDifficulty atomic.Value // The current difficulty of the chain (total difficulty)
 
+	finalizedHeader atomic.Value // The latest header finalized by the consensus, if any
+	safeHeader      atomic.Value // The latest header considered safe by the consensus, if any
+
 	stream *eventStream // Event subscriptions
 
 	gpAverage *gasPriceAverage // A reference to the average gas price
@@ -91,6 +100,11 @@ type Verifier interface {
 	PreCommitState(header *types.Header, txn *state.Transition) error
 }
 
+// BatchVerifier is implemented by the consensus mechanisms able to verify headers in batches
+type BatchVerifier interface {
+	VerifyHeaders(headers []*types.Header) error
+}
+
 type Executor interface {
 	ProcessBlock(parentRoot types.Hash, block *types.Block, blockCreator types.Address) (*state.Transition, error)
 }
@@ -294,6 +308,10 @@ func (b *Blockchain) ComputeGenesis() error {
 		)
 
 		b.setCurrentHeader(header, diff)
+
+		if err := b.loadFinality(); err != nil {
+			return err
+		}
 	} else {
 		// empty storage, write the genesis
 		if err := b.writeGenesis(b.config.Genesis); err != nil {
@@ -336,6 +354,120 @@ func (b *Blockchain) Header() *types.Header {
 	return header
 }
 
+// loadFinality loads the finalized and safe headers from the storage, if any
+func (b *Blockchain) loadFinality() error {
+	if hash, ok := b.db.ReadFinalizedHash(); ok && hash != types.ZeroHash {
+		header, ok := b.GetHeaderByHash(hash)
+		if !ok {
+			return fmt.Errorf("failed to get finalized header with hash %s", hash.String())
+		}
+
+		b.finalizedHeader.Store(header)
+	}
+
+	if hash, ok := b.db.ReadSafeHash(); ok && hash != types.ZeroHash {
+		header, ok := b.GetHeaderByHash(hash)
+		if !ok {
+			return fmt.Errorf("failed to get safe header with hash %s", hash.String())
+		}
+
+		b.safeHeader.Store(header)
+	}
+
+	return nil
+}
+
+// FinalizedHeader returns the latest finalized header (atomic), if any
+func (b *Blockchain) FinalizedHeader() (*types.Header, bool) {
+	header, ok := b.finalizedHeader.Load().(*types.Header)
+
+	return header, ok && header != nil
+}
+
+// SafeHeader returns the latest safe header (atomic), if any
+func (b *Blockchain) SafeHeader() (*types.Header, bool) {
+	header, ok := b.safeHeader.Load().(*types.Header)
+
+	return header, ok && header != nil
+}
+
+// SetFinalized marks the canonical header with the given hash as finalized.
+// The finalized header can't be reorganized away, so it can only move forward.
+// The safe header is moved along if it falls behind
+func (b *Blockchain) SetFinalized(hash types.Hash) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	header, err := b.readCanonicalHeader(hash)
+	if err != nil {
+		return err
+	}
+
+	if finalized, ok := b.FinalizedHeader(); ok && header.Number < finalized.Number {
+		return ErrFinalizedRollback
+	}
+
+	if err := b.db.WriteFinalizedHash(header.Hash); err != nil {
+		return err
+	}
+
+	b.finalizedHeader.Store(header)
+
+	if safe, ok := b.SafeHeader(); !ok || safe.Number < header.Number {
+		return b.writeSafeHeader(header)
+	}
+
+	return nil
+}
+
+// SetSafe marks the canonical header with the given hash as safe
+func (b *Blockchain) SetSafe(hash types.Hash) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	header, err := b.readCanonicalHeader(hash)
+	if err != nil {
+		return err
+	}
+
+	if finalized, ok := b.FinalizedHeader(); ok && header.Number < finalized.Number {
+		return ErrSafeBelowFinalized
+	}
+
+	return b.writeSafeHeader(header)
+}
+
+// readCanonicalHeader reads the header with the given hash,
+// making sure it is part of the canonical chain
+func (b *Blockchain) readCanonicalHeader(hash types.Hash) (*types.Header, error) {
+	header, ok := b.readHeader(hash)
+	if !ok {
+		return nil, ErrHeaderNotFound
+	}
+
+	if canonicalHash, ok := b.db.ReadCanonicalHash(header.Number); !ok || canonicalHash != header.Hash {
+		return nil, ErrNotCanonical
+	}
+
+	return header, nil
+}
+
+// writeSafeHeader persists and sets the safe header. A nil header unsets it
+func (b *Blockchain) writeSafeHeader(header *types.Header) error {
+	hash := types.ZeroHash
+	if header != nil {
+		hash = header.Hash
+	}
+
+	if err := b.db.WriteSafeHash(hash); err != nil {
+		return err
+	}
+
+	b.safeHeader.Store(header)
+
+	return nil
+}
+
 // CurrentTD returns the current total difficulty (atomic)
 func (b *Blockchain) CurrentTD() *big.Int {
 	td, ok := b.currentDifficulty.Load().(*big.Int)
@@ -412,6 +544,44 @@ func (b *Blockchain) calculateGasLimit(parentGasLimit uint64) uint64 {
 	return common.Max(blockGasTarget, common.Max(parentGasLimit-delta, 0))
 }
 
+// CalculateBaseFee returns the base fee of the block following the parent (EIP-1559), zero before the London fork.
+// The base fee moves towards the gas target, half the gas limit, by up to 1/8 per block
+func (b *Blockchain) CalculateBaseFee(parent *types.Header) uint64 {
+	forks := b.config.Params.Forks
+
+	if !forks.IsLondon(parent.Number + 1) {
+		return 0
+	}
+
+	if !forks.IsLondon(parent.Number) {
+		// the first London block starts from the initial base fee
+		return b.config.Genesis.BaseFee
+	}
+
+	parentGasTarget := parent.GasLimit / chain.ElasticityMultiplier
+	if parentGasTarget == 0 || parent.GasUsed == parentGasTarget {
+		return parent.BaseFee
+	}
+
+	// delta = parentBaseFee * |parentGasUsed - parentGasTarget| / parentGasTarget / 8
+	delta := new(big.Int).SetUint64(parent.BaseFee)
+
+	if parent.GasUsed > parentGasTarget {
+		delta.Mul(delta, new(big.Int).SetUint64(parent.GasUsed-parentGasTarget))
+		delta.Div(delta, new(big.Int).SetUint64(parentGasTarget))
+		delta.Div(delta, new(big.Int).SetUint64(chain.BaseFeeChangeDenominator))
+
+		// the base fee increases by at least 1
+		return parent.BaseFee + common.Max(delta.Uint64(), 1)
+	}
+
+	delta.Mul(delta, new(big.Int).SetUint64(parentGasTarget-parent.GasUsed))
+	delta.Div(delta, new(big.Int).SetUint64(parentGasTarget))
+	delta.Div(delta, new(big.Int).SetUint64(chain.BaseFeeChangeDenominator))
+
+	return parent.BaseFee - delta.Uint64()
+}
+
 // writeGenesis wrapper for the genesis write function
 func (b *Blockchain) writeGenesis(genesis *chain.Genesis) error {
 	header := genesis.GenesisHeader()
@@ -698,6 +868,17 @@ func (b *Blockchain) VerifyFinalizedBlock(block *types.Block) error {
 	return nil
 }
 
+// VerifyHeaders verifies a batch of headers ahead of their blocks, if the consensus layer supports it.
+// The consensus layer still verifies each header when its block is verified
+func (b *Blockchain) VerifyHeaders(headers []*types.Header) error {
+	batchVerifier, ok := b.consensus.(BatchVerifier)
+	if !ok {
+		return nil
+	}
+
+	return batchVerifier.VerifyHeaders(headers)
+}
+
 // verifyBlock does the base (common) block verification steps by
 // verifying the block body as well as the parent information
 func (b *Blockchain) verifyBlock(block *types.Block) error {
@@ -767,6 +948,16 @@ func (b *Blockchain) verifyBlockParent(childBlock *types.Block) error {
 		return fmt.Errorf("invalid gas limit, %w", gasLimitErr)
 	}
 
+	// Make sure the base fee follows the parent
+	if baseFee := b.CalculateBaseFee(parent); childBlock.Header.BaseFee != baseFee {
+		return fmt.Errorf(
+			"%w: have %d, want %d",
+			ErrInvalidBaseFee,
+			childBlock.Header.BaseFee,
+			baseFee,
+		)
+	}
+
 	return nil
 }
 
@@ -886,6 +1077,27 @@ func (b *Blockchain) WriteBlock(block *types.Block, source string) error {
 		return nil
 	}
 
+	return b.writeBlockImpl(block, source)
+}
+
+// ImportBlock writes a single block to the local blockchain, even if it is
+// not above the current head, so that forks can be written ahead of SetHead.
+// It is meant for consensus engines that make the fork choice themselves
+func (b *Blockchain) ImportBlock(block *types.Block, source string) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	if _, ok := b.readHeader(block.Hash()); ok {
+		b.logger.Info("block already inserted", "block", block.Number(), "source", source)
+
+		return nil
+	}
+
+	return b.writeBlockImpl(block, source)
+}
+
+// writeBlockImpl commits the block, its receipts and the resulting chain event
+func (b *Blockchain) writeBlockImpl(block *types.Block, source string) error {
 	header := block.Header
 
 	if err := b.writeBody(block); err != nil {
@@ -972,7 +1184,7 @@ func (b *Blockchain) updateGasPriceAvgWithBlock(block *types.Block) {
 
 	gasPrices := make([]*big.Int, len(block.Transactions))
 	for i, transaction := range block.Transactions {
-		gasPrices[i] = transaction.GasPrice
+		gasPrices[i] = transaction.EffectiveGasPrice(block.Header.BaseFee)
 	}
 
 	b.updateGasPriceAvg(gasPrices)
@@ -1174,13 +1386,25 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	b.headersCache.Add(header.Hash, header)
 
 	incomingTD := big.NewInt(0).Add(parentTD, big.NewInt(0).SetUint64(header.Difficulty))
-	if incomingTD.Cmp(currentTD) > 0 {
+
+	reorg := incomingTD.Cmp(currentTD) > 0
+	if reorg {
+		// the finalized chain can't be replaced, the heavier chain is written as a fork
+		belowFinalized, err := b.forksBelowFinalized(header)
+		if err != nil {
+			return err
+		}
+
+		reorg = !belowFinalized
+	}
+
+	if reorg {
 		// new block has higher difficulty, reorg the chain
 		if err := b.handleReorg(evnt, currentHeader, header); err != nil {
 			return err
 		}
 	} else {
-		// new block has lower difficulty, create a new fork
+		// new block has lower difficulty or forks below the finalized header, create a new fork
 		evnt.AddOldHeader(header)
 		evnt.Type = EventFork
 
@@ -1192,6 +1416,26 @@ func (b *Blockchain) writeHeaderImpl(evnt *Event, header *types.Header) error {
 	return nil
 }
 
+// forksBelowFinalized checks if the chain of the given header forks off
+// the canonical chain below the finalized header
+func (b *Blockchain) forksBelowFinalized(header *types.Header) (bool, error) {
+	finalized, ok := b.FinalizedHeader()
+	if !ok {
+		return false, nil
+	}
+
+	for header.Number > finalized.Number {
+		parent, ok := b.readHeader(header.ParentHash)
+		if !ok {
+			return false, fmt.Errorf("header '%s' not found", header.ParentHash.String())
+		}
+
+		header = parent
+	}
+
+	return header.Hash != finalized.Hash, nil
+}
+
 // writeFork writes the new header forks to the DB
 func (b *Blockchain) writeFork(header *types.Header) error {
 	forks, err := b.db.ReadForks()
@@ -1265,6 +1509,25 @@ func (b *Blockchain) handleReorg(
 		}
 
 		oldChain = append(oldChain, oldHeader)
+
+		// the common ancestor is already part of the canonical chain
+		if newHeader.Hash != oldHeader.Hash {
+			newChain = append(newChain, newHeader)
+		}
+	}
+
+	// oldHeader is now the common ancestor, the finalized chain can't be replaced
+	if finalized, ok := b.FinalizedHeader(); ok && oldHeader.Number < finalized.Number {
+		return ErrReorgBelowFinalized
+	}
+
+	// the safe header is no longer canonical, fall back to the finalized one
+	if safe, ok := b.SafeHeader(); ok && oldHeader.Number < safe.Number {
+		finalized, _ := b.FinalizedHeader()
+
+		if err := b.writeSafeHeader(finalized); err != nil {
+			return err
+		}
 	}
 
 	for _, b := range oldChain[:len(oldChain)-1] {
@@ -1301,6 +1564,92 @@ func (b *Blockchain) handleReorg(
 	return nil
 }
 
+// SetHead sets the already written header with the given hash as the head
+// of the canonical chain, regardless of the total difficulty.
+// It is meant for consensus engines that make the fork choice themselves
+func (b *Blockchain) SetHead(hash types.Hash, source string) error {
+	b.writeLock.Lock()
+	defer b.writeLock.Unlock()
+
+	newHeader, ok := b.readHeader(hash)
+	if !ok {
+		return ErrHeaderNotFound
+	}
+
+	currentHeader := b.Header()
+	if newHeader.Hash == currentHeader.Hash {
+		return nil
+	}
+
+	evnt := &Event{Source: source}
+
+	if newChain, ok := b.readChainSegment(currentHeader, newHeader); ok {
+		// The new head extends the current chain
+		if err := b.advanceCanonicalChain(evnt, newChain); err != nil {
+			return err
+		}
+	} else if err := b.handleReorg(evnt, currentHeader, newHeader); err != nil {
+		return err
+	}
+
+	// Remove the canonical entries of the old chain above the new head
+	for number := newHeader.Number + 1; number <= currentHeader.Number; number++ {
+		if err := b.db.WriteCanonicalHash(number, types.ZeroHash); err != nil {
+			return err
+		}
+	}
+
+	b.dispatchEvent(evnt)
+
+	return nil
+}
+
+// readChainSegment returns the headers following the ancestor up to (and including) the head,
+// in ascending order. The second return value is false if the ancestor is not part of the head's chain
+func (b *Blockchain) readChainSegment(ancestor, head *types.Header) ([]*types.Header, bool) {
+	if head.Number <= ancestor.Number {
+		return nil, false
+	}
+
+	segment := make([]*types.Header, head.Number-ancestor.Number)
+	current := head
+
+	for i := len(segment) - 1; i >= 0; i-- {
+		segment[i] = current
+
+		parent, ok := b.readHeader(current.ParentHash)
+		if !ok {
+			return nil, false
+		}
+
+		current = parent
+	}
+
+	return segment, current.Hash == ancestor.Hash
+}
+
+// advanceCanonicalChain appends the passed in headers to the canonical chain
+// and sets the last one as the new head
+func (b *Blockchain) advanceCanonicalChain(evnt *Event, headers []*types.Header) error {
+	for _, h := range headers {
+		if err := b.db.WriteCanonicalHash(h.Number, h.Hash); err != nil {
+			return err
+		}
+
+		evnt.AddNewHeader(h)
+	}
+
+	diff, err := b.advanceHead(headers[len(headers)-1])
+	if err != nil {
+		return err
+	}
+
+	evnt.Type = EventHead
+	evnt.SetDifficulty(diff)
+
+	return nil
+}
+
 // GetForks returns the forks
 func (b *Blockchain) GetForks() ([]types.Hash, error) {
 	return b.db.ReadForks()
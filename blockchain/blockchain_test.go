@@ -966,6 +966,66 @@ func TestCalculateGasLimit(t *testing.T) {
 	}
 }
 
+func TestCalculateBaseFee(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		parent          *types.Header
+		expectedBaseFee uint64
+	}{
+		{
+			name:            "should be zero before the london fork",
+			parent:          &types.Header{Number: 3, GasLimit: 20000000, BaseFee: 0},
+			expectedBaseFee: 0,
+		},
+		{
+			name:            "should start from the genesis base fee at the london fork",
+			parent:          &types.Header{Number: 4, GasLimit: 20000000, BaseFee: 0},
+			expectedBaseFee: 1000,
+		},
+		{
+			name:            "should not change when the parent used the gas target",
+			parent:          &types.Header{Number: 10, GasLimit: 20000000, GasUsed: 10000000, BaseFee: 1000},
+			expectedBaseFee: 1000,
+		},
+		{
+			name:            "should increase when the parent used more than the gas target",
+			parent:          &types.Header{Number: 10, GasLimit: 20000000, GasUsed: 20000000, BaseFee: 1000},
+			expectedBaseFee: 1125,
+		},
+		{
+			name:            "should increase by at least one",
+			parent:          &types.Header{Number: 10, GasLimit: 20000000, GasUsed: 10000001, BaseFee: 1000},
+			expectedBaseFee: 1001,
+		},
+		{
+			name:            "should decrease when the parent used less than the gas target",
+			parent:          &types.Header{Number: 10, GasLimit: 20000000, GasUsed: 0, BaseFee: 1000},
+			expectedBaseFee: 875,
+		},
+	}
+
+	b := &Blockchain{
+		config: &chain.Chain{
+			Genesis: &chain.Genesis{BaseFee: 1000},
+			Params: &chain.Params{
+				Forks: &chain.Forks{London: chain.NewFork(5)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expectedBaseFee, b.CalculateBaseFee(tt.parent))
+		})
+	}
+}
+
 // TestGasPriceAverage tests the average gas price of the
 // blockchain
 func TestGasPriceAverage(t *testing.T) {
@@ -1330,3 +1390,165 @@ func TestBlockchain_VerifyBlockBody(t *testing.T) {
 		assert.ErrorIs(t, blockchain.verifyBlockBody(block), errUnableToExecute)
 	})
 }
+
+func TestBlockchain_SetHead(t *testing.T) {
+	t.Parallel()
+
+	newChain := func(t *testing.T) (*Blockchain, []*types.Header, []*types.Header) {
+		t.Helper()
+
+		mainChain := NewTestHeaders(10)
+		forkChain := AppendNewTestheadersWithSeed(mainChain[:5], 3, 1)
+
+		b := NewTestBlockchain(t, mainChain)
+
+		// The fork has lower difficulty, so it is not canonical
+		assert.NoError(t, b.WriteHeaders(forkChain[5:]))
+		assert.Equal(t, mainChain[9].Hash, b.Header().Hash)
+
+		return b, mainChain, forkChain
+	}
+
+	assertCanonical := func(t *testing.T, b *Blockchain, headers []*types.Header) {
+		t.Helper()
+
+		head := headers[len(headers)-1]
+		assert.Equal(t, head.Hash, b.Header().Hash)
+
+		// the genesis is only set as the head, its header is not written
+		for _, h := range headers[1:] {
+			canonical, ok := b.GetHeaderByNumber(h.Number)
+			if assert.True(t, ok) {
+				assert.Equal(t, h.Hash, canonical.Hash)
+			}
+		}
+
+		_, ok := b.GetHeaderByNumber(head.Number + 1)
+		assert.False(t, ok)
+	}
+
+	t.Run("Unknown header", func(t *testing.T) {
+		t.Parallel()
+
+		b, _, _ := newChain(t)
+
+		assert.ErrorIs(t, b.SetHead(types.StringToHash("1"), "test"), ErrHeaderNotFound)
+	})
+
+	t.Run("Reorg to a fork with lower difficulty", func(t *testing.T) {
+		t.Parallel()
+
+		b, _, forkChain := newChain(t)
+
+		assert.NoError(t, b.SetHead(forkChain[7].Hash, "test"))
+		assertCanonical(t, b, forkChain)
+	})
+
+	t.Run("Rewind and advance the canonical chain", func(t *testing.T) {
+		t.Parallel()
+
+		b, mainChain, _ := newChain(t)
+
+		assert.NoError(t, b.SetHead(mainChain[3].Hash, "test"))
+		assertCanonical(t, b, mainChain[:4])
+
+		assert.NoError(t, b.SetHead(mainChain[9].Hash, "test"))
+		assertCanonical(t, b, mainChain)
+	})
+}
+
+func TestBlockchain_Finality(t *testing.T) {
+	t.Parallel()
+
+	newChain := func(t *testing.T) (*Blockchain, []*types.Header, []*types.Header) {
+		t.Helper()
+
+		mainChain := NewTestHeaders(10)
+		forkChain := AppendNewTestheadersWithSeed(mainChain[:5], 3, 1)
+
+		b := NewTestBlockchain(t, mainChain)
+		assert.NoError(t, b.WriteHeaders(forkChain[5:]))
+
+		return b, mainChain, forkChain
+	}
+
+	t.Run("Finalized and safe headers", func(t *testing.T) {
+		t.Parallel()
+
+		b, mainChain, forkChain := newChain(t)
+
+		_, ok := b.FinalizedHeader()
+		assert.False(t, ok)
+
+		assert.ErrorIs(t, b.SetFinalized(forkChain[6].Hash), ErrNotCanonical)
+		assert.NoError(t, b.SetFinalized(mainChain[6].Hash))
+
+		// the safe header follows the finalized header
+		safe, ok := b.SafeHeader()
+		assert.True(t, ok)
+		assert.Equal(t, mainChain[6].Hash, safe.Hash)
+
+		assert.ErrorIs(t, b.SetSafe(mainChain[5].Hash), ErrSafeBelowFinalized)
+		assert.NoError(t, b.SetSafe(mainChain[8].Hash))
+		assert.ErrorIs(t, b.SetFinalized(mainChain[5].Hash), ErrFinalizedRollback)
+
+		// both are persisted
+		finalizedHash, ok := b.db.ReadFinalizedHash()
+		assert.True(t, ok)
+		assert.Equal(t, mainChain[6].Hash, finalizedHash)
+
+		safeHash, ok := b.db.ReadSafeHash()
+		assert.True(t, ok)
+		assert.Equal(t, mainChain[8].Hash, safeHash)
+	})
+
+	t.Run("Reorg below the finalized header", func(t *testing.T) {
+		t.Parallel()
+
+		b, mainChain, forkChain := newChain(t)
+
+		assert.NoError(t, b.SetFinalized(mainChain[6].Hash))
+
+		assert.ErrorIs(t, b.SetHead(forkChain[7].Hash, "test"), ErrReorgBelowFinalized)
+		assert.ErrorIs(t, b.SetHead(mainChain[5].Hash, "test"), ErrReorgBelowFinalized)
+		assert.Equal(t, mainChain[9].Hash, b.Header().Hash)
+	})
+
+	t.Run("Heavier fork below the finalized header", func(t *testing.T) {
+		t.Parallel()
+
+		b, mainChain, _ := newChain(t)
+
+		assert.NoError(t, b.SetFinalized(mainChain[6].Hash))
+
+		// the fork outweighs the canonical chain, but is only written as a fork
+		heavierChain := AppendNewTestheadersWithSeed(mainChain[:5], 7, 2)
+		assert.NoError(t, b.WriteHeaders(heavierChain[5:]))
+
+		assert.Equal(t, mainChain[9].Hash, b.Header().Hash)
+
+		head, ok := b.GetHeaderByHash(heavierChain[11].Hash)
+		assert.True(t, ok)
+		assert.Equal(t, heavierChain[11].Hash, head.Hash)
+
+		forks, err := b.GetForks()
+		assert.NoError(t, err)
+		assert.Contains(t, forks, heavierChain[11].Hash)
+	})
+
+	t.Run("Reorg below the safe header", func(t *testing.T) {
+		t.Parallel()
+
+		b, mainChain, forkChain := newChain(t)
+
+		assert.NoError(t, b.SetFinalized(mainChain[3].Hash))
+		assert.NoError(t, b.SetSafe(mainChain[8].Hash))
+
+		assert.NoError(t, b.SetHead(forkChain[7].Hash, "test"))
+
+		// the safe header falls back to the finalized header
+		safe, ok := b.SafeHeader()
+		assert.True(t, ok)
+		assert.Equal(t, mainChain[3].Hash, safe.Hash)
+	})
+}
@@ -43,9 +43,11 @@ var (
 
 // Sub-prefixes
 var (
-	HASH   = []byte("hash")
-	NUMBER = []byte("number")
-	EMPTY  = []byte("empty")
+	HASH      = []byte("hash")
+	NUMBER    = []byte("number")
+	EMPTY     = []byte("empty")
+	FINALIZED = []byte("finalized")
+	SAFE      = []byte("safe")
 )
 
 // KV is a key value storage interface.
@@ -132,6 +134,40 @@ func (s *KeyValueStorage) WriteHeadNumber(n uint64) error {
 	return s.set(HEAD, NUMBER, s.encodeUint(n))
 }
 
+// FINALIZED //
+
+// ReadFinalizedHash returns the hash of the finalized block
+func (s *KeyValueStorage) ReadFinalizedHash() (types.Hash, bool) {
+	data, ok := s.get(HEAD, FINALIZED)
+	if !ok {
+		return types.Hash{}, false
+	}
+
+	return types.BytesToHash(data), true
+}
+
+// WriteFinalizedHash writes the hash of the finalized block
+func (s *KeyValueStorage) WriteFinalizedHash(h types.Hash) error {
+	return s.set(HEAD, FINALIZED, h.Bytes())
+}
+
+// SAFE //
+
+// ReadSafeHash returns the hash of the safe block
+func (s *KeyValueStorage) ReadSafeHash() (types.Hash, bool) {
+	data, ok := s.get(HEAD, SAFE)
+	if !ok {
+		return types.Hash{}, false
+	}
+
+	return types.BytesToHash(data), true
+}
+
+// WriteSafeHash writes the hash of the safe block
+func (s *KeyValueStorage) WriteSafeHash(h types.Hash) error {
+	return s.set(HEAD, SAFE, h.Bytes())
+}
+
 // FORK //
 
 // WriteForks writes the current forks
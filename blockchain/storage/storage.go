@@ -17,6 +17,11 @@ type Storage interface {
 	WriteHeadHash(h types.Hash) error
 	WriteHeadNumber(uint64) error
 
+	ReadFinalizedHash() (types.Hash, bool)
+	WriteFinalizedHash(h types.Hash) error
+	ReadSafeHash() (types.Hash, bool)
+	WriteSafeHash(h types.Hash) error
+
 	WriteForks(forks []types.Hash) error
 	ReadForks() ([]types.Hash, error)
 
@@ -33,6 +33,9 @@ func TestStorage(t *testing.T, m PlaceholderStorage) {
 	t.Run("", func(t *testing.T) {
 		testHead(t, m)
 	})
+	t.Run("", func(t *testing.T) {
+		testFinalizedAndSafe(t, m)
+	})
 	t.Run("", func(t *testing.T) {
 		testForks(t, m)
 	})
@@ -195,6 +198,30 @@ func testHead(t *testing.T, m PlaceholderStorage) {
 	}
 }
 
+func testFinalizedAndSafe(t *testing.T, m PlaceholderStorage) {
+	t.Helper()
+
+	s, closeFn := m(t)
+	defer closeFn()
+
+	_, ok := s.ReadFinalizedHash()
+	assert.False(t, ok)
+
+	_, ok = s.ReadSafeHash()
+	assert.False(t, ok)
+
+	assert.NoError(t, s.WriteFinalizedHash(hash1))
+	assert.NoError(t, s.WriteSafeHash(hash2))
+
+	finalized, ok := s.ReadFinalizedHash()
+	assert.True(t, ok)
+	assert.Equal(t, hash1, finalized)
+
+	safe, ok := s.ReadSafeHash()
+	assert.True(t, ok)
+	assert.Equal(t, hash2, safe)
+}
+
 func testForks(t *testing.T, m PlaceholderStorage) {
 	t.Helper()
 
@@ -454,6 +481,10 @@ type readHeadHashDelegate func() (types.Hash, bool)
 type readHeadNumberDelegate func() (uint64, bool)
 type writeHeadHashDelegate func(types.Hash) error
 type writeHeadNumberDelegate func(uint64) error
+type readFinalizedHashDelegate func() (types.Hash, bool)
+type writeFinalizedHashDelegate func(types.Hash) error
+type readSafeHashDelegate func() (types.Hash, bool)
+type writeSafeHashDelegate func(types.Hash) error
 type writeForksDelegate func([]types.Hash) error
 type readForksDelegate func() ([]types.Hash, error)
 type writeTotalDifficultyDelegate func(types.Hash, *big.Int) error
@@ -478,6 +509,10 @@ type MockStorage struct {
 	readHeadNumberFn       readHeadNumberDelegate
 	writeHeadHashFn        writeHeadHashDelegate
 	writeHeadNumberFn      writeHeadNumberDelegate
+	readFinalizedHashFn    readFinalizedHashDelegate
+	writeFinalizedHashFn   writeFinalizedHashDelegate
+	readSafeHashFn         readSafeHashDelegate
+	writeSafeHashFn        writeSafeHashDelegate
 	writeForksFn           writeForksDelegate
 	readForksFn            readForksDelegate
 	writeTotalDifficultyFn writeTotalDifficultyDelegate
@@ -572,6 +607,54 @@ func (m *MockStorage) HookWriteHeadNumber(fn writeHeadNumberDelegate) {
 	m.writeHeadNumberFn = fn
 }
 
+func (m *MockStorage) ReadFinalizedHash() (types.Hash, bool) {
+	if m.readFinalizedHashFn != nil {
+		return m.readFinalizedHashFn()
+	}
+
+	return types.Hash{}, false
+}
+
+func (m *MockStorage) HookReadFinalizedHash(fn readFinalizedHashDelegate) {
+	m.readFinalizedHashFn = fn
+}
+
+func (m *MockStorage) WriteFinalizedHash(h types.Hash) error {
+	if m.writeFinalizedHashFn != nil {
+		return m.writeFinalizedHashFn(h)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteFinalizedHash(fn writeFinalizedHashDelegate) {
+	m.writeFinalizedHashFn = fn
+}
+
+func (m *MockStorage) ReadSafeHash() (types.Hash, bool) {
+	if m.readSafeHashFn != nil {
+		return m.readSafeHashFn()
+	}
+
+	return types.Hash{}, false
+}
+
+func (m *MockStorage) HookReadSafeHash(fn readSafeHashDelegate) {
+	m.readSafeHashFn = fn
+}
+
+func (m *MockStorage) WriteSafeHash(h types.Hash) error {
+	if m.writeSafeHashFn != nil {
+		return m.writeSafeHashFn(h)
+	}
+
+	return nil
+}
+
+func (m *MockStorage) HookWriteSafeHash(fn writeSafeHashDelegate) {
+	m.writeSafeHashFn = fn
+}
+
 func (m *MockStorage) WriteForks(forks []types.Hash) error {
 	if m.writeForksFn != nil {
 		return m.writeForksFn(forks)
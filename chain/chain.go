@@ -20,6 +20,14 @@ var (
 	GenesisDifficulty = big.NewInt(131072)
 )
 
+const (
+	// BaseFeeChangeDenominator bounds the change of the base fee between blocks (EIP-1559)
+	BaseFeeChangeDenominator uint64 = 8
+
+	// ElasticityMultiplier bounds the gas limit of the blocks to a multiple of the gas target (EIP-1559)
+	ElasticityMultiplier uint64 = 2
+)
+
 // Chain is the blockchain chain configuration
 type Chain struct {
 	Name      string   `json:"name"`
@@ -41,6 +49,9 @@ type Genesis struct {
 	Coinbase   types.Address                     `json:"coinbase"`
 	Alloc      map[types.Address]*GenesisAccount `json:"alloc,omitempty"`
 
+	// BaseFee is the base fee of the first London block, the genesis if enabled from it
+	BaseFee uint64 `json:"baseFee"`
+
 	// Override
 	StateRoot types.Hash
 
@@ -84,6 +95,10 @@ func (g *Genesis) GenesisHeader() *types.Header {
 		head.Difficulty = GenesisDifficulty.Uint64()
 	}
 
+	if g.Config != nil && g.Config.Forks != nil && g.Config.Forks.IsLondon(g.Number) {
+		head.BaseFee = g.BaseFee
+	}
+
 	return head
 }
 
@@ -106,6 +121,7 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 		Mixhash    types.Hash                  `json:"mixHash"`
 		Coinbase   types.Address               `json:"coinbase"`
 		Alloc      *map[string]*GenesisAccount `json:"alloc,omitempty"`
+		BaseFee    *string                     `json:"baseFee,omitempty"`
 		Number     *string                     `json:"number,omitempty"`
 		GasUsed    *string                     `json:"gasUsed,omitempty"`
 		ParentHash types.Hash                  `json:"parentHash"`
@@ -132,6 +148,10 @@ func (g *Genesis) MarshalJSON() ([]byte, error) {
 		enc.Alloc = &alloc
 	}
 
+	if g.BaseFee != 0 {
+		enc.BaseFee = types.EncodeUint64(g.BaseFee)
+	}
+
 	enc.Number = types.EncodeUint64(g.Number)
 	enc.GasUsed = types.EncodeUint64(g.GasUsed)
 	enc.ParentHash = g.ParentHash
@@ -150,6 +170,7 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		Mixhash    *types.Hash                `json:"mixHash"`
 		Coinbase   *types.Address             `json:"coinbase"`
 		Alloc      map[string]*GenesisAccount `json:"alloc"`
+		BaseFee    *string                    `json:"baseFee"`
 		Number     *string                    `json:"number"`
 		GasUsed    *string                    `json:"gasUsed"`
 		ParentHash *types.Hash                `json:"parentHash"`
@@ -214,6 +235,13 @@ func (g *Genesis) UnmarshalJSON(data []byte) error {
 		}
 	}
 
+	if dec.BaseFee != nil {
+		g.BaseFee, subErr = types.ParseUint64orHex(dec.BaseFee)
+		if subErr != nil {
+			parseError("basefee", subErr)
+		}
+	}
+
 	g.Number, subErr = types.ParseUint64orHex(dec.Number)
 	if subErr != nil {
 		parseError("number", subErr)
@@ -370,8 +398,8 @@ func importChain(content []byte) (*Chain, error) {
 		return nil, err
 	}
 
-	if engines := chain.Params.Engine; len(engines) != 1 {
-		return nil, fmt.Errorf("expected one consensus engine but found %d", len(engines))
+	if _, err := chain.Params.GetEngineSchedule(); err != nil {
+		return nil, fmt.Errorf("invalid consensus engines: %w", err)
 	}
 
 	return chain, nil
@@ -1,11 +1,27 @@
 package chain
 
 import (
+	"errors"
+	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/Gabulhas/polygon-external-consensus/types"
 )
 
+const (
+	// EngineFromKey is the key of the engine configuration with the height
+	// the engine seals the blocks from. The engine without it starts from the genesis
+	EngineFromKey = "from"
+)
+
+var (
+	ErrNoEngine              = errors.New("no consensus engine")
+	ErrNoGenesisEngine       = errors.New("no consensus engine starting from the genesis")
+	ErrDuplicateEngineHeight = errors.New("multiple consensus engines starting from the same height")
+	ErrInvalidEngineHeight   = errors.New("invalid consensus engine start height")
+)
+
 // Params are all the set of params for the chain
 type Params struct {
 	Forks          *Forks                 `json:"forks"`
@@ -13,15 +29,108 @@ type Params struct {
 	Engine         map[string]interface{} `json:"engine"`
 	Whitelists     *Whitelists            `json:"whitelists,omitempty"`
 	BlockGasTarget uint64                 `json:"blockGasTarget"`
+
+	// BaseFeeCollector receives the base fee paid by the transactions, burned if not set (EIP-1559)
+	BaseFeeCollector *types.Address `json:"baseFeeCollector,omitempty"`
 }
 
+// GetEngine returns the name of the consensus engine sealing the genesis
 func (p *Params) GetEngine() string {
-	// We know there is already one
-	for k := range p.Engine {
-		return k
+	schedule, err := p.GetEngineSchedule()
+	if err != nil {
+		// We know there is already one
+		for k := range p.Engine {
+			return k
+		}
+
+		return ""
 	}
 
-	return ""
+	return schedule[0].Name
+}
+
+// EngineTransition is a consensus engine sealing the blocks from the given height
+type EngineTransition struct {
+	Name   string
+	From   uint64
+	Config map[string]interface{}
+}
+
+// GetEngineSchedule returns the consensus engines sorted by the height they start from
+func (p *Params) GetEngineSchedule() ([]*EngineTransition, error) {
+	if len(p.Engine) == 0 {
+		return nil, ErrNoEngine
+	}
+
+	schedule := make([]*EngineTransition, 0, len(p.Engine))
+
+	for name, rawConfig := range p.Engine {
+		config, ok := rawConfig.(map[string]interface{})
+		if !ok {
+			config = map[string]interface{}{}
+		}
+
+		from, err := readEngineFrom(config)
+		if err != nil {
+			return nil, fmt.Errorf("engine '%s': %w", name, err)
+		}
+
+		schedule = append(schedule, &EngineTransition{
+			Name:   name,
+			From:   from,
+			Config: config,
+		})
+	}
+
+	sort.Slice(schedule, func(i, j int) bool {
+		return schedule[i].From < schedule[j].From
+	})
+
+	if schedule[0].From != 0 {
+		return nil, ErrNoGenesisEngine
+	}
+
+	for i := 1; i < len(schedule); i++ {
+		if schedule[i].From == schedule[i-1].From {
+			return nil, fmt.Errorf(
+				"%w: '%s' and '%s'",
+				ErrDuplicateEngineHeight,
+				schedule[i-1].Name,
+				schedule[i].Name,
+			)
+		}
+	}
+
+	return schedule, nil
+}
+
+// readEngineFrom reads the start height from the engine configuration,
+// either a JSON number or a (hex) string
+func readEngineFrom(config map[string]interface{}) (uint64, error) {
+	raw, ok := config[EngineFromKey]
+	if !ok {
+		return 0, nil
+	}
+
+	switch from := raw.(type) {
+	case float64:
+		if from < 0 || from != float64(uint64(from)) {
+			return 0, ErrInvalidEngineHeight
+		}
+
+		return uint64(from), nil
+	case uint64:
+		return from, nil
+	case string:
+		height, err := types.ParseUint64orHex(&from)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidEngineHeight, from)
+		}
+
+		return height, nil
+	default:
+		return 0, ErrInvalidEngineHeight
+	}
 }
 
 // Whitelists specifies supported whitelists
@@ -36,6 +145,10 @@ type Forks struct {
 	Constantinople *Fork `json:"constantinople,omitempty"`
 	Petersburg     *Fork `json:"petersburg,omitempty"`
 	Istanbul       *Fork `json:"istanbul,omitempty"`
+	Berlin         *Fork `json:"berlin,omitempty"`
+	London         *Fork `json:"london,omitempty"`
+	Shanghai       *Fork `json:"shanghai,omitempty"`
+	Cancun         *Fork `json:"cancun,omitempty"`
 	EIP150         *Fork `json:"EIP150,omitempty"`
 	EIP158         *Fork `json:"EIP158,omitempty"`
 	EIP155         *Fork `json:"EIP155,omitempty"`
@@ -65,6 +178,22 @@ func (f *Forks) IsPetersburg(block uint64) bool {
 	return f.active(f.Petersburg, block)
 }
 
+func (f *Forks) IsBerlin(block uint64) bool {
+	return f.active(f.Berlin, block)
+}
+
+func (f *Forks) IsLondon(block uint64) bool {
+	return f.active(f.London, block)
+}
+
+func (f *Forks) IsShanghai(block uint64) bool {
+	return f.active(f.Shanghai, block)
+}
+
+func (f *Forks) IsCancun(block uint64) bool {
+	return f.active(f.Cancun, block)
+}
+
 func (f *Forks) IsEIP150(block uint64) bool {
 	return f.active(f.EIP150, block)
 }
@@ -84,6 +213,10 @@ func (f *Forks) At(block uint64) ForksInTime {
 		Constantinople: f.active(f.Constantinople, block),
 		Petersburg:     f.active(f.Petersburg, block),
 		Istanbul:       f.active(f.Istanbul, block),
+		Berlin:         f.active(f.Berlin, block),
+		London:         f.active(f.London, block),
+		Shanghai:       f.active(f.Shanghai, block),
+		Cancun:         f.active(f.Cancun, block),
 		EIP150:         f.active(f.EIP150, block),
 		EIP158:         f.active(f.EIP158, block),
 		EIP155:         f.active(f.EIP155, block),
@@ -112,6 +245,10 @@ type ForksInTime struct {
 	Constantinople,
 	Petersburg,
 	Istanbul,
+	Berlin,
+	London,
+	Shanghai,
+	Cancun,
 	EIP150,
 	EIP158,
 	EIP155 bool
@@ -126,4 +263,8 @@ var AllForksEnabled = &Forks{
 	Constantinople: NewFork(0),
 	Petersburg:     NewFork(0),
 	Istanbul:       NewFork(0),
+	Berlin:         NewFork(0),
+	London:         NewFork(0),
+	Shanghai:       NewFork(0),
+	Cancun:         NewFork(0),
 }
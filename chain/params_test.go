@@ -2,6 +2,7 @@ package chain
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
@@ -66,3 +67,85 @@ func TestParamsForksInTime(t *testing.T) {
 	expect("constantinople", ff.Constantinople, false)
 	expect("eip150", ff.EIP150, false)
 }
+
+func TestParamsEngineSchedule(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		engine   string
+		expected []*EngineTransition
+		err      error
+	}{
+		{
+			name:   "single engine",
+			engine: `{"ibft": {"epochSize": 10}}`,
+			expected: []*EngineTransition{
+				{Name: "ibft", Config: map[string]interface{}{"epochSize": float64(10)}},
+			},
+		},
+		{
+			name:   "scheduled engines",
+			engine: `{"external": {"from": "0x64"}, "dev": {}, "clique": {"from": 50}}`,
+			expected: []*EngineTransition{
+				{Name: "dev", Config: map[string]interface{}{}},
+				{Name: "clique", From: 50, Config: map[string]interface{}{"from": float64(50)}},
+				{Name: "external", From: 100, Config: map[string]interface{}{"from": "0x64"}},
+			},
+		},
+		{
+			name:   "no engines",
+			engine: `{}`,
+			err:    ErrNoEngine,
+		},
+		{
+			name:   "no genesis engine",
+			engine: `{"dev": {"from": 1}}`,
+			err:    ErrNoGenesisEngine,
+		},
+		{
+			name:   "same start height",
+			engine: `{"dev": {}, "external": {"from": 5}, "clique": {"from": "5"}}`,
+			err:    ErrDuplicateEngineHeight,
+		},
+		{
+			name:   "invalid start height",
+			engine: `{"dev": {}, "external": {"from": -5}}`,
+			err:    ErrInvalidEngineHeight,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			params := &Params{}
+			if err := json.Unmarshal([]byte(c.engine), &params.Engine); err != nil {
+				t.Fatal(err)
+			}
+
+			schedule, err := params.GetEngineSchedule()
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Fatalf("expected error %v but found %v", c.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(schedule, c.expected) {
+				t.Fatal("bad")
+			}
+
+			if params.GetEngine() != c.expected[0].Name {
+				t.Fatalf("expected genesis engine %s but found %s", c.expected[0].Name, params.GetEngine())
+			}
+		})
+	}
+}
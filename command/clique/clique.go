@@ -0,0 +1,33 @@
+package clique
+
+import (
+	"github.com/Gabulhas/polygon-external-consensus/command/clique/discard"
+	"github.com/Gabulhas/polygon-external-consensus/command/clique/propose"
+	"github.com/Gabulhas/polygon-external-consensus/command/clique/status"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	cliqueCmd := &cobra.Command{
+		Use:   "clique",
+		Short: "Top level Clique command for interacting with the Clique consensus. Only accepts subcommands.",
+	}
+
+	helper.RegisterGRPCAddressFlag(cliqueCmd)
+
+	registerSubcommands(cliqueCmd)
+
+	return cliqueCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// clique status
+		status.GetCommand(),
+		// clique propose
+		propose.GetCommand(),
+		// clique discard
+		discard.GetCommand(),
+	)
+}
@@ -0,0 +1,48 @@
+package discard
+
+import (
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	cliqueDiscardCmd := &cobra.Command{
+		Use:     "discard",
+		Short:   "Drops the pending proposal of the node for the given address",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(cliqueDiscardCmd)
+
+	helper.SetRequiredFlags(cliqueDiscardCmd, params.getRequiredFlags())
+
+	return cliqueDiscardCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.addressRaw,
+		addressFlag,
+		"",
+		"the address of the proposal to be discarded",
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.discard(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,68 @@
+package discard
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	cliqueOp "github.com/Gabulhas/polygon-external-consensus/consensus/clique/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+const (
+	addressFlag = "addr"
+)
+
+var (
+	errInvalidAddressFormat = errors.New("invalid address format")
+)
+
+var (
+	params = &discardParams{}
+)
+
+type discardParams struct {
+	addressRaw string
+
+	address types.Address
+}
+
+func (p *discardParams) getRequiredFlags() []string {
+	return []string{
+		addressFlag,
+	}
+}
+
+func (p *discardParams) initRawParams() error {
+	p.address = types.Address{}
+	if err := p.address.UnmarshalText([]byte(p.addressRaw)); err != nil {
+		return errInvalidAddressFormat
+	}
+
+	return nil
+}
+
+func (p *discardParams) discard(grpcAddress string) error {
+	cliqueClient, err := helper.GetCliqueOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cliqueClient.Discard(
+		context.Background(),
+		&cliqueOp.DiscardReq{
+			Address: p.address.String(),
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *discardParams) getResult() command.CommandResult {
+	return &CliqueDiscardResult{
+		Address: p.address.String(),
+	}
+}
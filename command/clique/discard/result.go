@@ -0,0 +1,28 @@
+package discard
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type CliqueDiscardResult struct {
+	Address string `json:"-"`
+}
+
+func (r *CliqueDiscardResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CLIQUE DISCARD]\n")
+	buffer.WriteString(r.Message())
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func (r *CliqueDiscardResult) Message() string {
+	return fmt.Sprintf("Successfully discarded the proposal for address [%s]", r.Address)
+}
+
+func (r *CliqueDiscardResult) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"message": "%s"}`, r.Message())), nil
+}
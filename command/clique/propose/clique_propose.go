@@ -0,0 +1,65 @@
+package propose
+
+import (
+	"fmt"
+
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	cliqueProposeCmd := &cobra.Command{
+		Use:     "propose",
+		Short:   "Proposes a signer to be added or removed from the signers, voting on it in the blocks the node seals",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(cliqueProposeCmd)
+
+	helper.SetRequiredFlags(cliqueProposeCmd, params.getRequiredFlags())
+
+	return cliqueProposeCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.addressRaw,
+		addressFlag,
+		"",
+		"the address of the account to be voted for",
+	)
+
+	cmd.Flags().StringVar(
+		&params.vote,
+		voteFlag,
+		"",
+		fmt.Sprintf(
+			"requested change to the signers. Possible values: [%s, %s]",
+			authVote,
+			dropVote,
+		),
+	)
+}
+
+func runPreRun(_ *cobra.Command, _ []string) error {
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	if err := params.propose(helper.GetGRPCAddress(cmd)); err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(params.getResult())
+}
@@ -0,0 +1,87 @@
+package propose
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	cliqueOp "github.com/Gabulhas/polygon-external-consensus/consensus/clique/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+const (
+	voteFlag    = "vote"
+	addressFlag = "addr"
+)
+
+const (
+	authVote = "auth"
+	dropVote = "drop"
+)
+
+var (
+	errInvalidVoteType      = errors.New("invalid vote type")
+	errInvalidAddressFormat = errors.New("invalid address format")
+)
+
+var (
+	params = &proposeParams{}
+)
+
+type proposeParams struct {
+	addressRaw string
+
+	vote    string
+	address types.Address
+}
+
+func (p *proposeParams) getRequiredFlags() []string {
+	return []string{
+		voteFlag,
+		addressFlag,
+	}
+}
+
+func (p *proposeParams) validateFlags() error {
+	if p.vote != authVote && p.vote != dropVote {
+		return errInvalidVoteType
+	}
+
+	return nil
+}
+
+func (p *proposeParams) initRawParams() error {
+	p.address = types.Address{}
+	if err := p.address.UnmarshalText([]byte(p.addressRaw)); err != nil {
+		return errInvalidAddressFormat
+	}
+
+	return nil
+}
+
+func (p *proposeParams) propose(grpcAddress string) error {
+	cliqueClient, err := helper.GetCliqueOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cliqueClient.Propose(
+		context.Background(),
+		&cliqueOp.Proposal{
+			Address: p.address.String(),
+			Auth:    p.vote == authVote,
+		},
+	); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *proposeParams) getResult() command.CommandResult {
+	return &CliqueProposeResult{
+		Address: p.address.String(),
+		Vote:    p.vote,
+	}
+}
@@ -0,0 +1,39 @@
+package propose
+
+import (
+	"bytes"
+	"fmt"
+)
+
+type CliqueProposeResult struct {
+	Address string `json:"-"`
+	Vote    string `json:"-"`
+}
+
+func (r *CliqueProposeResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CLIQUE PROPOSE]\n")
+	buffer.WriteString(r.Message())
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func (r *CliqueProposeResult) Message() string {
+	if r.Vote == authVote {
+		return fmt.Sprintf(
+			"Successfully proposed the addition of address [%s] to the signers",
+			r.Address,
+		)
+	}
+
+	return fmt.Sprintf(
+		"Successfully proposed the removal of signer at address [%s] from the signers",
+		r.Address,
+	)
+}
+
+func (r *CliqueProposeResult) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf(`{"message": "%s"}`, r.Message())), nil
+}
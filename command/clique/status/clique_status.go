@@ -0,0 +1,44 @@
+package status
+
+import (
+	"context"
+
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	cliqueOp "github.com/Gabulhas/polygon-external-consensus/consensus/clique/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Returns the signer key, the signers and the pending votes of the Clique client",
+		Run:   runCommand,
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	statusResponse, err := getCliqueStatus(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newCliqueStatusResult(statusResponse))
+}
+
+func getCliqueStatus(grpcAddress string) (*cliqueOp.CliqueStatusResp, error) {
+	client, err := helper.GetCliqueOperatorClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Status(context.Background(), &empty.Empty{})
+}
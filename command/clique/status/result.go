@@ -0,0 +1,123 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	cliqueOp "github.com/Gabulhas/polygon-external-consensus/consensus/clique/proto"
+)
+
+type CliqueStatusResult struct {
+	SignerKey string           `json:"signer_key"`
+	Number    uint64           `json:"number"`
+	Hash      string           `json:"hash"`
+	Signers   []string         `json:"signers"`
+	Votes     []CliqueVote     `json:"votes"`
+	Proposals []CliqueProposal `json:"proposals"`
+}
+
+type CliqueVote struct {
+	Signer  string `json:"signer"`
+	Address string `json:"address"`
+	Vote    string `json:"vote"`
+}
+
+type CliqueProposal struct {
+	Address string `json:"address"`
+	Vote    string `json:"vote"`
+}
+
+func newCliqueStatusResult(resp *cliqueOp.CliqueStatusResp) *CliqueStatusResult {
+	res := &CliqueStatusResult{
+		SignerKey: resp.Key,
+		Number:    resp.Number,
+		Hash:      resp.Hash,
+		Signers:   resp.Signers,
+		Votes:     make([]CliqueVote, len(resp.Votes)),
+		Proposals: make([]CliqueProposal, len(resp.Proposals)),
+	}
+
+	for i, v := range resp.Votes {
+		res.Votes[i] = CliqueVote{
+			Signer:  v.Signer,
+			Address: v.Address,
+			Vote:    voteString(v.Auth),
+		}
+	}
+
+	for i, p := range resp.Proposals {
+		res.Proposals[i] = CliqueProposal{
+			Address: p.Address,
+			Vote:    voteString(p.Auth),
+		}
+	}
+
+	return res
+}
+
+func voteString(auth bool) string {
+	if auth {
+		return "ADD"
+	}
+
+	return "REMOVE"
+}
+
+func (r *CliqueStatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[CLIQUE STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Signer key|%s", r.SignerKey),
+		fmt.Sprintf("Block|%d", r.Number),
+		fmt.Sprintf("Hash|%s", r.Hash),
+	}))
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[SIGNERS]\n")
+
+	if len(r.Signers) == 0 {
+		buffer.WriteString("No signers found")
+	} else {
+		buffer.WriteString(helper.FormatList(r.Signers))
+	}
+
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[VOTES]\n")
+
+	if len(r.Votes) == 0 {
+		buffer.WriteString("No votes found")
+	} else {
+		votes := make([]string, len(r.Votes)+1)
+		votes[0] = "SIGNER|ADDRESS|VOTE"
+
+		for i, v := range r.Votes {
+			votes[i+1] = fmt.Sprintf("%s|%s|%s", v.Signer, v.Address, v.Vote)
+		}
+
+		buffer.WriteString(helper.FormatList(votes))
+	}
+
+	buffer.WriteString("\n")
+
+	buffer.WriteString("\n[PROPOSALS]\n")
+
+	if len(r.Proposals) == 0 {
+		buffer.WriteString("No proposals found")
+	} else {
+		proposals := make([]string, len(r.Proposals)+1)
+		proposals[0] = "ADDRESS|VOTE"
+
+		for i, p := range r.Proposals {
+			proposals[i+1] = fmt.Sprintf("%s|%s", p.Address, p.Vote)
+		}
+
+		buffer.WriteString(helper.FormatList(proposals))
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
@@ -0,0 +1,274 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/external"
+	externalOp "github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/status"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ProposerMode defines how the node building each block is selected
+type ProposerMode string
+
+const (
+	// RoundRobin rotates the proposer among the nodes, by block number
+	RoundRobin ProposerMode = "round-robin"
+
+	// SingleLeader always builds the blocks on the first node
+	SingleLeader ProposerMode = "leader"
+)
+
+var (
+	ErrNoNodes           = errors.New("no nodes to drive")
+	ErrNoProposer        = errors.New("no node was able to build the block")
+	ErrRemoteSealingUsed = errors.New("remote sealing is not supported by the reference client")
+
+	errNoPendingTransactions = errors.New("no pending transactions")
+)
+
+// Config is the configuration of the reference client
+type Config struct {
+	Nodes         []string      // gRPC addresses of the nodes
+	BlockTime     time.Duration // Time between blocks
+	FinalityDepth uint64        // Number of blocks after which a block is finalized
+	Coinbase      types.Address // Address credited with the block rewards and fees
+	Proposer      ProposerMode  // Proposer selection mode
+}
+
+// node is a node driven by the client
+type node struct {
+	addr   string
+	client externalOp.ExternalEngineClient
+}
+
+// Client is a minimal external consensus client for local testing.
+// It builds a block on one of the nodes on every tick, imports it into all of them
+// and finalizes the blocks once they are deep enough in the chain
+type Client struct {
+	logger hclog.Logger
+	config *Config
+	nodes  []*node
+
+	head   types.Hash
+	number uint64
+
+	// Hashes of the recent canonical blocks, to find the ones to finalize
+	hashes map[uint64]types.Hash
+}
+
+// NewClient creates a new client connected to the given nodes
+func NewClient(logger hclog.Logger, config *Config) (*Client, error) {
+	if len(config.Nodes) == 0 {
+		return nil, ErrNoNodes
+	}
+
+	c := &Client{
+		logger: logger,
+		config: config,
+		nodes:  make([]*node, 0, len(config.Nodes)),
+		hashes: make(map[uint64]types.Hash),
+	}
+
+	for _, addr := range config.Nodes {
+		client, err := helper.GetExternalEngineClientConnection(addr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to connect to %s, %w", addr, err)
+		}
+
+		c.nodes = append(c.nodes, &node{addr: addr, client: client})
+	}
+
+	return c, nil
+}
+
+// Run produces blocks until the context is canceled
+func (c *Client) Run(ctx context.Context) error {
+	if err := c.syncHead(ctx); err != nil {
+		return err
+	}
+
+	c.logger.Info(
+		"client started",
+		"nodes", len(c.nodes),
+		"head", c.number,
+		"proposer", c.config.Proposer,
+	)
+
+	ticker := time.NewTicker(c.config.BlockTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := c.produceBlock(ctx); err != nil {
+			if errors.Is(err, ErrRemoteSealingUsed) {
+				return err
+			}
+
+			if errors.Is(err, errNoPendingTransactions) {
+				c.logger.Debug("skipping empty block", "number", c.number+1)
+
+				continue
+			}
+
+			c.logger.Error("unable to produce block", "number", c.number+1, "err", err)
+		}
+	}
+}
+
+// syncHead starts from the highest head among the nodes
+func (c *Client) syncHead(ctx context.Context) error {
+	found := false
+
+	for _, n := range c.nodes {
+		status, err := n.client.Status(ctx, &empty.Empty{})
+		if err != nil {
+			c.logger.Warn("unable to get node status", "node", n.addr, "err", err)
+
+			continue
+		}
+
+		if !found || status.HeadNumber > c.number {
+			c.head = types.BytesToHash(status.HeadHash)
+			c.number = status.HeadNumber
+			found = true
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("unable to get the status of any node")
+	}
+
+	c.hashes[c.number] = c.head
+
+	return nil
+}
+
+// proposerIndex returns the index of the node building the given block
+func (c *Client) proposerIndex(number uint64) int {
+	if c.config.Proposer == SingleLeader {
+		return 0
+	}
+
+	return int(number % uint64(len(c.nodes)))
+}
+
+// produceBlock builds the next block, imports it into all the nodes and updates their fork choice
+func (c *Client) produceBlock(ctx context.Context) error {
+	payload, err := c.buildPayload(ctx, c.number+1)
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+
+	for _, n := range c.nodes {
+		if _, err := n.client.ImportPayload(ctx, payload); err != nil {
+			c.logger.Warn("unable to import payload", "node", n.addr, "number", payload.Number, "err", err)
+
+			continue
+		}
+
+		imported++
+	}
+
+	if imported == 0 {
+		return fmt.Errorf("no node imported block %d", payload.Number)
+	}
+
+	c.head = types.BytesToHash(payload.Hash)
+	c.number = payload.Number
+	c.hashes[c.number] = c.head
+
+	state := &externalOp.ForkchoiceState{
+		HeadHash: c.head.Bytes(),
+	}
+
+	if finalized, ok := c.finalizedHash(); ok {
+		state.FinalizedHash = finalized.Bytes()
+		state.SafeHash = finalized.Bytes()
+	}
+
+	for _, n := range c.nodes {
+		if _, err := n.client.ForkchoiceUpdated(ctx, state); err != nil {
+			c.logger.Warn("unable to update fork choice", "node", n.addr, "number", c.number, "err", err)
+		}
+	}
+
+	c.logger.Info("block produced", "number", c.number, "hash", c.head)
+
+	return nil
+}
+
+// buildPayload builds the block on its proposer, falling back to the next nodes on failure
+func (c *Client) buildPayload(ctx context.Context, number uint64) (*externalOp.Payload, error) {
+	req := &externalOp.BuildPayloadRequest{
+		ParentHash: c.head.Bytes(),
+	}
+
+	if c.config.Coinbase != types.ZeroAddress {
+		req.Coinbase = c.config.Coinbase.Bytes()
+	}
+
+	start := c.proposerIndex(number)
+
+	for i := 0; i < len(c.nodes); i++ {
+		n := c.nodes[(start+i)%len(c.nodes)]
+
+		payload, err := n.client.BuildPayload(ctx, req)
+		if status.Convert(err).Message() == external.ErrEmptyPayload.Error() {
+			// the sealing policy of the node doesn't allow empty blocks
+			return nil, errNoPendingTransactions
+		}
+
+		if err != nil {
+			c.logger.Warn("unable to build payload", "node", n.addr, "number", number, "err", err)
+
+			continue
+		}
+
+		if len(payload.SealHash) != 0 {
+			return nil, ErrRemoteSealingUsed
+		}
+
+		return payload, nil
+	}
+
+	return nil, ErrNoProposer
+}
+
+// finalizedHash returns the hash of the block FinalityDepth blocks below the head, if any.
+// The hashes of the blocks below it aren't needed anymore
+func (c *Client) finalizedHash() (types.Hash, bool) {
+	if c.config.FinalityDepth == 0 {
+		return c.head, true
+	}
+
+	if c.number < c.config.FinalityDepth {
+		return types.ZeroHash, false
+	}
+
+	finalized := c.number - c.config.FinalityDepth
+
+	for number := range c.hashes {
+		if number < finalized {
+			delete(c.hashes, number)
+		}
+	}
+
+	hash, ok := c.hashes[finalized]
+
+	return hash, ok
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/Gabulhas/polygon-external-consensus/helper/common"
+	"github.com/hashicorp/go-hclog"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	clientCmd := &cobra.Command{
+		Use: "client",
+		Short: "Runs the reference external consensus client, producing blocks on the given nodes " +
+			"for local testing",
+		PreRunE: runPreRun,
+		Run:     runCommand,
+	}
+
+	setFlags(clientCmd)
+
+	return clientCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&params.nodes,
+		nodeFlag,
+		[]string{},
+		fmt.Sprintf(
+			"the gRPC address of a node driven by the client. Can be repeated, the --%s is used if not set",
+			command.GRPCAddressFlag,
+		),
+	)
+
+	cmd.Flags().DurationVar(
+		&params.blockTime,
+		blockTimeFlag,
+		defaultBlockTime,
+		"the time between blocks",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.finalityDepth,
+		finalityDepthFlag,
+		defaultFinalityDepth,
+		"the number of blocks after which a block is finalized",
+	)
+
+	cmd.Flags().StringVar(
+		&params.coinbaseRaw,
+		coinbaseFlag,
+		"",
+		"the address credited with the block rewards and fees",
+	)
+
+	cmd.Flags().StringVar(
+		&params.proposerRaw,
+		proposerFlag,
+		string(RoundRobin),
+		fmt.Sprintf(
+			"the proposer selection mode: %s rotates the proposer among the nodes, %s always uses the first node",
+			RoundRobin,
+			SingleLeader,
+		),
+	)
+}
+
+func runPreRun(cmd *cobra.Command, _ []string) error {
+	if len(params.nodes) == 0 {
+		params.nodes = []string{helper.GetGRPCAddress(cmd)}
+	}
+
+	if err := params.validateFlags(); err != nil {
+		return err
+	}
+
+	return params.initRawParams()
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	logger := hclog.New(&hclog.LoggerOptions{
+		Name:  "external-client",
+		Level: hclog.LevelFromString("INFO"),
+	})
+
+	client, err := NewClient(logger, params.getConfig())
+	if err != nil {
+		outputter := command.InitializeOutputter(cmd)
+		outputter.SetError(err)
+		outputter.WriteOutput()
+
+		os.Exit(1)
+	}
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	go func() {
+		<-common.GetTerminationSignalCh()
+		cancelFn()
+	}()
+
+	if err := client.Run(ctx); err != nil {
+		logger.Error("client stopped", "err", err)
+	}
+}
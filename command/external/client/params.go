@@ -0,0 +1,76 @@
+package client
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+const (
+	nodeFlag          = "node"
+	blockTimeFlag     = "block-time"
+	finalityDepthFlag = "finality-depth"
+	coinbaseFlag      = "coinbase"
+	proposerFlag      = "proposer"
+)
+
+const (
+	defaultBlockTime     = 2 * time.Second
+	defaultFinalityDepth = uint64(2)
+)
+
+var (
+	errInvalidBlockTime     = errors.New("block time must be at least 1s")
+	errInvalidAddressFormat = errors.New("invalid coinbase address format")
+	errInvalidProposerMode  = errors.New("invalid proposer mode, expected round-robin or leader")
+)
+
+var (
+	params = &clientParams{}
+)
+
+type clientParams struct {
+	nodes         []string
+	blockTime     time.Duration
+	finalityDepth uint64
+	coinbaseRaw   string
+	proposerRaw   string
+
+	coinbase types.Address
+}
+
+func (p *clientParams) validateFlags() error {
+	// block timestamps have a resolution of a second
+	if p.blockTime < time.Second {
+		return errInvalidBlockTime
+	}
+
+	if p.proposerRaw != string(RoundRobin) && p.proposerRaw != string(SingleLeader) {
+		return errInvalidProposerMode
+	}
+
+	return nil
+}
+
+func (p *clientParams) initRawParams() error {
+	if p.coinbaseRaw == "" {
+		return nil
+	}
+
+	if err := p.coinbase.UnmarshalText([]byte(p.coinbaseRaw)); err != nil {
+		return errInvalidAddressFormat
+	}
+
+	return nil
+}
+
+func (p *clientParams) getConfig() *Config {
+	return &Config{
+		Nodes:         p.nodes,
+		BlockTime:     p.blockTime,
+		FinalityDepth: p.finalityDepth,
+		Coinbase:      p.coinbase,
+		Proposer:      ProposerMode(p.proposerRaw),
+	}
+}
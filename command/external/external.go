@@ -0,0 +1,30 @@
+package external
+
+import (
+	"github.com/Gabulhas/polygon-external-consensus/command/external/client"
+	"github.com/Gabulhas/polygon-external-consensus/command/external/status"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	externalCmd := &cobra.Command{
+		Use:   "external",
+		Short: "Top level command for interacting with the external consensus engine. Only accepts subcommands.",
+	}
+
+	helper.RegisterGRPCAddressFlag(externalCmd)
+
+	registerSubcommands(externalCmd)
+
+	return externalCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// external status
+		status.GetCommand(),
+		// external client
+		client.GetCommand(),
+	)
+}
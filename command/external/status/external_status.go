@@ -0,0 +1,64 @@
+package status
+
+import (
+	"context"
+
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	externalOp "github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Returns the head, finalized and safe blocks of the external engine",
+		Run:   runCommand,
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	statusResponse, err := getExternalStatus(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(newExternalStatusResult(statusResponse))
+}
+
+func getExternalStatus(grpcAddress string) (*externalOp.EngineStatus, error) {
+	client, err := helper.GetExternalEngineClientConnection(
+		grpcAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Status(context.Background(), &empty.Empty{})
+}
+
+func newExternalStatusResult(status *externalOp.EngineStatus) *ExternalStatusResult {
+	res := &ExternalStatusResult{
+		HeadHash:   types.BytesToHash(status.HeadHash).String(),
+		HeadNumber: status.HeadNumber,
+	}
+
+	if len(status.FinalizedHash) != 0 {
+		res.FinalizedHash = types.BytesToHash(status.FinalizedHash).String()
+		res.FinalizedNumber = status.FinalizedNumber
+	}
+
+	if len(status.SafeHash) != 0 {
+		res.SafeHash = types.BytesToHash(status.SafeHash).String()
+		res.SafeNumber = status.SafeNumber
+	}
+
+	return res
+}
@@ -0,0 +1,39 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+)
+
+type ExternalStatusResult struct {
+	HeadHash        string `json:"head_hash"`
+	HeadNumber      uint64 `json:"head_number"`
+	FinalizedHash   string `json:"finalized_hash,omitempty"`
+	FinalizedNumber uint64 `json:"finalized_number,omitempty"`
+	SafeHash        string `json:"safe_hash,omitempty"`
+	SafeNumber      uint64 `json:"safe_number,omitempty"`
+}
+
+func (r *ExternalStatusResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[EXTERNAL ENGINE STATUS]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Head|%d (%s)", r.HeadNumber, r.HeadHash),
+		fmt.Sprintf("Finalized|%s", formatBlock(r.FinalizedNumber, r.FinalizedHash)),
+		fmt.Sprintf("Safe|%s", formatBlock(r.SafeNumber, r.SafeHash)),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func formatBlock(number uint64, hash string) string {
+	if hash == "" {
+		return "-"
+	}
+
+	return fmt.Sprintf("%d (%s)", number, hash)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/command"
 	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/clique"
 	"github.com/Gabulhas/polygon-external-consensus/consensus/ibft"
 	"github.com/Gabulhas/polygon-external-consensus/consensus/ibft/fork"
 	"github.com/Gabulhas/polygon-external-consensus/consensus/ibft/signer"
@@ -81,7 +82,7 @@ func (p *genesisParams) validateFlags() error {
 	}
 
 	// Check if validator information is set at all
-	if p.isIBFTConsensus() &&
+	if (p.isIBFTConsensus() || p.isCliqueConsensus()) &&
 		!p.areValidatorsSetManually() &&
 		!p.areValidatorsSetByPrefix() {
 		return errValidatorsNotSpecified
@@ -112,6 +113,10 @@ func (p *genesisParams) isIBFTConsensus() bool {
 	return server.ConsensusType(p.consensusRaw) == server.IBFTConsensus
 }
 
+func (p *genesisParams) isCliqueConsensus() bool {
+	return server.ConsensusType(p.consensusRaw) == server.CliqueConsensus
+}
+
 func (p *genesisParams) areValidatorsSetManually() bool {
 	return len(p.ibftValidatorsRaw) != 0
 }
@@ -138,6 +143,7 @@ func (p *genesisParams) initRawParams() error {
 	}
 
 	p.initIBFTExtraData()
+	p.initCliqueExtraData()
 	p.initConsensusEngineConfig()
 
 	return nil
@@ -184,6 +190,13 @@ func (p *genesisParams) setValidatorSetFromPrefixPath() error {
 }
 
 func (p *genesisParams) initIBFTValidatorType() error {
+	// Clique signers always seal with their ECDSA keys
+	if p.consensus == server.CliqueConsensus {
+		p.ibftValidatorType = validators.ECDSAValidatorType
+
+		return nil
+	}
+
 	var err error
 	if p.ibftValidatorType, err = validators.ParseValidatorType(p.rawIBFTValidatorType); err != nil {
 		return err
@@ -241,7 +254,25 @@ func (p *genesisParams) initIBFTExtraData() {
 	p.extraData = ibftExtra.MarshalRLPTo(p.extraData)
 }
 
+func (p *genesisParams) initCliqueExtraData() {
+	if p.consensus != server.CliqueConsensus {
+		return
+	}
+
+	p.extraData = clique.GenesisExtraData(p.ibftValidators)
+}
+
 func (p *genesisParams) initConsensusEngineConfig() {
+	if p.consensus == server.CliqueConsensus {
+		p.consensusEngineConfig = map[string]interface{}{
+			string(server.CliqueConsensus): map[string]interface{}{
+				clique.KeyEpoch: p.epochSize,
+			},
+		}
+
+		return
+	}
+
 	if p.consensus != server.IBFTConsensus {
 		p.consensusEngineConfig = map[string]interface{}{
 			p.consensusRaw: map[string]interface{}{},
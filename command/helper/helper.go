@@ -11,7 +11,10 @@ import (
 
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/command"
+	cliqueOp "github.com/Gabulhas/polygon-external-consensus/consensus/clique/proto"
+	externalOp "github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
 	ibftOp "github.com/Gabulhas/polygon-external-consensus/consensus/ibft/proto"
+	sealingOp "github.com/Gabulhas/polygon-external-consensus/consensus/sealing/proto"
 	"github.com/Gabulhas/polygon-external-consensus/helper/common"
 	"github.com/Gabulhas/polygon-external-consensus/server"
 	"github.com/Gabulhas/polygon-external-consensus/server/proto"
@@ -137,6 +140,45 @@ func GetIBFTOperatorClientConnection(address string) (
 	return ibftOp.NewIbftOperatorClient(conn), nil
 }
 
+// GetCliqueOperatorClientConnection returns the Clique operator client connection
+func GetCliqueOperatorClientConnection(address string) (
+	cliqueOp.CliqueOperatorClient,
+	error,
+) {
+	conn, err := GetGRPCConnection(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return cliqueOp.NewCliqueOperatorClient(conn), nil
+}
+
+// GetExternalEngineClientConnection returns the external engine API client connection
+func GetExternalEngineClientConnection(address string) (
+	externalOp.ExternalEngineClient,
+	error,
+) {
+	conn, err := GetGRPCConnection(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return externalOp.NewExternalEngineClient(conn), nil
+}
+
+// GetSealingOperatorClientConnection returns the sealing policy operator client connection
+func GetSealingOperatorClientConnection(address string) (
+	sealingOp.SealingOperatorClient,
+	error,
+) {
+	conn, err := GetGRPCConnection(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return sealingOp.NewSealingOperatorClient(conn), nil
+}
+
 // GetGRPCConnection returns a grpc client connection
 func GetGRPCConnection(address string) (*grpc.ClientConn, error) {
 	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/Gabulhas/polygon-external-consensus/command/backup"
+	"github.com/Gabulhas/polygon-external-consensus/command/clique"
+	"github.com/Gabulhas/polygon-external-consensus/command/external"
 	"github.com/Gabulhas/polygon-external-consensus/command/genesis"
 	"github.com/Gabulhas/polygon-external-consensus/command/helper"
 	"github.com/Gabulhas/polygon-external-consensus/command/ibft"
@@ -12,6 +14,7 @@ import (
 	"github.com/Gabulhas/polygon-external-consensus/command/loadbot"
 	"github.com/Gabulhas/polygon-external-consensus/command/monitor"
 	"github.com/Gabulhas/polygon-external-consensus/command/peers"
+	"github.com/Gabulhas/polygon-external-consensus/command/sealing"
 	"github.com/Gabulhas/polygon-external-consensus/command/secrets"
 	"github.com/Gabulhas/polygon-external-consensus/command/server"
 	"github.com/Gabulhas/polygon-external-consensus/command/status"
@@ -49,6 +52,9 @@ func (rc *RootCommand) registerSubCommands() {
 		monitor.GetCommand(),
 		loadbot.GetCommand(),
 		ibft.GetCommand(),
+		external.GetCommand(),
+		clique.GetCommand(),
+		sealing.GetCommand(),
 		backup.GetCommand(),
 		genesis.GetCommand(),
 		server.GetCommand(),
@@ -0,0 +1,31 @@
+package sealing
+
+import (
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/Gabulhas/polygon-external-consensus/command/sealing/set"
+	"github.com/Gabulhas/polygon-external-consensus/command/sealing/status"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	sealingCmd := &cobra.Command{
+		Use: "sealing",
+		Short: "Top level command for managing the sealing policy of the dev and external engines. " +
+			"Only accepts subcommands.",
+	}
+
+	helper.RegisterGRPCAddressFlag(sealingCmd)
+
+	registerSubcommands(sealingCmd)
+
+	return sealingCmd
+}
+
+func registerSubcommands(baseCmd *cobra.Command) {
+	baseCmd.AddCommand(
+		// sealing status
+		status.GetCommand(),
+		// sealing set
+		set.GetCommand(),
+	)
+}
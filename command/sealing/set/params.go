@@ -0,0 +1,58 @@
+package set
+
+import (
+	"context"
+
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	sealingOp "github.com/Gabulhas/polygon-external-consensus/consensus/sealing/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	modeFlag        = "mode"
+	intervalFlag    = "interval"
+	maxBlockTxsFlag = "max-block-txs"
+	maxBlockGasFlag = "max-block-gas"
+)
+
+var (
+	params = &setParams{}
+)
+
+type setParams struct {
+	mode        string
+	interval    uint64
+	maxBlockTxs uint64
+	maxBlockGas uint64
+}
+
+// setPolicy replaces the fields of the current policy whose flags are set
+func (p *setParams) setPolicy(grpcAddress string, isSet func(string) bool) (*sealingOp.Policy, error) {
+	client, err := helper.GetSealingOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := client.GetPolicy(context.Background(), &empty.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	if isSet(modeFlag) {
+		policy.Mode = p.mode
+	}
+
+	if isSet(intervalFlag) {
+		policy.Interval = p.interval
+	}
+
+	if isSet(maxBlockTxsFlag) {
+		policy.MaxBlockTxs = p.maxBlockTxs
+	}
+
+	if isSet(maxBlockGasFlag) {
+		policy.MaxBlockGas = p.maxBlockGas
+	}
+
+	return client.SetPolicy(context.Background(), policy)
+}
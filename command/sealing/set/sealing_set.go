@@ -0,0 +1,72 @@
+package set
+
+import (
+	"fmt"
+
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/Gabulhas/polygon-external-consensus/command/sealing/status"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing"
+	"github.com/spf13/cobra"
+)
+
+func GetCommand() *cobra.Command {
+	sealingSetCmd := &cobra.Command{
+		Use:   "set",
+		Short: "Changes the sealing policy of the engine, the fields that are not set are kept",
+		Run:   runCommand,
+	}
+
+	setFlags(sealingSetCmd)
+
+	return sealingSetCmd
+}
+
+func setFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&params.mode,
+		modeFlag,
+		"",
+		fmt.Sprintf(
+			"the sealing mode. Possible values: [%s, %s, %s]",
+			sealing.Interval,
+			sealing.OnDemand,
+			sealing.NonEmpty,
+		),
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.interval,
+		intervalFlag,
+		0,
+		"the time between the blocks in seconds, for the interval and non-empty modes",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.maxBlockTxs,
+		maxBlockTxsFlag,
+		0,
+		"the maximum number of transactions per block, unlimited if 0",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.maxBlockGas,
+		maxBlockGasFlag,
+		0,
+		"the maximum gas used per block, up to the block gas limit if 0",
+	)
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	policy, err := params.setPolicy(helper.GetGRPCAddress(cmd), cmd.Flags().Changed)
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(status.NewSealingPolicyResult(policy))
+}
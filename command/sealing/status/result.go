@@ -0,0 +1,48 @@
+package status
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	sealingOp "github.com/Gabulhas/polygon-external-consensus/consensus/sealing/proto"
+)
+
+type SealingPolicyResult struct {
+	Mode        string `json:"mode"`
+	Interval    uint64 `json:"interval"`
+	MaxBlockTxs uint64 `json:"max_block_txs"`
+	MaxBlockGas uint64 `json:"max_block_gas"`
+}
+
+func NewSealingPolicyResult(policy *sealingOp.Policy) *SealingPolicyResult {
+	return &SealingPolicyResult{
+		Mode:        policy.Mode,
+		Interval:    policy.Interval,
+		MaxBlockTxs: policy.MaxBlockTxs,
+		MaxBlockGas: policy.MaxBlockGas,
+	}
+}
+
+func (r *SealingPolicyResult) GetOutput() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString("\n[SEALING POLICY]\n")
+	buffer.WriteString(helper.FormatKV([]string{
+		fmt.Sprintf("Mode|%s", r.Mode),
+		fmt.Sprintf("Interval|%ds", r.Interval),
+		fmt.Sprintf("Max block transactions|%s", formatLimit(r.MaxBlockTxs)),
+		fmt.Sprintf("Max block gas|%s", formatLimit(r.MaxBlockGas)),
+	}))
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+func formatLimit(limit uint64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+
+	return fmt.Sprintf("%d", limit)
+}
@@ -0,0 +1,42 @@
+package status
+
+import (
+	"context"
+
+	"github.com/Gabulhas/polygon-external-consensus/command"
+	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	sealingOp "github.com/Gabulhas/polygon-external-consensus/consensus/sealing/proto"
+	"github.com/spf13/cobra"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+func GetCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Returns the current sealing policy of the engine",
+		Run:   runCommand,
+	}
+}
+
+func runCommand(cmd *cobra.Command, _ []string) {
+	outputter := command.InitializeOutputter(cmd)
+	defer outputter.WriteOutput()
+
+	policy, err := getPolicy(helper.GetGRPCAddress(cmd))
+	if err != nil {
+		outputter.SetError(err)
+
+		return
+	}
+
+	outputter.SetCommandResult(NewSealingPolicyResult(policy))
+}
+
+func getPolicy(grpcAddress string) (*sealingOp.Policy, error) {
+	client, err := helper.GetSealingOperatorClientConnection(grpcAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetPolicy(context.Background(), &empty.Empty{})
+}
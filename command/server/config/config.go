@@ -13,23 +13,31 @@ import (
 
 // Config defines the server configuration params
 type Config struct {
-	GenesisPath              string     `json:"chain_config" yaml:"chain_config"`
-	SecretsConfigPath        string     `json:"secrets_config" yaml:"secrets_config"`
-	DataDir                  string     `json:"data_dir" yaml:"data_dir"`
-	BlockGasTarget           string     `json:"block_gas_target" yaml:"block_gas_target"`
-	GRPCAddr                 string     `json:"grpc_addr" yaml:"grpc_addr"`
-	JSONRPCAddr              string     `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
-	Telemetry                *Telemetry `json:"telemetry" yaml:"telemetry"`
-	Network                  *Network   `json:"network" yaml:"network"`
-	ShouldSeal               bool       `json:"seal" yaml:"seal"`
-	TxPool                   *TxPool    `json:"tx_pool" yaml:"tx_pool"`
-	LogLevel                 string     `json:"log_level" yaml:"log_level"`
-	RestoreFile              string     `json:"restore_file" yaml:"restore_file"`
-	BlockTime                uint64     `json:"block_time_s" yaml:"block_time_s"`
-	Headers                  *Headers   `json:"headers" yaml:"headers"`
-	LogFilePath              string     `json:"log_to" yaml:"log_to"`
-	JSONRPCBatchRequestLimit uint64     `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
-	JSONRPCBlockRangeLimit   uint64     `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	GenesisPath              string       `json:"chain_config" yaml:"chain_config"`
+	SecretsConfigPath        string       `json:"secrets_config" yaml:"secrets_config"`
+	DataDir                  string       `json:"data_dir" yaml:"data_dir"`
+	BlockGasTarget           string       `json:"block_gas_target" yaml:"block_gas_target"`
+	GRPCAddr                 string       `json:"grpc_addr" yaml:"grpc_addr"`
+	JSONRPCAddr              string       `json:"jsonrpc_addr" yaml:"jsonrpc_addr"`
+	Telemetry                *Telemetry   `json:"telemetry" yaml:"telemetry"`
+	Network                  *Network     `json:"network" yaml:"network"`
+	ShouldSeal               bool         `json:"seal" yaml:"seal"`
+	TxPool                   *TxPool      `json:"tx_pool" yaml:"tx_pool"`
+	LogLevel                 string       `json:"log_level" yaml:"log_level"`
+	RestoreFile              string       `json:"restore_file" yaml:"restore_file"`
+	BlockTime                uint64       `json:"block_time_s" yaml:"block_time_s"`
+	Headers                  *Headers     `json:"headers" yaml:"headers"`
+	LogFilePath              string       `json:"log_to" yaml:"log_to"`
+	JSONRPCBatchRequestLimit uint64       `json:"json_rpc_batch_request_limit" yaml:"json_rpc_batch_request_limit"`
+	JSONRPCBlockRangeLimit   uint64       `json:"json_rpc_block_range_limit" yaml:"json_rpc_block_range_limit"`
+	JSONRPCIPCPath           string       `json:"json_rpc_ipc_path" yaml:"json_rpc_ipc_path"`
+	JSONRPCAuth              *JSONRPCAuth `json:"json_rpc_auth,omitempty" yaml:"json_rpc_auth,omitempty"`
+	JSONRPCRateLimit         float64      `json:"json_rpc_rate_limit" yaml:"json_rpc_rate_limit"`
+	JSONRPCRateBurst         float64      `json:"json_rpc_rate_burst" yaml:"json_rpc_rate_burst"`
+	JSONRPCDebug             bool         `json:"json_rpc_debug" yaml:"json_rpc_debug"`
+
+	// JSONRPCMethodCosts are the rate limiting costs of the methods, or of patterns such as debug_*
+	JSONRPCMethodCosts map[string]float64 `json:"json_rpc_method_costs,omitempty" yaml:"json_rpc_method_costs,omitempty"`
 }
 
 // Telemetry holds the config details for metric services.
@@ -53,6 +61,25 @@ type TxPool struct {
 	PriceLimit         uint64 `json:"price_limit" yaml:"price_limit"`
 	MaxSlots           uint64 `json:"max_slots" yaml:"max_slots"`
 	MaxAccountEnqueued uint64 `json:"max_account_enqueued" yaml:"max_account_enqueued"`
+	MaxAccountPromoted uint64 `json:"max_account_promoted" yaml:"max_account_promoted"`
+	PriceBump          uint64 `json:"price_bump" yaml:"price_bump"`
+	Journal            string `json:"journal" yaml:"journal"`
+	JournalRotate      uint64 `json:"journal_rotate_s" yaml:"journal_rotate_s"`
+	PrivateTxLifetime  uint64 `json:"private_tx_lifetime" yaml:"private_tx_lifetime"`
+}
+
+// JSONRPCAuth defines the credentials accepted by the JSON-RPC server over HTTP and WS
+type JSONRPCAuth struct {
+	JWT     *JSONRPCCredential   `json:"jwt,omitempty" yaml:"jwt,omitempty"`
+	APIKeys []*JSONRPCCredential `json:"api_keys,omitempty" yaml:"api_keys,omitempty"`
+}
+
+// JSONRPCCredential defines a JSON-RPC credential and the methods it allows,
+// the lists accept method names and patterns such as eth_*
+type JSONRPCCredential struct {
+	Key   string   `json:"key,omitempty" yaml:"key,omitempty"`
+	Allow []string `json:"allow,omitempty" yaml:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty" yaml:"deny,omitempty"`
 }
 
 // Headers defines the HTTP response headers required to enable CORS.
@@ -100,6 +127,10 @@ func DefaultConfig() *Config {
 			PriceLimit:         0,
 			MaxSlots:           4096,
 			MaxAccountEnqueued: 128,
+			PriceBump:          10,
+			Journal:            "transactions.rlp",
+			JournalRotate:      3600,
+			PrivateTxLifetime:  100,
 		},
 		LogLevel:    "INFO",
 		RestoreFile: "",
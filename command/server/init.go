@@ -12,6 +12,7 @@ import (
 
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/command/helper"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing"
 	"github.com/Gabulhas/polygon-external-consensus/network"
 	"github.com/Gabulhas/polygon-external-consensus/secrets"
 	"github.com/Gabulhas/polygon-external-consensus/server"
@@ -19,8 +20,11 @@ import (
 )
 
 var (
-	errInvalidBlockTime       = errors.New("invalid block time specified")
-	errDataDirectoryUndefined = errors.New("data directory not defined")
+	errInvalidBlockTime        = errors.New("invalid block time specified")
+	errDataDirectoryUndefined  = errors.New("data directory not defined")
+	errEmptyJSONRPCAPIKey      = errors.New("empty JSON-RPC API key")
+	errDuplicateJSONRPCAPIKey  = errors.New("duplicate JSON-RPC API key")
+	errInvalidJSONRPCRateLimit = errors.New("invalid JSON-RPC rate limit")
 )
 
 func (p *serverParams) initConfigFromFile() error {
@@ -54,6 +58,14 @@ func (p *serverParams) initRawParams() error {
 		return err
 	}
 
+	if err := p.initJSONRPCAuth(); err != nil {
+		return err
+	}
+
+	if err := p.initJSONRPCRateLimit(); err != nil {
+		return err
+	}
+
 	if p.isDevMode {
 		p.initDevMode()
 	}
@@ -72,6 +84,42 @@ func (p *serverParams) initBlockTime() error {
 	return nil
 }
 
+func (p *serverParams) initJSONRPCAuth() error {
+	if p.rawConfig.JSONRPCAuth == nil {
+		return nil
+	}
+
+	apiKeys := make(map[string]struct{}, len(p.rawConfig.JSONRPCAuth.APIKeys))
+
+	for _, apiKey := range p.rawConfig.JSONRPCAuth.APIKeys {
+		if apiKey.Key == "" {
+			return errEmptyJSONRPCAPIKey
+		}
+
+		if _, ok := apiKeys[apiKey.Key]; ok {
+			return errDuplicateJSONRPCAPIKey
+		}
+
+		apiKeys[apiKey.Key] = struct{}{}
+	}
+
+	return nil
+}
+
+func (p *serverParams) initJSONRPCRateLimit() error {
+	if p.rawConfig.JSONRPCRateLimit < 0 || p.rawConfig.JSONRPCRateBurst < 0 {
+		return errInvalidJSONRPCRateLimit
+	}
+
+	for method, cost := range p.rawConfig.JSONRPCMethodCosts {
+		if cost < 0 {
+			return fmt.Errorf("%w: negative cost of %s", errInvalidJSONRPCRateLimit, method)
+		}
+	}
+
+	return nil
+}
+
 func (p *serverParams) initDataDirLocation() error {
 	if p.rawConfig.DataDir == "" {
 		return errDataDirectoryUndefined
@@ -146,10 +194,19 @@ func (p *serverParams) initDevConsensusConfig() {
 		return
 	}
 
+	// keep the sealing policy of the genesis file, if any
+	devConfig, ok := p.genesisConfig.Params.Engine[string(server.DevConsensus)].(map[string]interface{})
+	if !ok {
+		devConfig = map[string]interface{}{}
+	}
+
+	// the interval of the genesis file, or the default one of the engine, is used if not set
+	if p.devInterval != 0 {
+		devConfig[sealing.KeyInterval] = p.devInterval
+	}
+
 	p.genesisConfig.Params.Engine = map[string]interface{}{
-		string(server.DevConsensus): map[string]interface{}{
-			"interval": p.devInterval,
-		},
+		string(server.DevConsensus): devConfig,
 	}
 }
 
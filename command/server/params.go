@@ -3,9 +3,11 @@ package server
 import (
 	"errors"
 	"net"
+	"path/filepath"
 
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/command/server/config"
+	"github.com/Gabulhas/polygon-external-consensus/jsonrpc"
 	"github.com/Gabulhas/polygon-external-consensus/network"
 	"github.com/Gabulhas/polygon-external-consensus/secrets"
 	"github.com/Gabulhas/polygon-external-consensus/server"
@@ -28,8 +30,18 @@ const (
 	priceLimitFlag               = "price-limit"
 	jsonRPCBatchRequestLimitFlag = "json-rpc-batch-request-limit"
 	jsonRPCBlockRangeLimitFlag   = "json-rpc-block-range-limit"
+	jsonRPCIPCPathFlag           = "json-rpc-ipc-path"
+	jsonRPCJWTAuthFlag           = "json-rpc-jwt-auth"
+	jsonRPCRateLimitFlag         = "json-rpc-rate-limit"
+	jsonRPCRateBurstFlag         = "json-rpc-rate-burst"
+	jsonRPCDebugFlag             = "json-rpc-debug"
 	maxSlotsFlag                 = "max-slots"
 	maxEnqueuedFlag              = "max-enqueued"
+	maxPromotedFlag              = "max-promoted"
+	priceBumpFlag                = "price-bump"
+	txJournalFlag                = "tx-journal"
+	txJournalRotateFlag          = "tx-journal-rotate"
+	privateTxLifetimeFlag        = "private-tx-lifetime"
 	blockGasTargetFlag           = "block-gas-target"
 	secretsConfigFlag            = "secrets-config"
 	restoreFlag                  = "restore"
@@ -80,6 +92,7 @@ type serverParams struct {
 	isDevMode      bool
 
 	corsAllowedOrigins []string
+	jsonRPCJWTAuth     bool
 
 	ibftBaseTimeoutLegacy uint64
 
@@ -130,6 +143,80 @@ func (p *serverParams) getRestoreFilePath() *string {
 	return nil
 }
 
+// getIPCPath returns the path of the JSON-RPC IPC socket,
+// a relative path is resolved in the data directory
+func (p *serverParams) getIPCPath() string {
+	ipcPath := p.rawConfig.JSONRPCIPCPath
+	if ipcPath == "" || filepath.IsAbs(ipcPath) {
+		return ipcPath
+	}
+
+	return filepath.Join(p.rawConfig.DataDir, ipcPath)
+}
+
+// getTxJournalPath returns the path of the journal of the local transactions,
+// a relative path is resolved in the data directory
+func (p *serverParams) getTxJournalPath() string {
+	journalPath := p.rawConfig.TxPool.Journal
+	if journalPath == "" || filepath.IsAbs(journalPath) {
+		return journalPath
+	}
+
+	return filepath.Join(p.rawConfig.DataDir, journalPath)
+}
+
+// getJSONRPCAuth returns the authentication of the JSON-RPC server, nil if it's disabled
+func (p *serverParams) getJSONRPCAuth() *server.JSONRPCAuth {
+	rawAuth := p.rawConfig.JSONRPCAuth
+	if rawAuth == nil && !p.jsonRPCJWTAuth {
+		return nil
+	}
+
+	auth := &server.JSONRPCAuth{
+		JWT:     p.jsonRPCJWTAuth,
+		APIKeys: make(map[string]*jsonrpc.MethodRules),
+	}
+
+	if rawAuth == nil {
+		return auth
+	}
+
+	if rawAuth.JWT != nil {
+		auth.JWT = true
+		auth.JWTMethods = &jsonrpc.MethodRules{
+			Allow: rawAuth.JWT.Allow,
+			Deny:  rawAuth.JWT.Deny,
+		}
+	}
+
+	for _, apiKey := range rawAuth.APIKeys {
+		auth.APIKeys[apiKey.Key] = &jsonrpc.MethodRules{
+			Allow: apiKey.Allow,
+			Deny:  apiKey.Deny,
+		}
+	}
+
+	return auth
+}
+
+// getJSONRPCRateLimit returns the rate limiting of the JSON-RPC server, nil if it's disabled
+func (p *serverParams) getJSONRPCRateLimit() *jsonrpc.RateLimitConfig {
+	if p.rawConfig.JSONRPCRateLimit == 0 {
+		return nil
+	}
+
+	methodCosts := p.rawConfig.JSONRPCMethodCosts
+	if len(methodCosts) == 0 {
+		methodCosts = jsonrpc.DefaultMethodCosts()
+	}
+
+	return &jsonrpc.RateLimitConfig{
+		Rate:        p.rawConfig.JSONRPCRateLimit,
+		Burst:       p.rawConfig.JSONRPCRateBurst,
+		MethodCosts: methodCosts,
+	}
+}
+
 func (p *serverParams) setRawGRPCAddress(grpcAddress string) {
 	p.rawConfig.GRPCAddr = grpcAddress
 }
@@ -146,6 +233,10 @@ func (p *serverParams) generateConfig() *server.Config {
 			AccessControlAllowOrigin: p.corsAllowedOrigins,
 			BatchLengthLimit:         p.rawConfig.JSONRPCBatchRequestLimit,
 			BlockRangeLimit:          p.rawConfig.JSONRPCBlockRangeLimit,
+			IPCPath:                  p.getIPCPath(),
+			Auth:                     p.getJSONRPCAuth(),
+			RateLimit:                p.getJSONRPCRateLimit(),
+			EnableDebug:              p.rawConfig.JSONRPCDebug,
 		},
 		GRPCAddr:   p.grpcAddress,
 		LibP2PAddr: p.libp2pAddress,
@@ -168,6 +259,11 @@ func (p *serverParams) generateConfig() *server.Config {
 		PriceLimit:         p.rawConfig.TxPool.PriceLimit,
 		MaxSlots:           p.rawConfig.TxPool.MaxSlots,
 		MaxAccountEnqueued: p.rawConfig.TxPool.MaxAccountEnqueued,
+		MaxAccountPromoted: p.rawConfig.TxPool.MaxAccountPromoted,
+		PriceBump:          p.rawConfig.TxPool.PriceBump,
+		TxJournal:          p.getTxJournalPath(),
+		TxJournalRotate:    p.rawConfig.TxPool.JournalRotate,
+		PrivateTxLifetime:  p.rawConfig.TxPool.PrivateTxLifetime,
 		SecretsManager:     p.secretsConfig,
 		RestoreFile:        p.getRestoreFilePath(),
 		BlockTime:          p.rawConfig.BlockTime,
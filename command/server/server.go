@@ -185,6 +185,44 @@ func setFlags(cmd *cobra.Command) {
 		"maximum number of enqueued transactions per account",
 	)
 
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.MaxAccountPromoted,
+		maxPromotedFlag,
+		defaultConfig.TxPool.MaxAccountPromoted,
+		"maximum number of promoted transactions per account, value of 0 disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PriceBump,
+		priceBumpFlag,
+		defaultConfig.TxPool.PriceBump,
+		"the minimum percentage a transaction must be priced above the pooled one "+
+			"with the same nonce to replace it",
+	)
+
+	cmd.Flags().StringVar(
+		&params.rawConfig.TxPool.Journal,
+		txJournalFlag,
+		defaultConfig.TxPool.Journal,
+		"the path of the journal of the local transactions, relative to the data directory "+
+			"unless absolute, an empty value disables it",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.JournalRotate,
+		txJournalRotateFlag,
+		defaultConfig.TxPool.JournalRotate,
+		"the interval in seconds of the rewrites of the journal of the local transactions",
+	)
+
+	cmd.Flags().Uint64Var(
+		&params.rawConfig.TxPool.PrivateTxLifetime,
+		privateTxLifetimeFlag,
+		defaultConfig.TxPool.PrivateTxLifetime,
+		"the number of blocks the private transactions are kept in the pool until included, "+
+			"value of 0 keeps them until included",
+	)
+
 	cmd.Flags().Uint64Var(
 		&params.rawConfig.BlockTime,
 		blockTimeFlag,
@@ -214,6 +252,44 @@ func setFlags(cmd *cobra.Command) {
 			"that consider fromBlock/toBlock values (e.g. eth_getLogs), value of 0 disables it",
 	)
 
+	cmd.Flags().StringVar(
+		&params.rawConfig.JSONRPCIPCPath,
+		jsonRPCIPCPathFlag,
+		defaultConfig.JSONRPCIPCPath,
+		"the path of the IPC socket serving the JSON-RPC endpoints, relative to the data directory "+
+			"unless absolute, an empty value disables it",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.jsonRPCJWTAuth,
+		jsonRPCJWTAuthFlag,
+		false,
+		"require the JSON-RPC requests over HTTP and WS to carry a JWT token signed with the secret "+
+			"of the secrets manager, which is generated if missing",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.JSONRPCRateLimit,
+		jsonRPCRateLimitFlag,
+		defaultConfig.JSONRPCRateLimit,
+		"the cost of the JSON-RPC requests over HTTP and WS allowed per second for each client, "+
+			"identified by its API key or IP address, value of 0 disables it",
+	)
+
+	cmd.Flags().Float64Var(
+		&params.rawConfig.JSONRPCRateBurst,
+		jsonRPCRateBurstFlag,
+		defaultConfig.JSONRPCRateBurst,
+		"the cost of the JSON-RPC requests allowed at once for each client, the rate limit if lower",
+	)
+
+	cmd.Flags().BoolVar(
+		&params.rawConfig.JSONRPCDebug,
+		jsonRPCDebugFlag,
+		defaultConfig.JSONRPCDebug,
+		"serve the debug and trace JSON-RPC endpoints, which replay whole blocks and are expensive",
+	)
+
 	cmd.Flags().StringVar(
 		&params.rawConfig.LogFilePath,
 		logFileLocationFlag,
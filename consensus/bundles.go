@@ -0,0 +1,121 @@
+package consensus
+
+import (
+	"errors"
+
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/txpool"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	errBundleTxFailed    = errors.New("bundle transaction failed")
+	errBundleNotAdmitted = errors.New("bundle transaction not admitted in the block")
+)
+
+// BundlePool is the pool of the bundles of transactions
+type BundlePool interface {
+	Bundles(number, timestamp uint64) []*txpool.Bundle
+}
+
+// BundleTransition is the state transition the bundles are simulated on
+type BundleTransition interface {
+	Write(txn *types.Transaction) error
+	TotalGas() uint64
+	Receipts() []*types.Receipt
+	Snapshot() *state.TransitionSnapshot
+	RevertToSnapshot(snapshot *state.TransitionSnapshot)
+}
+
+// WriteBundlesParams are parameters passed into the WriteBundles helper method
+type WriteBundlesParams struct {
+	Logger     hclog.Logger
+	Pool       BundlePool
+	Transition BundleTransition
+	Header     *types.Header
+
+	// Admits checks if a transaction with the given gas fits in the block which already
+	// includes the given number of transactions and gas, any transaction fits if nil
+	Admits func(txs int, gasUsed, gas uint64) bool
+}
+
+// WriteBundles writes the bundles of the pool admitted by the block, ahead of its other transactions.
+// Each bundle is simulated on a snapshot of the transition, and is included atomically only if
+// every one of its transactions succeeds, otherwise the transition is reverted to the snapshot
+func WriteBundles(params WriteBundlesParams) []*types.Transaction {
+	var included []*types.Transaction
+
+	for _, bundle := range params.Pool.Bundles(params.Header.Number, params.Header.Timestamp) {
+		snapshot := params.Transition.Snapshot()
+
+		if err := writeBundle(params, bundle, len(included)); err != nil {
+			params.Transition.RevertToSnapshot(snapshot)
+
+			params.Logger.Debug("bundle not included", "hash", bundle.Hash, "err", err)
+
+			continue
+		}
+
+		included = append(included, bundle.Txs...)
+	}
+
+	if len(included) != 0 {
+		params.Logger.Info("included bundles txns", "num", len(included))
+	}
+
+	return included
+}
+
+// BundledTxs are the transactions written by the included bundles, by sender and nonce
+type BundledTxs map[types.Address]map[uint64]*types.Transaction
+
+// NewBundledTxs indexes the given transactions written by the included bundles
+func NewBundledTxs(txs []*types.Transaction) BundledTxs {
+	bundled := make(BundledTxs)
+
+	for _, tx := range txs {
+		if bundled[tx.From] == nil {
+			bundled[tx.From] = make(map[uint64]*types.Transaction)
+		}
+
+		bundled[tx.From][tx.Nonce] = tx
+	}
+
+	return bundled
+}
+
+// Get returns the bundled transaction with the sender and nonce of the given transaction
+// of the pool, if any. The transaction of the pool can't be written anymore, and has to be
+// popped from the pool with the bundled one, which removes it if it's a different transaction
+func (b BundledTxs) Get(tx *types.Transaction) (*types.Transaction, bool) {
+	if tx == nil {
+		return nil, false
+	}
+
+	bundledTx, ok := b[tx.From][tx.Nonce]
+
+	return bundledTx, ok
+}
+
+// writeBundle writes the transactions of the bundle, failing on the first one not succeeding
+func writeBundle(params WriteBundlesParams, bundle *txpool.Bundle, included int) error {
+	for i, tx := range bundle.Txs {
+		if tx.ExceedsBlockGasLimit(params.Header.GasLimit) ||
+			(params.Admits != nil && !params.Admits(included+i, params.Transition.TotalGas(), tx.Gas)) {
+			return errBundleNotAdmitted
+		}
+
+		if err := params.Transition.Write(tx); err != nil {
+			return err
+		}
+
+		// the reverted transactions are written with a failed receipt
+		receipts := params.Transition.Receipts()
+		if status := receipts[len(receipts)-1].Status; status != nil && *status == types.ReceiptFailed {
+			return errBundleTxFailed
+		}
+	}
+
+	return nil
+}
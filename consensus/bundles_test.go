@@ -0,0 +1,197 @@
+package consensus
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/txpool"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBundlePool []*txpool.Bundle
+
+func (p mockBundlePool) Bundles(number, timestamp uint64) (bundles []*txpool.Bundle) {
+	for _, bundle := range p {
+		if bundle.Admits(number, timestamp) {
+			bundles = append(bundles, bundle)
+		}
+	}
+
+	return
+}
+
+// mockTransition writes the txs with the gas of their nonce,
+// failing the ones in errs and reverting the ones in reverts
+type mockTransition struct {
+	written   []*types.Transaction
+	receipts  []*types.Receipt
+	totalGas  uint64
+	snapshots map[*state.TransitionSnapshot]int
+
+	errs    map[types.Hash]error
+	reverts map[types.Hash]bool
+}
+
+func newMockTransition() *mockTransition {
+	return &mockTransition{
+		snapshots: make(map[*state.TransitionSnapshot]int),
+		errs:      make(map[types.Hash]error),
+		reverts:   make(map[types.Hash]bool),
+	}
+}
+
+func (m *mockTransition) Write(tx *types.Transaction) error {
+	if err := m.errs[tx.Hash]; err != nil {
+		return err
+	}
+
+	receipt := &types.Receipt{TxHash: tx.Hash, GasUsed: tx.Nonce}
+	if m.reverts[tx.Hash] {
+		receipt.SetStatus(types.ReceiptFailed)
+	} else {
+		receipt.SetStatus(types.ReceiptSuccess)
+	}
+
+	m.written = append(m.written, tx)
+	m.receipts = append(m.receipts, receipt)
+	m.totalGas += tx.Nonce
+
+	return nil
+}
+
+func (m *mockTransition) TotalGas() uint64 {
+	return m.totalGas
+}
+
+func (m *mockTransition) Receipts() []*types.Receipt {
+	return m.receipts
+}
+
+func (m *mockTransition) Snapshot() *state.TransitionSnapshot {
+	snapshot := &state.TransitionSnapshot{}
+	m.snapshots[snapshot] = len(m.written)
+
+	return snapshot
+}
+
+func (m *mockTransition) RevertToSnapshot(snapshot *state.TransitionSnapshot) {
+	n := m.snapshots[snapshot]
+
+	m.written = m.written[:n]
+	m.receipts = m.receipts[:n]
+	m.totalGas = 0
+
+	for _, tx := range m.written {
+		m.totalGas += tx.Nonce
+	}
+}
+
+func TestWriteBundles(t *testing.T) {
+	t.Parallel()
+
+	newTx := func(nonce uint64) *types.Transaction {
+		return (&types.Transaction{Nonce: nonce, Gas: nonce, Input: []byte{byte(nonce)}}).ComputeHash()
+	}
+
+	newBundle := func(number uint64, txs ...*types.Transaction) *txpool.Bundle {
+		return &txpool.Bundle{
+			Hash:        txs[0].Hash,
+			Txs:         txs,
+			BlockNumber: number,
+		}
+	}
+
+	header := &types.Header{Number: 10, Timestamp: 100, GasLimit: 1000}
+
+	t.Run("bundles are included atomically", func(t *testing.T) {
+		t.Parallel()
+
+		tx1, tx2, tx3, tx4, tx5 := newTx(1), newTx(2), newTx(3), newTx(4), newTx(5)
+
+		transition := newMockTransition()
+		transition.reverts[tx3.Hash] = true
+		transition.errs[tx5.Hash] = errors.New("nonce too low")
+
+		included := WriteBundles(WriteBundlesParams{
+			Logger: hclog.NewNullLogger(),
+			Pool: mockBundlePool{
+				newBundle(10, tx1, tx2),
+				newBundle(10, tx4, tx3), // the reverted tx3 discards tx4
+				newBundle(10, tx4, tx5), // the failed tx5 discards tx4
+				newBundle(11, tx4),      // targets the next block
+			},
+			Transition: transition,
+			Header:     header,
+		})
+
+		assert.Equal(t, []*types.Transaction{tx1, tx2}, included)
+		assert.Equal(t, []*types.Transaction{tx1, tx2}, transition.written)
+		assert.Len(t, transition.receipts, 2)
+		assert.Equal(t, uint64(3), transition.totalGas)
+	})
+
+	t.Run("bundles not admitted by the block are discarded", func(t *testing.T) {
+		t.Parallel()
+
+		tx1, tx2, tx3 := newTx(1), newTx(2), newTx(3)
+
+		transition := newMockTransition()
+
+		included := WriteBundles(WriteBundlesParams{
+			Logger: hclog.NewNullLogger(),
+			Pool: mockBundlePool{
+				newBundle(10, tx1),
+				newBundle(10, tx2, tx3),
+			},
+			Transition: transition,
+			Header:     header,
+			// at most 2 txs per block
+			Admits: func(txs int, gasUsed, gas uint64) bool {
+				return txs < 2
+			},
+		})
+
+		assert.Equal(t, []*types.Transaction{tx1}, included)
+		assert.Equal(t, []*types.Transaction{tx1}, transition.written)
+	})
+}
+
+func TestBundledTxs_Get(t *testing.T) {
+	t.Parallel()
+
+	addr1, addr2 := types.Address{0x1}, types.Address{0x2}
+
+	newTx := func(from types.Address, nonce uint64, input byte) *types.Transaction {
+		return (&types.Transaction{From: from, Nonce: nonce, Input: []byte{input}}).ComputeHash()
+	}
+
+	bundledTx1, bundledTx2 := newTx(addr1, 0, 1), newTx(addr1, 1, 1)
+	bundled := NewBundledTxs([]*types.Transaction{bundledTx1, bundledTx2, newTx(addr2, 5, 1)})
+
+	tests := []struct {
+		name     string
+		tx       *types.Transaction
+		expected *types.Transaction
+	}{
+		{"the bundled tx", bundledTx1, bundledTx1},
+		{"another tx with a bundled nonce", newTx(addr1, 1, 2), bundledTx2},
+		{"the next nonce", newTx(addr1, 2, 1), nil},
+		{"another sender", newTx(addr2, 0, 1), nil},
+		{"no tx", nil, nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, ok := bundled.Get(tt.tx)
+			assert.Equal(t, tt.expected != nil, ok)
+			assert.Equal(t, tt.expected, tx)
+		})
+	}
+}
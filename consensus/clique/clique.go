@@ -0,0 +1,652 @@
+package clique
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/blockchain"
+	"github.com/Gabulhas/polygon-external-consensus/consensus"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/clique/proto"
+	"github.com/Gabulhas/polygon-external-consensus/crypto"
+	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/syncer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc"
+)
+
+const (
+	DefaultEpochSize = 30000
+	KeyPeriod        = "period"
+	KeyEpoch         = "epoch"
+
+	cliqueConsensus = "clique"
+
+	// Maximum random delay of the out-of-turn signers, per signer
+	wiggleTime = 500 * time.Millisecond
+
+	// Interval of the checks of the transaction pool when blocks are only sealed on demand
+	pendingCheckInterval = time.Second
+)
+
+var (
+	ErrMissingVanity                = errors.New("extra-data 32 byte vanity prefix missing")
+	ErrMissingSignature             = errors.New("extra-data 65 byte signature suffix missing")
+	ErrExtraSigners                 = errors.New("non-checkpoint block contains extra signer list")
+	ErrInvalidCheckpointSigners     = errors.New("invalid signer list on checkpoint block")
+	ErrMismatchingCheckpointSigners = errors.New("mismatching signer list on checkpoint block")
+	ErrInvalidCheckpointBeneficiary = errors.New("beneficiary in checkpoint block non-zero")
+	ErrInvalidVote                  = errors.New("vote nonce not 0x00..0 or 0xff..f")
+	ErrInvalidCheckpointVote        = errors.New("vote nonce in checkpoint block non-zero")
+	ErrInvalidMixDigest             = errors.New("non-zero mix digest")
+	ErrInvalidUncleHash             = errors.New("non empty uncle hash")
+	ErrInvalidDifficulty            = errors.New("invalid difficulty")
+	ErrWrongDifficulty              = errors.New("wrong difficulty")
+	ErrInvalidTimestamp             = errors.New("invalid timestamp")
+	ErrUnknownAncestor              = errors.New("unknown ancestor")
+	ErrUnauthorizedSigner           = errors.New("unauthorized signer")
+	ErrRecentlySigned               = errors.New("recently signed")
+	errInvalidConfigType            = errors.New("invalid type assertion for clique config")
+)
+
+type txPoolInterface interface {
+	Prepare(baseFee uint64)
+	Length() uint64
+	Peek() *types.Transaction
+	Pop(tx *types.Transaction)
+	Drop(tx *types.Transaction)
+	Demote(tx *types.Transaction)
+	ResetWithHeaders(headers ...*types.Header)
+	SetSealing(bool)
+}
+
+type blockchainInterface interface {
+	Header() *types.Header
+	GetHeaderByNumber(uint64) (*types.Header, bool)
+	GetHeaderByHash(types.Hash) (*types.Header, bool)
+	SubscribeEvents() blockchain.Subscription
+	CalculateGasLimit(number uint64) (uint64, error)
+	CalculateBaseFee(parent *types.Header) uint64
+	VerifyFinalizedBlock(block *types.Block) error
+	WriteBlock(block *types.Block, source string) error
+}
+
+// Clique is the proof-of-authority consensus protocol of the Ethereum clients,
+// where the authorized signers seal the blocks in turn and vote on adding and removing signers
+type Clique struct {
+	logger hclog.Logger
+
+	blockchain blockchainInterface
+	executor   *state.Executor
+	txpool     txPoolInterface
+	syncer     syncer.Syncer
+	grpc       *grpc.Server
+	operator   *operator
+
+	config *consensus.Config
+	period uint64 // Minimum time between blocks, in seconds. Blocks are sealed on demand if 0
+	epoch  uint64 // Number of blocks after which the votes are reset and the signers are checkpointed
+
+	key     *ecdsa.PrivateKey // Signer key of the node
+	address types.Address     // Signer address of the node
+
+	signer  *headerSigner
+	signers *signerStore
+
+	closeCh chan struct{}
+}
+
+// Factory implements the base consensus Factory method
+func Factory(params *consensus.Params) (consensus.Consensus, error) {
+	logger := params.Logger.Named("clique")
+
+	period, err := readUint64(params.Config.Config, KeyPeriod, params.BlockTime)
+	if err != nil {
+		return nil, err
+	}
+
+	epoch, err := readUint64(params.Config.Config, KeyEpoch, DefaultEpochSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if epoch == 0 {
+		return nil, fmt.Errorf("clique epoch must be greater than 0")
+	}
+
+	key, err := crypto.ReadConsensusKey(params.SecretsManager)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the signer key, %w", err)
+	}
+
+	signer, err := newHeaderSigner()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Clique{
+		logger:     logger,
+		blockchain: params.Blockchain,
+		executor:   params.Executor,
+		txpool:     params.TxPool,
+		syncer: syncer.NewSyncer(
+			params.Logger,
+			params.Network,
+			params.Blockchain,
+			time.Duration(period+1)*3*time.Second,
+		),
+		grpc:    params.Grpc,
+		config:  params.Config,
+		period:  period,
+		epoch:   epoch,
+		key:     key,
+		address: crypto.PubKeyToAddress(&key.PublicKey),
+		signer:  signer,
+		closeCh: make(chan struct{}),
+	}
+
+	return c, nil
+}
+
+// readUint64 reads the number at the given key of the engine configuration, if any
+func readUint64(config map[string]interface{}, key string, defaultValue uint64) (uint64, error) {
+	raw, ok := config[key]
+	if !ok {
+		return defaultValue, nil
+	}
+
+	value, ok := raw.(float64)
+	if !ok {
+		return 0, errInvalidConfigType
+	}
+
+	return uint64(value), nil
+}
+
+// Initialize initializes the consensus
+func (c *Clique) Initialize() error {
+	signers, err := newSignerStore(
+		c.logger,
+		c.blockchain,
+		c.signer,
+		c.config.Path,
+		c.epoch,
+		c.address,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.signers = signers
+
+	// register the grpc operator
+	if c.grpc != nil {
+		c.operator = &operator{clique: c}
+		proto.RegisterCliqueOperatorServer(c.grpc, c.operator)
+	}
+
+	c.logger.Info("signer key", "addr", c.address.String())
+
+	return nil
+}
+
+// Start starts the consensus mechanism
+func (c *Clique) Start() error {
+	// Start the syncer
+	if err := c.syncer.Start(); err != nil {
+		return err
+	}
+
+	// Start syncing blocks from other peers
+	go c.startSyncing()
+
+	// Start sealing the blocks
+	go c.run()
+
+	return nil
+}
+
+// startSyncing runs the syncer in the background to receive blocks from advanced peers
+func (c *Clique) startSyncing() {
+	if err := c.syncer.Sync(func(block *types.Block) bool {
+		c.txpool.ResetWithHeaders(block.Header)
+
+		return false
+	}); err != nil {
+		c.logger.Error("watch sync failed", "err", err)
+	}
+}
+
+// run seals a new block on top of each new head, whenever the node is allowed to
+func (c *Clique) run() {
+	c.logger.Info("consensus started")
+
+	sub := c.blockchain.SubscribeEvents()
+	defer sub.Close()
+
+	eventCh := sub.GetEventCh()
+
+	for {
+		parent := c.blockchain.Header()
+
+		delay, err := c.sealDelay(parent)
+		if err != nil {
+			c.logger.Debug("not sealing", "number", parent.Number+1, "reason", err)
+		}
+
+		// only wait for the next head if the node can't seal the next block
+		var timerCh <-chan time.Time
+
+		if err == nil {
+			timerCh = time.After(delay)
+		} else if c.period == 0 {
+			// the transaction pool is checked again later
+			timerCh = time.After(pendingCheckInterval)
+		}
+
+		select {
+		case <-eventCh:
+			continue
+		case <-timerCh:
+		case <-c.closeCh:
+			return
+		}
+
+		if err != nil {
+			continue
+		}
+
+		if err := c.writeNewBlock(parent); err != nil {
+			c.logger.Error("failed to seal block", "number", parent.Number+1, "err", err)
+
+			// retry once the head changes or the period elapses
+			select {
+			case <-eventCh:
+			case <-time.After(time.Duration(c.period+1) * time.Second):
+			case <-c.closeCh:
+				return
+			}
+		}
+	}
+}
+
+var errNoPendingTransactions = errors.New("no pending transactions")
+
+// sealDelay returns the time to wait before sealing the block on top of the parent,
+// or an error if the node is not allowed to seal it
+func (c *Clique) sealDelay(parent *types.Header) (time.Duration, error) {
+	set, err := c.signers.signersAt(parent.Number)
+	if err != nil {
+		return 0, err
+	}
+
+	c.txpool.SetSealing(set.Includes(c.address))
+
+	if !set.Includes(c.address) {
+		return 0, ErrUnauthorizedSigner
+	}
+
+	if c.period == 0 && c.txpool.Length() == 0 {
+		return 0, errNoPendingTransactions
+	}
+
+	signers := sortedSigners(set)
+	number := parent.Number + 1
+
+	if err := c.verifyRecents(parent, len(signers), c.address); err != nil {
+		return 0, err
+	}
+
+	delay := time.Until(time.Unix(int64(c.blockTimestamp(parent)), 0))
+
+	if !inTurn(signers, number, c.address) {
+		// give the in-turn signer a head start
+		//nolint:gosec
+		delay += time.Duration(rand.Int63n(int64(time.Duration(len(signers)/2+1) * wiggleTime)))
+	}
+
+	return delay, nil
+}
+
+// blockTimestamp returns the timestamp of the block sealed on top of the parent
+func (c *Clique) blockTimestamp(parent *types.Header) uint64 {
+	timestamp := parent.Timestamp + c.period
+
+	if now := uint64(time.Now().Unix()); timestamp < now {
+		timestamp = now
+	}
+
+	return timestamp
+}
+
+type transitionInterface interface {
+	Write(txn *types.Transaction) error
+}
+
+func (c *Clique) writeTransactions(
+	gasLimit,
+	baseFee uint64,
+	transition transitionInterface,
+) []*types.Transaction {
+	var successful []*types.Transaction
+
+	c.txpool.Prepare(baseFee)
+
+	for {
+		tx := c.txpool.Peek()
+		if tx == nil {
+			break
+		}
+
+		if tx.ExceedsBlockGasLimit(gasLimit) {
+			c.txpool.Drop(tx)
+
+			continue
+		}
+
+		if err := transition.Write(tx); err != nil {
+			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
+				break
+			} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { //nolint:errorlint
+				c.txpool.Demote(tx)
+			} else {
+				c.txpool.Drop(tx)
+			}
+
+			continue
+		}
+
+		// no errors, pop the tx from the pool
+		c.txpool.Pop(tx)
+
+		successful = append(successful, tx)
+	}
+
+	c.logger.Info("picked out txns from pool", "num", len(successful), "remaining", c.txpool.Length())
+
+	return successful
+}
+
+// writeNewBlock seals a new block on top of the parent, with the transactions of the pool,
+// and writes it to the blockchain
+func (c *Clique) writeNewBlock(parent *types.Header) error {
+	set, err := c.signers.signersAt(parent.Number)
+	if err != nil {
+		return err
+	}
+
+	signers := sortedSigners(set)
+
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Number:     parent.Number + 1,
+		Timestamp:  c.blockTimestamp(parent),
+		Miner:      types.ZeroAddress.Bytes(),
+		Nonce:      nonceDropVote,
+	}
+
+	header.Difficulty = calcDifficulty(signers, header.Number, c.address)
+
+	if header.Number%c.epoch == 0 {
+		// checkpoint blocks list the signers instead of voting
+		header.ExtraData = buildExtra(parent.ExtraData, signers)
+	} else {
+		header.ExtraData = buildExtra(parent.ExtraData, nil)
+
+		if err := c.signers.current().ModifyHeader(header, c.address); err != nil {
+			return err
+		}
+	}
+
+	gasLimit, err := c.blockchain.CalculateGasLimit(header.Number)
+	if err != nil {
+		return err
+	}
+
+	header.GasLimit = gasLimit
+	header.BaseFee = c.blockchain.CalculateBaseFee(parent)
+
+	// the signer is credited with the fees, as in GetBlockCreator
+	transition, err := c.executor.BeginTxn(parent.StateRoot, header, c.address)
+	if err != nil {
+		return err
+	}
+
+	txns := c.writeTransactions(gasLimit, header.BaseFee, transition)
+
+	// Commit the changes
+	_, root := transition.Commit()
+
+	// Update the header
+	header.StateRoot = root
+	header.GasUsed = transition.TotalGas()
+
+	// Build the actual block
+	block := consensus.BuildBlock(consensus.BuildBlockParams{
+		Header:   header,
+		Txns:     txns,
+		Receipts: transition.Receipts(),
+	})
+
+	seal, err := crypto.Sign(c.key, sealHash(header))
+	if err != nil {
+		return err
+	}
+
+	writeSeal(header, seal)
+	header.ComputeHash()
+
+	if err := c.blockchain.VerifyFinalizedBlock(block); err != nil {
+		return err
+	}
+
+	// Write the block to the blockchain
+	if err := c.blockchain.WriteBlock(block, cliqueConsensus); err != nil {
+		return err
+	}
+
+	c.logger.Info("block sealed", "number", header.Number, "hash", header.Hash, "in-turn", header.Difficulty == diffInTurn)
+
+	// after the block has been written we reset the txpool so that
+	// the old transactions are removed
+	c.txpool.ResetWithHeaders(block.Header)
+
+	return nil
+}
+
+// REQUIRED BASE INTERFACE METHODS //
+
+// VerifyHeader checks the header follows the Clique rules, and is sealed by an authorized signer.
+// The signers are the ones of the canonical chain at the parent height
+func (c *Clique) VerifyHeader(header *types.Header) error {
+	if header.Number == 0 {
+		return nil
+	}
+
+	if err := c.verifyCascadingFields(header); err != nil {
+		return err
+	}
+
+	parent, ok := c.blockchain.GetHeaderByHash(header.ParentHash)
+	if !ok || parent.Number+1 != header.Number {
+		return ErrUnknownAncestor
+	}
+
+	if parent.Timestamp+c.period > header.Timestamp {
+		return ErrInvalidTimestamp
+	}
+
+	set, err := c.signers.signersAt(parent.Number)
+	if err != nil {
+		return err
+	}
+
+	signers := sortedSigners(set)
+
+	if header.Number%c.epoch == 0 {
+		checkpointSigners, err := extraSigners(header)
+		if err != nil {
+			return err
+		}
+
+		if !equalSigners(signers, checkpointSigners) {
+			return ErrMismatchingCheckpointSigners
+		}
+	}
+
+	signer, err := c.signer.EcrecoverFromHeader(header)
+	if err != nil {
+		return err
+	}
+
+	if !set.Includes(signer) {
+		return ErrUnauthorizedSigner
+	}
+
+	if err := c.verifyRecents(parent, len(signers), signer); err != nil {
+		return err
+	}
+
+	if header.Difficulty != calcDifficulty(signers, header.Number, signer) {
+		return ErrWrongDifficulty
+	}
+
+	return nil
+}
+
+// verifyCascadingFields checks the fields of the header that don't depend on the chain
+func (c *Clique) verifyCascadingFields(header *types.Header) error {
+	checkpoint := header.Number%c.epoch == 0
+
+	if checkpoint && !bytes.Equal(header.Miner, types.ZeroAddress.Bytes()) {
+		return ErrInvalidCheckpointBeneficiary
+	}
+
+	if header.Nonce != nonceAuthVote && header.Nonce != nonceDropVote {
+		return ErrInvalidVote
+	}
+
+	if checkpoint && header.Nonce != nonceDropVote {
+		return ErrInvalidCheckpointVote
+	}
+
+	if err := verifyExtraLength(header); err != nil {
+		return err
+	}
+
+	signersBytes := len(header.ExtraData) - ExtraVanity - ExtraSeal
+	if !checkpoint && signersBytes != 0 {
+		return ErrExtraSigners
+	}
+
+	if checkpoint && signersBytes%types.AddressLength != 0 {
+		return ErrInvalidCheckpointSigners
+	}
+
+	if header.MixHash != types.ZeroHash {
+		return ErrInvalidMixDigest
+	}
+
+	if header.Sha3Uncles != types.EmptyUncleHash {
+		return ErrInvalidUncleHash
+	}
+
+	if header.Difficulty != diffInTurn && header.Difficulty != diffNoTurn {
+		return ErrInvalidDifficulty
+	}
+
+	return nil
+}
+
+// verifyRecents checks that the signer didn't seal any of the recent ancestors
+// of the block sealed on top of the parent
+func (c *Clique) verifyRecents(parent *types.Header, signersCount int, signer types.Address) error {
+	header := parent
+
+	for i := uint64(1); i < recentsLimit(signersCount) && header.Number > 0; i++ {
+		recent, err := c.signer.EcrecoverFromHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if recent == signer {
+			return ErrRecentlySigned
+		}
+
+		var ok bool
+		if header, ok = c.blockchain.GetHeaderByHash(header.ParentHash); !ok {
+			return ErrUnknownAncestor
+		}
+	}
+
+	return nil
+}
+
+// equalSigners checks if both lists contain the same signers in the same order
+func equalSigners(a, b []types.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ProcessHeaders updates the signer snapshots with the votes of the written headers
+func (c *Clique) ProcessHeaders(headers []*types.Header) error {
+	for _, header := range headers {
+		if err := c.signers.processHeader(header); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetBlockCreator returns the signer that sealed the block
+func (c *Clique) GetBlockCreator(header *types.Header) (types.Address, error) {
+	if header.Number == 0 {
+		return types.BytesToAddress(header.Miner), nil
+	}
+
+	return c.signer.EcrecoverFromHeader(header)
+}
+
+// PreCommitState a hook to be called before finalizing state transition on inserting block
+func (c *Clique) PreCommitState(_header *types.Header, _txn *state.Transition) error {
+	return nil
+}
+
+// GetSyncProgression gets the latest sync progression, if any
+func (c *Clique) GetSyncProgression() *progress.Progression {
+	return c.syncer.GetSyncProgression()
+}
+
+// Close closes the consensus, saving the signer snapshots
+func (c *Clique) Close() error {
+	close(c.closeCh)
+
+	if c.syncer != nil {
+		if err := c.syncer.Close(); err != nil {
+			return err
+		}
+	}
+
+	if c.signers != nil {
+		if err := c.signers.close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,365 @@
+package clique
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/blockchain"
+	"github.com/Gabulhas/polygon-external-consensus/crypto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+const testPeriod = 5
+
+// mockChain is a canonical chain of headers
+type mockChain struct {
+	headers []*types.Header
+}
+
+func (m *mockChain) Header() *types.Header {
+	return m.headers[len(m.headers)-1]
+}
+
+func (m *mockChain) GetHeaderByNumber(number uint64) (*types.Header, bool) {
+	if number >= uint64(len(m.headers)) {
+		return nil, false
+	}
+
+	return m.headers[number], true
+}
+
+func (m *mockChain) GetHeaderByHash(hash types.Hash) (*types.Header, bool) {
+	for _, header := range m.headers {
+		if header.Hash == hash {
+			return header, true
+		}
+	}
+
+	return nil, false
+}
+
+func (m *mockChain) SubscribeEvents() blockchain.Subscription {
+	return nil
+}
+
+func (m *mockChain) CalculateGasLimit(number uint64) (uint64, error) {
+	return 0, nil
+}
+
+func (m *mockChain) CalculateBaseFee(parent *types.Header) uint64 {
+	return 0
+}
+
+func (m *mockChain) VerifyFinalizedBlock(block *types.Block) error {
+	return nil
+}
+
+func (m *mockChain) WriteBlock(block *types.Block, source string) error {
+	return nil
+}
+
+// testChain builds the headers of a chain sealed in turn by the signers
+type testChain struct {
+	t     *testing.T
+	chain *mockChain
+	keys  map[types.Address]*ecdsa.PrivateKey
+
+	signers []types.Address // Sorted signers of the genesis block
+}
+
+func newTestChain(t *testing.T, numSigners int, numBlocks uint64) *testChain {
+	t.Helper()
+
+	keys, addrs := newTestKeys(t, numSigners)
+
+	tc := &testChain{
+		t:       t,
+		chain:   &mockChain{},
+		keys:    map[types.Address]*ecdsa.PrivateKey{},
+		signers: sortedSigners(newTestSignerSet(t, addrs)),
+	}
+
+	for i, addr := range addrs {
+		tc.keys[addr] = keys[i]
+	}
+
+	genesis := &types.Header{
+		Number:     0,
+		Difficulty: 1,
+		Sha3Uncles: types.EmptyUncleHash,
+		ExtraData:  GenesisExtraData(newTestSignerSet(t, addrs)),
+	}
+	genesis.ComputeHash()
+
+	tc.chain.headers = append(tc.chain.headers, genesis)
+
+	for i := uint64(0); i < numBlocks; i++ {
+		tc.chain.headers = append(tc.chain.headers, tc.nextHeader(tc.inTurnSigner()))
+	}
+
+	return tc
+}
+
+// inTurnSigner returns the signer in turn for the next block
+func (tc *testChain) inTurnSigner() types.Address {
+	return tc.signers[(tc.chain.Header().Number+1)%uint64(len(tc.signers))]
+}
+
+// nextHeader returns a valid header on top of the head sealed by the signer
+func (tc *testChain) nextHeader(signer types.Address) *types.Header {
+	parent := tc.chain.Header()
+
+	header := &types.Header{
+		ParentHash: parent.Hash,
+		Number:     parent.Number + 1,
+		Timestamp:  parent.Timestamp + testPeriod,
+		Miner:      types.ZeroAddress.Bytes(),
+		Nonce:      nonceDropVote,
+		Sha3Uncles: types.EmptyUncleHash,
+		Difficulty: calcDifficulty(tc.signers, parent.Number+1, signer),
+		ExtraData:  buildExtra(nil, nil),
+	}
+
+	return tc.seal(header, signer)
+}
+
+func (tc *testChain) seal(header *types.Header, signer types.Address) *types.Header {
+	seal, err := crypto.Sign(tc.keys[signer], sealHash(header))
+	assert.NoError(tc.t, err)
+
+	writeSeal(header, seal)
+
+	return header.ComputeHash()
+}
+
+func (tc *testChain) newClique(epoch uint64) *Clique {
+	tc.t.Helper()
+
+	signer, err := newHeaderSigner()
+	assert.NoError(tc.t, err)
+
+	signers, err := newSignerStore(hclog.NewNullLogger(), tc.chain, signer, tc.t.TempDir(), epoch, types.ZeroAddress)
+	assert.NoError(tc.t, err)
+
+	return &Clique{
+		logger:     hclog.NewNullLogger(),
+		blockchain: tc.chain,
+		period:     testPeriod,
+		epoch:      epoch,
+		signer:     signer,
+		signers:    signers,
+	}
+}
+
+func TestClique_VerifyHeader(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestChain(t, 3, 4)
+	c := tc.newClique(DefaultEpochSize)
+
+	inTurn := tc.inTurnSigner()
+
+	var outOfTurn, recent types.Address
+
+	// the signer of the head signed recently, the remaining one is out of turn
+	head, err := c.GetBlockCreator(tc.chain.Header())
+	assert.NoError(t, err)
+
+	for _, signer := range tc.signers {
+		if signer != inTurn && signer != head {
+			outOfTurn = signer
+		}
+	}
+
+	recent = head
+
+	unauthorized, _ := newTestKeys(t, 1)
+	unauthorizedAddr := crypto.PubKeyToAddress(&unauthorized[0].PublicKey)
+	tc.keys[unauthorizedAddr] = unauthorized[0]
+
+	testCases := []struct {
+		name   string
+		header func() *types.Header
+		err    error
+	}{
+		{
+			name: "in-turn signer",
+			header: func() *types.Header {
+				return tc.nextHeader(inTurn)
+			},
+		},
+		{
+			name: "out-of-turn signer",
+			header: func() *types.Header {
+				return tc.nextHeader(outOfTurn)
+			},
+		},
+		{
+			name: "recent signer",
+			header: func() *types.Header {
+				return tc.nextHeader(recent)
+			},
+			err: ErrRecentlySigned,
+		},
+		{
+			name: "unauthorized signer",
+			header: func() *types.Header {
+				return tc.nextHeader(unauthorizedAddr)
+			},
+			err: ErrUnauthorizedSigner,
+		},
+		{
+			name: "wrong difficulty",
+			header: func() *types.Header {
+				h := tc.nextHeader(inTurn)
+				h.Difficulty = diffNoTurn
+
+				return tc.seal(h, inTurn)
+			},
+			err: ErrWrongDifficulty,
+		},
+		{
+			name: "invalid difficulty",
+			header: func() *types.Header {
+				h := tc.nextHeader(inTurn)
+				h.Difficulty = 3
+
+				return tc.seal(h, inTurn)
+			},
+			err: ErrInvalidDifficulty,
+		},
+		{
+			name: "too early",
+			header: func() *types.Header {
+				h := tc.nextHeader(inTurn)
+				h.Timestamp--
+
+				return tc.seal(h, inTurn)
+			},
+			err: ErrInvalidTimestamp,
+		},
+		{
+			name: "invalid vote",
+			header: func() *types.Header {
+				h := tc.nextHeader(inTurn)
+				h.Nonce = types.Nonce{0x1}
+
+				return tc.seal(h, inTurn)
+			},
+			err: ErrInvalidVote,
+		},
+		{
+			name: "signers outside checkpoint",
+			header: func() *types.Header {
+				h := tc.nextHeader(inTurn)
+				h.ExtraData = buildExtra(nil, tc.signers)
+
+				return tc.seal(h, inTurn)
+			},
+			err: ErrExtraSigners,
+		},
+		{
+			name: "non-zero mix digest",
+			header: func() *types.Header {
+				h := tc.nextHeader(inTurn)
+				h.MixHash = types.StringToHash("1")
+
+				return tc.seal(h, inTurn)
+			},
+			err: ErrInvalidMixDigest,
+		},
+		{
+			name: "unknown parent",
+			header: func() *types.Header {
+				h := tc.nextHeader(inTurn)
+				h.ParentHash = types.StringToHash("1")
+
+				return tc.seal(h, inTurn)
+			},
+			err: ErrUnknownAncestor,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			err := c.VerifyHeader(test.header())
+
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestClique_VerifyCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	const epoch = 4
+
+	tc := newTestChain(t, 2, epoch-1)
+	c := tc.newClique(epoch)
+
+	signer := tc.inTurnSigner()
+
+	checkpoint := func(signers []types.Address) *types.Header {
+		h := tc.nextHeader(signer)
+		h.ExtraData = buildExtra(nil, signers)
+
+		return tc.seal(h, signer)
+	}
+
+	assert.NoError(t, c.VerifyHeader(checkpoint(tc.signers)))
+
+	assert.ErrorIs(
+		t,
+		c.VerifyHeader(checkpoint(tc.signers[:1])),
+		ErrMismatchingCheckpointSigners,
+	)
+
+	vote := tc.nextHeader(signer)
+	vote.ExtraData = buildExtra(nil, tc.signers)
+	vote.Miner = types.StringToAddress("1").Bytes()
+
+	assert.ErrorIs(t, c.VerifyHeader(tc.seal(vote, signer)), ErrInvalidCheckpointBeneficiary)
+
+	vote.Miner = types.ZeroAddress.Bytes()
+	vote.Nonce = nonceAuthVote
+
+	assert.ErrorIs(t, c.VerifyHeader(tc.seal(vote, signer)), ErrInvalidCheckpointVote)
+}
+
+func TestClique_Votes(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestChain(t, 3, 0)
+	c := tc.newClique(DefaultEpochSize)
+
+	candidate := types.StringToAddress("1")
+
+	// a majority of the signers vote to add the candidate
+	for i := 0; i < 2; i++ {
+		signer := tc.inTurnSigner()
+
+		h := tc.nextHeader(signer)
+		h.Miner = candidate.Bytes()
+		h.Nonce = nonceAuthVote
+		tc.seal(h, signer)
+
+		assert.NoError(t, c.VerifyHeader(h))
+
+		tc.chain.headers = append(tc.chain.headers, h)
+		assert.NoError(t, c.ProcessHeaders([]*types.Header{h}))
+	}
+
+	set, err := c.signers.signersAt(tc.chain.Header().Number)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 4, set.Len())
+	assert.True(t, set.Includes(candidate))
+}
@@ -0,0 +1,197 @@
+package clique
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/Gabulhas/polygon-external-consensus/crypto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/Gabulhas/polygon-external-consensus/validators"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	ExtraVanity = 32 // Fixed number of extra-data prefix bytes reserved for signer vanity
+	ExtraSeal   = 65 // Fixed number of extra-data suffix bytes reserved for signer seal
+
+	diffInTurn = 2 // Block difficulty for in-turn signatures
+	diffNoTurn = 1 // Block difficulty for out-of-turn signatures
+
+	signaturesCacheSize = 4096 // Number of recent block signers to keep in memory
+)
+
+var (
+	// Magic nonce number to vote on adding a new signer
+	nonceAuthVote = types.Nonce{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+	// Magic nonce number to vote on removing a signer
+	nonceDropVote = types.Nonce{}
+)
+
+// sealHash returns the hash of the header the seal signs,
+// which is the RLP hash of the header without the seal in the extra data
+func sealHash(header *types.Header) []byte {
+	h := header.Copy()
+	h.ExtraData = h.ExtraData[:len(h.ExtraData)-ExtraSeal]
+
+	return crypto.Keccak256(h.MarshalRLP())
+}
+
+// extraSigners returns the signers list of the checkpoint header extra data
+func extraSigners(header *types.Header) ([]types.Address, error) {
+	if err := verifyExtraLength(header); err != nil {
+		return nil, err
+	}
+
+	raw := header.ExtraData[ExtraVanity : len(header.ExtraData)-ExtraSeal]
+	if len(raw)%types.AddressLength != 0 {
+		return nil, ErrInvalidCheckpointSigners
+	}
+
+	signers := make([]types.Address, len(raw)/types.AddressLength)
+	for i := range signers {
+		copy(signers[i][:], raw[i*types.AddressLength:])
+	}
+
+	return signers, nil
+}
+
+// verifyExtraLength checks that the extra data contains the vanity and the seal
+func verifyExtraLength(header *types.Header) error {
+	if len(header.ExtraData) < ExtraVanity {
+		return ErrMissingVanity
+	}
+
+	if len(header.ExtraData) < ExtraVanity+ExtraSeal {
+		return ErrMissingSignature
+	}
+
+	return nil
+}
+
+// buildExtra builds the extra data of a new header with an empty seal,
+// listing the signers if the header is a checkpoint
+func buildExtra(vanity []byte, signers []types.Address) []byte {
+	extra := make([]byte, ExtraVanity, ExtraVanity+len(signers)*types.AddressLength+ExtraSeal)
+	copy(extra, vanity)
+
+	for _, signer := range signers {
+		extra = append(extra, signer.Bytes()...)
+	}
+
+	return append(extra, make([]byte, ExtraSeal)...)
+}
+
+// GenesisExtraData returns the extra data of the genesis block,
+// listing the initial signers in the same layout as the checkpoint blocks
+func GenesisExtraData(set validators.Validators) []byte {
+	return buildExtra(nil, sortedSigners(set))
+}
+
+// writeSeal writes the signature into the seal of the header
+func writeSeal(header *types.Header, seal []byte) {
+	copy(header.ExtraData[len(header.ExtraData)-ExtraSeal:], seal)
+}
+
+// sortedSigners returns the addresses of the signers in ascending order,
+// the order the turns follow
+func sortedSigners(set validators.Validators) []types.Address {
+	signers := make([]types.Address, set.Len())
+	for i := range signers {
+		signers[i] = set.At(uint64(i)).Addr()
+	}
+
+	sort.Slice(signers, func(i, j int) bool {
+		return bytes.Compare(signers[i][:], signers[j][:]) < 0
+	})
+
+	return signers
+}
+
+// inTurn checks if it's the turn of the signer to seal the block of the given number
+func inTurn(signers []types.Address, number uint64, signer types.Address) bool {
+	if len(signers) == 0 {
+		return false
+	}
+
+	return signers[number%uint64(len(signers))] == signer
+}
+
+// calcDifficulty returns the difficulty of the block of the given number sealed by the signer
+func calcDifficulty(signers []types.Address, number uint64, signer types.Address) uint64 {
+	if inTurn(signers, number, signer) {
+		return diffInTurn
+	}
+
+	return diffNoTurn
+}
+
+// recentsLimit returns the number of consecutive blocks a signer can only seal once
+func recentsLimit(signersCount int) uint64 {
+	return uint64(signersCount/2 + 1)
+}
+
+// headerSigner recovers the signers of the headers from their seal,
+// and implements the signer the snapshot validator store uses
+type headerSigner struct {
+	signatures *lru.Cache // Signers of the recent blocks, by hash
+}
+
+func newHeaderSigner() (*headerSigner, error) {
+	signatures, err := lru.New(signaturesCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &headerSigner{
+		signatures: signatures,
+	}, nil
+}
+
+// Type returns the validator type of the signers, Clique only supports ECDSA
+func (s *headerSigner) Type() validators.ValidatorType {
+	return validators.ECDSAValidatorType
+}
+
+// EcrecoverFromHeader recovers the address of the signer that sealed the header
+func (s *headerSigner) EcrecoverFromHeader(header *types.Header) (types.Address, error) {
+	if signer, ok := s.signatures.Get(header.Hash); ok {
+		if addr, ok := signer.(types.Address); ok {
+			return addr, nil
+		}
+	}
+
+	if err := verifyExtraLength(header); err != nil {
+		return types.ZeroAddress, err
+	}
+
+	seal := header.ExtraData[len(header.ExtraData)-ExtraSeal:]
+
+	pub, err := crypto.RecoverPubkey(seal, sealHash(header))
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	signer := crypto.PubKeyToAddress(pub)
+	s.signatures.Add(header.Hash, signer)
+
+	return signer, nil
+}
+
+// GetValidators returns the signers listed in the checkpoint header
+func (s *headerSigner) GetValidators(header *types.Header) (validators.Validators, error) {
+	signers, err := extraSigners(header)
+	if err != nil {
+		return nil, err
+	}
+
+	set := validators.NewECDSAValidatorSet()
+
+	for _, signer := range signers {
+		if err := set.Add(validators.NewECDSAValidator(signer)); err != nil {
+			return nil, err
+		}
+	}
+
+	return set, nil
+}
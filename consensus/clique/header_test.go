@@ -0,0 +1,145 @@
+package clique
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/chain"
+	"github.com/Gabulhas/polygon-external-consensus/crypto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/Gabulhas/polygon-external-consensus/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKeys(t *testing.T, num int) ([]*ecdsa.PrivateKey, []types.Address) {
+	t.Helper()
+
+	keys := make([]*ecdsa.PrivateKey, num)
+	addrs := make([]types.Address, num)
+
+	for i := range keys {
+		key, err := crypto.GenerateECDSAKey()
+		assert.NoError(t, err)
+
+		keys[i] = key
+		addrs[i] = crypto.PubKeyToAddress(&key.PublicKey)
+	}
+
+	return keys, addrs
+}
+
+func newTestSignerSet(t *testing.T, addrs []types.Address) validators.Validators {
+	t.Helper()
+
+	set := validators.NewECDSAValidatorSet()
+	for _, addr := range addrs {
+		assert.NoError(t, set.Add(validators.NewECDSAValidator(addr)))
+	}
+
+	return set
+}
+
+func TestHeaderSigner_SealRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	keys, addrs := newTestKeys(t, 1)
+
+	header := &types.Header{
+		Number:     1,
+		Difficulty: diffInTurn,
+		ExtraData:  buildExtra([]byte("vanity"), nil),
+	}
+
+	seal, err := crypto.Sign(keys[0], sealHash(header))
+	assert.NoError(t, err)
+
+	writeSeal(header, seal)
+	header.ComputeHash()
+
+	signer, err := newHeaderSigner()
+	assert.NoError(t, err)
+
+	addr, err := signer.EcrecoverFromHeader(header)
+	assert.NoError(t, err)
+	assert.Equal(t, addrs[0], addr)
+
+	// the seal doesn't cover itself
+	assert.Equal(t, sealHash(header), sealHash(header.Copy()))
+	assert.Len(t, header.ExtraData, ExtraVanity+ExtraSeal)
+	assert.Equal(t, []byte("vanity"), header.ExtraData[:len("vanity")])
+}
+
+func TestHeaderSigner_MissingSeal(t *testing.T) {
+	t.Parallel()
+
+	signer, err := newHeaderSigner()
+	assert.NoError(t, err)
+
+	_, err = signer.EcrecoverFromHeader(&types.Header{ExtraData: make([]byte, ExtraVanity-1)})
+	assert.ErrorIs(t, err, ErrMissingVanity)
+
+	_, err = signer.EcrecoverFromHeader(&types.Header{ExtraData: make([]byte, ExtraVanity+ExtraSeal-1)})
+	assert.ErrorIs(t, err, ErrMissingSignature)
+}
+
+func TestHeaderSigner_GenesisSigners(t *testing.T) {
+	t.Parallel()
+
+	goerli, err := chain.ImportFromName("goerli")
+	assert.NoError(t, err)
+
+	signer, err := newHeaderSigner()
+	assert.NoError(t, err)
+
+	set, err := signer.GetValidators(&types.Header{ExtraData: goerli.Genesis.ExtraData})
+	assert.NoError(t, err)
+
+	assert.Equal(
+		t,
+		[]types.Address{types.StringToAddress("0xe0a2bd4258d2768837baa26a28fe71dc079f84c7")},
+		sortedSigners(set),
+	)
+}
+
+func TestGenesisExtraData(t *testing.T) {
+	t.Parallel()
+
+	_, addrs := newTestKeys(t, 3)
+
+	extra := GenesisExtraData(newTestSignerSet(t, addrs))
+	assert.Len(t, extra, ExtraVanity+len(addrs)*types.AddressLength+ExtraSeal)
+
+	signers, err := extraSigners(&types.Header{ExtraData: extra})
+	assert.NoError(t, err)
+
+	// the signers are listed in ascending order
+	assert.Equal(t, sortedSigners(newTestSignerSet(t, addrs)), signers)
+
+	_, err = extraSigners(&types.Header{ExtraData: append(extra, 0x1)})
+	assert.ErrorIs(t, err, ErrInvalidCheckpointSigners)
+}
+
+func TestCalcDifficulty(t *testing.T) {
+	t.Parallel()
+
+	signers := []types.Address{
+		types.StringToAddress("1"),
+		types.StringToAddress("2"),
+		types.StringToAddress("3"),
+	}
+
+	for number := uint64(1); number < 7; number++ {
+		for i, signer := range signers {
+			expected := uint64(diffNoTurn)
+			if number%uint64(len(signers)) == uint64(i) {
+				expected = diffInTurn
+			}
+
+			assert.Equal(t, expected, calcDifficulty(signers, number, signer))
+		}
+	}
+
+	assert.False(t, inTurn(nil, 1, signers[0]))
+	assert.Equal(t, uint64(2), recentsLimit(3))
+	assert.Equal(t, uint64(3), recentsLimit(4))
+}
@@ -0,0 +1,99 @@
+package clique
+
+import (
+	"context"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/clique/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/Gabulhas/polygon-external-consensus/validators"
+	"github.com/Gabulhas/polygon-external-consensus/validators/store"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+type operator struct {
+	proto.UnimplementedCliqueOperatorServer
+
+	clique *Clique
+}
+
+// Status returns the signers and the votes at the head of the chain
+func (o *operator) Status(ctx context.Context, req *empty.Empty) (*proto.CliqueStatusResp, error) {
+	header := o.clique.blockchain.Header()
+	snapshotStore := o.clique.signers.current()
+
+	set, err := snapshotStore.GetValidatorsByHeight(header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := snapshotStore.Votes(header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := sortedSigners(set)
+
+	resp := &proto.CliqueStatusResp{
+		Key:       o.clique.address.String(),
+		Number:    header.Number,
+		Hash:      header.Hash.String(),
+		Signers:   make([]string, len(signers)),
+		Votes:     votesToProtoVotes(votes),
+		Proposals: candidatesToProtoProposals(snapshotStore.Candidates()),
+	}
+
+	for i, signer := range signers {
+		resp.Signers[i] = signer.String()
+	}
+
+	return resp, nil
+}
+
+// Propose proposes a signer to be added to / removed from the signers by the node
+func (o *operator) Propose(ctx context.Context, req *proto.Proposal) (*empty.Empty, error) {
+	candidate := validators.NewECDSAValidator(types.StringToAddress(req.Address))
+
+	if err := o.clique.signers.current().Propose(candidate, req.Auth, o.clique.address); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// Discard drops the pending proposal of the node for the given address
+func (o *operator) Discard(ctx context.Context, req *proto.DiscardReq) (*empty.Empty, error) {
+	if err := o.clique.signers.current().Discard(types.StringToAddress(req.Address)); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// votesToProtoVotes converts votes to response of votes
+func votesToProtoVotes(votes []*store.Vote) []*proto.CliqueStatusResp_Vote {
+	protoVotes := make([]*proto.CliqueStatusResp_Vote, len(votes))
+
+	for idx := range votes {
+		protoVotes[idx] = &proto.CliqueStatusResp_Vote{
+			Signer:  votes[idx].Validator.String(),
+			Address: votes[idx].Candidate.Addr().String(),
+			Auth:    votes[idx].Authorize,
+		}
+	}
+
+	return protoVotes
+}
+
+// candidatesToProtoProposals converts candidates to response of proposals
+func candidatesToProtoProposals(candidates []*store.Candidate) []*proto.Proposal {
+	protoProposals := make([]*proto.Proposal, len(candidates))
+
+	for idx := range candidates {
+		protoProposals[idx] = &proto.Proposal{
+			Address: candidates[idx].Validator.Addr().String(),
+			Auth:    candidates[idx].Authorize,
+		}
+	}
+
+	return protoProposals
+}
@@ -0,0 +1,438 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: consensus/clique/proto/clique_operator.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CliqueStatusResp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Address of the signer key of the node
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// Number of the latest block
+	Number uint64 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	// Hash of the latest block
+	Hash string `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Authorized signers after the latest block, sorted by address
+	Signers []string `protobuf:"bytes,4,rep,name=signers,proto3" json:"signers,omitempty"`
+	// Votes cast in the current epoch
+	Votes []*CliqueStatusResp_Vote `protobuf:"bytes,5,rep,name=votes,proto3" json:"votes,omitempty"`
+	// Pending proposals of the node
+	Proposals []*Proposal `protobuf:"bytes,6,rep,name=proposals,proto3" json:"proposals,omitempty"`
+}
+
+func (x *CliqueStatusResp) Reset() {
+	*x = CliqueStatusResp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_clique_proto_clique_operator_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CliqueStatusResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CliqueStatusResp) ProtoMessage() {}
+
+func (x *CliqueStatusResp) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_clique_proto_clique_operator_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CliqueStatusResp.ProtoReflect.Descriptor instead.
+func (*CliqueStatusResp) Descriptor() ([]byte, []int) {
+	return file_consensus_clique_proto_clique_operator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CliqueStatusResp) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CliqueStatusResp) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *CliqueStatusResp) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *CliqueStatusResp) GetSigners() []string {
+	if x != nil {
+		return x.Signers
+	}
+	return nil
+}
+
+func (x *CliqueStatusResp) GetVotes() []*CliqueStatusResp_Vote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+func (x *CliqueStatusResp) GetProposals() []*Proposal {
+	if x != nil {
+		return x.Proposals
+	}
+	return nil
+}
+
+type Proposal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Auth    bool   `protobuf:"varint,2,opt,name=auth,proto3" json:"auth,omitempty"`
+}
+
+func (x *Proposal) Reset() {
+	*x = Proposal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_clique_proto_clique_operator_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proposal) ProtoMessage() {}
+
+func (x *Proposal) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_clique_proto_clique_operator_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proposal.ProtoReflect.Descriptor instead.
+func (*Proposal) Descriptor() ([]byte, []int) {
+	return file_consensus_clique_proto_clique_operator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Proposal) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Proposal) GetAuth() bool {
+	if x != nil {
+		return x.Auth
+	}
+	return false
+}
+
+type DiscardReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *DiscardReq) Reset() {
+	*x = DiscardReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_clique_proto_clique_operator_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiscardReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardReq) ProtoMessage() {}
+
+func (x *DiscardReq) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_clique_proto_clique_operator_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiscardReq.ProtoReflect.Descriptor instead.
+func (*DiscardReq) Descriptor() ([]byte, []int) {
+	return file_consensus_clique_proto_clique_operator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DiscardReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type CliqueStatusResp_Vote struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signer  string `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Auth    bool   `protobuf:"varint,3,opt,name=auth,proto3" json:"auth,omitempty"`
+}
+
+func (x *CliqueStatusResp_Vote) Reset() {
+	*x = CliqueStatusResp_Vote{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_clique_proto_clique_operator_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CliqueStatusResp_Vote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CliqueStatusResp_Vote) ProtoMessage() {}
+
+func (x *CliqueStatusResp_Vote) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_clique_proto_clique_operator_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CliqueStatusResp_Vote.ProtoReflect.Descriptor instead.
+func (*CliqueStatusResp_Vote) Descriptor() ([]byte, []int) {
+	return file_consensus_clique_proto_clique_operator_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *CliqueStatusResp_Vote) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *CliqueStatusResp_Vote) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CliqueStatusResp_Vote) GetAuth() bool {
+	if x != nil {
+		return x.Auth
+	}
+	return false
+}
+
+var File_consensus_clique_proto_clique_operator_proto protoreflect.FileDescriptor
+
+var file_consensus_clique_proto_clique_operator_proto_rawDesc = []byte{
+	0x0a, 0x2c, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f, 0x63, 0x6c, 0x69, 0x71,
+	0x75, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x63, 0x6c, 0x69, 0x71, 0x75, 0x65, 0x5f,
+	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02,
+	0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x95, 0x02, 0x0a, 0x10, 0x43, 0x6c, 0x69, 0x71, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x2f, 0x0a, 0x05,
+	0x76, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x6c, 0x69, 0x71, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x2a, 0x0a,
+	0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x73, 0x1a, 0x4c, 0x0a, 0x04, 0x56, 0x6f, 0x74,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0x38, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x6f,
+	0x73, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x61, 0x75, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x61, 0x75, 0x74,
+	0x68, 0x22, 0x26, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x32, 0xac, 0x01, 0x0a, 0x0e, 0x43, 0x6c,
+	0x69, 0x71, 0x75, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x36, 0x0a, 0x06,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6c, 0x69, 0x71, 0x75, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x2f, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x12,
+	0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x31, 0x0a, 0x07, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64,
+	0x12, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x52, 0x65, 0x71,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x19, 0x5a, 0x17, 0x2f, 0x63, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f, 0x63, 0x6c, 0x69, 0x71, 0x75, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_consensus_clique_proto_clique_operator_proto_rawDescOnce sync.Once
+	file_consensus_clique_proto_clique_operator_proto_rawDescData = file_consensus_clique_proto_clique_operator_proto_rawDesc
+)
+
+func file_consensus_clique_proto_clique_operator_proto_rawDescGZIP() []byte {
+	file_consensus_clique_proto_clique_operator_proto_rawDescOnce.Do(func() {
+		file_consensus_clique_proto_clique_operator_proto_rawDescData = protoimpl.X.CompressGZIP(file_consensus_clique_proto_clique_operator_proto_rawDescData)
+	})
+	return file_consensus_clique_proto_clique_operator_proto_rawDescData
+}
+
+var file_consensus_clique_proto_clique_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_consensus_clique_proto_clique_operator_proto_goTypes = []interface{}{
+	(*CliqueStatusResp)(nil),      // 0: v1.CliqueStatusResp
+	(*Proposal)(nil),              // 1: v1.Proposal
+	(*DiscardReq)(nil),            // 2: v1.DiscardReq
+	(*CliqueStatusResp_Vote)(nil), // 3: v1.CliqueStatusResp.Vote
+	(*emptypb.Empty)(nil),         // 4: google.protobuf.Empty
+}
+var file_consensus_clique_proto_clique_operator_proto_depIdxs = []int32{
+	3, // 0: v1.CliqueStatusResp.votes:type_name -> v1.CliqueStatusResp.Vote
+	1, // 1: v1.CliqueStatusResp.proposals:type_name -> v1.Proposal
+	4, // 2: v1.CliqueOperator.Status:input_type -> google.protobuf.Empty
+	1, // 3: v1.CliqueOperator.Propose:input_type -> v1.Proposal
+	2, // 4: v1.CliqueOperator.Discard:input_type -> v1.DiscardReq
+	0, // 5: v1.CliqueOperator.Status:output_type -> v1.CliqueStatusResp
+	4, // 6: v1.CliqueOperator.Propose:output_type -> google.protobuf.Empty
+	4, // 7: v1.CliqueOperator.Discard:output_type -> google.protobuf.Empty
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_consensus_clique_proto_clique_operator_proto_init() }
+func file_consensus_clique_proto_clique_operator_proto_init() {
+	if File_consensus_clique_proto_clique_operator_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_consensus_clique_proto_clique_operator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CliqueStatusResp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_clique_proto_clique_operator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_clique_proto_clique_operator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DiscardReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_consensus_clique_proto_clique_operator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CliqueStatusResp_Vote); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_consensus_clique_proto_clique_operator_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consensus_clique_proto_clique_operator_proto_goTypes,
+		DependencyIndexes: file_consensus_clique_proto_clique_operator_proto_depIdxs,
+		MessageInfos:      file_consensus_clique_proto_clique_operator_proto_msgTypes,
+	}.Build()
+	File_consensus_clique_proto_clique_operator_proto = out.File
+	file_consensus_clique_proto_clique_operator_proto_rawDesc = nil
+	file_consensus_clique_proto_clique_operator_proto_goTypes = nil
+	file_consensus_clique_proto_clique_operator_proto_depIdxs = nil
+}
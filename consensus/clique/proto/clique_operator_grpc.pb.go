@@ -0,0 +1,178 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.5
+// source: consensus/clique/proto/clique_operator.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CliqueOperatorClient is the client API for CliqueOperator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CliqueOperatorClient interface {
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CliqueStatusResp, error)
+	Propose(ctx context.Context, in *Proposal, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Discard(ctx context.Context, in *DiscardReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type cliqueOperatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCliqueOperatorClient(cc grpc.ClientConnInterface) CliqueOperatorClient {
+	return &cliqueOperatorClient{cc}
+}
+
+func (c *cliqueOperatorClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CliqueStatusResp, error) {
+	out := new(CliqueStatusResp)
+	err := c.cc.Invoke(ctx, "/v1.CliqueOperator/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliqueOperatorClient) Propose(ctx context.Context, in *Proposal, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.CliqueOperator/Propose", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cliqueOperatorClient) Discard(ctx context.Context, in *DiscardReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.CliqueOperator/Discard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CliqueOperatorServer is the server API for CliqueOperator service.
+// All implementations must embed UnimplementedCliqueOperatorServer
+// for forward compatibility
+type CliqueOperatorServer interface {
+	Status(context.Context, *emptypb.Empty) (*CliqueStatusResp, error)
+	Propose(context.Context, *Proposal) (*emptypb.Empty, error)
+	Discard(context.Context, *DiscardReq) (*emptypb.Empty, error)
+	mustEmbedUnimplementedCliqueOperatorServer()
+}
+
+// UnimplementedCliqueOperatorServer must be embedded to have forward compatible implementations.
+type UnimplementedCliqueOperatorServer struct {
+}
+
+func (UnimplementedCliqueOperatorServer) Status(context.Context, *emptypb.Empty) (*CliqueStatusResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedCliqueOperatorServer) Propose(context.Context, *Proposal) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Propose not implemented")
+}
+func (UnimplementedCliqueOperatorServer) Discard(context.Context, *DiscardReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Discard not implemented")
+}
+func (UnimplementedCliqueOperatorServer) mustEmbedUnimplementedCliqueOperatorServer() {}
+
+// UnsafeCliqueOperatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CliqueOperatorServer will
+// result in compilation errors.
+type UnsafeCliqueOperatorServer interface {
+	mustEmbedUnimplementedCliqueOperatorServer()
+}
+
+func RegisterCliqueOperatorServer(s grpc.ServiceRegistrar, srv CliqueOperatorServer) {
+	s.RegisterService(&CliqueOperator_ServiceDesc, srv)
+}
+
+func _CliqueOperator_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliqueOperatorServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CliqueOperator/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliqueOperatorServer).Status(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CliqueOperator_Propose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Proposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliqueOperatorServer).Propose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CliqueOperator/Propose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliqueOperatorServer).Propose(ctx, req.(*Proposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CliqueOperator_Discard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CliqueOperatorServer).Discard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.CliqueOperator/Discard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CliqueOperatorServer).Discard(ctx, req.(*DiscardReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CliqueOperator_ServiceDesc is the grpc.ServiceDesc for CliqueOperator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CliqueOperator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.CliqueOperator",
+	HandlerType: (*CliqueOperatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Status",
+			Handler:    _CliqueOperator_Status_Handler,
+		},
+		{
+			MethodName: "Propose",
+			Handler:    _CliqueOperator_Propose_Handler,
+		},
+		{
+			MethodName: "Discard",
+			Handler:    _CliqueOperator_Discard_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consensus/clique/proto/clique_operator.proto",
+}
@@ -0,0 +1,215 @@
+package clique
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/Gabulhas/polygon-external-consensus/validators"
+	"github.com/Gabulhas/polygon-external-consensus/validators/store"
+	"github.com/Gabulhas/polygon-external-consensus/validators/store/snapshot"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	snapshotMetadataFilename  = "clique_metadata"
+	snapshotSnapshotsFilename = "clique_snapshots"
+)
+
+// signerStore keeps the signers and votes of the chain in the snapshots of the validator store,
+// persisting them in the consensus directory
+type signerStore struct {
+	lock  sync.RWMutex
+	store *snapshot.SnapshotValidatorStore
+
+	logger     hclog.Logger
+	blockchain store.HeaderGetter
+	signer     *headerSigner
+	dirPath    string
+	epochSize  uint64
+	proposer   types.Address // Address of the node signer, voting for the proposals
+
+	lastHash types.Hash // Hash of the latest processed header
+}
+
+// newSignerStore loads the snapshots from the local storage and catches up with the chain
+func newSignerStore(
+	logger hclog.Logger,
+	blockchain store.HeaderGetter,
+	signer *headerSigner,
+	dirPath string,
+	epochSize uint64,
+	proposer types.Address,
+) (*signerStore, error) {
+	var (
+		meta  *snapshot.SnapshotMetadata
+		snaps = []*snapshot.Snapshot{}
+	)
+
+	if err := readDataStore(filepath.Join(dirPath, snapshotMetadataFilename), &meta); err != nil {
+		return nil, err
+	}
+
+	if err := readDataStore(filepath.Join(dirPath, snapshotSnapshotsFilename), &snaps); err != nil {
+		return nil, err
+	}
+
+	s := &signerStore{
+		logger:     logger,
+		blockchain: blockchain,
+		signer:     signer,
+		dirPath:    dirPath,
+		epochSize:  epochSize,
+		proposer:   proposer,
+	}
+
+	if err := s.load(meta, snaps); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// load creates the validator store from the given snapshots,
+// which processes the headers following them up to the head
+func (s *signerStore) load(meta *snapshot.SnapshotMetadata, snaps []*snapshot.Snapshot) error {
+	snapshotStore, err := snapshot.NewSnapshotValidatorStore(
+		s.logger,
+		s.blockchain,
+		func(uint64) (snapshot.SignerInterface, error) {
+			return s.signer, nil
+		},
+		s.epochSize,
+		meta,
+		snaps,
+	)
+	if err != nil {
+		return err
+	}
+
+	s.store = snapshotStore
+	s.lastHash = s.blockchain.Header().Hash
+
+	return nil
+}
+
+// current returns the validator store holding the snapshots of the canonical chain
+func (s *signerStore) current() *snapshot.SnapshotValidatorStore {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.store
+}
+
+// signersAt returns the signers authorized after the block of the given height
+func (s *signerStore) signersAt(height uint64) (validators.Validators, error) {
+	return s.current().GetValidatorsByHeight(height)
+}
+
+// processHeader updates the snapshots with the header written to the chain.
+// Headers of side chains are skipped, and the snapshots are rebuilt when the chain is reorganized
+func (s *signerStore) processHeader(header *types.Header) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	canonical, ok := s.blockchain.GetHeaderByNumber(header.Number)
+	if !ok || canonical.Hash != header.Hash {
+		return nil
+	}
+
+	if header.ParentHash == s.lastHash && header.Number == s.store.GetSnapshotMetadata().LastBlock+1 {
+		if err := s.store.ProcessHeader(header); err != nil {
+			return err
+		}
+
+		s.lastHash = header.Hash
+
+		return nil
+	}
+
+	return s.rebuild()
+}
+
+// rebuild drops the snapshots of the blocks that are no longer canonical,
+// and processes the canonical headers following the remaining ones.
+// It's called with the lock held
+func (s *signerStore) rebuild() error {
+	snaps := s.store.GetSnapshots()
+	kept := make([]*snapshot.Snapshot, 0, len(snaps))
+
+	for _, snap := range snaps {
+		header, ok := s.blockchain.GetHeaderByNumber(snap.Number)
+		if ok && header.Hash.String() == snap.Hash {
+			kept = append(kept, snap)
+		}
+	}
+
+	meta := &snapshot.SnapshotMetadata{}
+
+	for _, snap := range kept {
+		if snap.Number > meta.LastBlock {
+			meta.LastBlock = snap.Number
+		}
+	}
+
+	s.logger.Info("chain reorganized, rebuilding signer snapshots", "from", meta.LastBlock)
+
+	candidates := s.store.Candidates()
+
+	if err := s.load(meta, kept); err != nil {
+		return err
+	}
+
+	// keep the pending proposals of the node
+	for _, candidate := range candidates {
+		if err := s.store.Propose(candidate.Validator, candidate.Authorize, s.proposer); err != nil {
+			s.logger.Debug("dropping proposal", "address", candidate.Validator.Addr(), "err", err)
+		}
+	}
+
+	return nil
+}
+
+// close saves the snapshots into the local storage
+func (s *signerStore) close() error {
+	store := s.current()
+
+	if err := writeDataStore(
+		filepath.Join(s.dirPath, snapshotMetadataFilename),
+		store.GetSnapshotMetadata(),
+	); err != nil {
+		return err
+	}
+
+	return writeDataStore(
+		filepath.Join(s.dirPath, snapshotSnapshotsFilename),
+		store.GetSnapshots(),
+	)
+}
+
+// readDataStore attempts to read the specific file from file storage
+// return nil if the file doesn't exist
+func readDataStore(path string, obj interface{}) error {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, obj)
+}
+
+// writeDataStore attempts to write the specific file to file storage
+func writeDataStore(path string, obj interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, os.ModePerm)
+}
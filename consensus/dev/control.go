@@ -0,0 +1,224 @@
+package dev
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/chain"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing"
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+var (
+	ErrHeadNotFound = errors.New("head block not found")
+)
+
+// stateOverride changes the world state of a block outside of the transactions
+type stateOverride func(txn *state.Transition) error
+
+// chainSnapshot is the chain state saved by Snapshot
+type chainSnapshot struct {
+	head       types.Hash
+	timeOffset time.Duration
+}
+
+// isAutomine returns true if the blocks are sealed following the sealing policy
+func (d *Dev) isAutomine() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.automine
+}
+
+// nextTimestamp returns the timestamp of the block following the parent,
+// and consumes the timestamp set for it. The lock must be held
+func (d *Dev) nextTimestamp(parent *types.Header) uint64 {
+	timestamp := d.nextBlockTimestamp
+	d.nextBlockTimestamp = 0
+
+	if timestamp > parent.Timestamp {
+		// the following blocks continue from the forced timestamp
+		d.timeOffset = time.Until(time.Unix(int64(timestamp), 0))
+
+		return timestamp
+	}
+
+	return sealing.NextTimestamp(parent, time.Now().Add(d.timeOffset))
+}
+
+// Mine seals a new block right away, regardless of the sealing policy and automine.
+// The block has the given timestamp if not zero
+func (d *Dev) Mine(timestamp uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	parent := d.blockchain.Header()
+
+	if timestamp != 0 {
+		if err := sealing.VerifyTimestamp(parent, &types.Header{Timestamp: timestamp}); err != nil {
+			return err
+		}
+
+		d.nextBlockTimestamp = timestamp
+	}
+
+	return d.writeNewBlock(parent, d.sealing.Policy())
+}
+
+// IncreaseTime moves the clock of the next blocks forward, and returns the total offset
+func (d *Dev) IncreaseTime(offset time.Duration) time.Duration {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.timeOffset += offset
+
+	return d.timeOffset
+}
+
+// SetNextBlockTimestamp sets the timestamp of the next block,
+// the clock of the following blocks continues from it
+func (d *Dev) SetNextBlockTimestamp(timestamp uint64) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if err := sealing.VerifyTimestamp(d.blockchain.Header(), &types.Header{Timestamp: timestamp}); err != nil {
+		return err
+	}
+
+	d.nextBlockTimestamp = timestamp
+
+	return nil
+}
+
+// SetAutomine pauses or resumes the sealing following the sealing policy.
+// Blocks are still sealed by Mine and the state overrides while paused
+func (d *Dev) SetAutomine(enabled bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.automine = enabled
+}
+
+// Snapshot saves the current head and clock, and returns the id to revert to them
+func (d *Dev) Snapshot() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	d.lastSnapshotID++
+
+	d.snapshots[d.lastSnapshotID] = chainSnapshot{
+		head:       d.blockchain.Header().Hash,
+		timeOffset: d.timeOffset,
+	}
+
+	return d.lastSnapshotID
+}
+
+// Revert sets the head and the clock saved by the snapshot with the given id,
+// and deletes it along with the snapshots taken after it.
+// The pending transactions of the senders of the reverted blocks are dropped.
+// It returns false if the snapshot doesn't exist
+func (d *Dev) Revert(id uint64) (bool, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	snapshot, ok := d.snapshots[id]
+	if !ok {
+		return false, nil
+	}
+
+	reverted, err := d.revertedTransactions(snapshot.head)
+	if err != nil {
+		return false, err
+	}
+
+	if err := d.blockchain.SetHead(snapshot.head, devConsensus); err != nil {
+		return false, err
+	}
+
+	for snapshotID := range d.snapshots {
+		if snapshotID >= id {
+			delete(d.snapshots, snapshotID)
+		}
+	}
+
+	d.timeOffset = snapshot.timeOffset
+	d.nextBlockTimestamp = 0
+
+	// roll the nonces of the senders back to the first reverted transaction
+	for _, tx := range reverted {
+		d.txpool.Drop(tx)
+	}
+
+	return true, nil
+}
+
+// revertedTransactions returns the first transaction of each sender
+// in the blocks above the given ancestor of the head
+func (d *Dev) revertedTransactions(ancestor types.Hash) (map[types.Address]*types.Transaction, error) {
+	first := make(map[types.Address]*types.Transaction)
+
+	header := d.blockchain.Header()
+
+	for header.Hash != ancestor {
+		block, ok := d.blockchain.GetBlockByHash(header.Hash, true)
+		if !ok {
+			return nil, ErrHeadNotFound
+		}
+
+		for _, tx := range block.Transactions {
+			if prev, ok := first[tx.From]; !ok || tx.Nonce < prev.Nonce {
+				first[tx.From] = tx
+			}
+		}
+
+		if header, ok = d.blockchain.GetHeaderByHash(header.ParentHash); !ok {
+			return nil, ErrHeadNotFound
+		}
+	}
+
+	return first, nil
+}
+
+// SetBalance seals a new block setting the balance of the account
+func (d *Dev) SetBalance(addr types.Address, balance *big.Int) error {
+	return d.sealBlock(d.sealing.Policy(), func(txn *state.Transition) error {
+		if !txn.AccountExists(addr) {
+			return txn.SetAccountDirectly(addr, &chain.GenesisAccount{
+				Balance: balance,
+			})
+		}
+
+		return txn.SetBalanceDirectly(addr, balance)
+	})
+}
+
+// SetCode seals a new block setting the code of the account
+func (d *Dev) SetCode(addr types.Address, code []byte) error {
+	return d.sealBlock(d.sealing.Policy(), func(txn *state.Transition) error {
+		if !txn.AccountExists(addr) {
+			return txn.SetAccountDirectly(addr, &chain.GenesisAccount{
+				Code:    code,
+				Balance: big.NewInt(0),
+			})
+		}
+
+		return txn.SetCodeDirectly(addr, code)
+	})
+}
+
+// SetStorageAt seals a new block setting the storage slot of the account
+func (d *Dev) SetStorageAt(addr types.Address, key, value types.Hash) error {
+	return d.sealBlock(d.sealing.Policy(), func(txn *state.Transition) error {
+		if !txn.AccountExists(addr) {
+			return txn.SetAccountDirectly(addr, &chain.GenesisAccount{
+				Storage: map[types.Hash]types.Hash{key: value},
+				Balance: big.NewInt(0),
+			})
+		}
+
+		return txn.SetStorageDirectly(addr, key, value)
+	})
+}
@@ -1,34 +1,57 @@
 package dev
 
 import (
-	"fmt"
+	"sync"
 	"time"
 
 	"github.com/Gabulhas/polygon-external-consensus/blockchain"
 	"github.com/Gabulhas/polygon-external-consensus/consensus"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing"
 	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
 	"github.com/Gabulhas/polygon-external-consensus/state"
 	"github.com/Gabulhas/polygon-external-consensus/txpool"
+	txpoolProto "github.com/Gabulhas/polygon-external-consensus/txpool/proto"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/hashicorp/go-hclog"
 )
 
 const (
 	devConsensus = "dev-consensus"
+
+	// Interval of the checks for pending transactions left over by the on-demand sealing
+	pendingCheckInterval = time.Second
 )
 
-// Dev consensus protocol seals any new transaction immediately
+// defaultPolicy seals a block every second, even if empty
+var defaultPolicy = sealing.Policy{
+	Mode:     sealing.Interval,
+	Interval: 1,
+}
+
+// Dev consensus protocol seals the transactions following the sealing policy
 type Dev struct {
 	logger hclog.Logger
 
-	notifyCh chan struct{}
-	closeCh  chan struct{}
+	closeCh chan struct{}
 
-	interval uint64
-	txpool   *txpool.TxPool
+	sealing *sealing.Manager
+	txpool  *txpool.TxPool
 
 	blockchain *blockchain.Blockchain
 	executor   *state.Executor
+
+	// lock serializes the block sealing and guards the chain controls
+	lock sync.Mutex
+
+	// chain controls, see control.go
+	automine           bool
+	timeOffset         time.Duration
+	nextBlockTimestamp uint64
+	snapshots          map[uint64]chainSnapshot
+	lastSnapshotID     uint64
+
+	// state overrides applied to the block being sealed
+	overrides []stateOverride
 }
 
 // Factory implements the base factory method
@@ -37,23 +60,20 @@ func Factory(
 ) (consensus.Consensus, error) {
 	logger := params.Logger.Named("dev")
 
+	policy, err := sealing.ParsePolicy(params.Config.Config, defaultPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	d := &Dev{
 		logger:     logger,
-		notifyCh:   make(chan struct{}),
 		closeCh:    make(chan struct{}),
+		sealing:    sealing.NewManager(policy),
 		blockchain: params.Blockchain,
 		executor:   params.Executor,
 		txpool:     params.TxPool,
-	}
-
-	rawInterval, ok := params.Config.Config["interval"]
-	if ok {
-		interval, ok := rawInterval.(uint64)
-		if !ok {
-			return nil, fmt.Errorf("interval expected int")
-		}
-
-		d.interval = interval
+		automine:   true,
+		snapshots:  make(map[uint64]chainSnapshot),
 	}
 
 	return d, nil
@@ -66,6 +86,11 @@ func (d *Dev) Initialize() error {
 	return nil
 }
 
+// SealingManager returns the manager of the sealing policy
+func (d *Dev) SealingManager() *sealing.Manager {
+	return d.sealing
+}
+
 // Start starts the consensus mechanism
 func (d *Dev) Start() error {
 	go d.run()
@@ -73,46 +98,112 @@ func (d *Dev) Start() error {
 	return nil
 }
 
-func (d *Dev) nextNotify() chan struct{} {
-	if d.interval == 0 {
-		d.interval = 1
+// policyInterval returns the time between the checks of the pool the policy requires
+func policyInterval(policy sealing.Policy) time.Duration {
+	if policy.Mode == sealing.OnDemand {
+		return pendingCheckInterval
 	}
 
-	go func() {
-		<-time.After(time.Duration(d.interval) * time.Second)
-		d.notifyCh <- struct{}{}
-	}()
-
-	return d.notifyCh
+	return time.Duration(policy.Interval) * time.Second
 }
 
 func (d *Dev) run() {
 	d.logger.Info("consensus started")
 
+	txCh, cancel := d.txpool.SubscribeTxEvents(txpoolProto.EventType_PROMOTED)
+	defer cancel()
+
+	policy := d.sealing.Policy()
+
+	timer := time.NewTimer(policyInterval(policy))
+	defer timer.Stop()
+
 	for {
-		// wait until there is a new txn
 		select {
-		case <-d.nextNotify():
+		case <-txCh:
+			// new transactions are only sealed right away on demand
+			if policy.Mode != sealing.OnDemand {
+				continue
+			}
+		case <-timer.C:
+			timer.Reset(policyInterval(policy))
+		case <-d.sealing.UpdateCh():
+			policy = d.sealing.Policy()
+
+			if !timer.Stop() {
+				<-timer.C
+			}
+
+			timer.Reset(policyInterval(policy))
+
+			d.logger.Info("sealing policy updated", "mode", policy.Mode, "interval", policy.Interval)
+
+			continue
 		case <-d.closeCh:
 			return
 		}
 
-		// There are new transactions in the pool, try to seal them
-		header := d.blockchain.Header()
-		if err := d.writeNewBlock(header); err != nil {
+		if !d.isAutomine() {
+			continue
+		}
+
+		if !policy.SealsEmpty() && d.txpool.Length() == 0 &&
+			!d.txpool.HasBundles(d.blockchain.Header().Number+1) {
+			continue
+		}
+
+		if err := d.seal(policy); err != nil {
 			d.logger.Error("failed to mine block", "err", err)
 		}
 	}
 }
 
+// seal seals a new block with the pending transactions. On demand, blocks are sealed
+// until the pool is drained, as long as the blocks include some of the transactions
+func (d *Dev) seal(policy sealing.Policy) error {
+	pending := d.txpool.Length()
+
+	for {
+		if err := d.sealBlock(policy); err != nil {
+			return err
+		}
+
+		remaining := d.txpool.Length()
+		if policy.Mode != sealing.OnDemand || remaining == 0 || remaining >= pending {
+			return nil
+		}
+
+		pending = remaining
+	}
+}
+
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
+	TotalGas() uint64
+	Receipts() []*types.Receipt
+	Snapshot() *state.TransitionSnapshot
+	RevertToSnapshot(snapshot *state.TransitionSnapshot)
 }
 
-func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface) []*types.Transaction {
-	var successful []*types.Transaction
+func (d *Dev) writeTransactions(
+	header *types.Header,
+	policy sealing.Policy,
+	transition transitionInterface,
+) []*types.Transaction {
+	// the bundles are written ahead of the transactions of the pool
+	successful := consensus.WriteBundles(consensus.WriteBundlesParams{
+		Logger:     d.logger,
+		Pool:       d.txpool,
+		Transition: transition,
+		Header:     header,
+		Admits:     policy.Admits,
+	})
+
+	bundled := consensus.NewBundledTxs(successful)
+
+	gasLimit := header.GasLimit
 
-	d.txpool.Prepare()
+	d.txpool.Prepare(header.BaseFee)
 
 	for {
 		tx := d.txpool.Peek()
@@ -120,12 +211,23 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 			break
 		}
 
-		if tx.ExceedsBlockGasLimit(gasLimit) {
+		// the txs of the pool already written by the bundles are only popped
+		if bundledTx, ok := bundled.Get(tx); ok {
+			d.txpool.Pop(bundledTx)
+
+			continue
+		}
+
+		if tx.ExceedsBlockGasLimit(gasLimit) || !policy.Admits(0, 0, tx.Gas) {
 			d.txpool.Drop(tx)
 
 			continue
 		}
 
+		if !policy.Admits(len(successful), transition.TotalGas(), tx.Gas) {
+			break
+		}
+
 		if err := transition.Write(tx); err != nil {
 			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
 				break
@@ -149,16 +251,25 @@ func (d *Dev) writeTransactions(gasLimit uint64, transition transitionInterface)
 	return successful
 }
 
+// sealBlock writes a new block on top of the current head
+func (d *Dev) sealBlock(policy sealing.Policy, overrides ...stateOverride) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.writeNewBlock(d.blockchain.Header(), policy, overrides...)
+}
+
 // writeNewBLock generates a new block based on transactions from the pool,
-// and writes them to the blockchain
-func (d *Dev) writeNewBlock(parent *types.Header) error {
+// and writes them to the blockchain. A block applying state overrides
+// doesn't include any transaction. The lock must be held
+func (d *Dev) writeNewBlock(parent *types.Header, policy sealing.Policy, overrides ...stateOverride) error {
 	// Generate the base block
 	num := parent.Number
 	header := &types.Header{
 		ParentHash: parent.Hash,
 		Number:     num + 1,
 		GasLimit:   parent.GasLimit, // Inherit from parent for now, will need to adjust dynamically later.
-		Timestamp:  uint64(time.Now().Unix()),
+		Timestamp:  d.nextTimestamp(parent),
 	}
 
 	// calculate gas limit based on parent header
@@ -168,6 +279,7 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = d.blockchain.CalculateBaseFee(parent)
 
 	miner, err := d.GetBlockCreator(header)
 	if err != nil {
@@ -180,7 +292,21 @@ func (d *Dev) writeNewBlock(parent *types.Header) error {
 		return err
 	}
 
-	txns := d.writeTransactions(gasLimit, transition)
+	var txns []*types.Transaction
+
+	if len(overrides) == 0 {
+		txns = d.writeTransactions(header, policy, transition)
+	}
+
+	// the overrides are applied again when the block is verified
+	d.overrides = overrides
+	defer func() {
+		d.overrides = nil
+	}()
+
+	if err := d.PreCommitState(header, transition); err != nil {
+		return err
+	}
 
 	// Commit the changes
 	_, root := transition.Commit()
@@ -229,7 +355,13 @@ func (d *Dev) GetBlockCreator(header *types.Header) (types.Address, error) {
 }
 
 // PreCommitState a hook to be called before finalizing state transition on inserting block
-func (d *Dev) PreCommitState(_header *types.Header, _txn *state.Transition) error {
+func (d *Dev) PreCommitState(_header *types.Header, txn *state.Transition) error {
+	for _, override := range d.overrides {
+		if err := override(txn); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
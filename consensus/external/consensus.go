@@ -5,6 +5,8 @@ import (
 
 	"github.com/Gabulhas/polygon-external-consensus/blockchain"
 	"github.com/Gabulhas/polygon-external-consensus/consensus"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing"
 	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
 	"github.com/Gabulhas/polygon-external-consensus/network"
 	"github.com/Gabulhas/polygon-external-consensus/secrets"
@@ -36,7 +38,11 @@ type External struct {
 	secretsManager secrets.SecretsManager
 	syncer         syncer.Syncer
 	txpool         *txpool.TxPool
-	transport      transport
+	relay          *messageRelay   // Reference to the consensus message relay
+	operator       *operator       // Reference to the engine API service
+	verifier       *headerVerifier // Reference to the remote header verification, if enabled
+	sealer         *sealer         // Reference to the block sealing, if enabled
+	sealing        *sealing.Manager
 	blockTime      time.Duration
 }
 
@@ -46,6 +52,31 @@ func Factory(
 ) (consensus.Consensus, error) {
 	logger := params.Logger.Named("external")
 
+	verifier, err := newHeaderVerifier(logger, params.Config.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	sealer, err := newSealer(params.Config.Config, params.SecretsManager)
+	if err != nil {
+		return nil, err
+	}
+
+	// the blocks are paced by the external consensus client,
+	// the policy decides if empty blocks are built and caps their content
+	policy, err := sealing.ParsePolicy(params.Config.Config, sealing.Policy{
+		Mode:     sealing.Interval,
+		Interval: params.BlockTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if sealer != nil {
+		// Sealed blocks require a header hash that doesn't cover the seal
+		types.HeaderHash = sealer.headerHash(types.HeaderHash)
+	}
+
 	d := &External{
 		logger: logger,
 
@@ -67,6 +98,9 @@ func Factory(
 			time.Duration(params.BlockTime)*3*time.Second,
 		),
 		blockTime: time.Duration(params.BlockTime) * time.Second,
+		verifier:  verifier,
+		sealer:    sealer,
+		sealing:   sealing.NewManager(policy),
 	}
 
 	return d, nil
@@ -74,10 +108,14 @@ func Factory(
 
 // Initialize initializes the consensus
 func (d *External) Initialize() error {
-	// register the grpc operator
-	//	if d.Grpc != nil {
-	//		d.Grpc.RegisterService()
-	//	}
+	// register the engine API the external consensus client connects to
+	if d.Grpc != nil {
+		d.operator = &operator{external: d}
+		proto.RegisterExternalEngineServer(d.Grpc, d.operator)
+	}
+
+	// blocks are built from the pool, so gossiped transactions are accepted
+	d.txpool.SetSealing(true)
 
 	// start the transport protocol
 	if err := d.setupTransport(); err != nil {
@@ -87,66 +125,114 @@ func (d *External) Initialize() error {
 	return nil
 }
 
+// SealingManager returns the manager of the sealing policy
+func (d *External) SealingManager() *sealing.Manager {
+	return d.sealing
+}
+
 // Start starts the consensus mechanism
 func (d *External) Start() error {
+	// Start the syncer
+	if err := d.syncer.Start(); err != nil {
+		return err
+	}
+
+	// Start syncing blocks from other peers
+	go d.startSyncing()
+
 	go d.run()
 
 	return nil
 }
 
-func (d *External) run() {
-	d.logger.Info("consensus started")
+// startSyncing runs the syncer in the background to receive blocks from advanced peers.
+// The received headers are verified by the external consensus client, if enabled
+func (d *External) startSyncing() {
+	callInsertBlockHook := func(block *types.Block) bool {
+		d.txpool.ResetWithHeaders(block.Header)
 
-	for {
-		// wait until there is a new txn
-		select {
-		case <-d.closeCh:
-			return
-		}
+		return false
+	}
 
-		// There are new transactions in the pool, try to seal them
-		header := d.blockchain.Header()
-		if err := d.writeNewBlock(header); err != nil {
-			d.logger.Error("failed to mine block", "err", err)
-		}
+	if err := d.syncer.Sync(callInsertBlockHook); err != nil {
+		d.logger.Error("watch sync failed", "err", err)
 	}
 }
 
+func (d *External) run() {
+	d.logger.Info("consensus started")
+
+	// Blocks are produced on request of the external consensus client,
+	// through the engine API, so there is nothing to do until closing
+	<-d.closeCh
+}
+
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
+	TotalGas() uint64
+	Receipts() []*types.Receipt
+	Snapshot() *state.TransitionSnapshot
+	RevertToSnapshot(snapshot *state.TransitionSnapshot)
 }
 
-func (d *External) writeTransactions(gasLimit uint64, transition transitionInterface) []*types.Transaction {
-	var successful []*types.Transaction
+func (d *External) writeTransactions(
+	header *types.Header,
+	policy sealing.Policy,
+	transition transitionInterface,
+) []*types.Transaction {
+	// the bundles are written ahead of the transactions of the pool
+	successful := consensus.WriteBundles(consensus.WriteBundlesParams{
+		Logger:     d.logger,
+		Pool:       d.txpool,
+		Transition: transition,
+		Header:     header,
+		Admits:     policy.Admits,
+	})
+
+	bundled := consensus.NewBundledTxs(successful)
+
+	gasLimit := header.GasLimit
 
-	d.txpool.Prepare()
+	// the payload may be discarded by the external consensus client, so the pool
+	// is left untouched, and only updated once the block is imported
+	pending := d.txpool.Pending(header.BaseFee)
 
 	for {
-		tx := d.txpool.Peek()
+		tx := pending.Peek()
 		if tx == nil {
 			break
 		}
 
-		if tx.ExceedsBlockGasLimit(gasLimit) {
-			d.txpool.Drop(tx)
+		// the txs of the pool already written by the bundles are skipped
+		if _, ok := bundled.Get(tx); ok {
+			pending.Shift()
 
 			continue
 		}
 
+		if tx.ExceedsBlockGasLimit(gasLimit) || !policy.Admits(0, 0, tx.Gas) {
+			pending.Skip()
+
+			continue
+		}
+
+		if !policy.Admits(len(successful), transition.TotalGas(), tx.Gas) {
+			break
+		}
+
 		if err := transition.Write(tx); err != nil {
 			if _, ok := err.(*state.GasLimitReachedTransitionApplicationError); ok { //nolint:errorlint
 				break
-			} else if appErr, ok := err.(*state.TransitionApplicationError); ok && appErr.IsRecoverable { //nolint:errorlint
-				d.txpool.Demote(tx)
-			} else {
-				d.txpool.Drop(tx)
 			}
 
+			// the following txs of the account can't be written either
+			pending.Skip()
+
 			continue
 		}
 
-		// no errors, pop the tx from the pool
-		d.txpool.Pop(tx)
+		// no errors, go on with the following tx of the account
+		pending.Shift()
 
 		successful = append(successful, tx)
 	}
@@ -156,38 +242,68 @@ func (d *External) writeTransactions(gasLimit uint64, transition transitionInter
 	return successful
 }
 
-// writeNewBLock generates a new block based on transactions from the pool,
-// and writes them to the blockchain
-func (d *External) writeNewBlock(parent *types.Header) error {
+// buildBlock generates a new block on top of the parent, based on transactions from the pool.
+// If the blocks are sealed locally, the block is sealed by the validator key of the node,
+// which is credited instead of the coinbase. The block is not written to the blockchain,
+// and the transactions are only removed from the pool once it's imported.
+// Empty blocks are only built if the sealing policy allows them
+func (d *External) buildBlock(
+	parent *types.Header,
+	timestamp uint64,
+	coinbase types.Address,
+	extraData []byte,
+) (*types.Block, error) {
+	policy := d.sealing.Policy()
+
+	if !policy.SealsEmpty() && d.txpool.Length() == 0 && !d.txpool.HasBundles(parent.Number+1) {
+		return nil, ErrEmptyPayload
+	}
+
+	if timestamp == 0 {
+		timestamp = sealing.NextTimestamp(parent, time.Now())
+	} else if timestamp <= parent.Timestamp {
+		return nil, sealing.ErrInvalidTimestamp
+	}
+
+	if d.sealer != nil {
+		if d.sealer.local {
+			coinbase = d.sealer.keyManager.Address()
+		} else if coinbase == types.ZeroAddress {
+			// the seal has to be signed by the miner
+			return nil, ErrSealerRequired
+		}
+	}
+
 	// Generate the base block
 	num := parent.Number
 	header := &types.Header{
 		ParentHash: parent.Hash,
 		Number:     num + 1,
+		Miner:      coinbase.Bytes(),
+		ExtraData:  extraData,
 		GasLimit:   parent.GasLimit, // Inherit from parent for now, will need to adjust dynamically later.
-		Timestamp:  uint64(time.Now().Unix()),
+		Timestamp:  timestamp,
 	}
 
 	// calculate gas limit based on parent header
 	gasLimit, err := d.blockchain.CalculateGasLimit(header.Number)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = d.blockchain.CalculateBaseFee(parent)
 
-	miner, err := d.GetBlockCreator(header)
-	if err != nil {
-		return err
+	if d.sealer != nil {
+		d.sealer.initExtra(header)
 	}
 
-	transition, err := d.executor.BeginTxn(parent.StateRoot, header, miner)
-
+	transition, err := d.executor.BeginTxn(parent.StateRoot, header, coinbase)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	txns := d.writeTransactions(gasLimit, transition)
+	txns := d.writeTransactions(header, policy, transition)
 
 	// Commit the changes
 	_, root := transition.Commit()
@@ -196,6 +312,10 @@ func (d *External) writeNewBlock(parent *types.Header) error {
 	header.StateRoot = root
 	header.GasUsed = transition.TotalGas()
 
+	if len(txns) == 0 && !policy.SealsEmpty() {
+		return nil, ErrEmptyPayload
+	}
+
 	// Build the actual block
 	// The header hash is computed inside buildBlock
 	block := consensus.BuildBlock(consensus.BuildBlockParams{
@@ -204,12 +324,28 @@ func (d *External) writeNewBlock(parent *types.Header) error {
 		Receipts: transition.Receipts(),
 	})
 
+	if d.sealer != nil && d.sealer.local {
+		if err := d.sealer.sealLocally(block.Header); err != nil {
+			return nil, err
+		}
+	}
+
+	return block, nil
+}
+
+// importBlock verifies the block and writes it to the blockchain
+func (d *External) importBlock(block *types.Block) error {
+	if d.verifier != nil {
+		// the block was handed in by the external consensus client itself
+		d.verifier.deliver(block.Hash(), &verdict{accept: true})
+	}
+
 	if err := d.blockchain.VerifyFinalizedBlock(block); err != nil {
 		return err
 	}
 
-	// Write the block to the blockchain
-	if err := d.blockchain.WriteBlock(block, externalConsensus); err != nil {
+	// Write the block to the blockchain, the fork choice is made by the external consensus client
+	if err := d.blockchain.ImportBlock(block, externalConsensus); err != nil {
 		return err
 	}
 
@@ -220,18 +356,97 @@ func (d *External) writeNewBlock(parent *types.Header) error {
 	return nil
 }
 
+// setForkchoice sets the head of the canonical chain, and the latest finalized and safe blocks,
+// as decided by the external consensus client. Zero hashes leave the finalized and safe blocks unchanged
+func (d *External) setForkchoice(headHash, finalizedHash, safeHash types.Hash) error {
+	if err := d.blockchain.SetHead(headHash, externalConsensus); err != nil {
+		return err
+	}
+
+	if finalizedHash != types.ZeroHash {
+		if err := d.blockchain.SetFinalized(finalizedHash); err != nil {
+			return err
+		}
+	}
+
+	if safeHash != types.ZeroHash {
+		if err := d.blockchain.SetSafe(safeHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // REQUIRED BASE INTERFACE METHODS //
 
 func (d *External) VerifyHeader(header *types.Header) error {
-	// All blocks are valid
-	return nil
+	if err := d.verifyTimestamp(header); err != nil {
+		return err
+	}
+
+	if d.sealer != nil {
+		if err := d.sealer.verifySeal(header); err != nil {
+			return err
+		}
+	}
+
+	if d.verifier == nil {
+		return nil
+	}
+
+	return d.verifier.verifyHeaders([]*types.Header{header})
+}
+
+// VerifyHeaders verifies a batch of headers with the external consensus client,
+// caching the verdicts for the following verifications of each header
+func (d *External) VerifyHeaders(headers []*types.Header) error {
+	for _, header := range headers {
+		if err := d.verifyTimestamp(header); err != nil {
+			return err
+		}
+	}
+
+	if d.sealer != nil {
+		for _, header := range headers {
+			if err := d.sealer.verifySeal(header); err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.verifier == nil {
+		return nil
+	}
+
+	return d.verifier.verifyHeaders(headers)
+}
+
+// verifyTimestamp checks the timestamp of the header is greater than the parent timestamp,
+// if the parent is known
+func (d *External) verifyTimestamp(header *types.Header) error {
+	if header.Number == 0 {
+		return nil
+	}
+
+	parent, ok := d.blockchain.GetHeaderByHash(header.ParentHash)
+	if !ok {
+		return nil
+	}
+
+	return sealing.VerifyTimestamp(parent, header)
 }
 
 func (d *External) ProcessHeaders(headers []*types.Header) error {
 	return nil
 }
 
+// GetBlockCreator retrieves the block signer from the seal, if the blocks are sealed
 func (d *External) GetBlockCreator(header *types.Header) (types.Address, error) {
+	if d.sealer != nil {
+		return d.sealer.recoverSigner(header)
+	}
+
 	return types.BytesToAddress(header.Miner), nil
 }
 
@@ -241,11 +456,15 @@ func (d *External) PreCommitState(_header *types.Header, _txn *state.Transition)
 }
 
 func (d *External) GetSyncProgression() *progress.Progression {
-	return nil
+	return d.syncer.GetSyncProgression()
 }
 
 func (d *External) Close() error {
 	close(d.closeCh)
 
+	if err := d.syncer.Close(); err != nil {
+		return err
+	}
+
 	return nil
 }
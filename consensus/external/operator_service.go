@@ -0,0 +1,209 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	ErrParentNotFound       = errors.New("parent block not found")
+	ErrInvalidPayload       = errors.New("invalid payload")
+	ErrPayloadHashMismatch  = errors.New("payload hash doesn't match the block hash")
+	ErrVerificationDisabled = errors.New("remote header verification is not enabled")
+	ErrSealingDisabled      = errors.New("block sealing is not enabled")
+	ErrEmptyPayload         = errors.New("no pending transactions to build the payload with")
+)
+
+type operator struct {
+	proto.UnimplementedExternalEngineServer
+
+	external *External
+
+	// serializes the building and importing of the payloads, and the fork choice updates
+	lock sync.Mutex
+}
+
+// BuildPayload builds a new block on top of the requested parent
+func (o *operator) BuildPayload(ctx context.Context, req *proto.BuildPayloadRequest) (*proto.Payload, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	parent, ok := o.external.blockchain.GetHeaderByHash(types.BytesToHash(req.ParentHash))
+	if !ok {
+		return nil, ErrParentNotFound
+	}
+
+	block, err := o.external.buildBlock(
+		parent,
+		req.Timestamp,
+		types.BytesToAddress(req.Coinbase),
+		req.ExtraData,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := toProtoPayload(block)
+
+	if sealer := o.external.sealer; sealer != nil && !sealer.local {
+		// the block is sealed by the external consensus client before importing it
+		if payload.SealHash, err = sealer.sealHash(block.Header); err != nil {
+			return nil, err
+		}
+	}
+
+	return payload, nil
+}
+
+// ImportPayload seals the block in the payload with the given seal, if any,
+// then verifies the block and writes it to the chain
+func (o *operator) ImportPayload(ctx context.Context, req *proto.Payload) (*proto.ImportPayloadResponse, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	block, err := fromProtoPayload(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Seal) != 0 {
+		if o.external.sealer == nil {
+			return nil, ErrSealingDisabled
+		}
+
+		if err := o.external.sealer.writeSeal(block.Header, req.Seal); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := o.external.importBlock(block); err != nil {
+		return nil, err
+	}
+
+	head := o.external.blockchain.Header()
+
+	return &proto.ImportPayloadResponse{
+		HeadHash:   head.Hash.Bytes(),
+		HeadNumber: head.Number,
+	}, nil
+}
+
+// ForkchoiceUpdated sets the canonical head, and the latest finalized and safe blocks
+func (o *operator) ForkchoiceUpdated(
+	ctx context.Context,
+	req *proto.ForkchoiceState,
+) (*proto.ForkchoiceUpdatedResponse, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	if err := o.external.setForkchoice(
+		types.BytesToHash(req.HeadHash),
+		types.BytesToHash(req.FinalizedHash),
+		types.BytesToHash(req.SafeHash),
+	); err != nil {
+		return nil, err
+	}
+
+	head := o.external.blockchain.Header()
+
+	return &proto.ForkchoiceUpdatedResponse{
+		HeadHash:   head.Hash.Bytes(),
+		HeadNumber: head.Number,
+	}, nil
+}
+
+// Status returns the current head, and the latest finalized and safe blocks
+func (o *operator) Status(ctx context.Context, req *empty.Empty) (*proto.EngineStatus, error) {
+	head := o.external.blockchain.Header()
+
+	resp := &proto.EngineStatus{
+		HeadHash:   head.Hash.Bytes(),
+		HeadNumber: head.Number,
+	}
+
+	if finalized, ok := o.external.blockchain.FinalizedHeader(); ok {
+		resp.FinalizedHash = finalized.Hash.Bytes()
+		resp.FinalizedNumber = finalized.Number
+	}
+
+	if safe, ok := o.external.blockchain.SafeHeader(); ok {
+		resp.SafeHash = safe.Hash.Bytes()
+		resp.SafeNumber = safe.Number
+	}
+
+	return resp, nil
+}
+
+// VerifyHeaders streams the headers to be verified to the external consensus client,
+// and receives back the verdicts
+func (o *operator) VerifyHeaders(stream proto.ExternalEngine_VerifyHeadersServer) error {
+	if o.external.verifier == nil {
+		return ErrVerificationDisabled
+	}
+
+	return o.external.verifier.serve(stream)
+}
+
+// PublishMessage gossips the consensus message to the other nodes
+func (o *operator) PublishMessage(ctx context.Context, req *proto.PublishMessageRequest) (*empty.Empty, error) {
+	if err := o.external.relay.publish(req.Type, req.Payload); err != nil {
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
+
+// SubscribeMessages streams the consensus messages of the requested types
+// received from the other nodes, until the client disconnects
+func (o *operator) SubscribeMessages(
+	req *proto.SubscribeMessagesRequest,
+	stream proto.ExternalEngine_SubscribeMessagesServer,
+) error {
+	sub := o.external.relay.subscribe(req.Types)
+	defer o.external.relay.unsubscribe(sub)
+
+	for {
+		select {
+		case msg := <-sub.ch:
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-o.external.closeCh:
+			return nil
+		}
+	}
+}
+
+// toProtoPayload converts the block to the payload of the engine API
+func toProtoPayload(block *types.Block) *proto.Payload {
+	return &proto.Payload{
+		Block:  block.MarshalRLP(),
+		Hash:   block.Hash().Bytes(),
+		Number: block.Number(),
+	}
+}
+
+// fromProtoPayload decodes the block in the payload of the engine API
+func fromProtoPayload(payload *proto.Payload) (*types.Block, error) {
+	if payload == nil || len(payload.Block) == 0 {
+		return nil, ErrInvalidPayload
+	}
+
+	block := &types.Block{}
+	if err := block.UnmarshalRLP(payload.Block); err != nil {
+		return nil, err
+	}
+
+	if len(payload.Hash) != 0 && types.BytesToHash(payload.Hash) != block.Hash() {
+		return nil, ErrPayloadHashMismatch
+	}
+
+	return block, nil
+}
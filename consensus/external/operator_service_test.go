@@ -0,0 +1,336 @@
+package external
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/blockchain"
+	"github.com/Gabulhas/polygon-external-consensus/chain"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing"
+	"github.com/Gabulhas/polygon-external-consensus/crypto"
+	"github.com/Gabulhas/polygon-external-consensus/helper/keccak"
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	itrie "github.com/Gabulhas/polygon-external-consensus/state/immutable-trie"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/evm"
+	"github.com/Gabulhas/polygon-external-consensus/txpool"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChainID = 100
+
+// testPoolStore reads the account nonces and balances of the pool from the state
+type testPoolStore struct {
+	*blockchain.Blockchain
+	executor *state.Executor
+}
+
+// getAccount returns the account at the given state root, if any
+func (s *testPoolStore) getAccount(root types.Hash, addr types.Address) (*state.Account, bool) {
+	snap, err := s.executor.State().NewSnapshotAt(root)
+	if err != nil {
+		return nil, false
+	}
+
+	result, ok := snap.Get(keccak.Keccak256(nil, addr.Bytes()))
+	if !ok {
+		return nil, false
+	}
+
+	var account state.Account
+	if err := account.UnmarshalRlp(result); err != nil {
+		return nil, false
+	}
+
+	return &account, true
+}
+
+func (s *testPoolStore) GetNonce(root types.Hash, addr types.Address) uint64 {
+	if account, ok := s.getAccount(root, addr); ok {
+		return account.Nonce
+	}
+
+	return 0
+}
+
+func (s *testPoolStore) GetBalance(root types.Hash, addr types.Address) (*big.Int, error) {
+	if account, ok := s.getAccount(root, addr); ok {
+		return account.Balance, nil
+	}
+
+	return big.NewInt(0), nil
+}
+
+// newTestOperator returns the engine API of a chain with the given premined accounts,
+// building non-empty blocks
+func newTestOperator(t *testing.T, premined ...types.Address) *operator {
+	t.Helper()
+
+	params := &chain.Params{
+		Forks:          chain.AllForksEnabled,
+		ChainID:        testChainID,
+		BlockGasTarget: chain.GenesisGasLimit,
+	}
+
+	genesis := &chain.Genesis{
+		Config:   params,
+		GasLimit: chain.GenesisGasLimit,
+		BaseFee:  1,
+		Alloc:    map[types.Address]*chain.GenesisAccount{},
+	}
+
+	for _, addr := range premined {
+		genesis.Alloc[addr] = &chain.GenesisAccount{Balance: big.NewInt(1e18)}
+	}
+
+	logger := hclog.NewNullLogger()
+
+	executor := state.NewExecutor(params, itrie.NewState(itrie.NewMemoryStorage()), logger)
+	executor.SetRuntime(evm.NewEVM())
+
+	genesis.StateRoot = executor.WriteGenesis(genesis.Alloc)
+
+	signer := crypto.NewLondonSigner(testChainID)
+
+	bc, err := blockchain.NewBlockchain(logger, "", &chain.Chain{Genesis: genesis, Params: params}, nil, executor, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	executor.GetHash = bc.GetHashHelper
+
+	pool, err := txpool.NewTxPool(
+		logger,
+		params.Forks.At(0),
+		&testPoolStore{Blockchain: bc, executor: executor},
+		nil,
+		nil,
+		txpool.NilMetrics(),
+		&txpool.Config{
+			MaxSlots:           4096,
+			MaxAccountEnqueued: 128,
+			PriceBump:          10,
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool.SetSigner(signer)
+
+	external := &External{
+		logger:     logger,
+		closeCh:    make(chan struct{}),
+		blockchain: bc,
+		executor:   executor,
+		txpool:     pool,
+		sealing: sealing.NewManager(sealing.Policy{
+			Mode:     sealing.NonEmpty,
+			Interval: 1,
+		}),
+	}
+
+	bc.SetConsensus(external)
+
+	if err := bc.ComputeGenesis(); err != nil {
+		t.Fatal(err)
+	}
+
+	pool.Start()
+
+	t.Cleanup(func() {
+		pool.Close()
+		_ = bc.Close()
+	})
+
+	return &operator{external: external}
+}
+
+// addTestTxs adds signed transfers of the sender with the given nonces to the pool,
+// and waits for their promotion
+func addTestTxs(t *testing.T, o *operator, key *ecdsa.PrivateKey, nonces ...uint64) {
+	t.Helper()
+
+	signer := crypto.NewLondonSigner(testChainID)
+	to := types.StringToAddress("0x1")
+
+	for _, nonce := range nonces {
+		tx, err := signer.SignTx(&types.Transaction{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(1),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(1),
+		}, key)
+		assert.NoError(t, err)
+
+		assert.NoError(t, o.external.txpool.AddTx(tx))
+	}
+
+	assert.Eventually(t, func() bool {
+		return o.external.txpool.Length() == uint64(len(nonces))
+	}, time.Second, 10*time.Millisecond)
+}
+
+// buildTestPayload builds a payload on top of the given parent
+func buildTestPayload(t *testing.T, o *operator, parent *types.Header, timestamp uint64) *proto.Payload {
+	t.Helper()
+
+	payload, err := o.BuildPayload(context.Background(), &proto.BuildPayloadRequest{
+		ParentHash: parent.Hash.Bytes(),
+		Timestamp:  timestamp,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return payload
+}
+
+func TestOperator_BuildPayload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unknown parent", func(t *testing.T) {
+		t.Parallel()
+
+		o := newTestOperator(t)
+
+		_, err := o.BuildPayload(context.Background(), &proto.BuildPayloadRequest{
+			ParentHash: types.StringToHash("0x1").Bytes(),
+		})
+		assert.ErrorIs(t, err, ErrParentNotFound)
+	})
+
+	t.Run("no pending txs", func(t *testing.T) {
+		t.Parallel()
+
+		o := newTestOperator(t)
+
+		_, err := o.BuildPayload(context.Background(), &proto.BuildPayloadRequest{
+			ParentHash: o.external.blockchain.Header().Hash.Bytes(),
+		})
+		assert.ErrorIs(t, err, ErrEmptyPayload)
+	})
+
+	t.Run("the pool is left untouched", func(t *testing.T) {
+		t.Parallel()
+
+		key, addr := tests.GenerateKeyAndAddr(t)
+
+		o := newTestOperator(t, addr)
+		addTestTxs(t, o, key, 0, 1)
+
+		genesis := o.external.blockchain.Header()
+
+		// the same payload is built as long as it's not imported
+		first := buildTestPayload(t, o, genesis, 1)
+		second := buildTestPayload(t, o, genesis, 1)
+
+		assert.Equal(t, first.Hash, second.Hash)
+		assert.Equal(t, uint64(1), first.Number)
+		assert.Equal(t, uint64(2), o.external.txpool.Length())
+
+		block, err := fromProtoPayload(first)
+		assert.NoError(t, err)
+		assert.Len(t, block.Transactions, 2)
+
+		// the payload wasn't written to the chain
+		assert.Equal(t, genesis.Hash, o.external.blockchain.Header().Hash)
+	})
+}
+
+func TestOperator_ImportPayload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid payloads are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		o := newTestOperator(t)
+
+		_, err := o.ImportPayload(context.Background(), &proto.Payload{})
+		assert.ErrorIs(t, err, ErrInvalidPayload)
+
+		block := &types.Block{Header: &types.Header{Number: 1}}
+
+		_, err = o.ImportPayload(context.Background(), &proto.Payload{
+			Block: block.MarshalRLP(),
+			Hash:  types.StringToHash("0x1").Bytes(),
+		})
+		assert.ErrorIs(t, err, ErrPayloadHashMismatch)
+	})
+
+	t.Run("the txs of the imported payload are removed from the pool", func(t *testing.T) {
+		t.Parallel()
+
+		key, addr := tests.GenerateKeyAndAddr(t)
+
+		o := newTestOperator(t, addr)
+		addTestTxs(t, o, key, 0, 1)
+
+		payload := buildTestPayload(t, o, o.external.blockchain.Header(), 1)
+
+		resp, err := o.ImportPayload(context.Background(), payload)
+		assert.NoError(t, err)
+		assert.Equal(t, payload.Hash, resp.HeadHash)
+		assert.Equal(t, uint64(1), resp.HeadNumber)
+
+		assert.Equal(t, uint64(0), o.external.txpool.Length())
+		assert.Equal(t, uint64(2), o.external.txpool.GetNonce(addr))
+	})
+}
+
+func TestOperator_ForkchoiceUpdated(t *testing.T) {
+	t.Parallel()
+
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	o := newTestOperator(t, addr)
+	addTestTxs(t, o, key, 0)
+
+	genesis := o.external.blockchain.Header()
+
+	// two competing payloads on top of the genesis
+	first := buildTestPayload(t, o, genesis, 1)
+	second := buildTestPayload(t, o, genesis, 2)
+
+	for _, payload := range []*proto.Payload{first, second} {
+		_, err := o.ImportPayload(context.Background(), payload)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, first.Hash, o.external.blockchain.Header().Hash.Bytes())
+
+	_, err := o.ForkchoiceUpdated(context.Background(), &proto.ForkchoiceState{
+		HeadHash: types.StringToHash("0x1").Bytes(),
+	})
+	assert.Error(t, err)
+
+	// the second payload is chosen, and finalized
+	resp, err := o.ForkchoiceUpdated(context.Background(), &proto.ForkchoiceState{
+		HeadHash:      second.Hash,
+		FinalizedHash: second.Hash,
+		SafeHash:      second.Hash,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, second.Hash, resp.HeadHash)
+	assert.Equal(t, uint64(1), resp.HeadNumber)
+
+	status, err := o.Status(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, second.Hash, status.HeadHash)
+	assert.Equal(t, second.Hash, status.FinalizedHash)
+	assert.Equal(t, second.Hash, status.SafeHash)
+
+	// the finalized payload can't be reorged anymore
+	_, err = o.ForkchoiceUpdated(context.Background(), &proto.ForkchoiceState{
+		HeadHash: first.Hash,
+	})
+	assert.ErrorIs(t, err, blockchain.ErrReorgBelowFinalized)
+	assert.Equal(t, second.Hash, o.external.blockchain.Header().Hash.Bytes())
+}
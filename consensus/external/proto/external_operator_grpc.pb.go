@@ -0,0 +1,400 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.5
+// source: consensus/external/proto/external_operator.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExternalEngineClient is the client API for ExternalEngine service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExternalEngineClient interface {
+	// BuildPayload builds a new block on top of the given parent,
+	// using the transactions currently in the pool. The block is not written
+	BuildPayload(ctx context.Context, in *BuildPayloadRequest, opts ...grpc.CallOption) (*Payload, error)
+	// ImportPayload verifies the given block and writes it to the chain
+	ImportPayload(ctx context.Context, in *Payload, opts ...grpc.CallOption) (*ImportPayloadResponse, error)
+	// ForkchoiceUpdated sets the canonical head, the finalized and the safe blocks
+	ForkchoiceUpdated(ctx context.Context, in *ForkchoiceState, opts ...grpc.CallOption) (*ForkchoiceUpdatedResponse, error)
+	// Status returns the current head, finalized and safe blocks of the node
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EngineStatus, error)
+	// VerifyHeaders opens the stream the node sends headers to be verified through,
+	// before writing their blocks. The client replies with a verdict for each header
+	VerifyHeaders(ctx context.Context, opts ...grpc.CallOption) (ExternalEngine_VerifyHeadersClient, error)
+	// PublishMessage gossips a consensus message to the other nodes
+	PublishMessage(ctx context.Context, in *PublishMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SubscribeMessages streams the consensus messages received from the other nodes
+	SubscribeMessages(ctx context.Context, in *SubscribeMessagesRequest, opts ...grpc.CallOption) (ExternalEngine_SubscribeMessagesClient, error)
+}
+
+type externalEngineClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExternalEngineClient(cc grpc.ClientConnInterface) ExternalEngineClient {
+	return &externalEngineClient{cc}
+}
+
+func (c *externalEngineClient) BuildPayload(ctx context.Context, in *BuildPayloadRequest, opts ...grpc.CallOption) (*Payload, error) {
+	out := new(Payload)
+	err := c.cc.Invoke(ctx, "/v1.ExternalEngine/BuildPayload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalEngineClient) ImportPayload(ctx context.Context, in *Payload, opts ...grpc.CallOption) (*ImportPayloadResponse, error) {
+	out := new(ImportPayloadResponse)
+	err := c.cc.Invoke(ctx, "/v1.ExternalEngine/ImportPayload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalEngineClient) ForkchoiceUpdated(ctx context.Context, in *ForkchoiceState, opts ...grpc.CallOption) (*ForkchoiceUpdatedResponse, error) {
+	out := new(ForkchoiceUpdatedResponse)
+	err := c.cc.Invoke(ctx, "/v1.ExternalEngine/ForkchoiceUpdated", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalEngineClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*EngineStatus, error) {
+	out := new(EngineStatus)
+	err := c.cc.Invoke(ctx, "/v1.ExternalEngine/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalEngineClient) VerifyHeaders(ctx context.Context, opts ...grpc.CallOption) (ExternalEngine_VerifyHeadersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExternalEngine_ServiceDesc.Streams[0], "/v1.ExternalEngine/VerifyHeaders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &externalEngineVerifyHeadersClient{stream}
+	return x, nil
+}
+
+type ExternalEngine_VerifyHeadersClient interface {
+	Send(*HeaderVerdict) error
+	Recv() (*HeaderVerificationRequest, error)
+	grpc.ClientStream
+}
+
+type externalEngineVerifyHeadersClient struct {
+	grpc.ClientStream
+}
+
+func (x *externalEngineVerifyHeadersClient) Send(m *HeaderVerdict) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *externalEngineVerifyHeadersClient) Recv() (*HeaderVerificationRequest, error) {
+	m := new(HeaderVerificationRequest)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *externalEngineClient) PublishMessage(ctx context.Context, in *PublishMessageRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.ExternalEngine/PublishMessage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *externalEngineClient) SubscribeMessages(ctx context.Context, in *SubscribeMessagesRequest, opts ...grpc.CallOption) (ExternalEngine_SubscribeMessagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExternalEngine_ServiceDesc.Streams[1], "/v1.ExternalEngine/SubscribeMessages", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &externalEngineSubscribeMessagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ExternalEngine_SubscribeMessagesClient interface {
+	Recv() (*ReceivedMessage, error)
+	grpc.ClientStream
+}
+
+type externalEngineSubscribeMessagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *externalEngineSubscribeMessagesClient) Recv() (*ReceivedMessage, error) {
+	m := new(ReceivedMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExternalEngineServer is the server API for ExternalEngine service.
+// All implementations must embed UnimplementedExternalEngineServer
+// for forward compatibility
+type ExternalEngineServer interface {
+	// BuildPayload builds a new block on top of the given parent,
+	// using the transactions currently in the pool. The block is not written
+	BuildPayload(context.Context, *BuildPayloadRequest) (*Payload, error)
+	// ImportPayload verifies the given block and writes it to the chain
+	ImportPayload(context.Context, *Payload) (*ImportPayloadResponse, error)
+	// ForkchoiceUpdated sets the canonical head, the finalized and the safe blocks
+	ForkchoiceUpdated(context.Context, *ForkchoiceState) (*ForkchoiceUpdatedResponse, error)
+	// Status returns the current head, finalized and safe blocks of the node
+	Status(context.Context, *emptypb.Empty) (*EngineStatus, error)
+	// VerifyHeaders opens the stream the node sends headers to be verified through,
+	// before writing their blocks. The client replies with a verdict for each header
+	VerifyHeaders(ExternalEngine_VerifyHeadersServer) error
+	// PublishMessage gossips a consensus message to the other nodes
+	PublishMessage(context.Context, *PublishMessageRequest) (*emptypb.Empty, error)
+	// SubscribeMessages streams the consensus messages received from the other nodes
+	SubscribeMessages(*SubscribeMessagesRequest, ExternalEngine_SubscribeMessagesServer) error
+	mustEmbedUnimplementedExternalEngineServer()
+}
+
+// UnimplementedExternalEngineServer must be embedded to have forward compatible implementations.
+type UnimplementedExternalEngineServer struct {
+}
+
+func (UnimplementedExternalEngineServer) BuildPayload(context.Context, *BuildPayloadRequest) (*Payload, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildPayload not implemented")
+}
+func (UnimplementedExternalEngineServer) ImportPayload(context.Context, *Payload) (*ImportPayloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportPayload not implemented")
+}
+func (UnimplementedExternalEngineServer) ForkchoiceUpdated(context.Context, *ForkchoiceState) (*ForkchoiceUpdatedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForkchoiceUpdated not implemented")
+}
+func (UnimplementedExternalEngineServer) Status(context.Context, *emptypb.Empty) (*EngineStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedExternalEngineServer) VerifyHeaders(ExternalEngine_VerifyHeadersServer) error {
+	return status.Errorf(codes.Unimplemented, "method VerifyHeaders not implemented")
+}
+func (UnimplementedExternalEngineServer) PublishMessage(context.Context, *PublishMessageRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishMessage not implemented")
+}
+func (UnimplementedExternalEngineServer) SubscribeMessages(*SubscribeMessagesRequest, ExternalEngine_SubscribeMessagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeMessages not implemented")
+}
+func (UnimplementedExternalEngineServer) mustEmbedUnimplementedExternalEngineServer() {}
+
+// UnsafeExternalEngineServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExternalEngineServer will
+// result in compilation errors.
+type UnsafeExternalEngineServer interface {
+	mustEmbedUnimplementedExternalEngineServer()
+}
+
+func RegisterExternalEngineServer(s grpc.ServiceRegistrar, srv ExternalEngineServer) {
+	s.RegisterService(&ExternalEngine_ServiceDesc, srv)
+}
+
+func _ExternalEngine_BuildPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildPayloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalEngineServer).BuildPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ExternalEngine/BuildPayload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalEngineServer).BuildPayload(ctx, req.(*BuildPayloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalEngine_ImportPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Payload)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalEngineServer).ImportPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ExternalEngine/ImportPayload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalEngineServer).ImportPayload(ctx, req.(*Payload))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalEngine_ForkchoiceUpdated_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForkchoiceState)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalEngineServer).ForkchoiceUpdated(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ExternalEngine/ForkchoiceUpdated",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalEngineServer).ForkchoiceUpdated(ctx, req.(*ForkchoiceState))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalEngine_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalEngineServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ExternalEngine/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalEngineServer).Status(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalEngine_VerifyHeaders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ExternalEngineServer).VerifyHeaders(&externalEngineVerifyHeadersServer{stream})
+}
+
+type ExternalEngine_VerifyHeadersServer interface {
+	Send(*HeaderVerificationRequest) error
+	Recv() (*HeaderVerdict, error)
+	grpc.ServerStream
+}
+
+type externalEngineVerifyHeadersServer struct {
+	grpc.ServerStream
+}
+
+func (x *externalEngineVerifyHeadersServer) Send(m *HeaderVerificationRequest) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *externalEngineVerifyHeadersServer) Recv() (*HeaderVerdict, error) {
+	m := new(HeaderVerdict)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ExternalEngine_PublishMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExternalEngineServer).PublishMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.ExternalEngine/PublishMessage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExternalEngineServer).PublishMessage(ctx, req.(*PublishMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExternalEngine_SubscribeMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExternalEngineServer).SubscribeMessages(m, &externalEngineSubscribeMessagesServer{stream})
+}
+
+type ExternalEngine_SubscribeMessagesServer interface {
+	Send(*ReceivedMessage) error
+	grpc.ServerStream
+}
+
+type externalEngineSubscribeMessagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *externalEngineSubscribeMessagesServer) Send(m *ReceivedMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ExternalEngine_ServiceDesc is the grpc.ServiceDesc for ExternalEngine service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExternalEngine_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.ExternalEngine",
+	HandlerType: (*ExternalEngineServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "BuildPayload",
+			Handler:    _ExternalEngine_BuildPayload_Handler,
+		},
+		{
+			MethodName: "ImportPayload",
+			Handler:    _ExternalEngine_ImportPayload_Handler,
+		},
+		{
+			MethodName: "ForkchoiceUpdated",
+			Handler:    _ExternalEngine_ForkchoiceUpdated_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _ExternalEngine_Status_Handler,
+		},
+		{
+			MethodName: "PublishMessage",
+			Handler:    _ExternalEngine_PublishMessage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "VerifyHeaders",
+			Handler:       _ExternalEngine_VerifyHeaders_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeMessages",
+			Handler:       _ExternalEngine_SubscribeMessages_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "consensus/external/proto/external_operator.proto",
+}
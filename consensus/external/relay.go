@@ -0,0 +1,279 @@
+package external
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+)
+
+const (
+	KeySignMessages      = "signMessages"
+	KeyMessageRateLimits = "messageRateLimits"
+
+	relaySubscriptionBufferSize = 1024
+	rateLimitersCacheSize       = 4096
+)
+
+var (
+	ErrEmptyMessageType        = errors.New("empty consensus message type")
+	ErrInvalidMessageSignature = errors.New("invalid consensus message signature")
+	errInvalidRelayConfigType  = errors.New("invalid type assertion for message relay config")
+)
+
+// relaySubscription is the stream of the received consensus messages
+// of the subscribed types
+type relaySubscription struct {
+	types map[string]struct{} // Types of the messages to be received, all of them if empty
+	ch    chan *proto.ReceivedMessage
+}
+
+// accepts checks if the subscription receives the messages of the given type
+func (s *relaySubscription) accepts(msgType string) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+
+	_, ok := s.types[msgType]
+
+	return ok
+}
+
+// messageRelay gossips the opaque messages of the external consensus to the other nodes,
+// and hands the received ones to the subscribed external consensus clients
+type messageRelay struct {
+	logger hclog.Logger
+
+	transport transport
+	selfID    peer.ID        // ID of the node, to skip its own messages
+	signKey   crypto.PrivKey // libp2p key signing the published messages, if signatures are enabled
+
+	rateLimits map[string]rate.Limit // Maximum number of messages per second per peer, for each type
+	limiters   *lru.Cache            // Rate limiters of the peers, for each type
+
+	subsLock sync.RWMutex
+	subs     map[*relaySubscription]struct{}
+}
+
+// newMessageRelay creates a new message relay from the engine configuration.
+// The signing key is only used if the message signatures are enabled
+func newMessageRelay(
+	logger hclog.Logger,
+	config map[string]interface{},
+	selfID peer.ID,
+	readSignKey func() (crypto.PrivKey, error),
+) (*messageRelay, error) {
+	signMessages := false
+
+	if rawSignMessages, ok := config[KeySignMessages]; ok {
+		if signMessages, ok = rawSignMessages.(bool); !ok {
+			return nil, errInvalidRelayConfigType
+		}
+	}
+
+	rateLimits, err := readRateLimits(config)
+	if err != nil {
+		return nil, err
+	}
+
+	limiters, err := lru.New(rateLimitersCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create rate limiters cache, %w", err)
+	}
+
+	relay := &messageRelay{
+		logger:     logger.Named("relay"),
+		selfID:     selfID,
+		rateLimits: rateLimits,
+		limiters:   limiters,
+		subs:       make(map[*relaySubscription]struct{}),
+	}
+
+	if signMessages {
+		if relay.signKey, err = readSignKey(); err != nil {
+			return nil, fmt.Errorf("unable to read the message signing key, %w", err)
+		}
+	}
+
+	return relay, nil
+}
+
+// readRateLimits reads the maximum number of messages per second per peer, for each type
+func readRateLimits(config map[string]interface{}) (map[string]rate.Limit, error) {
+	rateLimits := make(map[string]rate.Limit)
+
+	rawRateLimits, ok := config[KeyMessageRateLimits]
+	if !ok {
+		return rateLimits, nil
+	}
+
+	rawLimits, ok := rawRateLimits.(map[string]interface{})
+	if !ok {
+		return nil, errInvalidRelayConfigType
+	}
+
+	for msgType, rawLimit := range rawLimits {
+		limit, ok := rawLimit.(float64)
+		if !ok || limit <= 0 {
+			return nil, fmt.Errorf("invalid rate limit for consensus message type %s", msgType)
+		}
+
+		rateLimits[msgType] = rate.Limit(limit)
+	}
+
+	return rateLimits, nil
+}
+
+// publish signs the message, if enabled, and gossips it to the other nodes
+func (r *messageRelay) publish(msgType string, payload []byte) error {
+	if msgType == "" {
+		return ErrEmptyMessageType
+	}
+
+	msg := &proto.ConsensusMessage{
+		Type:    msgType,
+		Payload: payload,
+	}
+
+	if r.signKey != nil {
+		signature, err := r.signKey.Sign(messageSigningData(msg))
+		if err != nil {
+			return err
+		}
+
+		msg.Signature = signature
+	}
+
+	return r.transport.Publish(msg)
+}
+
+// handleMessage checks the message received from the given peer
+// and hands it to the subscriptions of its type
+func (r *messageRelay) handleMessage(msg *proto.ConsensusMessage, from peer.ID) {
+	if from == r.selfID {
+		return
+	}
+
+	if r.signKey != nil {
+		if err := verifyMessageSignature(msg, from); err != nil {
+			r.logger.Warn("dropping consensus message", "type", msg.Type, "from", from, "err", err)
+
+			return
+		}
+	}
+
+	if !r.allow(msg.Type, from) {
+		r.logger.Debug("consensus message rate limit exceeded", "type", msg.Type, "from", from)
+
+		return
+	}
+
+	received := &proto.ReceivedMessage{
+		Type:    msg.Type,
+		Payload: msg.Payload,
+		PeerID:  from.String(),
+	}
+
+	r.subsLock.RLock()
+	defer r.subsLock.RUnlock()
+
+	for sub := range r.subs {
+		if !sub.accepts(msg.Type) {
+			continue
+		}
+
+		select {
+		case sub.ch <- received:
+		default:
+			r.logger.Warn("subscription buffer full, dropping consensus message", "type", msg.Type)
+		}
+	}
+}
+
+// allow checks the rate limit of the message type for the peer, if any
+func (r *messageRelay) allow(msgType string, from peer.ID) bool {
+	limit, ok := r.rateLimits[msgType]
+	if !ok {
+		return true
+	}
+
+	key := from.String() + "/" + msgType
+
+	limiter, ok := r.limiters.Get(key)
+	if !ok {
+		// allow bursts of up to a second worth of messages
+		limiter = rate.NewLimiter(limit, int(math.Max(1, math.Ceil(float64(limit)))))
+		r.limiters.Add(key, limiter)
+	}
+
+	rateLimiter, ok := limiter.(*rate.Limiter)
+
+	return !ok || rateLimiter.Allow()
+}
+
+// subscribe creates a new subscription to the received messages of the given types,
+// all of them if none is given
+func (r *messageRelay) subscribe(msgTypes []string) *relaySubscription {
+	sub := &relaySubscription{
+		types: make(map[string]struct{}, len(msgTypes)),
+		ch:    make(chan *proto.ReceivedMessage, relaySubscriptionBufferSize),
+	}
+
+	for _, msgType := range msgTypes {
+		sub.types[msgType] = struct{}{}
+	}
+
+	r.subsLock.Lock()
+	r.subs[sub] = struct{}{}
+	r.subsLock.Unlock()
+
+	return sub
+}
+
+// unsubscribe removes the subscription
+func (r *messageRelay) unsubscribe(sub *relaySubscription) {
+	r.subsLock.Lock()
+	delete(r.subs, sub)
+	r.subsLock.Unlock()
+}
+
+// messageSigningData returns the data of the message covered by the signature
+func messageSigningData(msg *proto.ConsensusMessage) []byte {
+	data := make([]byte, 4, 4+len(msg.Type)+len(msg.Payload))
+	binary.BigEndian.PutUint32(data, uint32(len(msg.Type)))
+
+	data = append(data, msg.Type...)
+
+	return append(data, msg.Payload...)
+}
+
+// verifyMessageSignature checks that the message is signed by the libp2p key of the peer
+func verifyMessageSignature(msg *proto.ConsensusMessage, from peer.ID) error {
+	if len(msg.Signature) == 0 {
+		return ErrInvalidMessageSignature
+	}
+
+	pubKey, err := from.ExtractPublicKey()
+	if err != nil {
+		return err
+	}
+
+	ok, err := pubKey.Verify(messageSigningData(msg), msg.Signature)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrInvalidMessageSignature
+	}
+
+	return nil
+}
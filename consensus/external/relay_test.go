@@ -0,0 +1,190 @@
+package external
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockTransport keeps the published messages
+type mockTransport struct {
+	published []*proto.ConsensusMessage
+}
+
+func (m *mockTransport) Publish(msg *proto.ConsensusMessage) error {
+	m.published = append(m.published, msg)
+
+	return nil
+}
+
+func newTestPeer(t *testing.T) (crypto.PrivKey, peer.ID) {
+	t.Helper()
+
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	assert.NoError(t, err)
+
+	id, err := peer.IDFromPrivateKey(key)
+	assert.NoError(t, err)
+
+	return key, id
+}
+
+func newTestRelay(t *testing.T, config map[string]interface{}, key crypto.PrivKey, id peer.ID) *messageRelay {
+	t.Helper()
+
+	relay, err := newMessageRelay(hclog.NewNullLogger(), config, id, func() (crypto.PrivKey, error) {
+		return key, nil
+	})
+	assert.NoError(t, err)
+
+	relay.transport = &mockTransport{}
+
+	return relay
+}
+
+// receivedTypes returns the types of the messages buffered in the subscription
+func receivedTypes(sub *relaySubscription) []string {
+	types := []string{}
+
+	for {
+		select {
+		case msg := <-sub.ch:
+			types = append(types, msg.Type)
+		default:
+			return types
+		}
+	}
+}
+
+func TestMessageRelay_Config(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid rate limit", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newMessageRelay(hclog.NewNullLogger(), map[string]interface{}{
+			KeyMessageRateLimits: map[string]interface{}{
+				"vote": float64(-1),
+			},
+		}, "", nil)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newMessageRelay(hclog.NewNullLogger(), map[string]interface{}{
+			KeySignMessages: "true",
+		}, "", nil)
+
+		assert.ErrorIs(t, err, errInvalidRelayConfigType)
+	})
+}
+
+func TestMessageRelay_Subscriptions(t *testing.T) {
+	t.Parallel()
+
+	_, selfID := newTestPeer(t)
+	_, senderID := newTestPeer(t)
+
+	relay := newTestRelay(t, map[string]interface{}{}, nil, selfID)
+
+	all := relay.subscribe(nil)
+	votes := relay.subscribe([]string{"vote"})
+
+	relay.handleMessage(&proto.ConsensusMessage{Type: "vote"}, senderID)
+	relay.handleMessage(&proto.ConsensusMessage{Type: "proposal"}, senderID)
+
+	// the messages published by the node itself are skipped
+	relay.handleMessage(&proto.ConsensusMessage{Type: "vote"}, selfID)
+
+	assert.Equal(t, []string{"vote", "proposal"}, receivedTypes(all))
+	assert.Equal(t, []string{"vote"}, receivedTypes(votes))
+
+	relay.unsubscribe(votes)
+	relay.handleMessage(&proto.ConsensusMessage{Type: "vote"}, senderID)
+
+	assert.Empty(t, receivedTypes(votes))
+	assert.Equal(t, []string{"vote"}, receivedTypes(all))
+}
+
+func TestMessageRelay_Signatures(t *testing.T) {
+	t.Parallel()
+
+	senderKey, senderID := newTestPeer(t)
+	receiverKey, receiverID := newTestPeer(t)
+
+	config := map[string]interface{}{
+		KeySignMessages: true,
+	}
+
+	sender := newTestRelay(t, config, senderKey, senderID)
+	receiver := newTestRelay(t, config, receiverKey, receiverID)
+
+	assert.ErrorIs(t, sender.publish("", nil), ErrEmptyMessageType)
+	assert.NoError(t, sender.publish("vote", []byte{1, 2, 3}))
+
+	published := sender.transport.(*mockTransport).published
+	assert.Len(t, published, 1)
+	assert.NotEmpty(t, published[0].Signature)
+
+	sub := receiver.subscribe(nil)
+
+	// a message signed by the sender is accepted
+	receiver.handleMessage(published[0], senderID)
+	assert.Equal(t, []string{"vote"}, receivedTypes(sub))
+
+	// a message claimed to be from another peer is dropped
+	_, otherID := newTestPeer(t)
+
+	receiver.handleMessage(published[0], otherID)
+	assert.Empty(t, receivedTypes(sub))
+
+	// a tampered message is dropped
+	receiver.handleMessage(&proto.ConsensusMessage{
+		Type:      "vote",
+		Payload:   []byte{4, 5, 6},
+		Signature: published[0].Signature,
+	}, senderID)
+	assert.Empty(t, receivedTypes(sub))
+}
+
+func TestMessageRelay_RateLimits(t *testing.T) {
+	t.Parallel()
+
+	_, selfID := newTestPeer(t)
+	_, firstID := newTestPeer(t)
+	_, secondID := newTestPeer(t)
+
+	relay := newTestRelay(t, map[string]interface{}{
+		KeyMessageRateLimits: map[string]interface{}{
+			"vote": float64(2),
+		},
+	}, nil, selfID)
+
+	sub := relay.subscribe(nil)
+
+	for i := 0; i < 5; i++ {
+		relay.handleMessage(&proto.ConsensusMessage{Type: "vote"}, firstID)
+		relay.handleMessage(&proto.ConsensusMessage{Type: "proposal"}, firstID)
+	}
+
+	// the limit applies per peer
+	relay.handleMessage(&proto.ConsensusMessage{Type: "vote"}, secondID)
+
+	votes := 0
+
+	for _, msgType := range receivedTypes(sub) {
+		if msgType == "vote" {
+			votes++
+		}
+	}
+
+	assert.Equal(t, 3, votes)
+}
@@ -0,0 +1,251 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/ibft/signer"
+	"github.com/Gabulhas/polygon-external-consensus/crypto"
+	"github.com/Gabulhas/polygon-external-consensus/secrets"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/Gabulhas/polygon-external-consensus/validators"
+)
+
+const (
+	KeySealType   = "sealType"
+	KeySealSource = "sealSource"
+
+	sealSourceLocal  = "local"
+	sealSourceRemote = "remote"
+)
+
+var (
+	ErrRemoteSealing           = errors.New("blocks are sealed by the external consensus client")
+	ErrMissingSeal             = errors.New("missing block seal")
+	ErrSealerMismatch          = errors.New("block seal is not signed by the block miner")
+	ErrSealerRequired          = errors.New("coinbase of the sealer is required for remote sealing")
+	errInvalidSealerConfigType = errors.New("invalid type assertion for sealing config")
+)
+
+// sealer writes and verifies the proposer seal of the blocks, reusing the IBFT extra data format.
+// The seal is either signed with the validator key of the node, or handed in by the
+// external consensus client through the engine API
+type sealer struct {
+	keyManager signer.KeyManager
+	signer     signer.Signer
+	local      bool // Flag indicating if the blocks are sealed with the local validator key
+}
+
+// newSealer creates a new sealer from the engine configuration.
+// It returns nil if the blocks aren't sealed
+func newSealer(config map[string]interface{}, secretsManager secrets.SecretsManager) (*sealer, error) {
+	rawSealType, ok := config[KeySealType]
+	if !ok {
+		return nil, nil
+	}
+
+	sealTypeStr, ok := rawSealType.(string)
+	if !ok {
+		return nil, errInvalidSealerConfigType
+	}
+
+	sealType, err := validators.ParseValidatorType(sealTypeStr)
+	if err != nil {
+		return nil, err
+	}
+
+	source := sealSourceLocal
+
+	if rawSource, ok := config[KeySealSource]; ok {
+		if source, ok = rawSource.(string); !ok {
+			return nil, errInvalidSealerConfigType
+		}
+	}
+
+	var keyManager signer.KeyManager
+
+	switch source {
+	case sealSourceLocal:
+		if keyManager, err = signer.NewKeyManagerFromType(secretsManager, sealType); err != nil {
+			return nil, err
+		}
+	case sealSourceRemote:
+		if keyManager, err = newRemoteKeyManager(sealType); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported seal source: %s", source)
+	}
+
+	return &sealer{
+		keyManager: keyManager,
+		signer:     signer.NewSigner(keyManager, keyManager),
+		local:      source == sealSourceLocal,
+	}, nil
+}
+
+// headerHash calculates the header hash without the seal, so that sealing a block doesn't change its hash.
+// Headers without the IBFT extra (e.g. genesis) are hashed by the given fallback function
+func (s *sealer) headerHash(fallback func(*types.Header) types.Hash) func(*types.Header) types.Hash {
+	return func(h *types.Header) types.Hash {
+		hash, err := s.signer.CalculateHeaderHash(h)
+		if err != nil {
+			return fallback(h)
+		}
+
+		return hash
+	}
+}
+
+// initExtra initializes the IBFT extra of the header, keeping the given extra data as vanity
+func (s *sealer) initExtra(header *types.Header) {
+	s.signer.InitIBFTExtra(header, s.keyManager.NewEmptyValidators(), nil)
+}
+
+// sealHash returns the digest the proposer seal of the header signs
+func (s *sealer) sealHash(header *types.Header) ([]byte, error) {
+	hash, err := s.signer.CalculateHeaderHash(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.Keccak256(hash.Bytes()), nil
+}
+
+// sealLocally signs the header with the validator key of the node
+func (s *sealer) sealLocally(header *types.Header) error {
+	if !s.local {
+		return ErrRemoteSealing
+	}
+
+	_, err := s.signer.WriteProposerSeal(header)
+
+	return err
+}
+
+// writeSeal writes the seal handed in by the external consensus client into the header
+func (s *sealer) writeSeal(header *types.Header, seal []byte) error {
+	extra, err := s.signer.GetIBFTExtra(header)
+	if err != nil {
+		return err
+	}
+
+	extra.ProposerSeal = seal
+
+	vanity := make([]byte, signer.IstanbulExtraVanity)
+	copy(vanity, header.ExtraData)
+
+	header.ExtraData = extra.MarshalRLPTo(vanity)
+
+	return nil
+}
+
+// recoverSigner recovers the address that signed the seal of the header
+func (s *sealer) recoverSigner(header *types.Header) (types.Address, error) {
+	extra, err := s.signer.GetIBFTExtra(header)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	if len(extra.ProposerSeal) == 0 {
+		return types.ZeroAddress, ErrMissingSeal
+	}
+
+	digest, err := s.sealHash(header)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return s.keyManager.Ecrecover(extra.ProposerSeal, digest)
+}
+
+// verifySeal checks that the header is sealed by its miner
+func (s *sealer) verifySeal(header *types.Header) error {
+	creator, err := s.recoverSigner(header)
+	if err != nil {
+		return err
+	}
+
+	if creator != types.BytesToAddress(header.Miner) {
+		return ErrSealerMismatch
+	}
+
+	return nil
+}
+
+// remoteKeyManager is the KeyManager of a node whose blocks are sealed by the external consensus client.
+// It holds no key, so it can only decode and verify seals
+type remoteKeyManager struct {
+	validatorType validators.ValidatorType
+}
+
+func newRemoteKeyManager(validatorType validators.ValidatorType) (signer.KeyManager, error) {
+	switch validatorType {
+	case validators.ECDSAValidatorType, validators.BLSValidatorType:
+		return &remoteKeyManager{validatorType: validatorType}, nil
+	default:
+		return nil, fmt.Errorf("unsupported validator type: %s", validatorType)
+	}
+}
+
+// Type returns the validator type KeyManager supports
+func (m *remoteKeyManager) Type() validators.ValidatorType {
+	return m.validatorType
+}
+
+// Address returns the zero address, as the node holds no key
+func (m *remoteKeyManager) Address() types.Address {
+	return types.ZeroAddress
+}
+
+// NewEmptyValidators returns empty validator collection of the validator type
+func (m *remoteKeyManager) NewEmptyValidators() validators.Validators {
+	if m.validatorType == validators.BLSValidatorType {
+		return validators.NewBLSValidatorSet()
+	}
+
+	return validators.NewECDSAValidatorSet()
+}
+
+// NewEmptyCommittedSeals returns empty CommittedSeals of the validator type
+func (m *remoteKeyManager) NewEmptyCommittedSeals() signer.Seals {
+	if m.validatorType == validators.BLSValidatorType {
+		return &signer.AggregatedSeal{}
+	}
+
+	return &signer.SerializedSeal{}
+}
+
+func (m *remoteKeyManager) SignProposerSeal([]byte) ([]byte, error) {
+	return nil, ErrRemoteSealing
+}
+
+func (m *remoteKeyManager) SignCommittedSeal([]byte) ([]byte, error) {
+	return nil, ErrRemoteSealing
+}
+
+func (m *remoteKeyManager) VerifyCommittedSeal(validators.Validators, types.Address, []byte, []byte) error {
+	return ErrRemoteSealing
+}
+
+func (m *remoteKeyManager) GenerateCommittedSeals(map[types.Address][]byte, validators.Validators) (signer.Seals, error) {
+	return nil, ErrRemoteSealing
+}
+
+func (m *remoteKeyManager) VerifyCommittedSeals(signer.Seals, []byte, validators.Validators) (int, error) {
+	return 0, ErrRemoteSealing
+}
+
+func (m *remoteKeyManager) SignIBFTMessage([]byte) ([]byte, error) {
+	return nil, ErrRemoteSealing
+}
+
+// Ecrecover recovers address from signature and message
+func (m *remoteKeyManager) Ecrecover(sig, digest []byte) (types.Address, error) {
+	pub, err := crypto.RecoverPubkey(sig, digest)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return crypto.PubKeyToAddress(pub), nil
+}
@@ -0,0 +1,155 @@
+package external
+
+import (
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/ibft/signer"
+	"github.com/Gabulhas/polygon-external-consensus/crypto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/Gabulhas/polygon-external-consensus/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSealer(t *testing.T, keyManager signer.KeyManager, local bool) *sealer {
+	t.Helper()
+
+	return &sealer{
+		keyManager: keyManager,
+		signer:     signer.NewSigner(keyManager, keyManager),
+		local:      local,
+	}
+}
+
+func newTestSealedHeader(s *sealer, miner types.Address) *types.Header {
+	header := &types.Header{
+		Number:    1,
+		Miner:     miner.Bytes(),
+		ExtraData: []byte("vanity"),
+	}
+
+	s.initExtra(header)
+
+	return header
+}
+
+func TestSealer_Config(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Parallel()
+
+		sealer, err := newSealer(map[string]interface{}{}, nil)
+
+		assert.NoError(t, err)
+		assert.Nil(t, sealer)
+	})
+
+	t.Run("remote sealing", func(t *testing.T) {
+		t.Parallel()
+
+		sealer, err := newSealer(map[string]interface{}{
+			KeySealType:   string(validators.BLSValidatorType),
+			KeySealSource: sealSourceRemote,
+		}, nil)
+
+		assert.NoError(t, err)
+		assert.False(t, sealer.local)
+		assert.Equal(t, validators.BLSValidatorType, sealer.keyManager.Type())
+	})
+
+	t.Run("invalid seal source", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newSealer(map[string]interface{}{
+			KeySealType:   string(validators.ECDSAValidatorType),
+			KeySealSource: "somewhere",
+		}, nil)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newSealer(map[string]interface{}{
+			KeySealType: 1,
+		}, nil)
+
+		assert.ErrorIs(t, err, errInvalidSealerConfigType)
+	})
+}
+
+func TestSealer_LocalSeal(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	keyManager := signer.NewECDSAKeyManagerFromKey(key)
+	sealer := newTestSealer(t, keyManager, true)
+
+	header := newTestSealedHeader(sealer, keyManager.Address())
+	unsealedHash := sealer.headerHash(types.HeaderHash)(header)
+
+	// the header can't be verified before sealing
+	assert.ErrorIs(t, sealer.verifySeal(header), ErrMissingSeal)
+
+	assert.NoError(t, sealer.sealLocally(header))
+	assert.NoError(t, sealer.verifySeal(header))
+
+	creator, err := sealer.recoverSigner(header)
+	assert.NoError(t, err)
+	assert.Equal(t, keyManager.Address(), creator)
+
+	// the seal isn't part of the header hash
+	assert.Equal(t, unsealedHash, sealer.headerHash(types.HeaderHash)(header))
+
+	// the vanity is preserved
+	assert.Equal(t, []byte("vanity"), header.ExtraData[:len("vanity")])
+}
+
+func TestSealer_RemoteSeal(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	keyManager, err := newRemoteKeyManager(validators.ECDSAValidatorType)
+	assert.NoError(t, err)
+
+	sealer := newTestSealer(t, keyManager, false)
+	sealerAddress := crypto.PubKeyToAddress(&key.PublicKey)
+
+	header := newTestSealedHeader(sealer, sealerAddress)
+
+	// the node can't sign the header
+	assert.ErrorIs(t, sealer.sealLocally(header), ErrRemoteSealing)
+
+	// the external consensus client signs the seal hash
+	sealHash, err := sealer.sealHash(header)
+	assert.NoError(t, err)
+
+	seal, err := crypto.Sign(key, sealHash)
+	assert.NoError(t, err)
+
+	assert.NoError(t, sealer.writeSeal(header, seal))
+	assert.NoError(t, sealer.verifySeal(header))
+
+	// a header sealed by someone else than the miner is rejected
+	header.Miner = types.StringToAddress("1").Bytes()
+	assert.ErrorIs(t, sealer.verifySeal(header), ErrSealerMismatch)
+}
+
+func TestSealer_HeaderHashFallback(t *testing.T) {
+	t.Parallel()
+
+	keyManager, err := newRemoteKeyManager(validators.ECDSAValidatorType)
+	assert.NoError(t, err)
+
+	sealer := newTestSealer(t, keyManager, false)
+
+	// headers without the IBFT extra, such as genesis, keep the default hash
+	header := &types.Header{Number: 0, ExtraData: make([]byte, 32)}
+
+	assert.Equal(t, types.HeaderHash(header), sealer.headerHash(types.HeaderHash)(header))
+}
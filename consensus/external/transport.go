@@ -1,65 +1,63 @@
 package external
 
 import (
-	"fmt"
-
 	"github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
 	"github.com/Gabulhas/polygon-external-consensus/network"
-	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 type transport interface {
-	Multicast(msg *proto.Message) error
+	Publish(msg *proto.ConsensusMessage) error
 }
 
 type gossipTransport struct {
 	topic *network.Topic
 }
 
-func (g *gossipTransport) Multicast(msg *proto.Message) error {
+func (g *gossipTransport) Publish(msg *proto.ConsensusMessage) error {
 	return g.topic.Publish(msg)
 }
 
-func (d *External) Multicast(msg *proto.Message) {
-	if err := d.transport.Multicast(msg); err != nil {
-		d.logger.Error("fail to gossip", "err", err)
+// setupTransport sets up the gossip transport protocol,
+// relaying the consensus messages between the nodes
+func (d *External) setupTransport() error {
+	relay, err := newMessageRelay(
+		d.logger,
+		d.config.Config,
+		d.network.AddrInfo().ID,
+		func() (crypto.PrivKey, error) {
+			return network.ReadLibp2pKey(d.secretsManager)
+		},
+	)
+	if err != nil {
+		return err
 	}
-}
 
-// setupTransport sets up the gossip transport protocol
-func (d *External) setupTransport() error {
 	// Define a new topic
-	topic, err := d.network.NewTopic(externalProto, &proto.Message{})
+	topic, err := d.network.NewTopic(externalProto, &proto.ConsensusMessage{})
 	if err != nil {
 		return err
 	}
 
 	// Subscribe to the newly created topic
 	if err := topic.Subscribe(
-		func(obj interface{}, _ peer.ID) {
-
-			msg, ok := obj.(*proto.Message)
-			fmt.Printf("msg: %v\n", msg)
+		func(obj interface{}, from peer.ID) {
+			msg, ok := obj.(*proto.ConsensusMessage)
 			if !ok {
-				d.logger.Error("invalid type assertion for message request")
+				d.logger.Error("invalid type assertion for consensus message")
 
 				return
 			}
 
-			d.logger.Debug(
-				"validator message received",
-				"type", msg.Type.String(),
-				"height", msg.GetView().Height,
-				"round", msg.GetView().Round,
-				"addr", types.BytesToAddress(msg.From).String(),
-			)
+			relay.handleMessage(msg, from)
 		},
 	); err != nil {
 		return err
 	}
 
-	d.transport = &gossipTransport{topic: topic}
+	relay.transport = &gossipTransport{topic: topic}
+	d.relay = relay
 
 	return nil
 }
@@ -0,0 +1,307 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	KeyVerifyHeaders    = "verifyHeaders"
+	KeyVerifyTimeout    = "verifyTimeout"
+	KeyVerifyFailClosed = "verifyFailClosed"
+
+	defaultVerifyTimeout = 2 * time.Second
+	verdictCacheSize     = 16384
+)
+
+var (
+	ErrHeaderRejected            = errors.New("header rejected by the external consensus")
+	ErrVerifierUnavailable       = errors.New("no external header verifier connected")
+	ErrVerifierAlreadyConnected  = errors.New("external header verifier already connected")
+	ErrVerificationTimeout       = errors.New("timeout awaiting header verdict")
+	errInvalidVerifierConfigType = errors.New("invalid type assertion for header verification config")
+)
+
+// verdict is the decision of the external consensus on a header
+type verdict struct {
+	accept bool
+	reason string
+}
+
+// err returns the error for a rejected header, or nil if it was accepted
+func (v *verdict) err() error {
+	if v.accept {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrHeaderRejected, v.reason)
+}
+
+// pendingVerdict is a verdict that was requested but hasn't been received yet
+type pendingVerdict struct {
+	doneCh  chan struct{} // closed once the verdict is received
+	verdict *verdict
+}
+
+// verdictStream is the stream the external consensus client receives headers
+// and sends verdicts through
+type verdictStream interface {
+	Send(*proto.HeaderVerificationRequest) error
+	Recv() (*proto.HeaderVerdict, error)
+}
+
+// headerVerifier delegates the header verification to the external consensus client.
+// Verdicts are cached per header hash, so batches verified ahead of time (e.g. while syncing)
+// aren't requested again when the blocks are written
+type headerVerifier struct {
+	logger hclog.Logger
+
+	timeout    time.Duration // Maximum time to wait for a verdict
+	failClosed bool          // Flag indicating if headers are rejected when no verdict is available
+
+	verdicts *lru.Cache // LRU cache for the received verdicts
+
+	lock    sync.Mutex
+	stream  verdictStream
+	pending map[types.Hash]*pendingVerdict
+
+	sendLock sync.Mutex
+}
+
+// newHeaderVerifier creates a new header verifier from the engine configuration.
+// It returns nil if the remote header verification is not enabled
+func newHeaderVerifier(logger hclog.Logger, config map[string]interface{}) (*headerVerifier, error) {
+	enabled, err := readBoolConfig(config, KeyVerifyHeaders, false)
+	if err != nil || !enabled {
+		return nil, err
+	}
+
+	failClosed, err := readBoolConfig(config, KeyVerifyFailClosed, true)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := defaultVerifyTimeout
+
+	if rawTimeout, ok := config[KeyVerifyTimeout]; ok {
+		// The timeout is defined in milliseconds
+		readTimeout, ok := rawTimeout.(float64)
+		if !ok {
+			return nil, errInvalidVerifierConfigType
+		}
+
+		timeout = time.Duration(readTimeout) * time.Millisecond
+	}
+
+	verdicts, err := lru.New(verdictCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create verdicts cache, %w", err)
+	}
+
+	return &headerVerifier{
+		logger:     logger.Named("verifier"),
+		timeout:    timeout,
+		failClosed: failClosed,
+		verdicts:   verdicts,
+		pending:    make(map[types.Hash]*pendingVerdict),
+	}, nil
+}
+
+// readBoolConfig reads the boolean value of the key from the engine configuration
+func readBoolConfig(config map[string]interface{}, key string, defaultValue bool) (bool, error) {
+	rawValue, ok := config[key]
+	if !ok {
+		return defaultValue, nil
+	}
+
+	value, ok := rawValue.(bool)
+	if !ok {
+		return false, errInvalidVerifierConfigType
+	}
+
+	return value, nil
+}
+
+// serve attaches the stream of the external consensus client and processes
+// the received verdicts until the stream is closed
+func (v *headerVerifier) serve(stream verdictStream) error {
+	v.lock.Lock()
+	if v.stream != nil {
+		v.lock.Unlock()
+
+		return ErrVerifierAlreadyConnected
+	}
+
+	v.stream = stream
+	v.lock.Unlock()
+
+	v.logger.Info("header verifier connected")
+
+	defer func() {
+		v.lock.Lock()
+		v.stream = nil
+		v.pending = make(map[types.Hash]*pendingVerdict)
+		v.lock.Unlock()
+
+		v.logger.Info("header verifier disconnected")
+	}()
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		v.deliver(types.BytesToHash(msg.Hash), &verdict{
+			accept: msg.Accept,
+			reason: msg.Reason,
+		})
+	}
+}
+
+// deliver caches the verdict and notifies the waiting verifications
+func (v *headerVerifier) deliver(hash types.Hash, verdict *verdict) {
+	v.verdicts.Add(hash, verdict)
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if pending, ok := v.pending[hash]; ok {
+		pending.verdict = verdict
+		close(pending.doneCh)
+
+		delete(v.pending, hash)
+	}
+}
+
+// verifyHeaders requests the verdicts of the headers that haven't been verified yet,
+// in a single batch, and returns the error of the first rejected header, if any
+func (v *headerVerifier) verifyHeaders(headers []*types.Header) error {
+	var (
+		cached  = make(map[types.Hash]*verdict)
+		waiting = make(map[types.Hash]*pendingVerdict)
+	)
+
+	v.lock.Lock()
+
+	request := &proto.HeaderVerificationRequest{}
+
+	for _, header := range headers {
+		if cachedVerdict, ok := v.getCachedVerdict(header.Hash); ok {
+			cached[header.Hash] = cachedVerdict
+
+			continue
+		}
+
+		if pending, ok := v.pending[header.Hash]; ok {
+			// the verdict was already requested
+			waiting[header.Hash] = pending
+
+			continue
+		}
+
+		if v.stream == nil {
+			continue
+		}
+
+		pending := &pendingVerdict{doneCh: make(chan struct{})}
+		v.pending[header.Hash] = pending
+		waiting[header.Hash] = pending
+
+		request.Headers = append(request.Headers, header.MarshalRLP())
+	}
+
+	stream := v.stream
+	v.lock.Unlock()
+
+	if len(request.Headers) > 0 {
+		v.sendLock.Lock()
+		err := stream.Send(request)
+		v.sendLock.Unlock()
+
+		if err != nil {
+			v.logger.Error("failed to send headers to the verifier", "err", err)
+		}
+	}
+
+	// the timeout applies to the whole batch
+	timer := time.NewTimer(v.timeout)
+	defer timer.Stop()
+
+	timedOut := false
+
+	for _, header := range headers {
+		if cachedVerdict, ok := cached[header.Hash]; ok {
+			if err := cachedVerdict.err(); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		pending, ok := waiting[header.Hash]
+		if !ok {
+			// no verifier is connected
+			if v.failClosed {
+				return ErrVerifierUnavailable
+			}
+
+			continue
+		}
+
+		if !timedOut {
+			select {
+			case <-pending.doneCh:
+			case <-timer.C:
+				timedOut = true
+			}
+		}
+
+		select {
+		case <-pending.doneCh:
+			if err := pending.verdict.err(); err != nil {
+				return err
+			}
+		default:
+			// the verdict is requested again on the next verification
+			v.dropPending(header.Hash, pending)
+
+			if v.failClosed {
+				return ErrVerificationTimeout
+			}
+
+			v.logger.Warn("header accepted without verdict", "hash", header.Hash)
+		}
+	}
+
+	return nil
+}
+
+// getCachedVerdict returns the cached verdict of the header, if any
+func (v *headerVerifier) getCachedVerdict(hash types.Hash) (*verdict, bool) {
+	cachedVerdict, ok := v.verdicts.Get(hash)
+	if !ok {
+		return nil, false
+	}
+
+	verdict, ok := cachedVerdict.(*verdict)
+
+	return verdict, ok
+}
+
+// dropPending removes the pending verdict, unless it was replaced in the meantime
+func (v *headerVerifier) dropPending(hash types.Hash, pending *pendingVerdict) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.pending[hash] == pending {
+		delete(v.pending, hash)
+	}
+}
@@ -0,0 +1,196 @@
+package external
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var errStreamClosed = errors.New("stream closed")
+
+// mockVerdictStream replies to each verification request using the verdict function
+type mockVerdictStream struct {
+	verdictFn  func(*types.Header) *proto.HeaderVerdict
+	requests   int
+	verdictsCh chan *proto.HeaderVerdict
+}
+
+func newMockVerdictStream(verdictFn func(*types.Header) *proto.HeaderVerdict) *mockVerdictStream {
+	return &mockVerdictStream{
+		verdictFn:  verdictFn,
+		verdictsCh: make(chan *proto.HeaderVerdict, 16),
+	}
+}
+
+func (m *mockVerdictStream) Send(req *proto.HeaderVerificationRequest) error {
+	m.requests++
+
+	for _, raw := range req.Headers {
+		header := &types.Header{}
+		if err := header.UnmarshalRLP(raw); err != nil {
+			return err
+		}
+
+		if verdict := m.verdictFn(header); verdict != nil {
+			m.verdictsCh <- verdict
+		}
+	}
+
+	return nil
+}
+
+func (m *mockVerdictStream) Recv() (*proto.HeaderVerdict, error) {
+	verdict, ok := <-m.verdictsCh
+	if !ok {
+		return nil, errStreamClosed
+	}
+
+	return verdict, nil
+}
+
+func newTestHeader(number uint64) *types.Header {
+	header := &types.Header{Number: number}
+	header.ComputeHash()
+
+	return header
+}
+
+func newTestHeaderVerifier(t *testing.T, config map[string]interface{}) *headerVerifier {
+	t.Helper()
+
+	config[KeyVerifyHeaders] = true
+
+	verifier, err := newHeaderVerifier(hclog.NewNullLogger(), config)
+	assert.NoError(t, err)
+
+	return verifier
+}
+
+// attachStream serves the stream in the background, and waits until it is attached
+func attachStream(t *testing.T, verifier *headerVerifier, stream *mockVerdictStream) {
+	t.Helper()
+
+	go func() {
+		_ = verifier.serve(stream)
+	}()
+
+	assert.Eventually(t, func() bool {
+		verifier.lock.Lock()
+		defer verifier.lock.Unlock()
+
+		return verifier.stream != nil
+	}, time.Second, 10*time.Millisecond)
+
+	t.Cleanup(func() {
+		close(stream.verdictsCh)
+	})
+}
+
+func TestHeaderVerifier_Config(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		t.Parallel()
+
+		verifier, err := newHeaderVerifier(hclog.NewNullLogger(), map[string]interface{}{})
+
+		assert.NoError(t, err)
+		assert.Nil(t, verifier)
+	})
+
+	t.Run("custom timeout and fail-open", func(t *testing.T) {
+		t.Parallel()
+
+		verifier := newTestHeaderVerifier(t, map[string]interface{}{
+			KeyVerifyTimeout:    float64(500),
+			KeyVerifyFailClosed: false,
+		})
+
+		assert.Equal(t, 500*time.Millisecond, verifier.timeout)
+		assert.False(t, verifier.failClosed)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newHeaderVerifier(hclog.NewNullLogger(), map[string]interface{}{
+			KeyVerifyHeaders: "yes",
+		})
+
+		assert.ErrorIs(t, err, errInvalidVerifierConfigType)
+	})
+}
+
+func TestHeaderVerifier_NoVerifierConnected(t *testing.T) {
+	t.Parallel()
+
+	header := newTestHeader(1)
+
+	failClosed := newTestHeaderVerifier(t, map[string]interface{}{})
+	assert.ErrorIs(t, failClosed.verifyHeaders([]*types.Header{header}), ErrVerifierUnavailable)
+
+	failOpen := newTestHeaderVerifier(t, map[string]interface{}{
+		KeyVerifyFailClosed: false,
+	})
+	assert.NoError(t, failOpen.verifyHeaders([]*types.Header{header}))
+}
+
+func TestHeaderVerifier_Verdicts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accepted = newTestHeader(1)
+		rejected = newTestHeader(2)
+	)
+
+	verifier := newTestHeaderVerifier(t, map[string]interface{}{})
+	stream := newMockVerdictStream(func(header *types.Header) *proto.HeaderVerdict {
+		return &proto.HeaderVerdict{
+			Hash:   header.Hash.Bytes(),
+			Accept: header.Hash == accepted.Hash,
+			Reason: "invalid proposer",
+		}
+	})
+
+	attachStream(t, verifier, stream)
+
+	// both headers are requested in a single batch
+	err := verifier.verifyHeaders([]*types.Header{accepted, rejected})
+
+	assert.ErrorIs(t, err, ErrHeaderRejected)
+	assert.ErrorContains(t, err, "invalid proposer")
+	assert.Equal(t, 1, stream.requests)
+
+	// the verdicts are cached
+	assert.NoError(t, verifier.verifyHeaders([]*types.Header{accepted}))
+	assert.ErrorIs(t, verifier.verifyHeaders([]*types.Header{rejected}), ErrHeaderRejected)
+	assert.Equal(t, 1, stream.requests)
+}
+
+func TestHeaderVerifier_Timeout(t *testing.T) {
+	t.Parallel()
+
+	header := newTestHeader(1)
+
+	verifier := newTestHeaderVerifier(t, map[string]interface{}{
+		KeyVerifyTimeout: float64(50),
+	})
+
+	// the client never replies
+	stream := newMockVerdictStream(func(*types.Header) *proto.HeaderVerdict {
+		return nil
+	})
+
+	attachStream(t, verifier, stream)
+
+	assert.ErrorIs(t, verifier.verifyHeaders([]*types.Header{header}), ErrVerificationTimeout)
+
+	// the verdict is requested again
+	assert.ErrorIs(t, verifier.verifyHeaders([]*types.Header{header}), ErrVerificationTimeout)
+	assert.Equal(t, 2, stream.requests)
+}
@@ -147,6 +147,7 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 	}
 
 	header.GasLimit = gasLimit
+	header.BaseFee = i.blockchain.CalculateBaseFee(parent)
 
 	if err := i.currentHooks.ModifyHeader(header, i.currentSigner.Address()); err != nil {
 		return nil, err
@@ -167,7 +168,7 @@ func (i *backendIBFT) buildBlock(parent *types.Header) (*types.Block, error) {
 		return nil, err
 	}
 
-	txs := i.writeTransactions(gasLimit, header.Number, transition)
+	txs := i.writeTransactions(gasLimit, header, transition)
 
 	if err := i.PreCommitState(header, transition); err != nil {
 		return nil, err
@@ -217,19 +218,33 @@ type txExeResult struct {
 type transitionInterface interface {
 	Write(txn *types.Transaction) error
 	WriteFailedReceipt(txn *types.Transaction) error
+	TotalGas() uint64
+	Receipts() []*types.Receipt
+	Snapshot() *state.TransitionSnapshot
+	RevertToSnapshot(snapshot *state.TransitionSnapshot)
 }
 
 func (i *backendIBFT) writeTransactions(
-	gasLimit,
-	blockNumber uint64,
+	gasLimit uint64,
+	header *types.Header,
 	transition transitionInterface,
 ) (executed []*types.Transaction) {
 	executed = make([]*types.Transaction, 0)
 
-	if !i.currentHooks.ShouldWriteTransactions(blockNumber) {
+	if !i.currentHooks.ShouldWriteTransactions(header.Number) {
 		return
 	}
 
+	// the bundles are written ahead of the transactions of the pool
+	executed = append(executed, consensus.WriteBundles(consensus.WriteBundlesParams{
+		Logger:     i.logger,
+		Pool:       i.txpool,
+		Transition: transition,
+		Header:     header,
+	})...)
+
+	bundled := consensus.NewBundledTxs(executed)
+
 	var (
 		blockTimer = time.NewTimer(i.blockTime)
 
@@ -248,7 +263,7 @@ func (i *backendIBFT) writeTransactions(
 		)
 	}()
 
-	i.txpool.Prepare()
+	i.txpool.Prepare(header.BaseFee)
 
 write:
 	for {
@@ -256,9 +271,18 @@ write:
 		case <-blockTimer.C:
 			return
 		default:
+			tx := i.txpool.Peek()
+
+			// the txs of the pool already written by the bundles are only popped
+			if bundledTx, ok := bundled.Get(tx); ok {
+				i.txpool.Pop(bundledTx)
+
+				continue
+			}
+
 			// execute transactions one by one
 			result, ok := i.writeTransaction(
-				i.txpool.Peek(),
+				tx,
 				transition,
 				gasLimit,
 			)
@@ -267,11 +291,9 @@ write:
 				break write
 			}
 
-			tx := result.tx
-
 			switch result.status {
 			case success:
-				executed = append(executed, tx)
+				executed = append(executed, result.tx)
 				successful++
 			case fail:
 				failed++
@@ -15,6 +15,7 @@ import (
 	"github.com/Gabulhas/polygon-external-consensus/secrets"
 	"github.com/Gabulhas/polygon-external-consensus/state"
 	"github.com/Gabulhas/polygon-external-consensus/syncer"
+	"github.com/Gabulhas/polygon-external-consensus/txpool"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/Gabulhas/polygon-external-consensus/validators"
 	"github.com/hashicorp/go-hclog"
@@ -39,7 +40,7 @@ var (
 )
 
 type txPoolInterface interface {
-	Prepare()
+	Prepare(baseFee uint64)
 	Length() uint64
 	Peek() *types.Transaction
 	Pop(tx *types.Transaction)
@@ -47,6 +48,7 @@ type txPoolInterface interface {
 	Demote(tx *types.Transaction)
 	ResetWithHeaders(headers ...*types.Header)
 	SetSealing(bool)
+	Bundles(number, timestamp uint64) []*txpool.Bundle
 }
 
 type forkManagerInterface interface {
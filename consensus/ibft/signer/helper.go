@@ -79,6 +79,10 @@ func calculateHeaderHash(h *types.Header) types.Hash {
 	vv.Set(arena.NewUint(h.Timestamp))
 	vv.Set(arena.NewCopyBytes(h.ExtraData))
 
+	if h.BaseFee != 0 {
+		vv.Set(arena.NewUint(h.BaseFee))
+	}
+
 	buf := keccak.Keccak256Rlp(nil, vv)
 
 	return types.BytesToHash(buf)
@@ -0,0 +1,80 @@
+package sealing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing/proto"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	ErrNoSealingPolicy = errors.New("the consensus engine has no sealing policy")
+)
+
+// Sealer is implemented by the engines whose sealing policy can be changed at runtime
+type Sealer interface {
+	// SealingManager returns the manager of the sealing policy, nil if the engine has none
+	SealingManager() *Manager
+}
+
+type operator struct {
+	proto.UnimplementedSealingOperatorServer
+
+	sealer Sealer
+}
+
+// NewOperator returns the gRPC service managing the policy of the given engine
+func NewOperator(sealer Sealer) proto.SealingOperatorServer {
+	return &operator{sealer: sealer}
+}
+
+// manager returns the manager of the engine sealing the blocks
+func (o *operator) manager() (*Manager, error) {
+	manager := o.sealer.SealingManager()
+	if manager == nil {
+		return nil, ErrNoSealingPolicy
+	}
+
+	return manager, nil
+}
+
+// GetPolicy returns the current sealing policy
+func (o *operator) GetPolicy(ctx context.Context, req *empty.Empty) (*proto.Policy, error) {
+	manager, err := o.manager()
+	if err != nil {
+		return nil, err
+	}
+
+	return toProtoPolicy(manager.Policy()), nil
+}
+
+// SetPolicy replaces the sealing policy
+func (o *operator) SetPolicy(ctx context.Context, req *proto.Policy) (*proto.Policy, error) {
+	policy := Policy{
+		Mode:     Mode(req.Mode),
+		Interval: req.Interval,
+		MaxTxs:   req.MaxBlockTxs,
+		MaxGas:   req.MaxBlockGas,
+	}
+
+	manager, err := o.manager()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := manager.SetPolicy(policy); err != nil {
+		return nil, err
+	}
+
+	return toProtoPolicy(policy), nil
+}
+
+func toProtoPolicy(policy Policy) *proto.Policy {
+	return &proto.Policy{
+		Mode:        string(policy.Mode),
+		Interval:    policy.Interval,
+		MaxBlockTxs: policy.MaxTxs,
+		MaxBlockGas: policy.MaxGas,
+	}
+}
@@ -0,0 +1,198 @@
+package sealing
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+const (
+	KeyMode     = "sealingMode"
+	KeyInterval = "interval"
+	KeyMaxTxs   = "maxBlockTxs"
+	KeyMaxGas   = "maxBlockGas"
+)
+
+// Mode decides when the blocks are sealed
+type Mode string
+
+const (
+	// Interval seals a block on every interval, even if there are no pending transactions
+	Interval Mode = "interval"
+
+	// OnDemand seals a block as soon as there are pending transactions
+	OnDemand Mode = "on-demand"
+
+	// NonEmpty seals a block on every interval, only if there are pending transactions
+	NonEmpty Mode = "non-empty"
+)
+
+var (
+	ErrInvalidMode      = errors.New("invalid sealing mode")
+	ErrInvalidInterval  = errors.New("sealing interval must be greater than 0")
+	ErrInvalidTimestamp = errors.New("timestamp not greater than the parent timestamp")
+	errInvalidType      = errors.New("invalid type assertion for sealing config")
+)
+
+// Policy decides when the blocks are sealed, and caps their content
+type Policy struct {
+	Mode     Mode
+	Interval uint64 // Time between the blocks, in seconds
+	MaxTxs   uint64 // Maximum number of transactions per block, unlimited if 0
+	MaxGas   uint64 // Maximum gas used per block, up to the block gas limit if 0
+}
+
+// Validate checks the policy is valid
+func (p Policy) Validate() error {
+	switch p.Mode {
+	case Interval, NonEmpty:
+		if p.Interval == 0 {
+			return ErrInvalidInterval
+		}
+	case OnDemand:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidMode, p.Mode)
+	}
+
+	return nil
+}
+
+// SealsEmpty checks if blocks are sealed when there are no pending transactions
+func (p Policy) SealsEmpty() bool {
+	return p.Mode == Interval
+}
+
+// Admits checks if a transaction with the given gas fits in a block
+// that already includes the given number of transactions and gas
+func (p Policy) Admits(txs int, gasUsed, gas uint64) bool {
+	if p.MaxTxs != 0 && uint64(txs) >= p.MaxTxs {
+		return false
+	}
+
+	if p.MaxGas != 0 && gasUsed+gas > p.MaxGas {
+		return false
+	}
+
+	return true
+}
+
+// ParsePolicy reads the sealing policy from the engine configuration,
+// the fields that are not set keep the values of the given default policy
+func ParsePolicy(config map[string]interface{}, policy Policy) (Policy, error) {
+	if raw, ok := config[KeyMode]; ok {
+		mode, ok := raw.(string)
+		if !ok {
+			return Policy{}, errInvalidType
+		}
+
+		policy.Mode = Mode(mode)
+	}
+
+	for key, field := range map[string]*uint64{
+		KeyInterval: &policy.Interval,
+		KeyMaxTxs:   &policy.MaxTxs,
+		KeyMaxGas:   &policy.MaxGas,
+	} {
+		raw, ok := config[key]
+		if !ok {
+			continue
+		}
+
+		value, err := readUint64(raw)
+		if err != nil {
+			return Policy{}, fmt.Errorf("%w: %s expected number", err, key)
+		}
+
+		*field = value
+	}
+
+	if err := policy.Validate(); err != nil {
+		return Policy{}, err
+	}
+
+	return policy, nil
+}
+
+// readUint64 reads the number of the configuration, either decoded from JSON or set by the server
+func readUint64(raw interface{}) (uint64, error) {
+	switch value := raw.(type) {
+	case float64:
+		return uint64(value), nil
+	case uint64:
+		return value, nil
+	case int:
+		return uint64(value), nil
+	default:
+		return 0, errInvalidType
+	}
+}
+
+// NextTimestamp returns the timestamp of a block sealed on top of the parent at the given time,
+// which is always greater than the parent timestamp
+func NextTimestamp(parent *types.Header, now time.Time) uint64 {
+	timestamp := uint64(now.Unix())
+
+	if timestamp <= parent.Timestamp {
+		timestamp = parent.Timestamp + 1
+	}
+
+	return timestamp
+}
+
+// VerifyTimestamp checks the timestamp of the header is greater than the parent timestamp
+func VerifyTimestamp(parent, header *types.Header) error {
+	if header.Timestamp <= parent.Timestamp {
+		return ErrInvalidTimestamp
+	}
+
+	return nil
+}
+
+// Manager holds the sealing policy of the engine, which can be changed at runtime
+type Manager struct {
+	lock     sync.RWMutex
+	policy   Policy
+	updateCh chan struct{}
+}
+
+// NewManager creates a new manager with the given policy
+func NewManager(policy Policy) *Manager {
+	return &Manager{
+		policy:   policy,
+		updateCh: make(chan struct{}, 1),
+	}
+}
+
+// Policy returns the current sealing policy
+func (m *Manager) Policy() Policy {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.policy
+}
+
+// SetPolicy replaces the sealing policy, notifying the sealing loop
+func (m *Manager) SetPolicy(policy Policy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	m.policy = policy
+	m.lock.Unlock()
+
+	select {
+	case m.updateCh <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// UpdateCh returns the channel notified when the policy is replaced
+func (m *Manager) UpdateCh() <-chan struct{} {
+	return m.updateCh
+}
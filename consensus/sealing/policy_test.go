@@ -0,0 +1,174 @@
+package sealing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolicy(t *testing.T) {
+	t.Parallel()
+
+	defaultPolicy := Policy{
+		Mode:     Interval,
+		Interval: 1,
+	}
+
+	testCases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected Policy
+		err      error
+	}{
+		{
+			name:     "default policy",
+			config:   map[string]interface{}{},
+			expected: defaultPolicy,
+		},
+		{
+			name: "policy from genesis",
+			config: map[string]interface{}{
+				KeyMode:     "non-empty",
+				KeyInterval: float64(3),
+				KeyMaxTxs:   float64(10),
+				KeyMaxGas:   float64(100000),
+			},
+			expected: Policy{
+				Mode:     NonEmpty,
+				Interval: 3,
+				MaxTxs:   10,
+				MaxGas:   100000,
+			},
+		},
+		{
+			name: "interval set by the server",
+			config: map[string]interface{}{
+				KeyInterval: uint64(5),
+			},
+			expected: Policy{
+				Mode:     Interval,
+				Interval: 5,
+			},
+		},
+		{
+			name: "on demand without interval",
+			config: map[string]interface{}{
+				KeyMode:     "on-demand",
+				KeyInterval: float64(0),
+			},
+			expected: Policy{
+				Mode: OnDemand,
+			},
+		},
+		{
+			name: "invalid mode",
+			config: map[string]interface{}{
+				KeyMode: "always",
+			},
+			err: ErrInvalidMode,
+		},
+		{
+			name: "missing interval",
+			config: map[string]interface{}{
+				KeyInterval: float64(0),
+			},
+			err: ErrInvalidInterval,
+		},
+		{
+			name: "invalid type",
+			config: map[string]interface{}{
+				KeyMaxTxs: "10",
+			},
+			err: errInvalidType,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := ParsePolicy(test.config, defaultPolicy)
+
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, policy)
+		})
+	}
+}
+
+func TestPolicy_Admits(t *testing.T) {
+	t.Parallel()
+
+	unlimited := Policy{Mode: OnDemand}
+	assert.True(t, unlimited.Admits(1000, 1000000, 1000000))
+
+	capped := Policy{Mode: OnDemand, MaxTxs: 2, MaxGas: 50000}
+
+	assert.True(t, capped.Admits(0, 0, 21000))
+	assert.True(t, capped.Admits(1, 21000, 29000))
+	assert.False(t, capped.Admits(1, 21000, 29001))
+	assert.False(t, capped.Admits(2, 0, 21000))
+}
+
+func TestNextTimestamp(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(100, 0)
+
+	// the current time is used if it's after the parent
+	parent := &types.Header{Timestamp: 99}
+	assert.Equal(t, uint64(100), NextTimestamp(parent, now))
+
+	// the timestamp is increased otherwise
+	for _, timestamp := range []uint64{100, 150} {
+		parent := &types.Header{Timestamp: timestamp}
+		next := &types.Header{Timestamp: NextTimestamp(parent, now)}
+
+		assert.Equal(t, timestamp+1, next.Timestamp)
+		assert.NoError(t, VerifyTimestamp(parent, next))
+	}
+
+	assert.ErrorIs(
+		t,
+		VerifyTimestamp(&types.Header{Timestamp: 10}, &types.Header{Timestamp: 10}),
+		ErrInvalidTimestamp,
+	)
+}
+
+func TestManager_SetPolicy(t *testing.T) {
+	t.Parallel()
+
+	m := NewManager(Policy{Mode: Interval, Interval: 1})
+
+	assert.ErrorIs(t, m.SetPolicy(Policy{Mode: NonEmpty}), ErrInvalidInterval)
+	assert.Equal(t, Policy{Mode: Interval, Interval: 1}, m.Policy())
+
+	select {
+	case <-m.UpdateCh():
+		t.Fatal("invalid policy notified")
+	default:
+	}
+
+	// multiple updates are notified once
+	assert.NoError(t, m.SetPolicy(Policy{Mode: OnDemand, MaxTxs: 1}))
+	assert.NoError(t, m.SetPolicy(Policy{Mode: OnDemand, MaxTxs: 2}))
+
+	<-m.UpdateCh()
+
+	assert.Equal(t, Policy{Mode: OnDemand, MaxTxs: 2}, m.Policy())
+
+	select {
+	case <-m.UpdateCh():
+		t.Fatal("update notified twice")
+	default:
+	}
+}
@@ -0,0 +1,192 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: consensus/sealing/proto/sealing_operator.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Policy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sealing mode: interval, on-demand or non-empty
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Time between the blocks, in seconds
+	Interval uint64 `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Maximum number of transactions per block, unlimited if 0
+	MaxBlockTxs uint64 `protobuf:"varint,3,opt,name=maxBlockTxs,proto3" json:"maxBlockTxs,omitempty"`
+	// Maximum gas used per block, up to the block gas limit if 0
+	MaxBlockGas uint64 `protobuf:"varint,4,opt,name=maxBlockGas,proto3" json:"maxBlockGas,omitempty"`
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_consensus_sealing_proto_sealing_operator_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_consensus_sealing_proto_sealing_operator_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_consensus_sealing_proto_sealing_operator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Policy) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *Policy) GetInterval() uint64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+func (x *Policy) GetMaxBlockTxs() uint64 {
+	if x != nil {
+		return x.MaxBlockTxs
+	}
+	return 0
+}
+
+func (x *Policy) GetMaxBlockGas() uint64 {
+	if x != nil {
+		return x.MaxBlockGas
+	}
+	return 0
+}
+
+var File_consensus_sealing_proto_sealing_operator_proto protoreflect.FileDescriptor
+
+var file_consensus_sealing_proto_sealing_operator_proto_rawDesc = []byte{
+	0x0a, 0x2e, 0x63, 0x6f, 0x6e, 0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f, 0x73, 0x65, 0x61, 0x6c,
+	0x69, 0x6e, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x61, 0x6c, 0x69, 0x6e,
+	0x67, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x02, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x7c, 0x0a, 0x06, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x6d,
+	0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x78, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x47, 0x61, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x47, 0x61, 0x73, 0x32,
+	0x67, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x2f, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0a, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x0a, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x1a, 0x0a, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x42, 0x1a, 0x5a, 0x18, 0x2f, 0x63, 0x6f, 0x6e,
+	0x73, 0x65, 0x6e, 0x73, 0x75, 0x73, 0x2f, 0x73, 0x65, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_consensus_sealing_proto_sealing_operator_proto_rawDescOnce sync.Once
+	file_consensus_sealing_proto_sealing_operator_proto_rawDescData = file_consensus_sealing_proto_sealing_operator_proto_rawDesc
+)
+
+func file_consensus_sealing_proto_sealing_operator_proto_rawDescGZIP() []byte {
+	file_consensus_sealing_proto_sealing_operator_proto_rawDescOnce.Do(func() {
+		file_consensus_sealing_proto_sealing_operator_proto_rawDescData = protoimpl.X.CompressGZIP(file_consensus_sealing_proto_sealing_operator_proto_rawDescData)
+	})
+	return file_consensus_sealing_proto_sealing_operator_proto_rawDescData
+}
+
+var file_consensus_sealing_proto_sealing_operator_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_consensus_sealing_proto_sealing_operator_proto_goTypes = []interface{}{
+	(*Policy)(nil),        // 0: v1.Policy
+	(*emptypb.Empty)(nil), // 1: google.protobuf.Empty
+}
+var file_consensus_sealing_proto_sealing_operator_proto_depIdxs = []int32{
+	1, // 0: v1.SealingOperator.GetPolicy:input_type -> google.protobuf.Empty
+	0, // 1: v1.SealingOperator.SetPolicy:input_type -> v1.Policy
+	0, // 2: v1.SealingOperator.GetPolicy:output_type -> v1.Policy
+	0, // 3: v1.SealingOperator.SetPolicy:output_type -> v1.Policy
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_consensus_sealing_proto_sealing_operator_proto_init() }
+func file_consensus_sealing_proto_sealing_operator_proto_init() {
+	if File_consensus_sealing_proto_sealing_operator_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_consensus_sealing_proto_sealing_operator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Policy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_consensus_sealing_proto_sealing_operator_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_consensus_sealing_proto_sealing_operator_proto_goTypes,
+		DependencyIndexes: file_consensus_sealing_proto_sealing_operator_proto_depIdxs,
+		MessageInfos:      file_consensus_sealing_proto_sealing_operator_proto_msgTypes,
+	}.Build()
+	File_consensus_sealing_proto_sealing_operator_proto = out.File
+	file_consensus_sealing_proto_sealing_operator_proto_rawDesc = nil
+	file_consensus_sealing_proto_sealing_operator_proto_goTypes = nil
+	file_consensus_sealing_proto_sealing_operator_proto_depIdxs = nil
+}
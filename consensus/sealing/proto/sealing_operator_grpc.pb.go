@@ -0,0 +1,146 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.5
+// source: consensus/sealing/proto/sealing_operator.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SealingOperatorClient is the client API for SealingOperator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SealingOperatorClient interface {
+	// GetPolicy returns the current sealing policy
+	GetPolicy(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Policy, error)
+	// SetPolicy replaces the sealing policy, and returns it
+	SetPolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*Policy, error)
+}
+
+type sealingOperatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSealingOperatorClient(cc grpc.ClientConnInterface) SealingOperatorClient {
+	return &sealingOperatorClient{cc}
+}
+
+func (c *sealingOperatorClient) GetPolicy(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*Policy, error) {
+	out := new(Policy)
+	err := c.cc.Invoke(ctx, "/v1.SealingOperator/GetPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sealingOperatorClient) SetPolicy(ctx context.Context, in *Policy, opts ...grpc.CallOption) (*Policy, error) {
+	out := new(Policy)
+	err := c.cc.Invoke(ctx, "/v1.SealingOperator/SetPolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SealingOperatorServer is the server API for SealingOperator service.
+// All implementations must embed UnimplementedSealingOperatorServer
+// for forward compatibility
+type SealingOperatorServer interface {
+	// GetPolicy returns the current sealing policy
+	GetPolicy(context.Context, *emptypb.Empty) (*Policy, error)
+	// SetPolicy replaces the sealing policy, and returns it
+	SetPolicy(context.Context, *Policy) (*Policy, error)
+	mustEmbedUnimplementedSealingOperatorServer()
+}
+
+// UnimplementedSealingOperatorServer must be embedded to have forward compatible implementations.
+type UnimplementedSealingOperatorServer struct {
+}
+
+func (UnimplementedSealingOperatorServer) GetPolicy(context.Context, *emptypb.Empty) (*Policy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPolicy not implemented")
+}
+func (UnimplementedSealingOperatorServer) SetPolicy(context.Context, *Policy) (*Policy, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPolicy not implemented")
+}
+func (UnimplementedSealingOperatorServer) mustEmbedUnimplementedSealingOperatorServer() {}
+
+// UnsafeSealingOperatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SealingOperatorServer will
+// result in compilation errors.
+type UnsafeSealingOperatorServer interface {
+	mustEmbedUnimplementedSealingOperatorServer()
+}
+
+func RegisterSealingOperatorServer(s grpc.ServiceRegistrar, srv SealingOperatorServer) {
+	s.RegisterService(&SealingOperator_ServiceDesc, srv)
+}
+
+func _SealingOperator_GetPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SealingOperatorServer).GetPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SealingOperator/GetPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SealingOperatorServer).GetPolicy(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SealingOperator_SetPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Policy)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SealingOperatorServer).SetPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.SealingOperator/SetPolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SealingOperatorServer).SetPolicy(ctx, req.(*Policy))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SealingOperator_ServiceDesc is the grpc.ServiceDesc for SealingOperator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SealingOperator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "v1.SealingOperator",
+	HandlerType: (*SealingOperatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPolicy",
+			Handler:    _SealingOperator_GetPolicy_Handler,
+		},
+		{
+			MethodName: "SetPolicy",
+			Handler:    _SealingOperator_SetPolicy_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "consensus/sealing/proto/sealing_operator.proto",
+}
@@ -0,0 +1,345 @@
+package switcher
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Gabulhas/polygon-external-consensus/blockchain"
+	"github.com/Gabulhas/polygon-external-consensus/consensus"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing"
+	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+)
+
+var (
+	ErrNoEngines        = errors.New("no consensus engines scheduled")
+	ErrUnsortedEngines  = errors.New("consensus engines not sorted by start height")
+	ErrEngineNotStarted = errors.New("the consensus engine of the block is not started yet")
+)
+
+type blockchainInterface interface {
+	Header() *types.Header
+	SubscribeEvents() blockchain.Subscription
+}
+
+// Engine is a consensus engine of the schedule, sealing the blocks from its height
+type Engine struct {
+	Name string
+	From uint64
+
+	consensus.Consensus
+
+	// headerHash is the header hash function set by the engine
+	headerHash func(*types.Header) types.Hash
+}
+
+// NewEngine creates the engine with the given factory. The header hash function
+// the engine sets is only used for its blocks, the previous one is restored
+func NewEngine(
+	name string,
+	from uint64,
+	factory consensus.Factory,
+	params *consensus.Params,
+) (*Engine, error) {
+	previousHash := types.HeaderHash
+	defer func() {
+		types.HeaderHash = previousHash
+	}()
+
+	engine, err := factory(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Engine{
+		Name:       name,
+		From:       from,
+		Consensus:  engine,
+		headerHash: types.HeaderHash,
+	}, nil
+}
+
+// Switcher runs the consensus engines scheduled by block height.
+// The blocks are verified by the engine owning their height, and only the engine
+// owning the next block runs, the previous one is closed once its last block is written
+type Switcher struct {
+	logger     hclog.Logger
+	blockchain blockchainInterface
+
+	// engines sorted by the height they start from
+	engines []*Engine
+
+	// switchLock serializes the start and close of the engines
+	switchLock sync.Mutex
+
+	// lock guards the running engine, read while verifying the blocks
+	lock sync.RWMutex
+
+	// index of the running engine, -1 if not started
+	active int
+
+	closeCh chan struct{}
+}
+
+// NewSwitcher creates the switcher of the given engines, the first one sealing the genesis
+func NewSwitcher(
+	logger hclog.Logger,
+	blockchain blockchainInterface,
+	engines []*Engine,
+) (*Switcher, error) {
+	if len(engines) == 0 {
+		return nil, ErrNoEngines
+	}
+
+	for i := 1; i < len(engines); i++ {
+		if engines[i].From <= engines[i-1].From {
+			return nil, ErrUnsortedEngines
+		}
+	}
+
+	return &Switcher{
+		logger:     logger.Named("switcher"),
+		blockchain: blockchain,
+		engines:    engines,
+		active:     -1,
+		closeCh:    make(chan struct{}),
+	}, nil
+}
+
+// engineIndex returns the index of the engine owning the given height
+func (s *Switcher) engineIndex(height uint64) int {
+	index := 0
+
+	for i, engine := range s.engines {
+		if engine.From <= height {
+			index = i
+		}
+	}
+
+	return index
+}
+
+// engineAt returns the engine owning the header height. The headers of the engines
+// following the running one are rejected, so that the running engine doesn't seal them
+func (s *Switcher) engineAt(height uint64) (*Engine, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	index := s.engineIndex(height)
+	if s.active >= 0 && index > s.active {
+		return nil, fmt.Errorf("%w: %s at %d", ErrEngineNotStarted, s.engines[index].Name, height)
+	}
+
+	return s.engines[index], nil
+}
+
+// HeaderHash calculates the header hash with the function of the engine owning its height
+func (s *Switcher) HeaderHash(header *types.Header) types.Hash {
+	return s.engines[s.engineIndex(header.Number)].headerHash(header)
+}
+
+// activeEngine returns the running engine, or the genesis one if not started
+func (s *Switcher) activeEngine() *Engine {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.active < 0 {
+		return s.engines[0]
+	}
+
+	return s.engines[s.active]
+}
+
+// Initialize initializes all the engines, they are expected to register distinct services
+func (s *Switcher) Initialize() error {
+	for _, engine := range s.engines {
+		if err := engine.Initialize(); err != nil {
+			return fmt.Errorf("failed to initialize %s: %w", engine.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Start starts the engine owning the next block, and switches the engines as the chain grows
+func (s *Switcher) Start() error {
+	sub := s.blockchain.SubscribeEvents()
+
+	if err := s.switchEngine(); err != nil {
+		sub.Close()
+
+		return err
+	}
+
+	go s.run(sub)
+
+	return nil
+}
+
+func (s *Switcher) run(sub blockchain.Subscription) {
+	defer sub.Close()
+
+	eventCh := sub.GetEventCh()
+
+	for {
+		select {
+		case <-eventCh:
+		case <-s.closeCh:
+			return
+		}
+
+		if err := s.switchEngine(); err != nil {
+			s.logger.Error("failed to switch the consensus engine", "err", err)
+		}
+	}
+}
+
+// switchEngine starts the engine owning the block following the head, if it's not running.
+// The engines only switch forward, a closed engine can't be restarted
+func (s *Switcher) switchEngine() error {
+	s.switchLock.Lock()
+	defer s.switchLock.Unlock()
+
+	next := s.engineIndex(s.blockchain.Header().Number + 1)
+	if next <= s.active {
+		return nil
+	}
+
+	// the blocks of the next engine are rejected until the previous one is closed
+	if s.active >= 0 {
+		previous := s.engines[s.active]
+
+		if err := previous.Close(); err != nil {
+			return fmt.Errorf("failed to close %s: %w", previous.Name, err)
+		}
+	}
+
+	s.lock.Lock()
+	s.active = next
+	s.lock.Unlock()
+
+	engine := s.engines[next]
+
+	if err := engine.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", engine.Name, err)
+	}
+
+	s.logger.Info("consensus engine started", "engine", engine.Name, "from", engine.From)
+
+	return nil
+}
+
+// VerifyHeader verifies the header with the engine owning its height
+func (s *Switcher) VerifyHeader(header *types.Header) error {
+	engine, err := s.engineAt(header.Number)
+	if err != nil {
+		return err
+	}
+
+	return engine.VerifyHeader(header)
+}
+
+// VerifyHeaders verifies the headers in batches with the engines owning their height,
+// if they support it
+func (s *Switcher) VerifyHeaders(headers []*types.Header) error {
+	return s.forEachEngine(headers, func(engine *Engine, headers []*types.Header) error {
+		batchVerifier, ok := engine.Consensus.(blockchain.BatchVerifier)
+		if !ok {
+			return nil
+		}
+
+		return batchVerifier.VerifyHeaders(headers)
+	})
+}
+
+// ProcessHeaders processes the headers with the engines owning their height
+func (s *Switcher) ProcessHeaders(headers []*types.Header) error {
+	return s.forEachEngine(headers, func(engine *Engine, headers []*types.Header) error {
+		return engine.ProcessHeaders(headers)
+	})
+}
+
+// forEachEngine calls the handler with each sequence of headers owned by the same engine
+func (s *Switcher) forEachEngine(
+	headers []*types.Header,
+	handler func(engine *Engine, headers []*types.Header) error,
+) error {
+	for start := 0; start < len(headers); {
+		engine, err := s.engineAt(headers[start].Number)
+		if err != nil {
+			return err
+		}
+
+		end := start + 1
+
+		for end < len(headers) {
+			next, err := s.engineAt(headers[end].Number)
+			if err != nil || next != engine {
+				break
+			}
+
+			end++
+		}
+
+		if err := handler(engine, headers[start:end]); err != nil {
+			return err
+		}
+
+		start = end
+	}
+
+	return nil
+}
+
+// GetBlockCreator retrieves the block creator with the engine owning the header height
+func (s *Switcher) GetBlockCreator(header *types.Header) (types.Address, error) {
+	engine, err := s.engineAt(header.Number)
+	if err != nil {
+		return types.ZeroAddress, err
+	}
+
+	return engine.GetBlockCreator(header)
+}
+
+// PreCommitState calls the hook of the engine owning the header height
+func (s *Switcher) PreCommitState(header *types.Header, txn *state.Transition) error {
+	engine, err := s.engineAt(header.Number)
+	if err != nil {
+		return err
+	}
+
+	return engine.PreCommitState(header, txn)
+}
+
+// GetSyncProgression retrieves the sync progression of the running engine
+func (s *Switcher) GetSyncProgression() *progress.Progression {
+	return s.activeEngine().GetSyncProgression()
+}
+
+// SealingManager returns the manager of the sealing policy of the running engine, if any
+func (s *Switcher) SealingManager() *sealing.Manager {
+	sealer, ok := s.activeEngine().Consensus.(sealing.Sealer)
+	if !ok {
+		return nil
+	}
+
+	return sealer.SealingManager()
+}
+
+// Close closes the running engine. The engines not started yet aren't closed,
+// since they expect to be running
+func (s *Switcher) Close() error {
+	close(s.closeCh)
+
+	s.switchLock.Lock()
+	defer s.switchLock.Unlock()
+
+	if s.active < 0 {
+		return nil
+	}
+
+	return s.engines[s.active].Close()
+}
@@ -0,0 +1,259 @@
+package switcher
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/blockchain"
+	"github.com/Gabulhas/polygon-external-consensus/consensus"
+	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+var errInvalidHeader = errors.New("invalid header")
+
+type mockEngine struct {
+	lock sync.Mutex
+
+	name      string
+	verified  []uint64
+	processed []uint64
+	started   bool
+	closed    bool
+}
+
+func (m *mockEngine) VerifyHeader(header *types.Header) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.verified = append(m.verified, header.Number)
+
+	if len(header.ExtraData) > 0 {
+		return errInvalidHeader
+	}
+
+	return nil
+}
+
+func (m *mockEngine) ProcessHeaders(headers []*types.Header) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, header := range headers {
+		m.processed = append(m.processed, header.Number)
+	}
+
+	return nil
+}
+
+func (m *mockEngine) GetBlockCreator(header *types.Header) (types.Address, error) {
+	return types.StringToAddress(m.name), nil
+}
+
+func (m *mockEngine) PreCommitState(header *types.Header, txn *state.Transition) error {
+	return nil
+}
+
+func (m *mockEngine) GetSyncProgression() *progress.Progression {
+	return nil
+}
+
+func (m *mockEngine) Initialize() error {
+	return nil
+}
+
+func (m *mockEngine) Start() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.started = true
+
+	return nil
+}
+
+func (m *mockEngine) Close() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.closed = true
+
+	return nil
+}
+
+func (m *mockEngine) isRunning() bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.started && !m.closed
+}
+
+type mockBlockchain struct {
+	lock   sync.Mutex
+	header *types.Header
+	sub    *blockchain.MockSubscription
+}
+
+func (m *mockBlockchain) Header() *types.Header {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.header
+}
+
+func (m *mockBlockchain) SubscribeEvents() blockchain.Subscription {
+	return m.sub
+}
+
+func (m *mockBlockchain) setHead(number uint64) {
+	m.lock.Lock()
+	m.header = &types.Header{Number: number}
+	m.lock.Unlock()
+
+	m.sub.Push(&blockchain.Event{})
+}
+
+func newTestEngine(t *testing.T, name string, from uint64, engine *mockEngine) *Engine {
+	t.Helper()
+
+	return &Engine{
+		Name:       name,
+		From:       from,
+		Consensus:  engine,
+		headerHash: types.HeaderHash,
+	}
+}
+
+func newTestSwitcher(t *testing.T, head uint64) (*Switcher, *mockBlockchain, *mockEngine, *mockEngine) {
+	t.Helper()
+
+	first, second := &mockEngine{name: "0x1"}, &mockEngine{name: "0x2"}
+	chain := &mockBlockchain{
+		header: &types.Header{Number: head},
+		sub:    blockchain.NewMockSubscription(),
+	}
+
+	s, err := NewSwitcher(hclog.NewNullLogger(), chain, []*Engine{
+		newTestEngine(t, "first", 0, first),
+		newTestEngine(t, "second", 10, second),
+	})
+	assert.NoError(t, err)
+
+	return s, chain, first, second
+}
+
+func TestNewSwitcher(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewSwitcher(hclog.NewNullLogger(), nil, nil)
+	assert.ErrorIs(t, err, ErrNoEngines)
+
+	_, err = NewSwitcher(hclog.NewNullLogger(), nil, []*Engine{
+		newTestEngine(t, "first", 0, &mockEngine{}),
+		newTestEngine(t, "second", 0, &mockEngine{}),
+	})
+	assert.ErrorIs(t, err, ErrUnsortedEngines)
+}
+
+func TestSwitcher_Delegation(t *testing.T) {
+	t.Parallel()
+
+	s, _, first, second := newTestSwitcher(t, 0)
+
+	// all the blocks are verified before the start, e.g. on restore
+	assert.NoError(t, s.VerifyHeader(&types.Header{Number: 9}))
+	assert.NoError(t, s.VerifyHeader(&types.Header{Number: 10}))
+	assert.ErrorIs(t, s.VerifyHeader(&types.Header{Number: 11, ExtraData: []byte{1}}), errInvalidHeader)
+
+	assert.Equal(t, []uint64{9}, first.verified)
+	assert.Equal(t, []uint64{10, 11}, second.verified)
+
+	headers := make([]*types.Header, 0)
+	for number := uint64(8); number < 12; number++ {
+		headers = append(headers, &types.Header{Number: number})
+	}
+
+	assert.NoError(t, s.ProcessHeaders(headers))
+	assert.Equal(t, []uint64{8, 9}, first.processed)
+	assert.Equal(t, []uint64{10, 11}, second.processed)
+
+	creator, err := s.GetBlockCreator(&types.Header{Number: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, types.StringToAddress("0x2"), creator)
+}
+
+func TestSwitcher_Switch(t *testing.T) {
+	t.Parallel()
+
+	s, chain, first, second := newTestSwitcher(t, 5)
+
+	assert.NoError(t, s.Start())
+
+	defer s.Close()
+
+	assert.True(t, first.isRunning())
+	assert.False(t, second.isRunning())
+
+	// the blocks of the next engine are rejected while the previous one runs
+	assert.ErrorIs(t, s.VerifyHeader(&types.Header{Number: 10}), ErrEngineNotStarted)
+
+	// the engines switch once the last block of the first one is written
+	chain.setHead(9)
+
+	assert.Eventually(t, second.isRunning, time.Second, 10*time.Millisecond)
+	assert.False(t, first.isRunning())
+	assert.NoError(t, s.VerifyHeader(&types.Header{Number: 10}))
+
+	// the engines don't switch back
+	chain.setHead(4)
+	chain.setHead(11)
+
+	assert.True(t, second.isRunning())
+}
+
+func TestSwitcher_StartAfterSchedule(t *testing.T) {
+	t.Parallel()
+
+	s, _, first, second := newTestSwitcher(t, 20)
+
+	assert.NoError(t, s.Start())
+
+	// the previous engines are never started
+	assert.False(t, first.started)
+	assert.True(t, second.isRunning())
+
+	assert.NoError(t, s.Close())
+	assert.False(t, first.closed)
+	assert.True(t, second.closed)
+}
+
+func TestSwitcher_HeaderHash(t *testing.T) {
+	defaultHash := types.HeaderHash
+
+	customHash := types.StringToHash("0x1")
+
+	custom, err := NewEngine("custom", 10, func(*consensus.Params) (consensus.Consensus, error) {
+		types.HeaderHash = func(*types.Header) types.Hash {
+			return customHash
+		}
+
+		return &mockEngine{}, nil
+	}, nil)
+	assert.NoError(t, err)
+
+	// the hash function set by the engine factory is restored
+	assert.Equal(t, defaultHash(&types.Header{}), types.HeaderHash(&types.Header{}))
+
+	s, err := NewSwitcher(hclog.NewNullLogger(), nil, []*Engine{
+		newTestEngine(t, "default", 0, &mockEngine{}),
+		custom,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, defaultHash(&types.Header{Number: 9}), s.HeaderHash(&types.Header{Number: 9}))
+	assert.Equal(t, customHash, s.HeaderHash(&types.Header{Number: 10}))
+}
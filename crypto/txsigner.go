@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/bits"
@@ -27,11 +28,19 @@ type TxSigner interface {
 	CalculateV(parity byte) []byte
 }
 
-// NewSigner creates a new signer object (EIP155 or FrontierSigner)
+var (
+	ErrInvalidChainID = errors.New("invalid chain id for signer")
+)
+
+// NewSigner creates a new signer object (London, Berlin, EIP155 or FrontierSigner)
 func NewSigner(forks chain.ForksInTime, chainID uint64) TxSigner {
 	var signer TxSigner
 
-	if forks.EIP155 {
+	if forks.London {
+		signer = NewLondonSigner(chainID)
+	} else if forks.Berlin {
+		signer = NewBerlinSigner(chainID)
+	} else if forks.EIP155 {
 		signer = &EIP155Signer{chainID: chainID}
 	} else {
 		signer = &FrontierSigner{}
@@ -90,6 +99,10 @@ var (
 
 // Sender decodes the signature and returns the sender of the transaction
 func (f *FrontierSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.IsTyped() {
+		return types.Address{}, types.ErrTxTypeNotSupported
+	}
+
 	refV := big.NewInt(0)
 	if tx.V != nil {
 		refV.SetBytes(tx.V.Bytes())
@@ -157,6 +170,10 @@ func (e *EIP155Signer) Hash(tx *types.Transaction) types.Hash {
 
 // Sender returns the transaction sender
 func (e *EIP155Signer) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.IsTyped() {
+		return types.Address{}, types.ErrTxTypeNotSupported
+	}
+
 	protected := true
 
 	// Check if v value conforms to an earlier standard (before EIP155)
@@ -227,6 +244,175 @@ func (e *EIP155Signer) CalculateV(parity byte) []byte {
 	return reference.Bytes()
 }
 
+// NewBerlinSigner returns a new BerlinSigner object
+func NewBerlinSigner(chainID uint64) *BerlinSigner {
+	return &BerlinSigner{EIP155Signer: EIP155Signer{chainID: chainID}}
+}
+
+// BerlinSigner signs the access list transactions (EIP-2930),
+// and the legacy transactions as the EIP155Signer
+type BerlinSigner struct {
+	EIP155Signer
+}
+
+// calcTypedTxHash calculates the signing hash of a typed transaction,
+// the keccak256 hash of the type followed by the RLP value of the unsigned payload
+func calcTypedTxHash(tx *types.Transaction, chainID uint64) types.Hash {
+	a := signerPool.Get()
+
+	v := a.NewArray()
+	v.Set(a.NewUint(chainID))
+	v.Set(a.NewUint(tx.Nonce))
+
+	if tx.Type == types.DynamicFeeTx {
+		v.Set(a.NewBigInt(tx.GasTipCap))
+		v.Set(a.NewBigInt(tx.GasFeeCap))
+	} else {
+		v.Set(a.NewBigInt(tx.GasPrice))
+	}
+
+	v.Set(a.NewUint(tx.Gas))
+
+	if tx.To == nil {
+		v.Set(a.NewNull())
+	} else {
+		v.Set(a.NewCopyBytes((*tx.To).Bytes()))
+	}
+
+	v.Set(a.NewBigInt(tx.Value))
+	v.Set(a.NewCopyBytes(tx.Input))
+	v.Set(tx.AccessList.MarshalRLPWith(a))
+
+	hash := keccak.Keccak256(nil, v.MarshalTo([]byte{byte(tx.Type)}))
+
+	signerPool.Put(a)
+
+	return types.BytesToHash(hash)
+}
+
+// Hash returns the signing hash of the transaction
+func (b *BerlinSigner) Hash(tx *types.Transaction) types.Hash {
+	if !tx.IsTyped() {
+		return b.EIP155Signer.Hash(tx)
+	}
+
+	return calcTypedTxHash(tx, b.chainID)
+}
+
+// Sender returns the transaction sender
+func (b *BerlinSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if !tx.IsTyped() {
+		return b.EIP155Signer.Sender(tx)
+	}
+
+	if tx.Type != types.AccessListTx {
+		return types.Address{}, types.ErrTxTypeNotSupported
+	}
+
+	return b.typedSender(tx)
+}
+
+// typedSender recovers the sender of a typed transaction, signed with the chain ID
+func (b *BerlinSigner) typedSender(tx *types.Transaction) (types.Address, error) {
+	if tx.ChainID == nil || !tx.ChainID.IsUint64() || tx.ChainID.Uint64() != b.chainID {
+		return types.Address{}, ErrInvalidChainID
+	}
+
+	// the V value of the typed transactions is the parity of the signature
+	parity := big.NewInt(0)
+	if tx.V != nil {
+		parity.Set(tx.V)
+	}
+
+	if !parity.IsUint64() || parity.Uint64() > 1 {
+		return types.Address{}, fmt.Errorf("invalid txn signature")
+	}
+
+	sig, err := encodeSignature(tx.R, tx.S, byte(parity.Uint64()))
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	pub, err := Ecrecover(b.Hash(tx).Bytes(), sig)
+	if err != nil {
+		return types.Address{}, err
+	}
+
+	buf := Keccak256(pub[1:])[12:]
+
+	return types.BytesToAddress(buf), nil
+}
+
+// SignTx signs the transaction using the passed in private key
+func (b *BerlinSigner) SignTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	if !tx.IsTyped() {
+		return b.EIP155Signer.SignTx(tx, privateKey)
+	}
+
+	if tx.Type != types.AccessListTx {
+		return nil, types.ErrTxTypeNotSupported
+	}
+
+	return b.signTyped(tx, privateKey)
+}
+
+// signTyped signs a typed transaction with the chain ID, the V value is the parity of the signature
+func (b *BerlinSigner) signTyped(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	tx = tx.Copy()
+	tx.ChainID = new(big.Int).SetUint64(b.chainID)
+
+	h := b.Hash(tx)
+
+	sig, err := Sign(privateKey, h[:])
+	if err != nil {
+		return nil, err
+	}
+
+	tx.R = new(big.Int).SetBytes(sig[:32])
+	tx.S = new(big.Int).SetBytes(sig[32:64])
+	tx.V = new(big.Int).SetUint64(uint64(sig[64]))
+
+	return tx, nil
+}
+
+// NewLondonSigner returns a new LondonSigner object
+func NewLondonSigner(chainID uint64) *LondonSigner {
+	return &LondonSigner{BerlinSigner: *NewBerlinSigner(chainID)}
+}
+
+// LondonSigner signs the dynamic fee transactions (EIP-1559),
+// and the other transactions as the BerlinSigner
+type LondonSigner struct {
+	BerlinSigner
+}
+
+// Sender returns the transaction sender
+func (l *LondonSigner) Sender(tx *types.Transaction) (types.Address, error) {
+	if tx.Type != types.DynamicFeeTx {
+		return l.BerlinSigner.Sender(tx)
+	}
+
+	return l.typedSender(tx)
+}
+
+// SignTx signs the transaction using the passed in private key
+func (l *LondonSigner) SignTx(
+	tx *types.Transaction,
+	privateKey *ecdsa.PrivateKey,
+) (*types.Transaction, error) {
+	if tx.Type != types.DynamicFeeTx {
+		return l.BerlinSigner.SignTx(tx, privateKey)
+	}
+
+	return l.signTyped(tx, privateKey)
+}
+
 // encodeSignature generates a signature value based on the R, S and V value
 func encodeSignature(R, S *big.Int, V byte) ([]byte, error) {
 	if !ValidateSignatureValues(V, R, S) {
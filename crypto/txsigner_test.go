@@ -143,3 +143,81 @@ func TestEIP155Signer_ChainIDMismatch(t *testing.T) {
 		}
 	}
 }
+
+func TestBerlinSigner(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+	key, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	signer := NewBerlinSigner(100)
+
+	for _, txType := range []types.TxType{types.LegacyTx, types.AccessListTx} {
+		txn := &types.Transaction{
+			Type:     txType,
+			To:       &toAddress,
+			Value:    big.NewInt(1),
+			GasPrice: big.NewInt(0),
+			AccessList: types.TxAccessList{
+				{Address: toAddress, StorageKeys: []types.Hash{types.StringToHash("1")}},
+			},
+		}
+
+		signedTx, err := signer.SignTx(txn, key)
+		assert.NoError(t, err)
+
+		from, err := signer.Sender(signedTx)
+		assert.NoError(t, err)
+		assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+		// the signature covers the chain ID
+		_, err = NewBerlinSigner(1).Sender(signedTx)
+		assert.Error(t, err)
+	}
+}
+
+func TestEIP155Signer_TypedTransaction(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEIP155Signer(100).Sender(&types.Transaction{Type: types.AccessListTx})
+	assert.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+}
+
+func TestLondonSigner(t *testing.T) {
+	t.Parallel()
+
+	toAddress := types.StringToAddress("1")
+	key, err := GenerateECDSAKey()
+	assert.NoError(t, err)
+
+	signer := NewLondonSigner(100)
+
+	txn := &types.Transaction{
+		Type:      types.DynamicFeeTx,
+		To:        &toAddress,
+		Value:     big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+	}
+
+	signedTx, err := signer.SignTx(txn, key)
+	assert.NoError(t, err)
+
+	from, err := signer.Sender(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, PubKeyToAddress(&key.PublicKey), from)
+
+	// the fee caps are covered by the signature
+	tamperedTx := signedTx.Copy()
+	tamperedTx.GasFeeCap = big.NewInt(11)
+
+	tamperedFrom, err := signer.Sender(tamperedTx)
+	if err == nil {
+		assert.NotEqual(t, from, tamperedFrom)
+	}
+
+	// the berlin signer does not support dynamic fee transactions
+	_, err = NewBerlinSigner(100).Sender(signedTx)
+	assert.ErrorIs(t, err, types.ErrTxTypeNotSupported)
+}
@@ -0,0 +1,91 @@
+package e2e
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/e2e/framework"
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+// TestBundle_PooledTx checks a bundle can include a transaction of the pool,
+// which is then popped from the pool without holding back the following ones of its sender
+func TestBundle_PooledTx(t *testing.T) {
+	senderKey, senderAddr := tests.GenerateKeyAndAddr(t)
+	backrunKey, backrunAddr := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetDevInterval(5)
+		config.Premine(senderAddr, framework.EthToWei(10))
+		config.Premine(backrunAddr, framework.EthToWei(10))
+	})[0]
+
+	client := srv.JSONRPC()
+
+	signTx := func(nonce uint64, to types.Address, key *ecdsa.PrivateKey) *types.Transaction {
+		t.Helper()
+
+		signed, err := srv.SignTx(&types.Transaction{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(framework.DefaultGasPrice),
+			Gas:      21000,
+			To:       &to,
+			Value:    big.NewInt(1),
+		}, key)
+		assert.NoError(t, err)
+
+		return signed.ComputeHash()
+	}
+
+	pooledTx, nextTx := signTx(0, backrunAddr, senderKey), signTx(1, backrunAddr, senderKey)
+	backrunTx := signTx(0, senderAddr, backrunKey)
+
+	// both txs of the sender are pending in the pool
+	for _, tx := range []*types.Transaction{pooledTx, nextTx} {
+		_, err := client.Eth().SendRawTransaction(tx.MarshalRLP())
+		assert.NoError(t, err)
+	}
+
+	number, err := client.Eth().BlockNumber()
+	assert.NoError(t, err)
+
+	// the bundle backruns the pending tx in the next block
+	var result struct {
+		BundleHash types.Hash `json:"bundleHash"`
+	}
+
+	assert.NoError(t, client.Call("eth_sendBundle", &result, map[string]interface{}{
+		"txs": []string{
+			hex.EncodeToHex(pooledTx.MarshalRLP()),
+			hex.EncodeToHex(backrunTx.MarshalRLP()),
+		},
+		"blockNumber": hex.EncodeUint64(number + 1),
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), framework.DefaultTimeout)
+	defer cancel()
+
+	receipts := make([]*ethgo.Receipt, 0, 3)
+
+	for _, tx := range []*types.Transaction{pooledTx, backrunTx, nextTx} {
+		receipt, err := srv.WaitForReceipt(ctx, ethgo.Hash(tx.Hash))
+		if !assert.NoError(t, err) || !assert.NotNil(t, receipt) {
+			return
+		}
+
+		receipts = append(receipts, receipt)
+	}
+
+	// the bundle comes first, and the following tx of the pool is not held back
+	for i, receipt := range receipts {
+		assert.Equal(t, number+1, receipt.BlockNumber)
+		assert.Equal(t, uint64(i), receipt.TransactionIndex)
+	}
+}
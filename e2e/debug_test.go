@@ -0,0 +1,110 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/e2e/framework"
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+// TestDebug_Trace checks the transactions of the chain and the calls
+// on top of it are traced through the debug endpoint
+func TestDebug_Trace(t *testing.T) {
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetJSONRPCDebug(true)
+		config.Premine(addr, framework.EthToWei(10))
+	})[0]
+
+	client := srv.JSONRPC()
+
+	ctx, cancel := context.WithTimeout(context.Background(), framework.DefaultTimeout)
+	defer cancel()
+
+	deployReceipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+		From:     addr,
+		Gas:      framework.DefaultGasLimit,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Input:    hex.MustDecodeHex(sampleByteCode),
+	}, key)
+	assert.NoError(t, err)
+
+	contractAddr := types.Address(deployReceipt.ContractAddress)
+
+	// the opcodes of the deployment are logged by default
+	var structTrace struct {
+		Failed     bool   `json:"failed"`
+		Gas        uint64 `json:"gas"`
+		StructLogs []struct {
+			Op    string `json:"op"`
+			Depth int    `json:"depth"`
+		} `json:"structLogs"`
+	}
+
+	assert.NoError(t, client.Call("debug_traceTransaction", &structTrace, deployReceipt.TransactionHash))
+	assert.False(t, structTrace.Failed)
+	assert.Equal(t, deployReceipt.GasUsed, structTrace.Gas)
+	assert.NotEmpty(t, structTrace.StructLogs)
+	assert.Equal(t, "PUSH1", structTrace.StructLogs[0].Op)
+	assert.Equal(t, 1, structTrace.StructLogs[0].Depth)
+
+	// the call frames of a contract call are traced by the call tracer
+	receipt := srv.InvokeMethod(ctx, contractAddr, "setA1", key)
+
+	type callFrame struct {
+		Type    string        `json:"type"`
+		From    types.Address `json:"from"`
+		To      types.Address `json:"to"`
+		GasUsed string        `json:"gasUsed"`
+		Error   string        `json:"error"`
+	}
+
+	callTracer := map[string]interface{}{"tracer": "callTracer"}
+
+	var frame callFrame
+
+	assert.NoError(t, client.Call("debug_traceTransaction", &frame, receipt.TransactionHash, callTracer))
+	assert.Equal(t, "CALL", frame.Type)
+	assert.Equal(t, addr, frame.From)
+	assert.Equal(t, contractAddr, frame.To)
+	assert.Equal(t, hex.EncodeUint64(receipt.GasUsed), frame.GasUsed)
+	assert.Empty(t, frame.Error)
+
+	// the block is traced transaction by transaction
+	var blockTraces []struct {
+		TxHash ethgo.Hash `json:"txHash"`
+		Result callFrame  `json:"result"`
+	}
+
+	assert.NoError(t, client.Call(
+		"debug_traceBlockByNumber",
+		&blockTraces,
+		hex.EncodeUint64(receipt.BlockNumber),
+		callTracer,
+	))
+	assert.Len(t, blockTraces, 1)
+	assert.Equal(t, receipt.TransactionHash, blockTraces[0].TxHash)
+	assert.Equal(t, frame, blockTraces[0].Result)
+
+	// the calls are traced on top of the latest block
+	call := map[string]interface{}{
+		"from": addr,
+		"to":   contractAddr,
+		"data": hex.EncodeToHex(framework.MethodSig("setA1")),
+	}
+
+	var callFrameResult callFrame
+
+	assert.NoError(t, client.Call("debug_traceCall", &callFrameResult, call, "latest", callTracer))
+	assert.Equal(t, "CALL", callFrameResult.Type)
+	assert.Equal(t, contractAddr, callFrameResult.To)
+	assert.Empty(t, callFrameResult.Error)
+}
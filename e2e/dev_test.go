@@ -0,0 +1,94 @@
+package e2e
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/e2e/framework"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+// TestDev_ChainControl checks the chain of the dev consensus can be frozen,
+// mined, time warped, changed and reverted through the evm and dev endpoints
+func TestDev_ChainControl(t *testing.T) {
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+	})[0]
+
+	client := srv.JSONRPC()
+	receiver := ethgo.Address(types.StringToAddress("0x1234"))
+
+	var automine bool
+
+	assert.NoError(t, client.Call("evm_setAutomine", &automine, false))
+
+	// a block being sealed when automine is disabled may still be written
+	time.Sleep(2 * time.Second)
+
+	head, err := srv.GetLatestBlockHeight()
+	assert.NoError(t, err)
+
+	time.Sleep(2 * time.Second)
+
+	frozenHead, err := srv.GetLatestBlockHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, head, frozenHead, "blocks sealed without automine")
+
+	var snapshotID string
+
+	assert.NoError(t, client.Call("evm_snapshot", &snapshotID))
+
+	// mine exactly 3 blocks
+	for i := 0; i < 3; i++ {
+		var res string
+
+		assert.NoError(t, client.Call("evm_mine", &res))
+	}
+
+	minedHead, err := srv.GetLatestBlockHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, head+3, minedHead)
+
+	// warp the time of the next block
+	parent, err := client.Eth().GetBlockByNumber(ethgo.Latest, false)
+	assert.NoError(t, err)
+
+	timestamp := parent.Timestamp + 3600
+
+	var res interface{}
+
+	assert.NoError(t, client.Call("evm_setNextBlockTimestamp", &res, timestamp))
+	assert.NoError(t, client.Call("evm_mine", &res))
+
+	block, err := client.Eth().GetBlockByNumber(ethgo.Latest, false)
+	assert.NoError(t, err)
+	assert.Equal(t, timestamp, block.Timestamp)
+
+	// the state is changed without a transaction
+	var set bool
+
+	balance := framework.EthToWei(5)
+
+	assert.NoError(t, client.Call("dev_setBalance", &set, receiver, "0x"+balance.Text(16)))
+	assert.True(t, set)
+
+	receiverBalance, err := client.Eth().GetBalance(receiver, ethgo.Latest)
+	assert.NoError(t, err)
+	assert.Equal(t, balance, receiverBalance)
+
+	// the chain is reverted to the snapshot
+	var reverted bool
+
+	assert.NoError(t, client.Call("evm_revert", &reverted, snapshotID))
+	assert.True(t, reverted)
+
+	revertedHead, err := srv.GetLatestBlockHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, head, revertedHead)
+
+	receiverBalance, err = client.Eth().GetBalance(receiver, ethgo.Latest)
+	assert.NoError(t, err)
+	assert.Zero(t, receiverBalance.Sign())
+}
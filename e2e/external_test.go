@@ -0,0 +1,201 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	externalOp "github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
+	"github.com/Gabulhas/polygon-external-consensus/e2e/framework"
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+	empty "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// Default settings for the nodes driven by the external consensus client
+const (
+	ExternalNodes         = 3
+	ExternalFinalityDepth = 2
+)
+
+func newExternalServersManager(t *testing.T, proposer string) *framework.ExternalServersManager {
+	t.Helper()
+
+	return framework.NewExternalServersManager(
+		t,
+		ExternalNodes,
+		&framework.ExternalClientConfig{
+			BlockTime:     time.Second,
+			FinalityDepth: ExternalFinalityDepth,
+			Proposer:      proposer,
+		},
+		func(i int, config *framework.TestServerConfig) {},
+	)
+}
+
+// waitForExternalBlock waits until all the nodes reach the given block
+func waitForExternalBlock(t *testing.T, manager *framework.ExternalServersManager, number uint64) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), framework.DefaultTimeout)
+	defer cancel()
+
+	for i := 0; i < ExternalNodes; i++ {
+		srv := manager.GetServer(i)
+
+		_, err := tests.RetryUntilTimeout(ctx, func() (interface{}, bool) {
+			num, err := srv.GetLatestBlockHeight()
+			if err != nil || num < number {
+				return nil, true
+			}
+
+			return nil, false
+		})
+
+		if err != nil {
+			t.Fatalf("node %d didn't reach block %d: %v", i, number, err)
+		}
+	}
+}
+
+func getBlockHash(t *testing.T, srv *framework.TestServer, number uint64) ethgo.Hash {
+	t.Helper()
+
+	block, err := srv.JSONRPC().Eth().GetBlockByNumber(ethgo.BlockNumber(number), false)
+	assert.NoError(t, err)
+
+	if block == nil {
+		t.Fatalf("block %d not found", number)
+	}
+
+	return block.Hash
+}
+
+// TestExternal_BlockProduction checks that the blocks built by the client
+// are imported and finalized on all the nodes
+func TestExternal_BlockProduction(t *testing.T) {
+	testCases := []struct {
+		name     string
+		proposer string
+	}{
+		{
+			name:     "round-robin proposer",
+			proposer: "round-robin",
+		},
+		{
+			name:     "single leader",
+			proposer: "leader",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			manager := newExternalServersManager(t, tc.proposer)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			manager.StartServers(ctx)
+
+			waitForExternalBlock(t, manager, 5)
+
+			// all the nodes share the same chain
+			expected := getBlockHash(t, manager.GetServer(0), 4)
+
+			for i := 1; i < ExternalNodes; i++ {
+				assert.Equal(t, expected, getBlockHash(t, manager.GetServer(i), 4))
+			}
+
+			// the blocks deep enough in the chain are finalized
+			for i := 0; i < ExternalNodes; i++ {
+				status, err := manager.GetServer(i).ExternalEngine().Status(ctx, &empty.Empty{})
+				assert.NoError(t, err)
+
+				assert.NotEmpty(t, status.FinalizedHash)
+				assert.GreaterOrEqual(t, status.FinalizedNumber, uint64(3-ExternalFinalityDepth))
+				assert.LessOrEqual(t, status.FinalizedNumber+ExternalFinalityDepth, status.HeadNumber)
+			}
+		})
+	}
+}
+
+// TestExternal_Fork checks that the nodes follow the fork choice of the client,
+// and reject the reorganizations below the finalized block
+func TestExternal_Fork(t *testing.T) {
+	manager := newExternalServersManager(t, "")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	manager.StartServers(ctx)
+
+	waitForExternalBlock(t, manager, 4)
+
+	// halt the block production to drive the nodes directly
+	manager.StopClient()
+
+	engine := manager.GetServer(0).ExternalEngine()
+
+	status, err := engine.Status(ctx, &empty.Empty{})
+	assert.NoError(t, err)
+
+	head := status.HeadNumber
+	parent := getBlockHash(t, manager.GetServer(0), head-1)
+
+	// build a sibling of the head and make it canonical
+	payload, err := engine.BuildPayload(ctx, &externalOp.BuildPayloadRequest{
+		ParentHash: parent.Bytes(),
+		ExtraData:  []byte("fork"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, head, payload.Number)
+
+	for i := 0; i < ExternalNodes; i++ {
+		srvEngine := manager.GetServer(i).ExternalEngine()
+
+		_, err := srvEngine.ImportPayload(ctx, payload)
+		assert.NoError(t, err)
+
+		_, err = srvEngine.ForkchoiceUpdated(ctx, &externalOp.ForkchoiceState{
+			HeadHash: payload.Hash,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(
+			t,
+			ethgo.Hash(types.BytesToHash(payload.Hash)),
+			getBlockHash(t, manager.GetServer(i), head),
+		)
+	}
+
+	// a fork below the finalized block is rejected
+	status, err = engine.Status(ctx, &empty.Empty{})
+	assert.NoError(t, err)
+
+	if status.FinalizedNumber == 0 {
+		t.Fatal("no finalized block")
+	}
+
+	staleParent := getBlockHash(t, manager.GetServer(0), status.FinalizedNumber-1)
+
+	stalePayload, err := engine.BuildPayload(ctx, &externalOp.BuildPayloadRequest{
+		ParentHash: staleParent.Bytes(),
+		ExtraData:  []byte("stale"),
+	})
+	assert.NoError(t, err)
+
+	_, err = engine.ImportPayload(ctx, stalePayload)
+	assert.NoError(t, err)
+
+	_, err = engine.ForkchoiceUpdated(ctx, &externalOp.ForkchoiceState{
+		HeadHash: stalePayload.Hash,
+	})
+	assert.Error(t, err)
+
+	// the chain resumes once the client is restarted
+	assert.NoError(t, manager.StartClient())
+
+	waitForExternalBlock(t, manager, head+2)
+}
@@ -17,6 +17,7 @@ const (
 	ConsensusIBFT ConsensusType = iota
 	ConsensusDev
 	ConsensusDummy
+	ConsensusExternal
 )
 
 type SrvAccount struct {
@@ -61,6 +62,7 @@ type TestServerConfig struct {
 	BlockTime               uint64                   // Minimum block generation time (in s)
 	IBFTBaseTimeout         uint64                   // Base Timeout in seconds for IBFT
 	PredeployParams         *PredeployParams
+	JSONRPCDebug            bool // Serves the debug and trace endpoints
 }
 
 func (t *TestServerConfig) SetPredeployParams(params *PredeployParams) {
@@ -70,7 +72,7 @@ func (t *TestServerConfig) SetPredeployParams(params *PredeployParams) {
 // DataDir returns path of data directory server uses
 func (t *TestServerConfig) DataDir() string {
 	switch t.Consensus {
-	case ConsensusIBFT:
+	case ConsensusIBFT, ConsensusExternal:
 		return filepath.Join(t.RootDir, t.IBFTDir)
 	default:
 		return t.RootDir
@@ -156,6 +158,11 @@ func (t *TestServerConfig) SetIBFTDir(ibftDir string) {
 	t.IBFTDir = ibftDir
 }
 
+// SetJSONRPCDebug sets flag for serving the debug and trace endpoints
+func (t *TestServerConfig) SetJSONRPCDebug(f bool) {
+	t.JSONRPCDebug = f
+}
+
 // SetBootnodes sets bootnodes
 func (t *TestServerConfig) SetBootnodes(bootnodes []string) {
 	t.Bootnodes = bootnodes
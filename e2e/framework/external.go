@@ -0,0 +1,223 @@
+package framework
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+const (
+	externalDirPrefix = "external-data-"
+	externalClientLog = "external-client.log"
+)
+
+// ExternalClientConfig is the configuration of the reference external consensus client
+type ExternalClientConfig struct {
+	BlockTime     time.Duration // Time between blocks
+	FinalityDepth uint64        // Number of blocks after which a block is finalized
+	Proposer      string        // Proposer selection mode, round-robin if not set
+}
+
+// ExternalServersManager runs a set of nodes driven by the reference external consensus client
+type ExternalServersManager struct {
+	t       *testing.T
+	servers []*TestServer
+
+	clientConfig *ExternalClientConfig
+	clientCmd    *exec.Cmd
+	logsDir      string
+}
+
+type ExternalServerConfigCallback func(index int, config *TestServerConfig)
+
+func NewExternalServersManager(
+	t *testing.T,
+	numNodes int,
+	clientConfig *ExternalClientConfig,
+	callback ExternalServerConfigCallback,
+) *ExternalServersManager {
+	t.Helper()
+
+	dataDir, err := tempDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logsDir, err := initLogsDir(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &ExternalServersManager{
+		t:            t,
+		servers:      make([]*TestServer, 0, numNodes),
+		clientConfig: clientConfig,
+		logsDir:      logsDir,
+	}
+
+	t.Cleanup(func() {
+		m.StopClient()
+
+		for _, s := range m.servers {
+			s.Stop()
+		}
+
+		if err := os.RemoveAll(dataDir); err != nil {
+			t.Log(err)
+		}
+	})
+
+	bootnodes := make([]string, 0, numNodes)
+	genesisAccounts := make([]string, 0, numNodes)
+
+	for i := 0; i < numNodes; i++ {
+		srv := NewTestServer(t, dataDir, func(config *TestServerConfig) {
+			config.SetConsensus(ConsensusExternal)
+			config.SetIBFTDir(fmt.Sprintf("%s%d", externalDirPrefix, i))
+			config.SetLogsDir(logsDir)
+			config.SetSaveLogs(true)
+			config.SetName(fmt.Sprintf("node-%d", i))
+			callback(i, config)
+		})
+
+		res, err := srv.SecretsInit()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m.servers = append(m.servers, srv)
+		bootnodes = append(bootnodes, ToLocalIPv4LibP2pAddr(srv.Config.LibP2PPort, res.NodeID))
+		genesisAccounts = append(genesisAccounts, res.Address)
+	}
+
+	srv := m.servers[0]
+	srv.Config.SetBootnodes(bootnodes)
+
+	for i, v := range genesisAccounts {
+		if balance := m.servers[i].Config.GenesisValidatorBalance; balance != nil {
+			srv.Config.Premine(types.StringToAddress(v), balance)
+		}
+	}
+
+	if err := srv.GenerateGenesis(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := srv.GenesisPredeploy(); err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
+
+// StartServers starts the nodes and the external consensus client driving them,
+// and waits for the first block
+func (m *ExternalServersManager) StartServers(ctx context.Context) {
+	for idx, srv := range m.servers {
+		if err := srv.Start(ctx); err != nil {
+			m.t.Logf("server %d failed to start: %+v", idx, err)
+			m.t.Fatal(err)
+		}
+	}
+
+	if err := m.StartClient(); err != nil {
+		m.t.Fatal(err)
+	}
+
+	for idx, srv := range m.servers {
+		if err := srv.WaitForReady(ctx); err != nil {
+			m.t.Logf("server %d couldn't advance block: %+v", idx, err)
+			m.t.Fatal(err)
+		}
+	}
+}
+
+// StartClient starts the reference external consensus client on all the running nodes
+func (m *ExternalServersManager) StartClient() error {
+	if m.clientCmd != nil {
+		return fmt.Errorf("external consensus client is already running")
+	}
+
+	args := []string{"external", "client"}
+
+	for _, srv := range m.servers {
+		args = append(args, "--node", srv.GrpcAddr())
+	}
+
+	if m.clientConfig != nil {
+		if m.clientConfig.BlockTime != 0 {
+			args = append(args, "--block-time", m.clientConfig.BlockTime.String())
+		}
+
+		args = append(args, "--finality-depth", strconv.FormatUint(m.clientConfig.FinalityDepth, 10))
+
+		if m.clientConfig.Proposer != "" {
+			args = append(args, "--proposer", m.clientConfig.Proposer)
+		}
+	}
+
+	f, err := os.OpenFile(
+		filepath.Join(m.logsDir, externalClientLog),
+		os.O_RDWR|os.O_APPEND|os.O_CREATE,
+		0660,
+	)
+	if err != nil {
+		return err
+	}
+
+	m.t.Cleanup(func() {
+		if err := f.Close(); err != nil {
+			m.t.Logf("Failed to close file. Error: %s", err)
+		}
+	})
+
+	cmd := exec.Command(resolveBinary(), args...) //nolint:gosec
+	cmd.Stdout = f
+	cmd.Stderr = f
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	m.clientCmd = cmd
+
+	return nil
+}
+
+// StopClient stops the external consensus client, halting the block production
+func (m *ExternalServersManager) StopClient() {
+	if m.clientCmd == nil {
+		return
+	}
+
+	if err := m.clientCmd.Process.Kill(); err != nil {
+		m.t.Error(err)
+	}
+
+	_ = m.clientCmd.Wait()
+
+	m.clientCmd = nil
+}
+
+func (m *ExternalServersManager) StopServers() {
+	m.StopClient()
+
+	for _, srv := range m.servers {
+		srv.Stop()
+	}
+}
+
+func (m *ExternalServersManager) GetServer(i int) *TestServer {
+	if i >= len(m.servers) {
+		return nil
+	}
+
+	return m.servers[i]
+}
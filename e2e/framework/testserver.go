@@ -28,8 +28,10 @@ import (
 	ibftSwitch "github.com/Gabulhas/polygon-external-consensus/command/ibft/switch"
 	initCmd "github.com/Gabulhas/polygon-external-consensus/command/secrets/init"
 	"github.com/Gabulhas/polygon-external-consensus/command/server"
+	externalOp "github.com/Gabulhas/polygon-external-consensus/consensus/external/proto"
 	"github.com/Gabulhas/polygon-external-consensus/consensus/ibft/fork"
 	ibftOp "github.com/Gabulhas/polygon-external-consensus/consensus/ibft/proto"
+	sealingOp "github.com/Gabulhas/polygon-external-consensus/consensus/sealing/proto"
 	"github.com/Gabulhas/polygon-external-consensus/crypto"
 	stakingHelper "github.com/Gabulhas/polygon-external-consensus/helper/staking"
 	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
@@ -156,6 +158,28 @@ func (t *TestServer) IBFTOperator() ibftOp.IbftOperatorClient {
 	return ibftOp.NewIbftOperatorClient(conn)
 }
 
+func (t *TestServer) ExternalEngine() externalOp.ExternalEngineClient {
+	conn, err := grpc.Dial(
+		t.GrpcAddr(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.t.Fatal(err)
+	}
+
+	return externalOp.NewExternalEngineClient(conn)
+}
+
+func (t *TestServer) Sealing() sealingOp.SealingOperatorClient {
+	conn, err := grpc.Dial(
+		t.GrpcAddr(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.t.Fatal(err)
+	}
+
+	return sealingOp.NewSealingOperatorClient(conn)
+}
+
 func (t *TestServer) ReleaseReservedPorts() {
 	for _, p := range t.Config.ReservedPorts {
 		if err := p.Close(); err != nil {
@@ -305,6 +329,8 @@ func (t *TestServer) GenerateGenesis() error {
 		}
 	case ConsensusDummy:
 		args = append(args, "--consensus", "dummy")
+	case ConsensusExternal:
+		args = append(args, "--consensus", "external")
 	}
 
 	for _, bootnode := range t.Config.Bootnodes {
@@ -399,7 +425,7 @@ func (t *TestServer) Start(ctx context.Context) error {
 	}
 
 	switch t.Config.Consensus {
-	case ConsensusIBFT:
+	case ConsensusIBFT, ConsensusExternal:
 		args = append(args, "--data-dir", filepath.Join(t.Config.RootDir, t.Config.IBFTDir))
 	case ConsensusDev:
 		args = append(args, "--data-dir", t.Config.RootDir)
@@ -416,6 +442,10 @@ func (t *TestServer) Start(ctx context.Context) error {
 		args = append(args, "--price-limit", strconv.FormatUint(*t.Config.PriceLimit, 10))
 	}
 
+	if t.Config.JSONRPCDebug {
+		args = append(args, "--json-rpc-debug")
+	}
+
 	if t.Config.ShowsLog || t.Config.SaveLogs {
 		args = append(args, "--log-level", "debug")
 	}
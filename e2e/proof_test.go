@@ -0,0 +1,132 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/crypto"
+	"github.com/Gabulhas/polygon-external-consensus/e2e/framework"
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	itrie "github.com/Gabulhas/polygon-external-consensus/state/immutable-trie"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+// TestEth_GetProof checks the proofs of the accounts and of their storage
+// are verified against the state root of the block
+func TestEth_GetProof(t *testing.T) {
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.Premine(addr, framework.EthToWei(10))
+	})[0]
+
+	client := srv.JSONRPC()
+
+	ctx, cancel := context.WithTimeout(context.Background(), framework.DefaultTimeout)
+	defer cancel()
+
+	// the creation code stores 1 in the slot 0 of the contract:
+	// PUSH1 0x01 PUSH1 0x00 SSTORE STOP
+	receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+		From:     addr,
+		Gas:      framework.DefaultGasLimit,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Input:    hex.MustDecodeHex("0x600160005500"),
+	}, key)
+	assert.NoError(t, err)
+
+	contractAddr := types.Address(receipt.ContractAddress)
+	blockNumber := hex.EncodeUint64(receipt.BlockNumber)
+
+	block, err := client.Eth().GetBlockByNumber(ethgo.BlockNumber(receipt.BlockNumber), false)
+	assert.NoError(t, err)
+
+	stateRoot := types.Hash(block.StateRoot)
+
+	var proof struct {
+		Address      types.Address `json:"address"`
+		AccountProof []string      `json:"accountProof"`
+		Balance      string        `json:"balance"`
+		Nonce        string        `json:"nonce"`
+		StorageHash  types.Hash    `json:"storageHash"`
+		StorageProof []struct {
+			Key   types.Hash `json:"key"`
+			Value string     `json:"value"`
+			Proof []string   `json:"proof"`
+		} `json:"storageProof"`
+	}
+
+	decodeProof := func(proof []string) [][]byte {
+		res := make([][]byte, len(proof))
+		for i, node := range proof {
+			res[i] = hex.MustDecodeHex(node)
+		}
+
+		return res
+	}
+
+	verifyAccount := func(addr types.Address) *state.Account {
+		t.Helper()
+
+		value, err := itrie.VerifyProof(stateRoot, crypto.Keccak256(addr.Bytes()), decodeProof(proof.AccountProof))
+		assert.NoError(t, err)
+		assert.NotNil(t, value)
+
+		var account state.Account
+		assert.NoError(t, account.UnmarshalRlp(value))
+
+		return &account
+	}
+
+	// the balance and the nonce of the sender are proved
+	assert.NoError(t, client.Call("eth_getProof", &proof, addr, []types.Hash{}, blockNumber))
+	assert.Equal(t, addr, proof.Address)
+
+	sender := verifyAccount(addr)
+	assert.Equal(t, hex.EncodeBig(sender.Balance), proof.Balance)
+	assert.Equal(t, hex.EncodeUint64(sender.Nonce), proof.Nonce)
+	assert.Equal(t, uint64(1), sender.Nonce)
+
+	// the slot set by the contract is proved, and the one not set is proved absent
+	slot0, slot1 := types.Hash{}, types.BytesToHash([]byte{0x1})
+
+	assert.NoError(t, client.Call("eth_getProof", &proof, contractAddr, []types.Hash{slot0, slot1}, blockNumber))
+	assert.Len(t, proof.StorageProof, 2)
+
+	contract := verifyAccount(contractAddr)
+	assert.Equal(t, contract.Root, proof.StorageHash)
+
+	value, err := itrie.VerifyProof(
+		proof.StorageHash,
+		crypto.Keccak256(slot0.Bytes()),
+		decodeProof(proof.StorageProof[0].Proof),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x1}, value)
+	assert.Equal(t, "0x1", proof.StorageProof[0].Value)
+
+	value, err = itrie.VerifyProof(
+		proof.StorageHash,
+		crypto.Keccak256(slot1.Bytes()),
+		decodeProof(proof.StorageProof[1].Proof),
+	)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, "0x0", proof.StorageProof[1].Value)
+
+	// a missing account is proved absent
+	missing := types.StringToAddress("0x1234")
+
+	assert.NoError(t, client.Call("eth_getProof", &proof, missing, []types.Hash{}, blockNumber))
+
+	value, err = itrie.VerifyProof(stateRoot, crypto.Keccak256(missing.Bytes()), decodeProof(proof.AccountProof))
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+	assert.Equal(t, types.EmptyRootHash, proof.StorageHash)
+}
@@ -0,0 +1,69 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	sealingOp "github.com/Gabulhas/polygon-external-consensus/consensus/sealing/proto"
+	"github.com/Gabulhas/polygon-external-consensus/e2e/framework"
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+// TestSealing_OnDemand checks that the dev engine stops sealing empty blocks
+// once the policy is changed at runtime, and seals the new transactions right away
+func TestSealing_OnDemand(t *testing.T) {
+	senderKey, sender := tests.GenerateKeyAndAddr(t)
+	_, receiver := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.Premine(sender, framework.EthToWei(10))
+	})[0]
+
+	ctx, cancel := context.WithTimeout(context.Background(), framework.DefaultTimeout)
+	defer cancel()
+
+	policy, err := srv.Sealing().SetPolicy(ctx, &sealingOp.Policy{
+		Mode: "on-demand",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "on-demand", policy.Mode)
+
+	// a block being sealed when the policy changes may still be written
+	time.Sleep(2 * time.Second)
+
+	head, err := srv.GetLatestBlockHeight()
+	assert.NoError(t, err)
+
+	time.Sleep(3 * time.Second)
+
+	idleHead, err := srv.GetLatestBlockHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, head, idleHead, "empty blocks sealed on demand")
+
+	receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+		From:     sender,
+		To:       &receiver,
+		GasPrice: big.NewInt(1048576),
+		Gas:      21000,
+		Value:    framework.EthToWei(1),
+	}, senderKey)
+	assert.NoError(t, err)
+	assert.NotNil(t, receipt)
+	assert.Equal(t, head+1, receipt.BlockNumber)
+
+	// the block timestamp is greater than the parent one
+	client := srv.JSONRPC().Eth()
+
+	block, err := client.GetBlockByNumber(ethgo.BlockNumber(receipt.BlockNumber), false)
+	assert.NoError(t, err)
+
+	parent, err := client.GetBlockByNumber(ethgo.BlockNumber(receipt.BlockNumber-1), false)
+	assert.NoError(t, err)
+
+	assert.Greater(t, block.Timestamp, parent.Timestamp)
+}
@@ -0,0 +1,111 @@
+package e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/e2e/framework"
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/umbracle/ethgo"
+)
+
+// TestTrace_Parity checks the calls and creations of the transactions
+// are traced in the parity format through the trace endpoint
+func TestTrace_Parity(t *testing.T) {
+	key, addr := tests.GenerateKeyAndAddr(t)
+
+	srv := framework.NewTestServers(t, 1, func(config *framework.TestServerConfig) {
+		config.SetConsensus(framework.ConsensusDev)
+		config.SetJSONRPCDebug(true)
+		config.Premine(addr, framework.EthToWei(10))
+	})[0]
+
+	client := srv.JSONRPC()
+
+	ctx, cancel := context.WithTimeout(context.Background(), framework.DefaultTimeout)
+	defer cancel()
+
+	deployReceipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+		From:     addr,
+		Gas:      framework.DefaultGasLimit,
+		GasPrice: big.NewInt(framework.DefaultGasPrice),
+		Input:    hex.MustDecodeHex(sampleByteCode),
+	}, key)
+	assert.NoError(t, err)
+
+	contractAddr := types.Address(deployReceipt.ContractAddress)
+	receipt := srv.InvokeMethod(ctx, contractAddr, "setA1", key)
+
+	type trace struct {
+		Action struct {
+			CallType string        `json:"callType"`
+			From     types.Address `json:"from"`
+			To       types.Address `json:"to"`
+		} `json:"action"`
+		Result *struct {
+			Address types.Address `json:"address"`
+		} `json:"result"`
+		Type                string     `json:"type"`
+		BlockNumber         uint64     `json:"blockNumber"`
+		TransactionHash     ethgo.Hash `json:"transactionHash"`
+		TransactionPosition uint64     `json:"transactionPosition"`
+	}
+
+	// the deployment is traced as a creation
+	var blockTraces []trace
+
+	assert.NoError(t, client.Call("trace_block", &blockTraces, hex.EncodeUint64(deployReceipt.BlockNumber)))
+	assert.Len(t, blockTraces, 1)
+	assert.Equal(t, "create", blockTraces[0].Type)
+	assert.Equal(t, addr, blockTraces[0].Action.From)
+	assert.Equal(t, contractAddr, blockTraces[0].Result.Address)
+	assert.Equal(t, deployReceipt.TransactionHash, blockTraces[0].TransactionHash)
+
+	// the contract call is traced as a call
+	var txTraces []trace
+
+	assert.NoError(t, client.Call("trace_transaction", &txTraces, receipt.TransactionHash))
+	assert.Len(t, txTraces, 1)
+	assert.Equal(t, "call", txTraces[0].Type)
+	assert.Equal(t, "call", txTraces[0].Action.CallType)
+	assert.Equal(t, contractAddr, txTraces[0].Action.To)
+	assert.Equal(t, receipt.BlockNumber, txTraces[0].BlockNumber)
+
+	// both are found by the address of the contract
+	var filterTraces []trace
+
+	assert.NoError(t, client.Call("trace_filter", &filterTraces, map[string]interface{}{
+		"fromBlock": "earliest",
+		"toAddress": []types.Address{contractAddr},
+	}))
+	assert.Len(t, filterTraces, 2)
+	assert.Equal(t, deployReceipt.TransactionHash, filterTraces[0].TransactionHash)
+	assert.Equal(t, receipt.TransactionHash, filterTraces[1].TransactionHash)
+
+	// the state of the sender paying the call changes, the contract only emits an event
+	var replays []struct {
+		StateDiff map[types.Address]struct {
+			Balance map[string]interface{} `json:"balance"`
+			Nonce   interface{}            `json:"nonce"`
+		} `json:"stateDiff"`
+		TransactionHash ethgo.Hash `json:"transactionHash"`
+	}
+
+	assert.NoError(t, client.Call(
+		"trace_replayBlockTransactions",
+		&replays,
+		hex.EncodeUint64(receipt.BlockNumber),
+		[]string{"stateDiff"},
+	))
+	assert.Len(t, replays, 1)
+	assert.Equal(t, receipt.TransactionHash, replays[0].TransactionHash)
+	assert.Equal(t, map[string]interface{}{
+		"*": map[string]interface{}{"from": "0x1", "to": "0x2"},
+	}, replays[0].StateDiff[addr].Nonce)
+	assert.Contains(t, replays[0].StateDiff[addr].Balance, "*")
+	assert.NotContains(t, replays[0].StateDiff, contractAddr)
+}
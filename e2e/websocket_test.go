@@ -149,4 +149,54 @@ func TestWS_Response(t *testing.T) {
 
 		assert.Equalf(t, 1, blockNumInt.Cmp(big.NewInt(0)), "Invalid block number")
 	})
+
+	t.Run("Pending transaction notified after subscription", func(t *testing.T) {
+		request, constructErr := constructWSRequest(
+			3,
+			"eth_subscribe",
+			[]string{"newPendingTransactions"},
+		)
+
+		if constructErr != nil {
+			t.Fatalf("Unable to construct request: %v", constructErr)
+		}
+
+		var subscriptionID string
+		if wsError := json.Unmarshal(getWSResponse(t, ws, request).Result, &subscriptionID); wsError != nil {
+			t.Fatalf("Unable to unmarshal WS result: %v", wsError)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), framework.DefaultTimeout)
+		defer cancel()
+
+		receipt, err := srv.SendRawTx(ctx, &framework.PreparedTransaction{
+			From:     preminedAccounts[0].address,
+			To:       &preminedAccounts[1].address,
+			GasPrice: big.NewInt(10000),
+			Gas:      1000000,
+			Value:    big.NewInt(10000),
+		}, preminedAccounts[0].key)
+		if err != nil {
+			t.Fatalf("Unable to send transaction, %v", err)
+		}
+
+		_, response, wsError := ws.ReadMessage()
+		if wsError != nil {
+			t.Fatalf("Unable to read message from WS connection: %v", wsError)
+		}
+
+		var notification struct {
+			Params struct {
+				Subscription string     `json:"subscription"`
+				Result       types.Hash `json:"result"`
+			} `json:"params"`
+		}
+
+		if wsError = json.Unmarshal(response, &notification); wsError != nil {
+			t.Fatalf("Unable to unmarshal WS notification: %v", wsError)
+		}
+
+		assert.Equal(t, subscriptionID, notification.Params.Subscription)
+		assert.Equal(t, types.Hash(receipt.TransactionHash), notification.Params.Result)
+	})
 }
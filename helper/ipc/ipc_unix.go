@@ -4,12 +4,17 @@
 package ipc
 
 import (
+	"errors"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
 	"time"
 )
 
+// ErrNotSocket is returned if the IPC path is taken by a file which is not a socket
+var ErrNotSocket = errors.New("the ipc path is not a socket")
+
 // Dial dials an IPC path
 func Dial(path string) (net.Conn, error) {
 	return net.Dial("unix", path)
@@ -20,14 +25,23 @@ func DialTimeout(path string, timeout time.Duration) (net.Conn, error) {
 	return net.DialTimeout("unix", path, timeout)
 }
 
-// Listen listens an IPC path
+// Listen listens an IPC path, the socket is only accessible by its owner
 func Listen(path string) (net.Listener, error) {
-	if err := os.MkdirAll(filepath.Dir(path), 0751); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
 		return nil, err
 	}
 
-	if removeErr := os.Remove(path); removeErr != nil {
-		return nil, removeErr
+	// remove the socket left by a previous run, but never another file
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNotSocket, path)
+		}
+
+		if removeErr := os.Remove(path); removeErr != nil {
+			return nil, removeErr
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
 	}
 
 	lis, err := net.Listen("unix", path)
@@ -36,6 +50,8 @@ func Listen(path string) (net.Listener, error) {
 	}
 
 	if chmodErr := os.Chmod(path, 0600); chmodErr != nil {
+		_ = lis.Close()
+
 		return nil, chmodErr
 	}
 
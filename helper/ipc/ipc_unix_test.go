@@ -0,0 +1,43 @@
+//go:build !windows
+// +build !windows
+
+package ipc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "ipc", "edge.ipc")
+
+	// the socket is created with its directory
+	lis, err := Listen(path)
+	assert.NoError(t, err)
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// the socket left by a previous listener is replaced
+	secondLis, err := Listen(path)
+	assert.NoError(t, err)
+
+	assert.NoError(t, secondLis.Close())
+	assert.NoError(t, lis.Close())
+
+	// a file which is not a socket is never removed
+	assert.NoError(t, os.WriteFile(path, []byte{0x1}, 0600))
+
+	_, err = Listen(path)
+	assert.ErrorIs(t, err, ErrNotSocket)
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x1}, data)
+}
@@ -0,0 +1,274 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingToken     = errors.New("missing bearer token")
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrTokenExpired     = errors.New("token is expired")
+	ErrTokenNotValidYet = errors.New("token is not valid yet")
+)
+
+// jwtAlgorithm is the only signing algorithm of the JWT tokens accepted
+const jwtAlgorithm = "HS256"
+
+// AuthConfig is the authentication of the HTTP and WS requests, the credentials are passed
+// as a bearer token in the Authorization header, or in the token query parameter
+type AuthConfig struct {
+	// JWTSecret is the secret signing the HS256 JWT tokens, which are not accepted if empty
+	JWTSecret []byte
+
+	// JWTMethods are the methods allowed with a JWT token, all of them if nil
+	JWTMethods *MethodRules
+
+	// APIKeys are the static API keys accepted, with the methods they allow
+	APIKeys map[string]*MethodRules
+}
+
+// MethodRules are the allow and deny lists of the methods, with entries being either
+// method names or patterns ending in a wildcard, such as eth_* or *
+type MethodRules struct {
+	// Allow are the methods allowed, all of them if empty
+	Allow []string
+
+	// Deny are the methods denied, even if they are allowed
+	Deny []string
+}
+
+// IsAllowed checks the method is allowed by the rules, nil rules allowing any method
+func (r *MethodRules) IsAllowed(method string) bool {
+	if r == nil {
+		return true
+	}
+
+	for _, pattern := range r.Deny {
+		if matchMethod(pattern, method) {
+			return false
+		}
+	}
+
+	if len(r.Allow) == 0 {
+		return true
+	}
+
+	for _, pattern := range r.Allow {
+		if matchMethod(pattern, method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkMethods returns the error response of the request, or batch of requests,
+// if one of its methods is not allowed. The invalid requests are left to the dispatcher
+func (r *MethodRules) checkMethods(body []byte) []byte {
+	if r == nil {
+		return nil
+	}
+
+	var requests []Request
+
+	if x := bytes.TrimLeft(body, " \t\r\n"); len(x) > 0 && x[0] == '[' {
+		if err := json.Unmarshal(body, &requests); err != nil {
+			return nil
+		}
+	} else {
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil
+		}
+
+		requests = append(requests, req)
+	}
+
+	for _, req := range requests {
+		if r.IsAllowed(req.Method) {
+			continue
+		}
+
+		// the batches are rejected as a whole
+		var id interface{}
+		if len(requests) == 1 {
+			id = req.ID
+		}
+
+		resp, _ := NewRPCResponse(id, "2.0", nil, NewMethodNotAllowedError(req.Method)).Bytes()
+
+		return resp
+	}
+
+	return nil
+}
+
+func matchMethod(pattern, method string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(method, strings.TrimSuffix(pattern, "*"))
+	}
+
+	return pattern == method
+}
+
+// authenticate returns the method rules of the credentials of the request,
+// and the API key of the request if it is authenticated by one
+func (c *AuthConfig) authenticate(r *http.Request) (*MethodRules, string, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return nil, "", ErrMissingToken
+	}
+
+	for key, rules := range c.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1 {
+			return rules, key, nil
+		}
+	}
+
+	if len(c.JWTSecret) == 0 {
+		return nil, "", ErrInvalidToken
+	}
+
+	if err := verifyJWT(token, c.JWTSecret, time.Now()); err != nil {
+		return nil, "", err
+	}
+
+	return c.JWTMethods, "", nil
+}
+
+// bearerToken returns the token of the Authorization header, or of the token
+// query parameter for the clients not able to set headers, such as the browser web sockets
+func bearerToken(r *http.Request) string {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") {
+			return ""
+		}
+
+		return strings.TrimSpace(token)
+	}
+
+	return r.URL.Query().Get("token")
+}
+
+// verifyJWT checks the token is signed with the secret, and is valid at the given time
+func verifyJWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	if err := decodeJWTPart(parts[0], &header); err != nil || header.Alg != jwtAlgorithm {
+		return ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrInvalidToken
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return ErrInvalidToken
+	}
+
+	var claims struct {
+		ExpiresAt *int64 `json:"exp"`
+		NotBefore *int64 `json:"nbf"`
+	}
+
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return ErrInvalidToken
+	}
+
+	if claims.ExpiresAt != nil && now.Unix() >= *claims.ExpiresAt {
+		return ErrTokenExpired
+	}
+
+	if claims.NotBefore != nil && now.Unix() < *claims.NotBefore {
+		return ErrTokenNotValidYet
+	}
+
+	return nil
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// methodRulesKey is the context key of the method rules of the authenticated request
+type methodRulesKey struct{}
+
+// methodRulesFromContext returns the method rules of the request, nil if it's not authenticated
+func methodRulesFromContext(ctx context.Context) *MethodRules {
+	rules, _ := ctx.Value(methodRulesKey{}).(*MethodRules)
+
+	return rules
+}
+
+// apiKeyKey is the context key of the API key of the authenticated request
+type apiKeyKey struct{}
+
+// clientFromRequest returns the key identifying the client of the request for the rate limiting,
+// which is its API key if it's authenticated by one, or its IP address otherwise
+func clientFromRequest(r *http.Request) string {
+	if key, ok := r.Context().Value(apiKeyKey{}).(string); ok && key != "" {
+		return "key:" + key
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// authMiddleware rejects the requests without valid credentials,
+// and passes the method rules of the credentials to the handler
+func authMiddleware(auth *AuthConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the CORS preflight requests don't carry credentials
+			if auth == nil || r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			rules, apiKey, err := auth.authenticate(r)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), methodRulesKey{}, rules)
+			ctx = context.WithValue(ctx, apiKeyKey{}, apiKey)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// signJWT returns the token of the claims, signed with the secret by the algorithm
+func signJWT(t *testing.T, alg string, secret []byte, claims map[string]interface{}) string {
+	t.Helper()
+
+	encode := func(v interface{}) string {
+		raw, err := json.Marshal(v)
+		assert.NoError(t, err)
+
+		return base64.RawURLEncoding.EncodeToString(raw)
+	}
+
+	unsigned := encode(map[string]string{"alg": alg, "typ": "JWT"}) + "." + encode(claims)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestMethodRules_IsAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rules   *MethodRules
+		method  string
+		allowed bool
+	}{
+		{
+			name:    "no rules",
+			rules:   nil,
+			method:  "debug_traceTransaction",
+			allowed: true,
+		},
+		{
+			name:    "empty rules",
+			rules:   &MethodRules{},
+			method:  "debug_traceTransaction",
+			allowed: true,
+		},
+		{
+			name:    "allowed namespace",
+			rules:   &MethodRules{Allow: []string{"eth_*"}, Deny: []string{"eth_sendRawTransaction"}},
+			method:  "eth_call",
+			allowed: true,
+		},
+		{
+			name:    "denied method of allowed namespace",
+			rules:   &MethodRules{Allow: []string{"eth_*"}, Deny: []string{"eth_sendRawTransaction"}},
+			method:  "eth_sendRawTransaction",
+			allowed: false,
+		},
+		{
+			name:    "namespace not allowed",
+			rules:   &MethodRules{Allow: []string{"eth_*"}, Deny: []string{"eth_sendRawTransaction"}},
+			method:  "debug_traceTransaction",
+			allowed: false,
+		},
+		{
+			name:    "allowed method",
+			rules:   &MethodRules{Allow: []string{"net_version"}},
+			method:  "net_version",
+			allowed: true,
+		},
+		{
+			name:    "denied namespace",
+			rules:   &MethodRules{Deny: []string{"debug_*", "dev_*"}},
+			method:  "dev_mine",
+			allowed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.allowed, tt.rules.IsAllowed(tt.method))
+		})
+	}
+}
+
+func TestVerifyJWT(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("secret")
+	now := time.Unix(1000, 0)
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{
+			name:  "valid token",
+			token: signJWT(t, jwtAlgorithm, secret, map[string]interface{}{"exp": 1001, "nbf": 1000}),
+			err:   nil,
+		},
+		{
+			name:  "token without expiration",
+			token: signJWT(t, jwtAlgorithm, secret, map[string]interface{}{"iat": 900}),
+			err:   nil,
+		},
+		{
+			name:  "other secret",
+			token: signJWT(t, jwtAlgorithm, []byte("other"), map[string]interface{}{}),
+			err:   ErrInvalidToken,
+		},
+		{
+			name:  "other algorithm",
+			token: signJWT(t, "none", secret, map[string]interface{}{}),
+			err:   ErrInvalidToken,
+		},
+		{
+			name:  "expired token",
+			token: signJWT(t, jwtAlgorithm, secret, map[string]interface{}{"exp": 1000}),
+			err:   ErrTokenExpired,
+		},
+		{
+			name:  "token not valid yet",
+			token: signJWT(t, jwtAlgorithm, secret, map[string]interface{}{"nbf": 1001}),
+			err:   ErrTokenNotValidYet,
+		},
+		{
+			name:  "malformed token",
+			token: "api-key",
+			err:   ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ErrorIs(t, verifyJWT(tt.token, secret, now), tt.err)
+		})
+	}
+}
+
+func TestHTTPServer_Auth(t *testing.T) {
+	t.Parallel()
+
+	port, err := tests.GetFreePort()
+	assert.NoError(t, err)
+
+	secret := []byte("secret")
+
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:            newMockStore(),
+		Addr:             &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		ChainID:          100,
+		BatchLengthLimit: 20,
+		BlockRangeLimit:  1000,
+		Auth: &AuthConfig{
+			JWTSecret: secret,
+			JWTMethods: &MethodRules{
+				Allow: []string{"net_*"},
+			},
+			APIKeys: map[string]*MethodRules{
+				"read-only": {
+					Allow: []string{"eth_*"},
+					Deny:  []string{"eth_sendRawTransaction"},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	defer srv.Close()
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+
+	post := func(token, body string) (int, string) {
+		t.Helper()
+
+		req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(body))
+		assert.NoError(t, err)
+
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err)
+
+		return resp.StatusCode, string(data)
+	}
+
+	chainID := `{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`
+
+	// the requests without valid credentials are rejected
+	status, _ := post("", chainID)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	status, _ = post("unknown", chainID)
+	assert.Equal(t, http.StatusUnauthorized, status)
+
+	// the API key allows its methods only
+	status, body := post("read-only", chainID)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"result":"0x64"`)
+
+	_, body = post("read-only", `{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0x00"]}`)
+	assert.Contains(t, body, "the method eth_sendRawTransaction is not allowed")
+
+	// a batch is rejected as a whole if one of its methods is not allowed
+	_, body = post("read-only", `[
+		{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]},
+		{"jsonrpc":"2.0","id":2,"method":"net_version","params":[]}
+	]`)
+	assert.Contains(t, body, "the method net_version is not allowed")
+
+	// the JWT token allows its methods only
+	token := signJWT(t, jwtAlgorithm, secret, map[string]interface{}{"iat": time.Now().Unix()})
+
+	_, body = post(token, `{"jsonrpc":"2.0","id":1,"method":"net_version","params":[]}`)
+	assert.Contains(t, body, `"result":"100"`)
+
+	_, body = post(token, chainID)
+	assert.Contains(t, body, "the method eth_chainId is not allowed")
+
+	// the web socket connections are authenticated on the upgrade
+	wsURL := fmt.Sprintf("ws://127.0.0.1:%d/ws", port)
+
+	_, resp, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.NoError(t, resp.Body.Close())
+
+	ws, _, err := websocket.DefaultDialer.Dial(wsURL+"?token=read-only", nil)
+	assert.NoError(t, err)
+
+	defer ws.Close()
+
+	assert.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte(chainID)))
+
+	_, msg, err := ws.ReadMessage()
+	assert.NoError(t, err)
+	assert.Contains(t, string(msg), `"result":"0x64"`)
+
+	assert.NoError(t, ws.WriteMessage(
+		websocket.TextMessage,
+		[]byte(`{"jsonrpc":"2.0","id":2,"method":"eth_sendRawTransaction","params":["0x00"]}`),
+	))
+
+	_, msg, err = ws.ReadMessage()
+	assert.NoError(t, err)
+	assert.Contains(t, string(msg), "the method eth_sendRawTransaction is not allowed")
+}
+
+func TestClientFromRequest(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.RemoteAddr = "10.0.0.1:4567"
+
+	assert.Equal(t, "ip:10.0.0.1", clientFromRequest(req))
+
+	req = req.WithContext(context.WithValue(req.Context(), apiKeyKey{}, "read-only"))
+
+	assert.Equal(t, "key:read-only", clientFromRequest(req))
+}
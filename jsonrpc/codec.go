@@ -13,6 +13,9 @@ type Request struct {
 	ID     interface{}     `json:"id"`
 	Method string          `json:"method"`
 	Params json.RawMessage `json:"params,omitempty"`
+
+	// client is the key of the rate limiting of the request, not limited if empty
+	client string
 }
 
 // Response is a jsonrpc response interface
@@ -94,9 +97,11 @@ func (e *ObjectError) Error() string {
 }
 
 const (
-	PendingBlockNumber  = BlockNumber(-3)
-	LatestBlockNumber   = BlockNumber(-2)
-	EarliestBlockNumber = BlockNumber(-1)
+	SafeBlockNumber      = BlockNumber(-5)
+	FinalizedBlockNumber = BlockNumber(-4)
+	PendingBlockNumber   = BlockNumber(-3)
+	LatestBlockNumber    = BlockNumber(-2)
+	EarliestBlockNumber  = BlockNumber(-1)
 )
 
 type BlockNumber int64
@@ -109,7 +114,8 @@ type BlockNumberOrHash struct {
 // UnmarshalJSON will try to extract the filter's data.
 // Here are the possible input formats :
 //
-// 1 - "latest", "pending" or "earliest"	- self-explaining keywords
+// 1 - "latest", "pending", "earliest",
+//     "finalized" or "safe"				- self-explaining keywords
 // 2 - "0x2"								- block number #2 (EIP-1898 backward compatible)
 // 3 - {blockNumber:	"0x2"}				- EIP-1898 compliant block number #2
 // 4 - {blockHash:		"0xe0e..."}			- EIP-1898 compliant block hash 0xe0e...
@@ -154,6 +160,10 @@ func stringToBlockNumber(str string) (BlockNumber, error) {
 		return LatestBlockNumber, nil
 	case "earliest":
 		return EarliestBlockNumber, nil
+	case "finalized":
+		return FinalizedBlockNumber, nil
+	case "safe":
+		return SafeBlockNumber, nil
 	}
 
 	n, err := types.ParseUint64orHex(&str)
@@ -0,0 +1,276 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer/calltracer"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer/structtracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+const (
+	// callTracerName is the name of the tracer building the tree of the call frames
+	callTracerName = "callTracer"
+
+	// defaultTraceTimeout is the time after which a tracing is cancelled, if not configured
+	defaultTraceTimeout = 5 * time.Second
+)
+
+var (
+	ErrTracerNotSupported = errors.New("tracer not supported")
+	ErrTraceTimeout       = errors.New("execution timeout")
+	ErrTraceGenesisBlock  = errors.New("genesis block is not traceable")
+	ErrTxnNotFound        = errors.New("transaction not found")
+)
+
+// debugStore provides the replay of the transactions needed for the debug endpoint
+type debugStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetBlockByNumber returns a block using the provided number
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+
+	// TraceBlock replays the transactions of the block on top of its parent,
+	// each traced by the tracer at the same index
+	TraceBlock(block *types.Block, tracers []tracer.Tracer) error
+
+	// TraceTxn replays the transactions of the block on top of its parent
+	// up to the one with the given hash, which is traced
+	TraceTxn(block *types.Block, txHash types.Hash, tracer tracer.Tracer) error
+
+	// TraceCall traces the transaction applied on top of the header
+	TraceCall(txn *types.Transaction, header *types.Header, tracer tracer.Tracer) error
+}
+
+// Debug is the debug jsonrpc endpoint
+type Debug struct {
+	store debugStore
+
+	// eth resolves the block numbers and decodes the calls like the eth endpoint
+	eth *Eth
+}
+
+// TraceConfig is the configuration of the tracing, compatible with geth.
+// The struct logger is used if no tracer is set
+type TraceConfig struct {
+	Tracer           string          `json:"tracer"`
+	TracerConfig     json.RawMessage `json:"tracerConfig"`
+	Timeout          *string         `json:"timeout"`
+	EnableMemory     bool            `json:"enableMemory"`
+	DisableStack     bool            `json:"disableStack"`
+	DisableStorage   bool            `json:"disableStorage"`
+	EnableReturnData bool            `json:"enableReturnData"`
+}
+
+// txTraceResult is the trace of a transaction of a block
+type txTraceResult struct {
+	TxHash types.Hash  `json:"txHash"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// TraceTransaction replays the block of the transaction up to it, and returns its trace
+func (d *Debug) TraceTransaction(hash types.Hash, config *TraceConfig) (interface{}, error) {
+	blockHash, ok := d.store.ReadTxLookup(hash)
+	if !ok {
+		return nil, ErrTxnNotFound
+	}
+
+	block, ok := d.store.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	t, err := newTracer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	stop, err := startTraceTimeout(config, t)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	if err := d.store.TraceTxn(block, hash, t); err != nil {
+		return nil, err
+	}
+
+	return t.GetResult()
+}
+
+// TraceBlockByNumber replays the block with the given number, and returns the traces of its transactions
+func (d *Debug) TraceBlockByNumber(number BlockNumber, config *TraceConfig) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, d.eth)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := d.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	return d.traceBlock(block, config)
+}
+
+// TraceBlockByHash replays the block with the given hash, and returns the traces of its transactions
+func (d *Debug) TraceBlockByHash(hash types.Hash, config *TraceConfig) (interface{}, error) {
+	block, ok := d.store.GetBlockByHash(hash, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	return d.traceBlock(block, config)
+}
+
+// TraceCall executes the call on top of the given block, and returns its trace
+func (d *Debug) TraceCall(arg *txnArgs, filter BlockNumberOrHash, config *TraceConfig) (interface{}, error) {
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	header, err := d.eth.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := d.eth.decodeTxn(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if txn.Gas == 0 {
+		txn.Gas = header.GasLimit
+	}
+
+	t, err := newTracer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	stop, err := startTraceTimeout(config, t)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	if err := d.store.TraceCall(txn, header, t); err != nil {
+		return nil, err
+	}
+
+	return t.GetResult()
+}
+
+func (d *Debug) traceBlock(block *types.Block, config *TraceConfig) (interface{}, error) {
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	tracers := make([]tracer.Tracer, len(block.Transactions))
+
+	for i := range tracers {
+		t, err := newTracer(config)
+		if err != nil {
+			return nil, err
+		}
+
+		tracers[i] = t
+	}
+
+	stop, err := startTraceTimeout(config, tracers...)
+	if err != nil {
+		return nil, err
+	}
+	defer stop()
+
+	if err := d.store.TraceBlock(block, tracers); err != nil {
+		return nil, err
+	}
+
+	results := make([]*txTraceResult, len(block.Transactions))
+
+	for i, txn := range block.Transactions {
+		results[i] = &txTraceResult{
+			TxHash: txn.Hash,
+		}
+
+		if res, err := tracers[i].GetResult(); err != nil {
+			results[i].Error = err.Error()
+		} else {
+			results[i].Result = res
+		}
+	}
+
+	return results, nil
+}
+
+// newTracer creates the tracer set by the configuration
+func newTracer(config *TraceConfig) (tracer.Tracer, error) {
+	if config == nil {
+		config = &TraceConfig{}
+	}
+
+	switch config.Tracer {
+	case "":
+		return structtracer.NewStructTracer(structtracer.Config{
+			EnableMemory:     config.EnableMemory,
+			DisableStack:     config.DisableStack,
+			DisableStorage:   config.DisableStorage,
+			EnableReturnData: config.EnableReturnData,
+		}), nil
+
+	case callTracerName:
+		var callConfig calltracer.Config
+
+		if len(config.TracerConfig) > 0 {
+			if err := json.Unmarshal(config.TracerConfig, &callConfig); err != nil {
+				return nil, fmt.Errorf("invalid tracer config: %w", err)
+			}
+		}
+
+		return calltracer.NewCallTracer(callConfig), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrTracerNotSupported, config.Tracer)
+	}
+}
+
+// startTraceTimeout cancels the tracers once the timeout of the configuration expires,
+// the returned function stops the timer
+func startTraceTimeout(config *TraceConfig, tracers ...tracer.Tracer) (func() bool, error) {
+	timeout := defaultTraceTimeout
+
+	if config != nil && config.Timeout != nil {
+		var err error
+
+		if timeout, err = time.ParseDuration(*config.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+	}
+
+	timer := time.AfterFunc(timeout, func() {
+		for _, t := range tracers {
+			t.Cancel(ErrTraceTimeout)
+		}
+	})
+
+	return timer.Stop, nil
+}
@@ -0,0 +1,248 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer/calltracer"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer/structtracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDebugStore struct {
+	*mockBlockStore
+
+	// delay of the execution of each transaction
+	delay time.Duration
+
+	replayed   []types.Hash
+	traced     []types.Hash
+	callHeader *types.Header
+}
+
+func newMockDebugStore() *mockDebugStore {
+	store := &mockDebugStore{
+		mockBlockStore: newMockBlockStore(),
+	}
+
+	store.add(newTestBlock(0, hash1))
+
+	block := newTestBlock(1, hash2)
+	block.Transactions = []*types.Transaction{
+		newTestDebugTxn(hash3, addr1, 30000),
+		newTestDebugTxn(hash4, addr2, 50000),
+	}
+
+	store.add(block)
+
+	return store
+}
+
+func newTestDebugTxn(hash types.Hash, to types.Address, gas uint64) *types.Transaction {
+	return &types.Transaction{
+		Hash:  hash,
+		From:  addr0,
+		To:    &to,
+		Gas:   gas,
+		Value: big.NewInt(1),
+	}
+}
+
+// execute feeds the tracer with the events of a transaction calling its recipient, which stops
+func (m *mockDebugStore) execute(txn *types.Transaction, tr tracer.Tracer) {
+	time.Sleep(m.delay)
+
+	m.replayed = append(m.replayed, txn.Hash)
+
+	if tr == nil {
+		return
+	}
+
+	m.traced = append(m.traced, txn.Hash)
+
+	tr.CaptureTxStart(txn.Gas)
+	tr.CaptureEnter("CALL", txn.From, *txn.To, txn.Input, txn.Gas-21000, txn.Value)
+	tr.CaptureState(&tracer.Step{Op: "STOP", Gas: txn.Gas - 21000, Depth: 1}, nil)
+	tr.CaptureStateEnd(0)
+	tr.CaptureExit(nil, 0, nil)
+	tr.CaptureTxEnd(txn.Gas - 21000)
+}
+
+func (m *mockDebugStore) TraceBlock(block *types.Block, tracers []tracer.Tracer) error {
+	for i, txn := range block.Transactions {
+		m.execute(txn, tracers[i])
+	}
+
+	return nil
+}
+
+func (m *mockDebugStore) TraceTxn(block *types.Block, txHash types.Hash, tr tracer.Tracer) error {
+	for _, txn := range block.Transactions {
+		if txn.Hash == txHash {
+			m.execute(txn, tr)
+
+			return nil
+		}
+
+		m.execute(txn, nil)
+	}
+
+	return ErrTxnNotFound
+}
+
+func (m *mockDebugStore) TraceCall(txn *types.Transaction, header *types.Header, tr tracer.Tracer) error {
+	m.callHeader = header
+	m.execute(txn, tr)
+
+	return nil
+}
+
+func newTestDebugEndpoint(store *mockDebugStore) *Debug {
+	return &Debug{
+		store: store,
+		eth:   newTestEthEndpoint(store.mockBlockStore),
+	}
+}
+
+func TestDebug_TraceTransaction(t *testing.T) {
+	t.Parallel()
+
+	store := newMockDebugStore()
+	debug := newTestDebugEndpoint(store)
+
+	res, err := debug.TraceTransaction(hash4, nil)
+	assert.NoError(t, err)
+
+	// the transactions before the traced one are replayed
+	assert.Equal(t, []types.Hash{hash3, hash4}, store.replayed)
+	assert.Equal(t, []types.Hash{hash4}, store.traced)
+
+	result, ok := res.(*structtracer.StructTraceResult)
+	assert.True(t, ok)
+
+	assert.False(t, result.Failed)
+	assert.Equal(t, uint64(21000), result.Gas)
+	assert.Len(t, result.StructLogs, 1)
+	assert.Equal(t, "STOP", result.StructLogs[0].Op)
+
+	_, err = debug.TraceTransaction(types.StringToHash("5"), nil)
+	assert.ErrorIs(t, err, ErrTxnNotFound)
+}
+
+func TestDebug_TraceTransaction_GenesisBlock(t *testing.T) {
+	t.Parallel()
+
+	store := newMockDebugStore()
+	store.blocks[0].Transactions = []*types.Transaction{
+		newTestDebugTxn(types.StringToHash("5"), addr1, 21000),
+	}
+
+	debug := newTestDebugEndpoint(store)
+
+	_, err := debug.TraceTransaction(types.StringToHash("5"), nil)
+	assert.ErrorIs(t, err, ErrTraceGenesisBlock)
+	assert.Empty(t, store.replayed)
+}
+
+func TestDebug_TraceBlock(t *testing.T) {
+	t.Parallel()
+
+	store := newMockDebugStore()
+	debug := newTestDebugEndpoint(store)
+
+	config := &TraceConfig{
+		Tracer:       callTracerName,
+		TracerConfig: json.RawMessage(`{"onlyTopCall": true}`),
+	}
+
+	byNumber, err := debug.TraceBlockByNumber(LatestBlockNumber, config)
+	assert.NoError(t, err)
+
+	byHash, err := debug.TraceBlockByHash(hash2, config)
+	assert.NoError(t, err)
+
+	assert.Equal(t, byNumber, byHash)
+
+	results, ok := byNumber.([]*txTraceResult)
+	assert.True(t, ok)
+	assert.Len(t, results, 2)
+
+	for i, to := range []types.Address{addr1, addr2} {
+		assert.Equal(t, store.blocks[1].Transactions[i].Hash, results[i].TxHash)
+		assert.Empty(t, results[i].Error)
+
+		frame, ok := results[i].Result.(*calltracer.Frame)
+		assert.True(t, ok)
+
+		assert.Equal(t, "CALL", frame.Type)
+		assert.Equal(t, to, frame.To)
+		assert.Equal(t, "0x5208", frame.GasUsed)
+	}
+
+	_, err = debug.TraceBlockByNumber(0, config)
+	assert.ErrorIs(t, err, ErrTraceGenesisBlock)
+
+	_, err = debug.TraceBlockByHash(types.StringToHash("5"), config)
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+}
+
+func TestDebug_TraceBlock_Timeout(t *testing.T) {
+	t.Parallel()
+
+	store := newMockDebugStore()
+	store.delay = 50 * time.Millisecond
+
+	debug := newTestDebugEndpoint(store)
+
+	timeout := "1ms"
+
+	res, err := debug.TraceBlockByHash(hash2, &TraceConfig{Timeout: &timeout})
+	assert.NoError(t, err)
+
+	results, ok := res.([]*txTraceResult)
+	assert.True(t, ok)
+
+	for _, result := range results {
+		assert.Nil(t, result.Result)
+		assert.Equal(t, ErrTraceTimeout.Error(), result.Error)
+	}
+
+	invalid := "soon"
+
+	_, err = debug.TraceBlockByHash(hash2, &TraceConfig{Timeout: &invalid})
+	assert.Error(t, err)
+}
+
+func TestDebug_TraceCall(t *testing.T) {
+	t.Parallel()
+
+	store := newMockDebugStore()
+	debug := newTestDebugEndpoint(store)
+
+	arg := &txnArgs{
+		From:  &addr0,
+		To:    &addr1,
+		Nonce: argUintPtr(0),
+		Gas:   argUintPtr(40000),
+	}
+
+	res, err := debug.TraceCall(arg, BlockNumberOrHash{}, &TraceConfig{Tracer: callTracerName})
+	assert.NoError(t, err)
+
+	// the call is traced on top of the latest block by default
+	assert.Equal(t, store.blocks[1].Header, store.callHeader)
+
+	frame, ok := res.(*calltracer.Frame)
+	assert.True(t, ok)
+
+	assert.Equal(t, addr0, frame.From)
+	assert.Equal(t, addr1, frame.To)
+	assert.Equal(t, "0x9c40", frame.Gas)
+
+	_, err = debug.TraceCall(arg, BlockNumberOrHash{}, &TraceConfig{Tracer: "prestateTracer"})
+	assert.ErrorIs(t, err, ErrTracerNotSupported)
+}
@@ -0,0 +1,163 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+var (
+	ErrStorageValueTooLong = errors.New("storage value longer than 32 bytes")
+)
+
+// DevStore provides the control of the chain needed for the Dev endpoint,
+// it is implemented by the dev consensus engine
+type DevStore interface {
+	// Mine seals a new block right away, with the given timestamp if not zero
+	Mine(timestamp uint64) error
+
+	// IncreaseTime moves the clock of the next blocks forward, and returns the total offset
+	IncreaseTime(offset time.Duration) time.Duration
+
+	// SetNextBlockTimestamp sets the timestamp of the next block
+	SetNextBlockTimestamp(timestamp uint64) error
+
+	// SetAutomine pauses or resumes the sealing following the sealing policy
+	SetAutomine(enabled bool)
+
+	// Snapshot saves the current head, and returns the id to revert to it
+	Snapshot() uint64
+
+	// Revert sets the head saved by the snapshot with the given id
+	Revert(id uint64) (bool, error)
+
+	// SetBalance sets the balance of the account
+	SetBalance(addr types.Address, balance *big.Int) error
+
+	// SetCode sets the code of the account
+	SetCode(addr types.Address, code []byte) error
+
+	// SetStorageAt sets the storage slot of the account
+	SetStorageAt(addr types.Address, key, value types.Hash) error
+}
+
+// Dev is the evm and dev jsonrpc endpoint, controlling the chain of the dev consensus
+type Dev struct {
+	store DevStore
+}
+
+// argQuantity is a number sent either as a JSON number or as a hex string
+type argQuantity uint64
+
+func (q *argQuantity) UnmarshalJSON(data []byte) error {
+	var num uint64
+	if err := json.Unmarshal(data, &num); err == nil {
+		*q = argQuantity(num)
+
+		return nil
+	}
+
+	var hex argUint64
+	if err := json.Unmarshal(data, &hex); err != nil {
+		return err
+	}
+
+	*q = argQuantity(hex)
+
+	return nil
+}
+
+// Mine seals a new block, with the given timestamp if set
+func (d *Dev) Mine(timestamp *argQuantity) (interface{}, error) {
+	var blockTimestamp uint64
+	if timestamp != nil {
+		blockTimestamp = uint64(*timestamp)
+	}
+
+	if err := d.store.Mine(blockTimestamp); err != nil {
+		return nil, err
+	}
+
+	return "0x0", nil
+}
+
+// IncreaseTime moves the clock of the next blocks forward by the given seconds,
+// and returns the total offset in seconds
+func (d *Dev) IncreaseTime(seconds argQuantity) (interface{}, error) {
+	offset := d.store.IncreaseTime(time.Duration(seconds) * time.Second)
+
+	return int64(offset / time.Second), nil
+}
+
+// SetNextBlockTimestamp sets the timestamp of the next block
+func (d *Dev) SetNextBlockTimestamp(timestamp argQuantity) (interface{}, error) {
+	if err := d.store.SetNextBlockTimestamp(uint64(timestamp)); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// SetAutomine pauses or resumes the sealing of new blocks by the consensus
+func (d *Dev) SetAutomine(enabled bool) (interface{}, error) {
+	d.store.SetAutomine(enabled)
+
+	return true, nil
+}
+
+// Snapshot saves the current head, and returns the id to revert to it
+func (d *Dev) Snapshot() (interface{}, error) {
+	return argUint64(d.store.Snapshot()), nil
+}
+
+// Revert sets the head saved by the snapshot, and returns false if it doesn't exist
+func (d *Dev) Revert(id argQuantity) (interface{}, error) {
+	reverted, err := d.store.Revert(uint64(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return reverted, nil
+}
+
+// SetBalance seals a new block setting the balance of the account
+func (d *Dev) SetBalance(addr types.Address, balance argBig) (interface{}, error) {
+	b := big.Int(balance)
+
+	if err := d.store.SetBalance(addr, &b); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// SetCode seals a new block setting the code of the account
+func (d *Dev) SetCode(addr types.Address, code argBytes) (interface{}, error) {
+	if err := d.store.SetCode(addr, code); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// SetStorageAt seals a new block setting the storage slot of the account
+func (d *Dev) SetStorageAt(addr types.Address, position argBig, value argBytes) (interface{}, error) {
+	if len(value) > types.HashLength {
+		return nil, ErrStorageValueTooLong
+	}
+
+	key := big.Int(position)
+
+	if err := d.store.SetStorageAt(
+		addr,
+		types.BytesToHash(key.Bytes()),
+		types.BytesToHash(value),
+	); err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,222 @@
+package jsonrpc
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockDevStore struct {
+	mined      []uint64
+	timeOffset time.Duration
+	next       uint64
+	automine   bool
+	snapshots  []uint64
+
+	balances map[types.Address]*big.Int
+	codes    map[types.Address][]byte
+	storage  map[types.Address]map[types.Hash]types.Hash
+}
+
+func newMockDevStore() *mockDevStore {
+	return &mockDevStore{
+		automine: true,
+		balances: make(map[types.Address]*big.Int),
+		codes:    make(map[types.Address][]byte),
+		storage:  make(map[types.Address]map[types.Hash]types.Hash),
+	}
+}
+
+func (m *mockDevStore) Mine(timestamp uint64) error {
+	m.mined = append(m.mined, timestamp)
+
+	return nil
+}
+
+func (m *mockDevStore) IncreaseTime(offset time.Duration) time.Duration {
+	m.timeOffset += offset
+
+	return m.timeOffset
+}
+
+func (m *mockDevStore) SetNextBlockTimestamp(timestamp uint64) error {
+	m.next = timestamp
+
+	return nil
+}
+
+func (m *mockDevStore) SetAutomine(enabled bool) {
+	m.automine = enabled
+}
+
+func (m *mockDevStore) Snapshot() uint64 {
+	m.snapshots = append(m.snapshots, uint64(len(m.mined)))
+
+	return uint64(len(m.snapshots))
+}
+
+func (m *mockDevStore) Revert(id uint64) (bool, error) {
+	if id == 0 || id > uint64(len(m.snapshots)) {
+		return false, nil
+	}
+
+	m.mined = m.mined[:m.snapshots[id-1]]
+	m.snapshots = m.snapshots[:id-1]
+
+	return true, nil
+}
+
+func (m *mockDevStore) SetBalance(addr types.Address, balance *big.Int) error {
+	m.balances[addr] = balance
+
+	return nil
+}
+
+func (m *mockDevStore) SetCode(addr types.Address, code []byte) error {
+	m.codes[addr] = code
+
+	return nil
+}
+
+func (m *mockDevStore) SetStorageAt(addr types.Address, key, value types.Hash) error {
+	if m.storage[addr] == nil {
+		m.storage[addr] = make(map[types.Hash]types.Hash)
+	}
+
+	m.storage[addr][key] = value
+
+	return nil
+}
+
+func newTestDevDispatcher(devStore DevStore) *Dispatcher {
+	return newDispatcher(
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+			devStore:                devStore,
+		},
+	)
+}
+
+func TestDispatcher_DevEndpointRegistration(t *testing.T) {
+	t.Parallel()
+
+	// the namespaces are missing without the dev consensus
+	dispatcher := newTestDevDispatcher(nil)
+
+	for _, method := range []string{"evm_mine", "dev_mine"} {
+		_, err := dispatcher.handleReq(Request{Method: method, Params: []byte("[]")})
+		assert.Error(t, err)
+	}
+
+	store := newMockDevStore()
+	dispatcher = newTestDevDispatcher(store)
+
+	for _, method := range []string{"evm_mine", "dev_mine"} {
+		res, err := dispatcher.handleReq(Request{Method: method, Params: []byte("[]")})
+		assert.NoError(t, err)
+		assert.Equal(t, `"0x0"`, string(res))
+	}
+
+	assert.Equal(t, []uint64{0, 0}, store.mined)
+}
+
+func TestDevEndpoint_Time(t *testing.T) {
+	t.Parallel()
+
+	store := newMockDevStore()
+	dispatcher := newTestDevDispatcher(store)
+
+	// the timestamps are accepted both as numbers and hex strings
+	_, err := dispatcher.handleReq(Request{Method: "evm_mine", Params: []byte(`[1000]`)})
+	assert.NoError(t, err)
+
+	_, err = dispatcher.handleReq(Request{Method: "evm_mine", Params: []byte(`["0x3e9"]`)})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []uint64{1000, 1001}, store.mined)
+
+	_, err = dispatcher.handleReq(Request{Method: "evm_setNextBlockTimestamp", Params: []byte(`[2000]`)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2000), store.next)
+
+	_, err = dispatcher.handleReq(Request{Method: "evm_increaseTime", Params: []byte(`[60]`)})
+	assert.NoError(t, err)
+
+	res, err := dispatcher.handleReq(Request{Method: "evm_increaseTime", Params: []byte(`["0x3c"]`)})
+	assert.NoError(t, err)
+	assert.Equal(t, "120", string(res))
+	assert.Equal(t, 2*time.Minute, store.timeOffset)
+
+	_, err = dispatcher.handleReq(Request{Method: "evm_setAutomine", Params: []byte(`[false]`)})
+	assert.NoError(t, err)
+	assert.False(t, store.automine)
+}
+
+func TestDevEndpoint_SnapshotRevert(t *testing.T) {
+	t.Parallel()
+
+	store := newMockDevStore()
+	dev := &Dev{store}
+
+	id, err := dev.Snapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, argUint64(1), id)
+
+	_, err = dev.Mine(nil)
+	assert.NoError(t, err)
+
+	reverted, err := dev.Revert(argQuantity(1))
+	assert.NoError(t, err)
+	assert.Equal(t, true, reverted)
+	assert.Len(t, store.mined, 0)
+
+	// a snapshot can be reverted only once
+	reverted, err = dev.Revert(argQuantity(1))
+	assert.NoError(t, err)
+	assert.Equal(t, false, reverted)
+}
+
+func TestDevEndpoint_SetState(t *testing.T) {
+	t.Parallel()
+
+	store := newMockDevStore()
+	dispatcher := newTestDevDispatcher(store)
+
+	addr := types.StringToAddress("0x1")
+
+	_, err := dispatcher.handleReq(Request{
+		Method: "dev_setBalance",
+		Params: []byte(`["` + addr.String() + `", "0xde0b6b3a7640000"]`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, oneEther, store.balances[addr])
+
+	_, err = dispatcher.handleReq(Request{
+		Method: "dev_setCode",
+		Params: []byte(`["` + addr.String() + `", "0x6001"]`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x60, 0x01}, store.codes[addr])
+
+	_, err = dispatcher.handleReq(Request{
+		Method: "dev_setStorageAt",
+		Params: []byte(`["` + addr.String() + `", "0x2", "0x0100"]`),
+	})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		types.BytesToHash([]byte{0x01, 0x00}),
+		store.storage[addr][types.BytesToHash([]byte{0x02})],
+	)
+
+	// the storage values are 32 bytes at most
+	_, setErr := (&Dev{store}).SetStorageAt(addr, argBig{}, make(argBytes, types.HashLength+1))
+	assert.ErrorIs(t, setErr, ErrStorageValueTooLong)
+}
@@ -34,6 +34,9 @@ type endpoints struct {
 	Web3   *Web3
 	Net    *Net
 	TxPool *TxPool
+	Debug  *Debug
+	Trace  *Trace
+	Dev    *Dev
 }
 
 // Dispatcher handles all json rpc requests by delegating
@@ -45,6 +48,10 @@ type Dispatcher struct {
 	endpoints     endpoints
 
 	params *dispatcherParams
+
+	// rateLimiter limits the requests of the clients, nil if disabled
+	rateLimiter *rateLimiter
+	metrics     *Metrics
 }
 
 type dispatcherParams struct {
@@ -54,6 +61,15 @@ type dispatcherParams struct {
 	priceLimit              uint64
 	jsonRPCBatchLengthLimit uint64
 	blockRangeLimit         uint64
+
+	// devStore controls the chain of the dev consensus, nil if not active
+	devStore DevStore
+
+	// enableDebug enables the debug and trace endpoints, off by default as they replay whole blocks
+	enableDebug bool
+
+	rateLimit *RateLimitConfig
+	metrics   *Metrics
 }
 
 func newDispatcher(
@@ -62,8 +78,17 @@ func newDispatcher(
 	params *dispatcherParams,
 ) *Dispatcher {
 	d := &Dispatcher{
-		logger: logger.Named("dispatcher"),
-		params: params,
+		logger:  logger.Named("dispatcher"),
+		params:  params,
+		metrics: params.metrics,
+	}
+
+	if d.metrics == nil {
+		d.metrics = NilMetrics()
+	}
+
+	if params.rateLimit != nil && params.rateLimit.Rate > 0 {
+		d.rateLimiter = newRateLimiter(params.rateLimit)
 	}
 
 	if store != nil {
@@ -98,6 +123,29 @@ func (d *Dispatcher) registerEndpoints(store JSONRPCStore) {
 	d.registerService("net", d.endpoints.Net)
 	d.registerService("web3", d.endpoints.Web3)
 	d.registerService("txpool", d.endpoints.TxPool)
+
+	if d.params.enableDebug {
+		d.endpoints.Debug = &Debug{
+			store,
+			d.endpoints.Eth,
+		}
+		d.endpoints.Trace = &Trace{
+			store,
+			d.endpoints.Eth,
+			d.params.blockRangeLimit,
+		}
+
+		d.registerService("debug", d.endpoints.Debug)
+		d.registerService("trace", d.endpoints.Trace)
+	}
+
+	if d.params.devStore != nil {
+		d.endpoints.Dev = &Dev{d.params.devStore}
+
+		// the same methods are served under the namespaces of the common dev tools
+		d.registerService("evm", d.endpoints.Dev)
+		d.registerService("dev", d.endpoints.Dev)
+	}
 }
 
 func (d *Dispatcher) getFnHandler(req Request) (*serviceData, *funcData, Error) {
@@ -170,6 +218,18 @@ func (d *Dispatcher) handleSubscribe(req Request, conn wsConn) (string, Error) {
 			return "", NewInternalError(err.Error())
 		}
 		filterID = d.filterManager.NewLogFilter(logQuery, conn)
+	} else if subscribeMethod == "newPendingTransactions" {
+		// the full transactions are sent if the optional flag is set
+		var fullTx bool
+		if len(params) > 1 {
+			if fullTx, ok = params[1].(bool); !ok {
+				return "", NewInvalidParamsError("Invalid params")
+			}
+		}
+
+		filterID = d.filterManager.NewPendingTxFilter(fullTx, conn)
+	} else if subscribeMethod == "syncing" {
+		filterID = d.filterManager.NewSyncingFilter(conn)
 	} else {
 		return "", NewSubscriptionNotFoundError(subscribeMethod)
 	}
@@ -199,12 +259,23 @@ func (d *Dispatcher) RemoveFilterByWs(conn wsConn) {
 	d.filterManager.RemoveFilterByWs(conn)
 }
 
-func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
+// HandleWs handles the request of the web socket connection,
+// the client being the key of its rate limiting, if any
+func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn, client string) ([]byte, error) {
 	var req Request
 	if err := json.Unmarshal(reqBody, &req); err != nil {
 		return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 	}
 
+	req.client = client
+
+	// the subscriptions bypass the handler of the methods, but are limited as well
+	if req.Method == "eth_subscribe" || req.Method == "eth_unsubscribe" {
+		if err := d.checkRateLimit(req); err != nil {
+			return NewRPCResponse(req.ID, "2.0", nil, err).Bytes()
+		}
+	}
+
 	// if the request method is eth_subscribe we need to create a
 	// new filter with ws connection
 	if req.Method == "eth_subscribe" {
@@ -250,7 +321,9 @@ func (d *Dispatcher) HandleWs(reqBody []byte, conn wsConn) ([]byte, error) {
 	return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
 }
 
-func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
+// Handle handles the request, or batch of requests,
+// the client being the key of its rate limiting, if any
+func (d *Dispatcher) Handle(reqBody []byte, client string) ([]byte, error) {
 	x := bytes.TrimLeft(reqBody, " \t\r\n")
 	if len(x) == 0 {
 		return NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
@@ -266,6 +339,8 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 			return NewRPCResponse(req.ID, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
 		}
 
+		req.client = client
+
 		resp, err := d.handleReq(req)
 
 		return NewRPCResponse(req.ID, "2.0", resp, err).Bytes()
@@ -295,6 +370,8 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	responses := make([]Response, 0)
 
 	for _, req := range requests {
+		req.client = client
+
 		var response, err = d.handleReq(req)
 		if err != nil {
 			errorResponse := NewRPCResponse(req.ID, "2.0", nil, err)
@@ -315,6 +392,17 @@ func (d *Dispatcher) Handle(reqBody []byte) ([]byte, error) {
 	return respBytes, nil
 }
 
+// checkRateLimit charges the request to the rate limit of its client, if any
+func (d *Dispatcher) checkRateLimit(req Request) Error {
+	if d.rateLimiter != nil && req.client != "" && !d.rateLimiter.allow(req.client, req.Method) {
+		d.metrics.RateLimitedRequests.With("method", req.Method).Add(1)
+
+		return NewLimitExceededError(req.Method)
+	}
+
+	return nil
+}
+
 func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 	d.logger.Debug("request", "method", req.Method, "id", req.ID)
 
@@ -323,6 +411,11 @@ func (d *Dispatcher) handleReq(req Request) ([]byte, Error) {
 		return nil, ferr
 	}
 
+	// the unknown methods are rejected before, so that they are not metric labels
+	if err := d.checkRateLimit(req); err != nil {
+		return nil, err
+	}
+
 	inArgs := make([]reflect.Value, fd.inNum)
 	inArgs[0] = service.sv
 
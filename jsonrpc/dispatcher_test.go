@@ -79,7 +79,7 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 		"method": "eth_subscribe",
 		"params": ["newHeads"]
 	}`)
-		if _, err := dispatcher.HandleWs(req, mockConnection); err != nil {
+		if _, err := dispatcher.HandleWs(req, mockConnection, ""); err != nil {
 			t.Fatal(err)
 		}
 
@@ -99,6 +99,58 @@ func TestDispatcher_HandleWebsocketConnection_EthSubscribe(t *testing.T) {
 			t.Fatal("\"newHeads\" event not received in 2 seconds")
 		}
 	})
+
+	t.Run("clients should be able to receive \"newPendingTransactions\" event thru eth_subscribe", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockStore()
+		dispatcher := newDispatcher(
+			hclog.NewNullLogger(),
+			store,
+			&dispatcherParams{
+				chainID:                 0,
+				priceLimit:              0,
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+			},
+		)
+
+		mockConnection, msgCh := newMockWsConnWithMsgCh()
+
+		// the full transactions flag must be a boolean
+		resp, err := dispatcher.HandleWs([]byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", "true"]
+	}`), mockConnection, "")
+		assert.NoError(t, err)
+		assert.Contains(t, string(resp), "Invalid params")
+
+		if _, err := dispatcher.HandleWs([]byte(`{
+		"method": "eth_subscribe",
+		"params": ["newPendingTransactions", true]
+	}`), mockConnection, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		tx := &types.Transaction{
+			Nonce:    1,
+			GasPrice: big.NewInt(10),
+			Value:    big.NewInt(0),
+			V:        big.NewInt(1),
+			R:        big.NewInt(1),
+			S:        big.NewInt(1),
+		}
+		tx.ComputeHash()
+
+		store.emitTxEvent(tx)
+
+		select {
+		case msg := <-msgCh:
+			assert.Contains(t, string(msg), `"nonce":"0x1"`)
+		case <-time.After(2 * time.Second):
+			t.Fatal("\"newPendingTransactions\" event not received in 2 seconds")
+		}
+	})
 }
 
 func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
@@ -161,7 +213,7 @@ func TestDispatcher_WebsocketConnection_RequestFormats(t *testing.T) {
 		},
 	}
 	for _, c := range cases {
-		data, err := dispatcher.HandleWs(c.msg, mockConnection)
+		data, err := dispatcher.HandleWs(c.msg, mockConnection, "")
 		resp := new(SuccessResponse)
 		merr := json.Unmarshal(data, resp)
 
@@ -288,9 +340,42 @@ func TestDispatcherFuncDecode(t *testing.T) {
 	}
 }
 
+func TestDispatcher_DebugEndpointRegistration(t *testing.T) {
+	t.Parallel()
+
+	newTestDebugDispatcher := func(enableDebug bool) *Dispatcher {
+		return newDispatcher(
+			hclog.NewNullLogger(),
+			newMockStore(),
+			&dispatcherParams{
+				jsonRPCBatchLengthLimit: 20,
+				blockRangeLimit:         1000,
+				enableDebug:             enableDebug,
+			},
+		)
+	}
+
+	methods := []string{"debug_traceTransaction", "trace_block"}
+
+	// the namespaces are missing unless enabled
+	dispatcher := newTestDebugDispatcher(false)
+
+	for _, method := range methods {
+		_, _, err := dispatcher.getFnHandler(Request{Method: method})
+		assert.IsType(t, &methodNotFoundError{}, err, method)
+	}
+
+	dispatcher = newTestDebugDispatcher(true)
+
+	for _, method := range methods {
+		_, _, err := dispatcher.getFnHandler(Request{Method: method})
+		assert.Nil(t, err, method)
+	}
+}
+
 func TestDispatcherBatchRequest(t *testing.T) {
 	handle := func(dispatcher *Dispatcher, reqBody []byte) []byte {
-		res, _ := dispatcher.Handle(reqBody)
+		res, _ := dispatcher.Handle(reqBody, "")
 
 		return res
 	}
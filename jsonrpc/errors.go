@@ -76,9 +76,41 @@ func (e *methodNotFoundError) ErrorCode() int {
 	return -32601
 }
 
+type methodNotAllowedError struct {
+	err string
+}
+
+func (e *methodNotAllowedError) Error() string {
+	return e.err
+}
+
+func (e *methodNotAllowedError) ErrorCode() int {
+	return -32601
+}
+
+type limitExceededError struct {
+	err string
+}
+
+func (e *limitExceededError) Error() string {
+	return e.err
+}
+
+func (e *limitExceededError) ErrorCode() int {
+	return -32005
+}
+
 func NewMethodNotFoundError(method string) *methodNotFoundError {
 	return &methodNotFoundError{fmt.Sprintf("the method %s does not exist/is not available", method)}
 }
+
+func NewMethodNotAllowedError(method string) *methodNotAllowedError {
+	return &methodNotAllowedError{fmt.Sprintf("the method %s is not allowed", method)}
+}
+
+func NewLimitExceededError(method string) *limitExceededError {
+	return &limitExceededError{fmt.Sprintf("rate limit exceeded for the method %s", method)}
+}
 func NewInvalidRequestError(msg string) *invalidRequestError {
 	return &invalidRequestError{msg}
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
 	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/txpool/proto"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -286,6 +287,119 @@ func TestEth_GasPrice(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("0x%x", store.averageGasPrice), res)
 }
 
+// newTestFeeBlock creates a london block with dynamic fee transactions paying the given tips
+func newTestFeeBlock(store *mockBlockStore, number, baseFee uint64, tips ...int64) {
+	block := newTestBlock(number, types.StringToHash(fmt.Sprintf("%d", number+1)))
+	block.Header.BaseFee = baseFee
+	block.Header.GasLimit = 100000
+
+	receipts := make([]*types.Receipt, len(tips))
+
+	for i, tip := range tips {
+		block.Transactions = append(block.Transactions, &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: new(big.Int).SetUint64(baseFee + uint64(tip)),
+		})
+
+		receipts[i] = &types.Receipt{GasUsed: 21000}
+		block.Header.GasUsed += 21000
+	}
+
+	store.receipts[block.Hash()] = receipts
+	store.add(block)
+}
+
+func TestEth_FeeHistory(t *testing.T) {
+	t.Parallel()
+
+	store := newMockBlockStore()
+	store.nextBaseFee = 105
+
+	newTestFeeBlock(store, 0, 0)
+	newTestFeeBlock(store, 1, 100, 1, 2, 3, 4)
+	newTestFeeBlock(store, 2, 110)
+	newTestFeeBlock(store, 3, 100, 5)
+
+	eth := newTestEthEndpoint(store)
+
+	t.Run("returns the fee history of the latest blocks", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.FeeHistory(3, LatestBlockNumber, []float64{0, 50, 100})
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		history := res.(*feeHistoryResult)
+
+		assert.Equal(t, argUint64(1), history.OldestBlock)
+		assert.Equal(t, []argUint64{100, 110, 100, 105}, history.BaseFeePerGas)
+		assert.Equal(t, []float64{0.84, 0, 0.21}, history.GasUsedRatio)
+		assert.Equal(t, [][]argBig{
+			{argBig(*big.NewInt(1)), argBig(*big.NewInt(2)), argBig(*big.NewInt(4))},
+			{argBig(*big.NewInt(0)), argBig(*big.NewInt(0)), argBig(*big.NewInt(0))},
+			{argBig(*big.NewInt(5)), argBig(*big.NewInt(5)), argBig(*big.NewInt(5))},
+		}, history.Reward)
+	})
+
+	t.Run("caps the block count at the genesis", func(t *testing.T) {
+		t.Parallel()
+
+		res, err := eth.FeeHistory(10, BlockNumber(1), nil)
+		assert.NoError(t, err)
+
+		//nolint:forcetypeassert
+		history := res.(*feeHistoryResult)
+
+		assert.Equal(t, argUint64(0), history.OldestBlock)
+		assert.Len(t, history.GasUsedRatio, 2)
+		assert.Len(t, history.BaseFeePerGas, 3)
+		assert.Nil(t, history.Reward)
+	})
+
+	t.Run("rejects invalid arguments", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := eth.FeeHistory(0, LatestBlockNumber, nil)
+		assert.ErrorIs(t, err, ErrInvalidBlockCount)
+
+		_, err = eth.FeeHistory(1, LatestBlockNumber, []float64{50, 10})
+		assert.ErrorIs(t, err, ErrInvalidRewardPercentile)
+
+		_, err = eth.FeeHistory(1, LatestBlockNumber, []float64{101})
+		assert.ErrorIs(t, err, ErrInvalidRewardPercentile)
+	})
+}
+
+func TestEth_MaxPriorityFeePerGas(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns the percentile of the recent tips", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		newTestFeeBlock(store, 0, 0)
+		newTestFeeBlock(store, 1, 100, 1, 2, 3, 4)
+		newTestFeeBlock(store, 2, 100, 5, 6)
+
+		res, err := newTestEthEndpoint(store).MaxPriorityFeePerGas()
+		assert.NoError(t, err)
+		assert.Equal(t, argBigPtr(big.NewInt(4)), res)
+	})
+
+	t.Run("returns the price limit if it is higher", func(t *testing.T) {
+		t.Parallel()
+
+		store := newMockBlockStore()
+		newTestFeeBlock(store, 0, 0)
+		newTestFeeBlock(store, 1, 100, 1)
+
+		res, err := newTestEthEndpointWithPriceLimit(store, 10).MaxPriorityFeePerGas()
+		assert.NoError(t, err)
+		assert.Equal(t, argBigPtr(big.NewInt(10)), res)
+	})
+}
+
 func TestEth_Call(t *testing.T) {
 	t.Parallel()
 
@@ -345,6 +459,7 @@ type mockBlockStore struct {
 	receipts        map[types.Hash][]*types.Receipt
 	isSyncing       bool
 	averageGasPrice int64
+	nextBaseFee     uint64
 	ethCallError    error
 }
 
@@ -507,6 +622,10 @@ func (m *mockBlockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bo
 	return nil, false
 }
 
+func (m *mockBlockStore) IsPrivateTx(txHash types.Hash) bool {
+	return false
+}
+
 func (m *mockBlockStore) GetSyncProgression() *progress.Progression {
 	if m.isSyncing {
 		return &progress.Progression{
@@ -524,6 +643,10 @@ func (m *mockBlockStore) GetAvgGasPrice() *big.Int {
 	return big.NewInt(m.averageGasPrice)
 }
 
+func (m *mockBlockStore) CalculateBaseFee(parent *types.Header) uint64 {
+	return m.nextBaseFee
+}
+
 func (m *mockBlockStore) ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error) {
 	return &runtime.ExecutionResult{Err: m.ethCallError}, nil
 }
@@ -532,6 +655,10 @@ func (m *mockBlockStore) SubscribeEvents() blockchain.Subscription {
 	return nil
 }
 
+func (m *mockBlockStore) SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	return nil, func() {}
+}
+
 func newTestBlock(number uint64, hash types.Hash) *types.Block {
 	return &types.Block{
 		Header: &types.Header{
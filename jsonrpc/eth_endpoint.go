@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
+	"sort"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/umbracle/fastrlp"
@@ -24,6 +26,12 @@ type ethTxPoolStore interface {
 	// AddTx adds a new transaction to the tx pool
 	AddTx(tx *types.Transaction) error
 
+	// AddPrivateTx adds a new transaction to the tx pool, without gossiping it
+	AddPrivateTx(tx *types.Transaction) error
+
+	// AddBundle adds a bundle of transactions to the tx pool, targeting the given block
+	AddBundle(txs []*types.Transaction, blockNumber, minTimestamp, maxTimestamp uint64) (types.Hash, error)
+
 	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
 	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
 }
@@ -33,9 +41,23 @@ type ethStateStore interface {
 	GetStorage(root types.Hash, addr types.Address, slot types.Hash) ([]byte, error)
 	GetForksInTime(blockNumber uint64) chain.ForksInTime
 	GetCode(hash types.Hash) ([]byte, error)
+
+	// GetProof returns the merkle proof of the key in the trie with the given root
+	GetProof(root types.Hash, key []byte) ([][]byte, error)
+}
+
+// finalityStore provides the headers marked as finalized and safe by the consensus
+type finalityStore interface {
+	// FinalizedHeader returns the latest finalized header, if any
+	FinalizedHeader() (*types.Header, bool)
+
+	// SafeHeader returns the latest safe header, if any
+	SafeHeader() (*types.Header, bool)
 }
 
 type ethBlockchainStore interface {
+	finalityStore
+
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
@@ -57,9 +79,19 @@ type ethBlockchainStore interface {
 	// GetAvgGasPrice returns the average gas price
 	GetAvgGasPrice() *big.Int
 
+	// CalculateBaseFee returns the base fee of the block following the given parent
+	CalculateBaseFee(parent *types.Header) uint64
+
 	// ApplyTxn applies a transaction object to the blockchain
 	ApplyTxn(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
 
+	// ApplyTxnWithAccessList applies a transaction object to the blockchain,
+	// and returns the accounts and storage slots accessed by the transaction
+	ApplyTxnWithAccessList(
+		header *types.Header,
+		txn *types.Transaction,
+	) (*runtime.ExecutionResult, types.TxAccessList, error)
+
 	// GetSyncProgression retrieves the current sync progression, if any
 	GetSyncProgression() *progress.Progression
 }
@@ -81,9 +113,30 @@ type Eth struct {
 }
 
 var (
-	ErrInsufficientFunds = errors.New("insufficient funds for execution")
+	ErrInsufficientFunds      = errors.New("insufficient funds for execution")
+	ErrFinalizedBlockNotFound = errors.New("finalized block not found")
+	ErrSafeBlockNotFound      = errors.New("safe block not found")
 )
 
+// getFinalityHeader returns the header the finalized or safe block tag refers to
+func getFinalityHeader(store finalityStore, number BlockNumber) (*types.Header, error) {
+	if number == FinalizedBlockNumber {
+		header, ok := store.FinalizedHeader()
+		if !ok {
+			return nil, ErrFinalizedBlockNotFound
+		}
+
+		return header, nil
+	}
+
+	header, ok := store.SafeHeader()
+	if !ok {
+		return nil, ErrSafeBlockNotFound
+	}
+
+	return header, nil
+}
+
 // ChainId returns the chain id of the client
 //
 //nolint:stylecheck
@@ -117,12 +170,7 @@ func (e *Eth) getHeaderFromBlockNumberOrHash(bnh *BlockNumberOrHash) (*types.Hea
 func (e *Eth) Syncing() (interface{}, error) {
 	if syncProgression := e.store.GetSyncProgression(); syncProgression != nil {
 		// Node is bulk syncing, return the status
-		return progression{
-			Type:          string(syncProgression.SyncType),
-			StartingBlock: hex.EncodeUint64(syncProgression.StartingBlock),
-			CurrentBlock:  hex.EncodeUint64(syncProgression.CurrentBlock),
-			HighestBlock:  hex.EncodeUint64(syncProgression.HighestBlock),
-		}, nil
+		return *toProgression(syncProgression), nil
 	}
 
 	// Node is not bulk syncing
@@ -140,6 +188,14 @@ func GetNumericBlockNumber(number BlockNumber, e *Eth) (uint64, error) {
 	case PendingBlockNumber:
 		return 0, fmt.Errorf("fetching the pending header is not supported")
 
+	case FinalizedBlockNumber, SafeBlockNumber:
+		header, err := getFinalityHeader(e.store, number)
+		if err != nil {
+			return 0, err
+		}
+
+		return header.Number, nil
+
 	default:
 		if number < 0 {
 			return 0, fmt.Errorf("invalid argument 0: block number larger than int64")
@@ -202,6 +258,67 @@ func (e *Eth) BlockNumber() (interface{}, error) {
 
 // SendRawTransaction sends a raw transaction
 func (e *Eth) SendRawTransaction(input string) (interface{}, error) {
+	tx, err := decodeRawTransaction(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.store.AddTx(tx); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// SendPrivateRawTransaction sends a raw transaction which is not gossiped to the network,
+// staying on this node until it's included or it expires
+func (e *Eth) SendPrivateRawTransaction(input string) (interface{}, error) {
+	tx, err := decodeRawTransaction(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.store.AddPrivateTx(tx); err != nil {
+		return nil, err
+	}
+
+	return tx.Hash.String(), nil
+}
+
+// SendBundle sends a bundle of raw transactions, which are included in the target block
+// in their order, all of them or none. The block timestamp is bounded by the optional
+// min and max timestamps
+func (e *Eth) SendBundle(args *bundleArgs) (interface{}, error) {
+	txs := make([]*types.Transaction, len(args.Txs))
+
+	for i, raw := range args.Txs {
+		tx := &types.Transaction{}
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return nil, fmt.Errorf("unable to decode transaction %d, %w", i, err)
+		}
+
+		txs[i] = tx.ComputeHash()
+	}
+
+	var minTimestamp, maxTimestamp uint64
+
+	if args.MinTimestamp != nil {
+		minTimestamp = *args.MinTimestamp
+	}
+
+	if args.MaxTimestamp != nil {
+		maxTimestamp = *args.MaxTimestamp
+	}
+
+	hash, err := e.store.AddBundle(txs, uint64(args.BlockNumber), minTimestamp, maxTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bundleResult{BundleHash: hash}, nil
+}
+
+func decodeRawTransaction(input string) (*types.Transaction, error) {
 	buf, decodeErr := hex.DecodeHex(input)
 	if decodeErr != nil {
 		return nil, fmt.Errorf("unable to decode input, %w", decodeErr)
@@ -214,11 +331,7 @@ func (e *Eth) SendRawTransaction(input string) (interface{}, error) {
 
 	tx.ComputeHash()
 
-	if err := e.store.AddTx(tx); err != nil {
-		return nil, err
-	}
-
-	return tx.Hash.String(), nil
+	return tx, nil
 }
 
 // SendTransaction rejects eth_sendTransaction json-rpc call as we don't support wallet management
@@ -251,12 +364,7 @@ func (e *Eth) GetTransactionByHash(hash types.Hash) (interface{}, error) {
 		// Find the transaction within the block
 		for idx, txn := range block.Transactions {
 			if txn.Hash == hash {
-				return toTransaction(
-					txn,
-					argUintPtr(block.Number()),
-					argHashPtr(block.Hash()),
-					&idx,
-				)
+				return toSealedTransaction(txn, block, idx)
 			}
 		}
 
@@ -364,6 +472,7 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 	}
 
 	res := &receipt{
+		Type:              argUint64(raw.TransactionType),
 		Root:              raw.Root,
 		CumulativeGasUsed: argUint64(raw.CumulativeGasUsed),
 		LogsBloom:         raw.LogsBloom,
@@ -373,6 +482,7 @@ func (e *Eth) GetTransactionReceipt(hash types.Hash) (interface{}, error) {
 		BlockHash:         block.Hash(),
 		BlockNumber:       argUint64(block.Number()),
 		GasUsed:           argUint64(raw.GasUsed),
+		EffectiveGasPrice: argBig(*txn.EffectiveGasPrice(block.Header.BaseFee)),
 		ContractAddress:   raw.ContractAddress,
 		FromAddr:          txn.From,
 		ToAddr:            txn.To,
@@ -441,6 +551,181 @@ func (e *Eth) GasPrice() (string, error) {
 	return hex.EncodeUint64(common.Max(e.priceLimit, avgGasPrice)), nil
 }
 
+const (
+	// maxFeeHistory is the maximum number of blocks eth_feeHistory reports on
+	maxFeeHistory = 1024
+
+	// priorityFeeBlocks is the number of recent blocks sampled to suggest a priority fee
+	priorityFeeBlocks = 20
+
+	// priorityFeePercentile is the percentile of the sampled tips suggested as the priority fee
+	priorityFeePercentile = 60
+)
+
+var (
+	ErrInvalidBlockCount       = errors.New("block count must be greater than zero")
+	ErrInvalidRewardPercentile = errors.New("reward percentiles must be ascending values between 0 and 100")
+)
+
+type feeHistoryResult struct {
+	OldestBlock   argUint64   `json:"oldestBlock"`
+	BaseFeePerGas []argUint64 `json:"baseFeePerGas"`
+	GasUsedRatio  []float64   `json:"gasUsedRatio"`
+	Reward        [][]argBig  `json:"reward,omitempty"`
+}
+
+// MaxPriorityFeePerGas returns a suggestion for the priority fee of dynamic fee transactions,
+// based on the tips paid in the recent blocks
+func (e *Eth) MaxPriorityFeePerGas() (interface{}, error) {
+	header := e.store.Header()
+	tips := make([]*big.Int, 0)
+
+	for i := uint64(0); i < priorityFeeBlocks && i <= header.Number; i++ {
+		block, ok := e.store.GetBlockByNumber(header.Number-i, true)
+		if !ok {
+			break
+		}
+
+		for _, txn := range block.Transactions {
+			tips = append(tips, txn.EffectiveTip(block.Header.BaseFee))
+		}
+	}
+
+	tip := new(big.Int).SetUint64(e.priceLimit)
+
+	if len(tips) > 0 {
+		sort.Slice(tips, func(i, j int) bool {
+			return tips[i].Cmp(tips[j]) < 0
+		})
+
+		if suggested := tips[(len(tips)-1)*priorityFeePercentile/100]; suggested.Cmp(tip) > 0 {
+			tip = suggested
+		}
+	}
+
+	return argBigPtr(tip), nil
+}
+
+// FeeHistory returns the base fees, the gas used ratios and the requested percentiles
+// of the effective tips of a range of blocks ending with the newest block
+func (e *Eth) FeeHistory(
+	blockCount argUint64,
+	newestBlock BlockNumber,
+	rewardPercentiles []float64,
+) (interface{}, error) {
+	if blockCount == 0 {
+		return nil, ErrInvalidBlockCount
+	}
+
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 || (i > 0 && p < rewardPercentiles[i-1]) {
+			return nil, ErrInvalidRewardPercentile
+		}
+	}
+
+	newest, err := e.getBlockHeader(newestBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	count := common.Min(uint64(blockCount), maxFeeHistory)
+	if count > newest.Number+1 {
+		count = newest.Number + 1
+	}
+
+	oldest := newest.Number + 1 - count
+	result := &feeHistoryResult{
+		OldestBlock:   argUint64(oldest),
+		BaseFeePerGas: make([]argUint64, 0, count+1),
+		GasUsedRatio:  make([]float64, 0, count),
+	}
+
+	for number := oldest; number <= newest.Number; number++ {
+		block, ok := e.store.GetBlockByNumber(number, true)
+		if !ok {
+			return nil, fmt.Errorf("unable to get block %d", number)
+		}
+
+		result.BaseFeePerGas = append(result.BaseFeePerGas, argUint64(block.Header.BaseFee))
+
+		ratio := float64(0)
+		if block.Header.GasLimit > 0 {
+			ratio = float64(block.Header.GasUsed) / float64(block.Header.GasLimit)
+		}
+
+		result.GasUsedRatio = append(result.GasUsedRatio, ratio)
+
+		if len(rewardPercentiles) > 0 {
+			rewards, err := e.blockRewards(block, rewardPercentiles)
+			if err != nil {
+				return nil, err
+			}
+
+			result.Reward = append(result.Reward, rewards)
+		}
+	}
+
+	// The base fee of the block following the newest one is known in advance
+	result.BaseFeePerGas = append(result.BaseFeePerGas, argUint64(e.store.CalculateBaseFee(newest)))
+
+	return result, nil
+}
+
+// blockRewards returns the effective tips paid at the given percentiles of the gas used in the block
+func (e *Eth) blockRewards(block *types.Block, percentiles []float64) ([]argBig, error) {
+	rewards := make([]argBig, len(percentiles))
+	for i := range rewards {
+		rewards[i] = argBig(*big.NewInt(0))
+	}
+
+	if len(block.Transactions) == 0 {
+		return rewards, nil
+	}
+
+	receipts, err := e.store.GetReceiptsByHash(block.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("unable to get receipts of block %d", block.Number())
+	}
+
+	type txnTip struct {
+		tip     *big.Int
+		gasUsed uint64
+	}
+
+	tips := make([]txnTip, len(block.Transactions))
+	for i, txn := range block.Transactions {
+		tips[i] = txnTip{
+			tip:     txn.EffectiveTip(block.Header.BaseFee),
+			gasUsed: receipts[i].GasUsed,
+		}
+	}
+
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].tip.Cmp(tips[j].tip) < 0
+	})
+
+	var (
+		index   = 0
+		sumUsed = tips[0].gasUsed
+	)
+
+	for i, p := range percentiles {
+		threshold := uint64(float64(block.Header.GasUsed) * p / 100)
+		for sumUsed < threshold && index < len(tips)-1 {
+			index++
+			sumUsed += tips[index].gasUsed
+		}
+
+		rewards[i] = argBig(*tips[index].tip)
+	}
+
+	return rewards, nil
+}
+
 // Call executes a smart contract call using the transaction object data
 func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
 	var (
@@ -486,6 +771,75 @@ func (e *Eth) Call(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error)
 	return argBytesPtr(result.ReturnValue), nil
 }
 
+// accessListResult is the access list created for a transaction
+type accessListResult struct {
+	AccessList types.TxAccessList `json:"accessList"`
+	GasUsed    argUint64          `json:"gasUsed"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// CreateAccessList creates the access list of the accounts and storage slots accessed by a transaction,
+// and returns the gas used by the transaction with the access list (EIP-2930)
+func (e *Eth) CreateAccessList(arg *txnArgs, filter BlockNumberOrHash) (interface{}, error) {
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header from block hash or block number")
+	}
+
+	transaction, err := e.decodeTxn(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if transaction.Gas == 0 {
+		transaction.Gas = header.GasLimit
+	}
+
+	// the dynamic fee transactions have an access list too
+	if transaction.Type != types.DynamicFeeTx {
+		transaction.Type = types.AccessListTx
+	}
+
+	transaction.ChainID = new(big.Int).SetUint64(e.chainID)
+
+	accessList := transaction.AccessList
+	if accessList == nil {
+		accessList = types.TxAccessList{}
+	}
+
+	// The accessed entries change with the access list, as the gas available to the
+	// execution does. The transaction is applied until the access list is stable
+	for {
+		transaction.AccessList = accessList
+
+		result, accessed, err := e.store.ApplyTxnWithAccessList(header, transaction)
+		if err != nil {
+			return nil, err
+		}
+
+		if reflect.DeepEqual(accessed, accessList) {
+			res := &accessListResult{
+				AccessList: accessed,
+				GasUsed:    argUint64(result.GasUsed),
+			}
+
+			if result.Failed() {
+				res.Error = result.Err.Error()
+			}
+
+			return res, nil
+		}
+
+		accessList = accessed
+	}
+}
+
 // EstimateGas estimates the gas needed to execute a transaction
 func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error) {
 	transaction, err := e.decodeTxn(arg)
@@ -526,7 +880,7 @@ func (e *Eth) EstimateGas(arg *txnArgs, rawNum *BlockNumber) (interface{}, error
 		highEnd = header.GasLimit
 	}
 
-	gasPriceInt := new(big.Int).Set(transaction.GasPrice)
+	gasPriceInt := new(big.Int).Set(transaction.GetGasFeeCap())
 	valueInt := new(big.Int).Set(transaction.Value)
 
 	var availableBalance *big.Int
@@ -796,6 +1150,90 @@ func (e *Eth) GetCode(address types.Address, filter BlockNumberOrHash) (interfac
 	return argBytesPtr(code), nil
 }
 
+// GetProof returns the merkle proof of the account and of the given slots of its storage (EIP-1186)
+func (e *Eth) GetProof(
+	address types.Address,
+	storageKeys []types.Hash,
+	filter BlockNumberOrHash,
+) (interface{}, error) {
+	// The filter is empty, use the latest block by default
+	if filter.BlockNumber == nil && filter.BlockHash == nil {
+		filter.BlockNumber, _ = createBlockNumberPointer("latest")
+	}
+
+	header, err := e.getHeaderFromBlockNumberOrHash(&filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header from block hash or block number")
+	}
+
+	acc, err := e.store.GetAccount(header.StateRoot, address)
+	if errors.Is(err, ErrStateNotFound) {
+		// the proof shows the account doesn't exist, its fields are the default ones
+		acc = &state.Account{
+			Balance:  big.NewInt(0),
+			Root:     types.EmptyRootHash,
+			CodeHash: types.EmptyCodeHash.Bytes(),
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	accountProof, err := e.store.GetProof(header.StateRoot, address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &accountProofResult{
+		Address:      address,
+		AccountProof: toArgBytesList(accountProof),
+		Balance:      *argBigPtr(acc.Balance),
+		CodeHash:     types.BytesToHash(acc.CodeHash),
+		Nonce:        argUint64(acc.Nonce),
+		StorageHash:  acc.Root,
+		StorageProof: make([]*storageProofResult, len(storageKeys)),
+	}
+
+	for i, key := range storageKeys {
+		value, err := e.getStorageValue(header.StateRoot, address, key)
+		if err != nil {
+			return nil, err
+		}
+
+		proof, err := e.store.GetProof(acc.Root, key.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		res.StorageProof[i] = &storageProofResult{
+			Key:   key,
+			Value: *argBigPtr(new(big.Int).SetBytes(value)),
+			Proof: toArgBytesList(proof),
+		}
+	}
+
+	return res, nil
+}
+
+// getStorageValue returns the value of the storage slot, nil if not set
+func (e *Eth) getStorageValue(root types.Hash, address types.Address, key types.Hash) ([]byte, error) {
+	result, err := e.store.GetStorage(root, address, key)
+	if errors.Is(err, ErrStateNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// the values are RLP encoded in the trie
+	p := &fastrlp.Parser{}
+
+	v, err := p.Parse(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Bytes()
+}
+
 // NewFilter creates a filter object, based on filter options, to notify when the state changes (logs).
 func (e *Eth) NewFilter(filter *LogQuery) (interface{}, error) {
 	return e.filterManager.NewLogFilter(filter, nil), nil
@@ -806,6 +1244,11 @@ func (e *Eth) NewBlockFilter() (interface{}, error) {
 	return e.filterManager.NewBlockFilter(nil), nil
 }
 
+// NewPendingTransactionFilter creates a filter in the node, to notify when new transactions are pending
+func (e *Eth) NewPendingTransactionFilter() (interface{}, error) {
+	return e.filterManager.NewPendingTxFilter(false, nil), nil
+}
+
 // GetFilterChanges is a polling method for a filter, which returns an array of logs which occurred since last poll.
 func (e *Eth) GetFilterChanges(id string) (interface{}, error) {
 	return e.filterManager.GetFilterChanges(id)
@@ -837,6 +1280,9 @@ func (e *Eth) getBlockHeader(number BlockNumber) (*types.Header, error) {
 	case PendingBlockNumber:
 		return nil, fmt.Errorf("fetching the pending header is not supported")
 
+	case FinalizedBlockNumber, SafeBlockNumber:
+		return getFinalityHeader(e.store, number)
+
 	default:
 		// Convert the block number from hex to uint64
 		header, ok := e.store.GetHeaderByNumber(uint64(number))
@@ -934,6 +1380,42 @@ func (e *Eth) decodeTxn(arg *txnArgs) (*types.Transaction, error) {
 		txn.To = arg.To
 	}
 
+	// the fee caps imply a dynamic fee transaction, and the access list
+	// an access list transaction, if the type is not set
+	if arg.Type != nil {
+		txn.Type = types.TxType(*arg.Type)
+	} else if arg.MaxFeePerGas != nil || arg.MaxPriorityFeePerGas != nil {
+		txn.Type = types.DynamicFeeTx
+	} else if arg.AccessList != nil {
+		txn.Type = types.AccessListTx
+	}
+
+	switch txn.Type {
+	case types.LegacyTx:
+	case types.AccessListTx, types.DynamicFeeTx:
+		txn.ChainID = new(big.Int).SetUint64(e.chainID)
+
+		if arg.AccessList != nil {
+			txn.AccessList = *arg.AccessList
+		}
+	default:
+		return nil, types.ErrTxTypeNotSupported
+	}
+
+	if txn.Type == types.DynamicFeeTx {
+		txn.GasPrice = nil
+		txn.GasTipCap = new(big.Int)
+		txn.GasFeeCap = new(big.Int)
+
+		if arg.MaxPriorityFeePerGas != nil {
+			txn.GasTipCap.SetBytes(*arg.MaxPriorityFeePerGas)
+		}
+
+		if arg.MaxFeePerGas != nil {
+			txn.GasFeeCap.SetBytes(*arg.MaxFeePerGas)
+		}
+	}
+
 	txn.ComputeHash()
 
 	return txn, nil
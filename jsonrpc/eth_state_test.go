@@ -618,6 +618,79 @@ func getExampleStore() *mockSpecialStore {
 // TestEth_EstimateGas_GasLimit tests eth_estimateGas, by using
 // the latest block gas limit for the upper bound, or the specified
 // gas limit in the transaction
+func TestEth_State_GetProof(t *testing.T) {
+	storageRoot := types.StringToHash("5")
+
+	a := &fastrlp.Arena{}
+
+	store := &mockSpecialStore{
+		account: &mockAccount{
+			address: addr0,
+			account: &state.Account{
+				Balance:  big.NewInt(100),
+				Nonce:    2,
+				Root:     storageRoot,
+				CodeHash: hash3.Bytes(),
+			},
+			storage: map[types.Hash][]byte{
+				hash1: a.NewBytes([]byte{0x1, 0x2}).MarshalTo(nil),
+			},
+		},
+		block: &types.Block{
+			Header: &types.Header{
+				Hash:      types.ZeroHash,
+				Number:    0,
+				StateRoot: hash4,
+			},
+		},
+	}
+
+	eth := newTestEthEndpoint(store)
+
+	res, err := eth.GetProof(addr0, []types.Hash{hash1, hash2}, BlockNumberOrHash{})
+	assert.NoError(t, err)
+
+	// the mock proves a key with the root and the key itself
+	assert.Equal(t, &accountProofResult{
+		Address:      addr0,
+		AccountProof: []argBytes{hash4.Bytes(), addr0.Bytes()},
+		Balance:      *argBigPtr(big.NewInt(100)),
+		CodeHash:     hash3,
+		Nonce:        2,
+		StorageHash:  storageRoot,
+		StorageProof: []*storageProofResult{
+			{
+				Key:   hash1,
+				Value: *argBigPtr(big.NewInt(0x102)),
+				Proof: []argBytes{storageRoot.Bytes(), hash1.Bytes()},
+			},
+			{
+				Key:   hash2,
+				Value: *argBigPtr(big.NewInt(0)),
+				Proof: []argBytes{storageRoot.Bytes(), hash2.Bytes()},
+			},
+		},
+	}, res)
+
+	// the fields of a missing account are the default ones
+	res, err = eth.GetProof(uninitializedAddress, []types.Hash{hash1}, BlockNumberOrHash{})
+	assert.NoError(t, err)
+
+	proof, ok := res.(*accountProofResult)
+	assert.True(t, ok)
+
+	assert.Equal(t, *argBigPtr(big.NewInt(0)), proof.Balance)
+	assert.Equal(t, argUint64(0), proof.Nonce)
+	assert.Equal(t, types.EmptyCodeHash, proof.CodeHash)
+	assert.Equal(t, types.EmptyRootHash, proof.StorageHash)
+	assert.Equal(t, []argBytes{types.EmptyRootHash.Bytes(), hash1.Bytes()}, proof.StorageProof[0].Proof)
+
+	invalidBlock := BlockNumber(0x1)
+
+	_, err = eth.GetProof(addr0, nil, BlockNumberOrHash{BlockNumber: &invalidBlock})
+	assert.Error(t, err)
+}
+
 func TestEth_EstimateGas_GasLimit(t *testing.T) {
 	// TODO Make this test run in parallel when the race
 	// condition is fixed in gas estimation
@@ -745,6 +818,45 @@ func TestEth_EstimateGas_Reverts(t *testing.T) {
 	assert.ErrorAs(t, estimateErr, &revertReason)
 }
 
+func TestEth_CreateAccessList(t *testing.T) {
+	store := getExampleStore()
+	ethEndpoint := newTestEthEndpoint(store)
+
+	slot := types.StringToHash("1")
+	applied := 0
+
+	// The slot is accessed once the address is warm, which takes a second run
+	store.applyTxnWithAccessListHook = func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (*runtime.ExecutionResult, types.TxAccessList, error) {
+		applied++
+
+		assert.Equal(t, types.AccessListTx, txn.Type)
+
+		accessed := types.TxAccessList{{Address: addr0, StorageKeys: []types.Hash{}}}
+		if len(txn.AccessList) > 0 {
+			accessed[0].StorageKeys = append(accessed[0].StorageKeys, slot)
+		}
+
+		return &runtime.ExecutionResult{
+			GasUsed: uint64(21000 + len(txn.AccessList)),
+			Err:     runtime.ErrExecutionReverted,
+		}, accessed, nil
+	}
+
+	res, err := ethEndpoint.CreateAccessList(constructMockTx(nil, nil), BlockNumberOrHash{})
+	assert.NoError(t, err)
+
+	// the transaction is applied until the access list doesn't change
+	assert.Equal(t, 3, applied)
+	assert.Equal(t, &accessListResult{
+		AccessList: types.TxAccessList{{Address: addr0, StorageKeys: []types.Hash{slot}}},
+		GasUsed:    argUint64(21001),
+		Error:      runtime.ErrExecutionReverted.Error(),
+	}, res)
+}
+
 func TestEth_EstimateGas_Errors(t *testing.T) {
 	store := getExampleStore()
 	ethEndpoint := newTestEthEndpoint(store)
@@ -774,6 +886,11 @@ type mockSpecialStore struct {
 	block   *types.Block
 
 	applyTxnHook func(header *types.Header, txn *types.Transaction) (*runtime.ExecutionResult, error)
+
+	applyTxnWithAccessListHook func(
+		header *types.Header,
+		txn *types.Transaction,
+	) (*runtime.ExecutionResult, types.TxAccessList, error)
 }
 
 func (m *mockSpecialStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
@@ -831,6 +948,10 @@ func (m *mockSpecialStore) GetCode(hash types.Hash) ([]byte, error) {
 	return nil, fmt.Errorf("code not found")
 }
 
+func (m *mockSpecialStore) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	return [][]byte{root.Bytes(), key}, nil
+}
+
 func (m *mockSpecialStore) GetForksInTime(blockNumber uint64) chain.ForksInTime {
 	return chain.ForksInTime{}
 }
@@ -842,3 +963,14 @@ func (m *mockSpecialStore) ApplyTxn(header *types.Header, txn *types.Transaction
 
 	return &runtime.ExecutionResult{}, nil
 }
+
+func (m *mockSpecialStore) ApplyTxnWithAccessList(
+	header *types.Header,
+	txn *types.Transaction,
+) (*runtime.ExecutionResult, types.TxAccessList, error) {
+	if m.applyTxnWithAccessListHook != nil {
+		return m.applyTxnWithAccessListHook(header, txn)
+	}
+
+	return &runtime.ExecutionResult{}, types.TxAccessList{}, nil
+}
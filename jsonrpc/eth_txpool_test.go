@@ -1,6 +1,8 @@
 package jsonrpc
 
 import (
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -46,20 +48,103 @@ func TestEth_TxnPool_SendTransaction(t *testing.T) {
 	_, err := eth.SendRawTransaction(hex.EncodeToHex(txToSend.MarshalRLP()))
 	assert.NoError(t, err)
 	assert.NotEqual(t, store.txn.Hash, types.ZeroHash)
+	assert.False(t, store.privateTx)
+}
+
+func TestEth_TxnPool_SendPrivateTransaction(t *testing.T) {
+	store := &mockStoreTxn{}
+	store.AddAccount(addr0)
+	eth := newTestEthEndpoint(store)
+
+	txToSend := &types.Transaction{
+		From:     addr0,
+		To:       argAddrPtr(addr0),
+		Nonce:    uint64(0),
+		GasPrice: big.NewInt(int64(1)),
+	}
+
+	hash, err := eth.SendPrivateRawTransaction(hex.EncodeToHex(txToSend.MarshalRLP()))
+	assert.NoError(t, err)
+	assert.Equal(t, store.txn.Hash.String(), hash)
+	assert.True(t, store.privateTx)
+
+	_, err = eth.SendPrivateRawTransaction("0xzz")
+	assert.Error(t, err)
+}
+
+func TestEth_TxnPool_SendBundle(t *testing.T) {
+	store := &mockStoreTxn{}
+	eth := newTestEthEndpoint(store)
+
+	tx0 := &types.Transaction{
+		From:     addr0,
+		To:       argAddrPtr(addr0),
+		Nonce:    uint64(0),
+		GasPrice: big.NewInt(int64(1)),
+	}
+	tx1 := tx0.Copy()
+	tx1.Nonce = 1
+
+	var args bundleArgs
+
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(
+		`{"txs":["%s","%s"],"blockNumber":"0x10","maxTimestamp":1700000000}`,
+		hex.EncodeToHex(tx0.MarshalRLP()),
+		hex.EncodeToHex(tx1.MarshalRLP()),
+	)), &args))
+
+	res, err := eth.SendBundle(&args)
+	assert.NoError(t, err)
+	assert.Equal(t, &bundleResult{BundleHash: types.StringToHash("bundle")}, res)
+
+	assert.Len(t, store.bundle.txs, 2)
+	assert.Equal(t, tx0.ComputeHash().Hash, store.bundle.txs[0].Hash)
+	assert.Equal(t, tx1.ComputeHash().Hash, store.bundle.txs[1].Hash)
+	assert.Equal(t, uint64(16), store.bundle.blockNumber)
+	assert.Equal(t, uint64(0), store.bundle.minTimestamp)
+	assert.Equal(t, uint64(1700000000), store.bundle.maxTimestamp)
+
+	// the invalid txs are rejected
+	_, err = eth.SendBundle(&bundleArgs{Txs: []argBytes{{0x01}}, BlockNumber: 16})
+	assert.Error(t, err)
 }
 
 type mockStoreTxn struct {
 	ethStore
-	accounts map[types.Address]*mockAccount
-	txn      *types.Transaction
+	accounts  map[types.Address]*mockAccount
+	txn       *types.Transaction
+	privateTx bool
+	bundle    *mockBundle
+}
+
+type mockBundle struct {
+	txs                                     []*types.Transaction
+	blockNumber, minTimestamp, maxTimestamp uint64
 }
 
 func (m *mockStoreTxn) AddTx(tx *types.Transaction) error {
 	m.txn = tx
+	m.privateTx = false
 
 	return nil
 }
 
+func (m *mockStoreTxn) AddPrivateTx(tx *types.Transaction) error {
+	m.txn = tx
+	m.privateTx = true
+
+	return nil
+}
+
+func (m *mockStoreTxn) AddBundle(
+	txs []*types.Transaction,
+	blockNumber, minTimestamp, maxTimestamp uint64,
+) (types.Hash, error) {
+	m.bundle = &mockBundle{txs, blockNumber, minTimestamp, maxTimestamp}
+
+	return types.StringToHash("bundle"), nil
+}
+
 func (m *mockStoreTxn) GetNonce(addr types.Address) uint64 {
 	return 1
 }
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/Gabulhas/polygon-external-consensus/blockchain"
+	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
+	"github.com/Gabulhas/polygon-external-consensus/txpool/proto"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -30,6 +32,9 @@ var (
 // defaultTimeout is the timeout to remove the filters that don't have a web socket stream
 var defaultTimeout = 1 * time.Minute
 
+// syncingCheckInterval is the interval to check the sync status for the syncing filters
+var syncingCheckInterval = 1 * time.Second
+
 const (
 	// The index in heap which is indicating the element is not in the heap
 	NoIndexInHeap = -1
@@ -212,8 +217,147 @@ func (f *logFilter) sendUpdates() error {
 	return nil
 }
 
+// pendingTxFilter is a filter to store the transactions promoted in the pool
+type pendingTxFilter struct {
+	filterBase
+	sync.Mutex
+
+	// fullTx is the flag indicating the full transactions are sent instead of their hashes
+	fullTx bool
+
+	txHashes []types.Hash
+	txs      []*types.Transaction
+}
+
+// appendTx appends the new pending transaction, the full transaction is only stored if required
+func (f *pendingTxFilter) appendTx(txHash types.Hash, tx *types.Transaction) {
+	f.Lock()
+	defer f.Unlock()
+
+	if f.fullTx {
+		f.txs = append(f.txs, tx)
+	} else {
+		f.txHashes = append(f.txHashes, txHash)
+	}
+}
+
+// takeTxUpdates returns all saved transactions in filter and resets them
+func (f *pendingTxFilter) takeTxUpdates() ([]types.Hash, []*types.Transaction) {
+	f.Lock()
+	defer f.Unlock()
+
+	txHashes, txs := f.txHashes, f.txs
+	f.txHashes, f.txs = []types.Hash{}, []*types.Transaction{}
+
+	return txHashes, txs
+}
+
+// getUpdates returns the hashes of the pending transactions
+func (f *pendingTxFilter) getUpdates() (interface{}, error) {
+	txHashes, _ := f.takeTxUpdates()
+
+	return txHashes, nil
+}
+
+// sendUpdates writes the pending transactions, or their hashes, to web socket stream
+func (f *pendingTxFilter) sendUpdates() error {
+	txHashes, txs := f.takeTxUpdates()
+
+	updates := make([]interface{}, 0, len(txHashes)+len(txs))
+	for _, txHash := range txHashes {
+		updates = append(updates, txHash)
+	}
+
+	for _, tx := range txs {
+		updates = append(updates, toPendingTransaction(tx))
+	}
+
+	for _, update := range updates {
+		raw, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncingResult is the notification of the syncing filter while the node is syncing
+type syncingResult struct {
+	Syncing bool        `json:"syncing"`
+	Status  progression `json:"status"`
+}
+
+// syncingFilter is a filter to store the changes of the sync status,
+// it's only available with a web socket stream
+type syncingFilter struct {
+	filterBase
+	sync.Mutex
+
+	// status is the last stored sync status, nil if the node is not syncing
+	status  *progression
+	updates []interface{}
+}
+
+// updateStatus stores the sync status if it changed, false is stored when the sync is over
+func (f *syncingFilter) updateStatus(status *progression) {
+	f.Lock()
+	defer f.Unlock()
+
+	if status == nil && f.status == nil ||
+		status != nil && f.status != nil && *status == *f.status {
+		return
+	}
+
+	f.status = status
+
+	if status == nil {
+		f.updates = append(f.updates, false)
+	} else {
+		f.updates = append(f.updates, &syncingResult{Syncing: true, Status: *status})
+	}
+}
+
+// takeStatusUpdates returns all saved sync status changes in filter and resets them
+func (f *syncingFilter) takeStatusUpdates() []interface{} {
+	f.Lock()
+	defer f.Unlock()
+
+	updates := f.updates
+	f.updates = []interface{}{}
+
+	return updates
+}
+
+// getUpdates returns the changes of the sync status
+func (f *syncingFilter) getUpdates() (interface{}, error) {
+	return f.takeStatusUpdates(), nil
+}
+
+// sendUpdates writes the changes of the sync status to web socket stream
+func (f *syncingFilter) sendUpdates() error {
+	for _, update := range f.takeStatusUpdates() {
+		raw, err := json.Marshal(update)
+		if err != nil {
+			return err
+		}
+
+		if err := f.writeMessageToWs(string(raw)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // filterManagerStore provides methods required by FilterManager
 type filterManagerStore interface {
+	finalityStore
+
 	// Header returns the current header of the chain (genesis if empty)
 	Header() *types.Header
 
@@ -228,6 +372,18 @@ type filterManagerStore interface {
 
 	// GetBlockByNumber returns a block using the provided number
 	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// SubscribeTxEvents subscribes for the given types of transaction pool events
+	SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func())
+
+	// GetPendingTx gets the pending transaction from the transaction pool, if it's present
+	GetPendingTx(txHash types.Hash) (*types.Transaction, bool)
+
+	// IsPrivateTx checks if the transaction is a private one of the transaction pool
+	IsPrivateTx(txHash types.Hash) bool
+
+	// GetSyncProgression retrieves the current sync progression, if any
+	GetSyncProgression() *progress.Progression
 }
 
 // FilterManager manages all running filters
@@ -240,6 +396,8 @@ type FilterManager struct {
 
 	store           filterManagerStore
 	subscription    blockchain.Subscription
+	txEventCh       <-chan *proto.TxPoolEvent
+	cancelTxEvents  func()
 	blockStream     *blockStream
 	blockRangeLimit uint64
 
@@ -270,6 +428,9 @@ func NewFilterManager(logger hclog.Logger, store filterManagerStore, blockRangeL
 	// start the head watcher
 	m.subscription = store.SubscribeEvents()
 
+	// start the watcher of the transactions promoted in the pool
+	m.txEventCh, m.cancelTxEvents = store.SubscribeTxEvents(proto.EventType_PROMOTED)
+
 	return m
 }
 
@@ -290,6 +451,11 @@ func (f *FilterManager) Run() {
 
 	var timeoutCh <-chan time.Time
 
+	txEventCh := f.txEventCh
+
+	syncingTicker := time.NewTicker(syncingCheckInterval)
+	defer syncingTicker.Stop()
+
 	for {
 		// check for the next filter to be removed
 		filterBase := f.nextTimeoutFilter()
@@ -306,6 +472,25 @@ func (f *FilterManager) Run() {
 				f.logger.Error("failed to dispatch event", "err", err)
 			}
 
+		case evnt, ok := <-txEventCh:
+			if !ok {
+				// the pool is closed, stop watching it
+				txEventCh = nil
+
+				continue
+			}
+
+			// new pending transaction
+			if err := f.dispatchTxEvent(evnt); err != nil {
+				f.logger.Error("failed to dispatch tx event", "err", err)
+			}
+
+		case <-syncingTicker.C:
+			// check the sync status
+			if err := f.dispatchSyncStatus(); err != nil {
+				f.logger.Error("failed to dispatch sync status", "err", err)
+			}
+
 		case <-timeoutCh:
 			// timeout for filter
 			// if filter still exists
@@ -325,6 +510,7 @@ func (f *FilterManager) Run() {
 
 // Close closed closeCh so that terminate worker
 func (f *FilterManager) Close() {
+	f.cancelTxEvents()
 	close(f.closeCh)
 }
 
@@ -356,6 +542,32 @@ func (f *FilterManager) NewLogFilter(logQuery *LogQuery, ws wsConn) string {
 	return f.addFilter(filter)
 }
 
+// NewPendingTxFilter adds new PendingTxFilter, sending the full transactions
+// instead of their hashes over the web socket stream if fullTx is set
+func (f *FilterManager) NewPendingTxFilter(fullTx bool, ws wsConn) string {
+	filter := &pendingTxFilter{
+		filterBase: newFilterBase(ws),
+		fullTx:     fullTx && ws != nil,
+	}
+
+	if filter.hasWSConn() {
+		ws.SetFilterID(filter.id)
+	}
+
+	return f.addFilter(filter)
+}
+
+// NewSyncingFilter adds new SyncingFilter, which requires a web socket stream
+func (f *FilterManager) NewSyncingFilter(ws wsConn) string {
+	filter := &syncingFilter{
+		filterBase: newFilterBase(ws),
+	}
+
+	ws.SetFilterID(filter.id)
+
+	return f.addFilter(filter)
+}
+
 // Exists checks the filter with given ID exists
 func (f *FilterManager) Exists(id string) bool {
 	f.RLock()
@@ -405,6 +617,13 @@ func (f *FilterManager) getLogsFromBlocks(query *LogQuery) ([]*Log, error) {
 			num = 0
 		case LatestBlockNumber:
 			return latestBlockNumber, nil
+		case FinalizedBlockNumber, SafeBlockNumber:
+			header, err := getFinalityHeader(f.store, num)
+			if err != nil {
+				return 0, err
+			}
+
+			return header.Number, nil
 		}
 
 		return uint64(num), nil
@@ -642,6 +861,73 @@ func (f *FilterManager) dispatchEvent(evnt *blockchain.Event) error {
 	return nil
 }
 
+// dispatchTxEvent is an event handler for new pending transaction event
+func (f *FilterManager) dispatchTxEvent(evnt *proto.TxPoolEvent) error {
+	if !f.processTxEvent(evnt) {
+		return nil
+	}
+
+	return f.flushWsFilters()
+}
+
+// processTxEvent makes each pending transaction filter append the new transaction,
+// it returns false if there are no such filters
+func (f *FilterManager) processTxEvent(evnt *proto.TxPoolEvent) bool {
+	txFilters := f.getPendingTxFilters()
+	if len(txFilters) == 0 {
+		return false
+	}
+
+	txHash := types.StringToHash(evnt.TxHash)
+
+	// the private transactions stay on the node
+	if f.store.IsPrivateTx(txHash) {
+		return false
+	}
+
+	// the full transaction is only fetched if a filter requires it
+	var (
+		tx        *types.Transaction
+		txFetched bool
+	)
+
+	for _, filter := range txFilters {
+		if filter.fullTx && !txFetched {
+			tx, _ = f.store.GetPendingTx(txHash)
+			txFetched = true
+		}
+
+		if filter.fullTx && tx == nil {
+			// the transaction has already left the pool
+			continue
+		}
+
+		filter.appendTx(txHash, tx)
+	}
+
+	return true
+}
+
+// dispatchSyncStatus stores the current sync status in the syncing filters
+func (f *FilterManager) dispatchSyncStatus() error {
+	syncingFilters := f.getSyncingFilters()
+	if len(syncingFilters) == 0 {
+		return nil
+	}
+
+	var status *progression
+
+	if syncProgression := f.store.GetSyncProgression(); syncProgression != nil {
+		status = toProgression(syncProgression)
+	}
+
+	for _, filter := range syncingFilters {
+		filter.updateStatus(status)
+	}
+
+	return f.flushWsFilters()
+}
+
 // processEvent makes each filter append the new data that interests them
 func (f *FilterManager) processEvent(evnt *blockchain.Event) {
 	f.RLock()
@@ -763,6 +1049,38 @@ func (f *FilterManager) getLogFilters() []*logFilter {
 	return logFilters
 }
 
+// getPendingTxFilters returns pendingTxFilters
+func (f *FilterManager) getPendingTxFilters() []*pendingTxFilter {
+	f.RLock()
+	defer f.RUnlock()
+
+	txFilters := make([]*pendingTxFilter, 0)
+
+	for _, f := range f.filters {
+		if txFilter, ok := f.(*pendingTxFilter); ok {
+			txFilters = append(txFilters, txFilter)
+		}
+	}
+
+	return txFilters
+}
+
+// getSyncingFilters returns syncingFilters
+func (f *FilterManager) getSyncingFilters() []*syncingFilter {
+	f.RLock()
+	defer f.RUnlock()
+
+	syncingFilters := make([]*syncingFilter, 0)
+
+	for _, f := range f.filters {
+		if syncingFilter, ok := f.(*syncingFilter); ok {
+			syncingFilters = append(syncingFilters, syncingFilter)
+		}
+	}
+
+	return syncingFilters
+}
+
 type timeHeapImpl []*filterBase
 
 func (t *timeHeapImpl) addFilter(filter *filterBase) {
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math/big"
 	"net"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/Gabulhas/polygon-external-consensus/blockchain"
+	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/gorilla/websocket"
 	"github.com/hashicorp/go-hclog"
@@ -467,6 +469,194 @@ func TestFilterWebsocket(t *testing.T) {
 	}
 }
 
+func TestFilterPendingTx(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	hashesMock, hashesMsgCh := newMockWsConnWithMsgCh()
+	fullTxMock, fullTxMsgCh := newMockWsConnWithMsgCh()
+
+	pollingID := m.NewPendingTxFilter(false, nil)
+	m.NewPendingTxFilter(false, hashesMock)
+	m.NewPendingTxFilter(true, fullTxMock)
+
+	tx := &types.Transaction{
+		Nonce:    1,
+		GasPrice: big.NewInt(10),
+		Value:    big.NewInt(0),
+		V:        big.NewInt(1),
+		R:        big.NewInt(1),
+		S:        big.NewInt(1),
+	}
+	tx.ComputeHash()
+
+	store.emitTxEvent(tx)
+
+	readResult := func(msgCh <-chan []byte) json.RawMessage {
+		t.Helper()
+
+		var msg struct {
+			Params struct {
+				Result json.RawMessage `json:"result"`
+			} `json:"params"`
+		}
+
+		select {
+		case raw := <-msgCh:
+			assert.NoError(t, json.Unmarshal(raw, &msg))
+		case <-time.After(2 * time.Second):
+			t.Fatal("pending transaction not received in 2 seconds")
+		}
+
+		return msg.Params.Result
+	}
+
+	// the web socket filters receive the hash or the full transaction
+	var txHash types.Hash
+
+	assert.NoError(t, json.Unmarshal(readResult(hashesMsgCh), &txHash))
+	assert.Equal(t, tx.Hash, txHash)
+
+	var fullTx transaction
+
+	assert.NoError(t, json.Unmarshal(readResult(fullTxMsgCh), &fullTx))
+	assert.Equal(t, tx.Hash, fullTx.Hash)
+	assert.Equal(t, argUint64(1), fullTx.Nonce)
+	assert.Nil(t, fullTx.BlockHash)
+
+	// the polling filter returns the hash
+	changes, err := m.GetFilterChanges(pollingID)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{tx.Hash}, changes)
+
+	changes, err = m.GetFilterChanges(pollingID)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestFilterPendingTx_Private(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	mock, msgCh := newMockWsConnWithMsgCh()
+
+	pollingID := m.NewPendingTxFilter(false, nil)
+	m.NewPendingTxFilter(false, mock)
+
+	newTx := func(nonce uint64) *types.Transaction {
+		return (&types.Transaction{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(10),
+			Value:    big.NewInt(0),
+			V:        big.NewInt(1),
+			R:        big.NewInt(1),
+			S:        big.NewInt(1),
+		}).ComputeHash()
+	}
+
+	privateTx, publicTx := newTx(1), newTx(2)
+
+	store.emitPrivateTxEvent(privateTx)
+	store.emitTxEvent(publicTx)
+
+	// the private tx is not pushed, the public one following it is
+	var msg struct {
+		Params struct {
+			Result types.Hash `json:"result"`
+		} `json:"params"`
+	}
+
+	select {
+	case raw := <-msgCh:
+		assert.NoError(t, json.Unmarshal(raw, &msg))
+	case <-time.After(2 * time.Second):
+		t.Fatal("pending transaction not received in 2 seconds")
+	}
+
+	assert.Equal(t, publicTx.Hash, msg.Params.Result)
+
+	changes, err := m.GetFilterChanges(pollingID)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.Hash{publicTx.Hash}, changes)
+}
+
+func TestFilterSyncing(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+
+	m := NewFilterManager(hclog.NewNullLogger(), store, 1000)
+	defer m.Close()
+
+	go m.Run()
+
+	mock, msgCh := newMockWsConnWithMsgCh()
+	m.NewSyncingFilter(mock)
+
+	readResult := func() string {
+		t.Helper()
+
+		var msg struct {
+			Params struct {
+				Result json.RawMessage `json:"result"`
+			} `json:"params"`
+		}
+
+		select {
+		case raw := <-msgCh:
+			assert.NoError(t, json.Unmarshal(raw, &msg))
+		case <-time.After(5 * time.Second):
+			t.Fatal("sync status not received in 5 seconds")
+		}
+
+		return string(msg.Params.Result)
+	}
+
+	// the start of the sync and its progress are notified
+	store.setSyncProgression(&progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  2,
+		HighestBlock:  10,
+	})
+
+	assert.JSONEq(t, `{
+		"syncing": true,
+		"status": {
+			"type": "bulk-sync",
+			"startingBlock": "0x1",
+			"currentBlock": "0x2",
+			"highestBlock": "0xa"
+		}
+	}`, readResult())
+
+	store.setSyncProgression(&progress.Progression{
+		SyncType:      progress.ChainSyncBulk,
+		StartingBlock: 1,
+		CurrentBlock:  10,
+		HighestBlock:  10,
+	})
+
+	assert.Contains(t, readResult(), `"currentBlock":"0xa"`)
+
+	// the end of the sync is notified
+	store.setSyncProgression(nil)
+
+	assert.Equal(t, "false", readResult())
+}
+
 type mockWsConn struct {
 	SetFilterIDFn  func(string)
 	GetFilterIDFn  func() string
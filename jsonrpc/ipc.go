@@ -0,0 +1,132 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/ipc"
+	"github.com/hashicorp/go-hclog"
+)
+
+// ipcConn is a connection to the IPC server, the subscriptions
+// write their updates to it like to a WS connection
+type ipcConn struct {
+	sync.Mutex
+
+	conn     net.Conn
+	logger   hclog.Logger
+	filterID string
+}
+
+func (c *ipcConn) SetFilterID(filterID string) {
+	c.filterID = filterID
+}
+
+func (c *ipcConn) GetFilterID() string {
+	return c.filterID
+}
+
+// WriteMessage writes out the message to the IPC peer on a single line,
+// so the peers can split the stream by new lines
+func (c *ipcConn) WriteMessage(_ int, data []byte) error {
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)+1))
+	if err := json.Compact(buf, data); err != nil {
+		return err
+	}
+
+	buf.WriteByte('\n')
+
+	c.Lock()
+	defer c.Unlock()
+
+	_, err := c.conn.Write(buf.Bytes())
+	if err != nil {
+		c.logger.Error("Unable to write IPC message", "err", err)
+	}
+
+	return err
+}
+
+// setupIPC serves the dispatcher on the unix socket (named pipe on windows) of the config
+func (j *JSONRPC) setupIPC() error {
+	lis, err := ipc.Listen(j.config.IPCPath)
+	if err != nil {
+		return err
+	}
+
+	j.ipcListener = lis
+
+	j.logger.Info("ipc server started", "path", j.config.IPCPath)
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				if !errors.Is(err, net.ErrClosed) {
+					j.logger.Error("closed ipc listener", "err", err)
+				}
+
+				return
+			}
+
+			go j.handleIPC(conn)
+		}
+	}()
+
+	return nil
+}
+
+// handleIPC reads the stream of requests of the IPC connection until it's closed
+func (j *JSONRPC) handleIPC(conn net.Conn) {
+	defer conn.Close()
+
+	wrapConn := &ipcConn{conn: conn, logger: j.logger}
+	decoder := json.NewDecoder(conn)
+
+	// the subscriptions of the connection are removed once it's closed
+	defer j.dispatcher.RemoveFilterByWs(wrapConn)
+
+	for {
+		var message json.RawMessage
+		if err := decoder.Decode(&message); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			// the stream can't be read past an invalid message
+			j.logger.Error("Unable to read IPC message", "err", err)
+
+			resp, _ := NewRPCResponse(nil, "2.0", nil, NewInvalidRequestError("Invalid json request")).Bytes()
+			_ = wrapConn.WriteMessage(0, resp)
+
+			return
+		}
+
+		go func() {
+			var (
+				resp []byte
+				err  error
+			)
+
+			// the batches are handled like HTTP ones, the single requests may be subscriptions.
+			// The local clients are not rate limited
+			if message[0] == '[' {
+				resp, err = j.dispatcher.Handle(message, "")
+			} else {
+				resp, err = j.dispatcher.HandleWs(message, wrapConn, "")
+			}
+
+			if err != nil {
+				j.logger.Error("Unable to handle IPC request", "err", err)
+
+				resp, _ = NewRPCResponse(nil, "2.0", nil, NewInternalError(err.Error())).Bytes()
+			}
+
+			_ = wrapConn.WriteMessage(0, resp)
+		}()
+	}
+}
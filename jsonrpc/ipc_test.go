@@ -0,0 +1,160 @@
+//go:build !windows
+// +build !windows
+
+package jsonrpc
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/tests"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestIPCServer(t *testing.T, store *mockStore) string {
+	t.Helper()
+
+	port, err := tests.GetFreePort()
+	assert.NoError(t, err)
+
+	ipcPath := filepath.Join(t.TempDir(), "edge.ipc")
+
+	srv, err := NewJSONRPC(hclog.NewNullLogger(), &Config{
+		Store:            store,
+		Addr:             &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: port},
+		ChainID:          100,
+		BatchLengthLimit: 20,
+		BlockRangeLimit:  1000,
+		IPCPath:          ipcPath,
+	})
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, srv.Close())
+	})
+
+	return ipcPath
+}
+
+func TestIPCServer(t *testing.T) {
+	t.Parallel()
+
+	store := newMockStore()
+	ipcPath := newTestIPCServer(t, store)
+
+	// only the owner can access the socket
+	info, err := os.Stat(ipcPath)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	conn, err := net.Dial("unix", ipcPath)
+	assert.NoError(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	readLine := func() []byte {
+		t.Helper()
+
+		assert.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+
+		line, err := reader.ReadBytes('\n')
+		assert.NoError(t, err)
+
+		return line
+	}
+
+	// a single request
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`))
+	assert.NoError(t, err)
+
+	var resp SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(readLine(), &resp))
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, `"0x64"`, string(resp.Result))
+
+	// a batch of requests
+	_, err = conn.Write([]byte(`[
+		{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]},
+		{"jsonrpc":"2.0","id":2,"method":"net_version","params":[]}
+	]`))
+	assert.NoError(t, err)
+
+	var batchResp []SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(readLine(), &batchResp))
+	assert.Len(t, batchResp, 2)
+	assert.Equal(t, `"0x64"`, string(batchResp[0].Result))
+	assert.Equal(t, `"100"`, string(batchResp[1].Result))
+
+	// a subscription notifies the new headers on the connection
+	_, err = conn.Write([]byte(`{"jsonrpc":"2.0","id":3,"method":"eth_subscribe","params":["newHeads"]}`))
+	assert.NoError(t, err)
+
+	var subscriptionID string
+
+	assert.NoError(t, json.Unmarshal(readLine(), &resp))
+	assert.NoError(t, json.Unmarshal(resp.Result, &subscriptionID))
+	assert.NotEmpty(t, subscriptionID)
+
+	store.emitEvent(&mockEvent{
+		NewChain: []*mockHeader{
+			{
+				header: &types.Header{
+					Hash: types.StringToHash("1"),
+				},
+			},
+		},
+	})
+
+	var notification struct {
+		Method string `json:"method"`
+		Params struct {
+			Subscription string `json:"subscription"`
+			Result       struct {
+				Hash types.Hash `json:"hash"`
+			} `json:"result"`
+		} `json:"params"`
+	}
+
+	assert.NoError(t, json.Unmarshal(readLine(), &notification))
+	assert.Equal(t, "eth_subscription", notification.Method)
+	assert.Equal(t, subscriptionID, notification.Params.Subscription)
+	assert.Equal(t, types.StringToHash("1"), notification.Params.Result.Hash)
+}
+
+func TestIPCServer_InvalidRequest(t *testing.T) {
+	t.Parallel()
+
+	ipcPath := newTestIPCServer(t, newMockStore())
+
+	conn, err := net.Dial("unix", ipcPath)
+	assert.NoError(t, err)
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(`{"jsonrpc":`))
+	assert.NoError(t, err)
+
+	// the connection is closed after the error, since the stream can't be read past it
+	assert.NoError(t, conn.(*net.UnixConn).CloseWrite())
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	assert.NoError(t, err)
+
+	var resp SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(line, &resp))
+	assert.NotNil(t, resp.Error)
+	assert.Equal(t, -32600, resp.Error.Code)
+}
@@ -2,6 +2,7 @@ package jsonrpc
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -40,12 +41,15 @@ type JSONRPC struct {
 	logger     hclog.Logger
 	config     *Config
 	dispatcher dispatcher
+
+	httpServer  *http.Server
+	ipcListener net.Listener
 }
 
 type dispatcher interface {
 	RemoveFilterByWs(conn wsConn)
-	HandleWs(reqBody []byte, conn wsConn) ([]byte, error)
-	Handle(reqBody []byte) ([]byte, error)
+	HandleWs(reqBody []byte, conn wsConn, client string) ([]byte, error)
+	Handle(reqBody []byte, client string) ([]byte, error)
 }
 
 // JSONRPCStore defines all the methods required
@@ -55,6 +59,8 @@ type JSONRPCStore interface {
 	networkStore
 	txPoolStore
 	filterManagerStore
+	debugStore
+	traceStore
 }
 
 type Config struct {
@@ -66,6 +72,25 @@ type Config struct {
 	PriceLimit               uint64
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+
+	// IPCPath is the path of the socket serving the endpoints over IPC, disabled if empty
+	IPCPath string
+
+	// Auth is the authentication of the HTTP and WS requests, disabled if nil.
+	// The IPC socket is only accessible by its owner, so it's not authenticated
+	Auth *AuthConfig
+
+	// RateLimit is the rate limiting of the HTTP and WS requests of each client, disabled if nil.
+	// The IPC requests are local, so they are not limited
+	RateLimit *RateLimitConfig
+
+	Metrics *Metrics
+
+	// DevStore enables the evm and dev endpoints, it is set if the dev consensus is active
+	DevStore DevStore
+
+	// EnableDebug enables the debug and trace endpoints, which replay whole blocks
+	EnableDebug bool
 }
 
 // NewJSONRPC returns the JSONRPC http server
@@ -82,6 +107,10 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 				priceLimit:              config.PriceLimit,
 				jsonRPCBatchLengthLimit: config.BatchLengthLimit,
 				blockRangeLimit:         config.BlockRangeLimit,
+				devStore:                config.DevStore,
+				enableDebug:             config.EnableDebug,
+				rateLimit:               config.RateLimit,
+				metrics:                 config.Metrics,
 			},
 		),
 	}
@@ -91,9 +120,28 @@ func NewJSONRPC(logger hclog.Logger, config *Config) (*JSONRPC, error) {
 		return nil, err
 	}
 
+	if config.IPCPath != "" {
+		if err := srv.setupIPC(); err != nil {
+			_ = srv.httpServer.Close()
+
+			return nil, err
+		}
+	}
+
 	return srv, nil
 }
 
+// Close stops the HTTP and IPC servers, the socket of the IPC server is removed
+func (j *JSONRPC) Close() error {
+	if j.ipcListener != nil {
+		if err := j.ipcListener.Close(); err != nil {
+			return err
+		}
+	}
+
+	return j.httpServer.Close()
+}
+
 func (j *JSONRPC) setupHTTP() error {
 	j.logger.Info("http server started", "addr", j.config.Addr.String())
 
@@ -109,17 +157,19 @@ func (j *JSONRPC) setupHTTP() error {
 
 	// The middleware factory returns a handler, so we need to wrap the handler function properly.
 	jsonRPCHandler := http.HandlerFunc(j.handle)
-	mux.Handle("/", middlewareFactory(j.config)(jsonRPCHandler))
+	mux.Handle("/", middlewareFactory(j.config)(authMiddleware(j.config.Auth)(jsonRPCHandler)))
 
-	mux.HandleFunc("/ws", j.handleWs)
+	mux.Handle("/ws", authMiddleware(j.config.Auth)(http.HandlerFunc(j.handleWs)))
 
-	srv := http.Server{
+	srv := &http.Server{
 		Handler:           mux,
 		ReadHeaderTimeout: 60 * time.Second,
 	}
 
+	j.httpServer = srv
+
 	go func() {
-		if err := srv.Serve(lis); err != nil {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			j.logger.Error("closed http connection", "err", err)
 		}
 	}()
@@ -223,6 +273,10 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 	wrapConn := &wsWrapper{ws: ws, logger: j.logger}
 
+	// the methods allowed by the credentials of the connection
+	rules := methodRulesFromContext(req.Context())
+	client := clientFromRequest(req)
+
 	j.logger.Info("Websocket connection established")
 	// Run the listen loop
 	for {
@@ -248,7 +302,13 @@ func (j *JSONRPC) handleWs(w http.ResponseWriter, req *http.Request) {
 
 		if isSupportedWSType(msgType) {
 			go func() {
-				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn)
+				if resp := rules.checkMethods(message); resp != nil {
+					_ = wrapConn.WriteMessage(msgType, resp)
+
+					return
+				}
+
+				resp, handleErr := j.dispatcher.HandleWs(message, wrapConn, client)
 				if handleErr != nil {
 					j.logger.Error(fmt.Sprintf("Unable to handle WS request, %s", handleErr.Error()))
 
@@ -295,7 +355,13 @@ func (j *JSONRPC) handleJSONRPCRequest(w http.ResponseWriter, req *http.Request)
 	// log request
 	j.logger.Debug("handle", "request", string(data))
 
-	resp, err := j.dispatcher.Handle(data)
+	if resp := methodRulesFromContext(req.Context()).checkMethods(data); resp != nil {
+		_, _ = w.Write(resp)
+
+		return
+	}
+
+	resp, err := j.dispatcher.Handle(data, clientFromRequest(req))
 
 	if err != nil {
 		_, _ = w.Write([]byte(err.Error()))
@@ -0,0 +1,39 @@
+package jsonrpc
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	prometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics represents the jsonrpc metrics
+type Metrics struct {
+	// Requests rejected by the rate limiter, labeled by method
+	RateLimitedRequests metrics.Counter
+}
+
+// GetPrometheusMetrics return the jsonrpc metrics instance
+func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics {
+	labels := []string{}
+
+	for i := 0; i < len(labelsWithValues); i += 2 {
+		labels = append(labels, labelsWithValues[i])
+	}
+
+	return &Metrics{
+		RateLimitedRequests: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "jsonrpc",
+			Name:      "rate_limited_requests",
+			Help:      "Requests rejected by the rate limiter",
+		}, append(labels, "method")).With(labelsWithValues...),
+	}
+}
+
+// NilMetrics will return the non operational jsonrpc metrics
+func NilMetrics() *Metrics {
+	return &Metrics{
+		RateLimitedRequests: discard.NewCounter(),
+	}
+}
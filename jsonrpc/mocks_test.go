@@ -6,7 +6,9 @@ import (
 	"sync"
 
 	"github.com/Gabulhas/polygon-external-consensus/blockchain"
+	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
 	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/txpool/proto"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 )
 
@@ -53,6 +55,13 @@ type mockStore struct {
 	receiptsLock sync.Mutex
 	receipts     map[types.Hash][]*types.Receipt
 	accounts     map[types.Address]*state.Account
+
+	txEventCh  chan *proto.TxPoolEvent
+	pendingTxs sync.Map
+	privateTxs sync.Map
+
+	syncLock        sync.Mutex
+	syncProgression *progress.Progression
 }
 
 func newMockStore() *mockStore {
@@ -60,9 +69,37 @@ func newMockStore() *mockStore {
 		header:       &types.Header{Number: 0},
 		subscription: blockchain.NewMockSubscription(),
 		accounts:     map[types.Address]*state.Account{},
+		txEventCh:    make(chan *proto.TxPoolEvent, 16),
 	}
 }
 
+// emitTxEvent adds the transaction to the pending ones, and notifies its promotion
+func (m *mockStore) emitTxEvent(tx *types.Transaction) {
+	m.pendingTxs.Store(tx.Hash, tx)
+
+	m.txEventCh <- &proto.TxPoolEvent{
+		Type:   proto.EventType_PROMOTED,
+		TxHash: tx.Hash.String(),
+	}
+}
+
+// emitPrivateTxEvent adds the transaction to the private ones, and notifies its promotion
+func (m *mockStore) emitPrivateTxEvent(tx *types.Transaction) {
+	m.privateTxs.Store(tx.Hash, tx)
+
+	m.txEventCh <- &proto.TxPoolEvent{
+		Type:   proto.EventType_PROMOTED,
+		TxHash: tx.Hash.String(),
+	}
+}
+
+func (m *mockStore) setSyncProgression(syncProgression *progress.Progression) {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	m.syncProgression = syncProgression
+}
+
 func (m *mockStore) emitEvent(evnt *mockEvent) {
 	if m.receipts == nil {
 		m.receipts = map[types.Hash][]*types.Receipt{}
@@ -115,6 +152,32 @@ func (m *mockStore) SubscribeEvents() blockchain.Subscription {
 	return m.subscription
 }
 
+func (m *mockStore) SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	return m.txEventCh, func() {}
+}
+
+func (m *mockStore) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	tx, ok := m.pendingTxs.Load(txHash)
+	if !ok {
+		return nil, false
+	}
+
+	return tx.(*types.Transaction), true //nolint:forcetypeassert
+}
+
+func (m *mockStore) IsPrivateTx(txHash types.Hash) bool {
+	_, ok := m.privateTxs.Load(txHash)
+
+	return ok
+}
+
+func (m *mockStore) GetSyncProgression() *progress.Progression {
+	m.syncLock.Lock()
+	defer m.syncLock.Unlock()
+
+	return m.syncProgression
+}
+
 func (m *mockStore) GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool) {
 	return nil, false
 }
@@ -0,0 +1,133 @@
+package jsonrpc
+
+import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimiterPruneInterval is the interval of the removal of the buckets of the idle clients
+const rateLimiterPruneInterval = time.Minute
+
+// RateLimitConfig is the rate limiting of the requests of each client, the clients
+// being identified by their API key, or by their IP address otherwise
+type RateLimitConfig struct {
+	// Rate is the cost of the requests allowed per second
+	Rate float64
+
+	// Burst is the cost of the requests allowed at once, the rate if lower
+	Burst float64
+
+	// MethodCosts are the costs of the methods, with entries being either method names
+	// or patterns ending in a wildcard, such as debug_*. The methods not listed cost 1
+	MethodCosts map[string]float64
+}
+
+// DefaultMethodCosts returns the costs of the methods heavier than a lookup
+func DefaultMethodCosts() map[string]float64 {
+	return map[string]float64{
+		"eth_call":        5,
+		"eth_estimateGas": 5,
+		"eth_getLogs":     10,
+		"eth_getProof":    5,
+		"eth_sendBundle":  5,
+		"debug_*":         20,
+		"trace_*":         20,
+	}
+}
+
+// methodCost returns the cost of the method, the exact entry or else
+// the longest pattern matching it taking precedence
+func (c *RateLimitConfig) methodCost(method string) float64 {
+	if cost, ok := c.MethodCosts[method]; ok {
+		return cost
+	}
+
+	cost, matched := float64(1), 0
+
+	for pattern, patternCost := range c.MethodCosts {
+		if len(pattern) > matched && strings.HasSuffix(pattern, "*") && matchMethod(pattern, method) {
+			cost, matched = patternCost, len(pattern)
+		}
+	}
+
+	return cost
+}
+
+// tokenBucket is the bucket of a client, refilled at the rate of the limiter
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// rateLimiter is the token bucket rate limiter of the clients
+type rateLimiter struct {
+	sync.Mutex
+
+	config *RateLimitConfig
+	burst  float64
+
+	buckets   map[string]*tokenBucket
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+func newRateLimiter(config *RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		config:  config,
+		burst:   math.Max(config.Burst, config.Rate),
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// allow takes the cost of the method from the bucket of the client,
+// and returns false if there are not enough tokens left.
+// The cost is capped at the burst, so that the methods costing more
+// than the burst are allowed once the bucket is full, draining it
+func (r *rateLimiter) allow(client, method string) bool {
+	cost := math.Min(r.config.methodCost(method), r.burst)
+
+	r.Lock()
+	defer r.Unlock()
+
+	now := r.now()
+
+	if now.Sub(r.lastPrune) >= rateLimiterPruneInterval {
+		r.prune(now)
+	}
+
+	bucket, ok := r.buckets[client]
+	if ok {
+		bucket.tokens = r.refill(bucket, now)
+		bucket.updated = now
+	} else {
+		bucket = &tokenBucket{tokens: r.burst, updated: now}
+		r.buckets[client] = bucket
+	}
+
+	if bucket.tokens < cost {
+		return false
+	}
+
+	bucket.tokens -= cost
+
+	return true
+}
+
+// refill returns the tokens of the bucket at the given time
+func (r *rateLimiter) refill(bucket *tokenBucket, now time.Time) float64 {
+	return math.Min(r.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*r.config.Rate)
+}
+
+// prune removes the full buckets, which are the same as the ones of new clients
+func (r *rateLimiter) prune(now time.Time) {
+	r.lastPrune = now
+
+	for client, bucket := range r.buckets {
+		if r.refill(bucket, now) >= r.burst {
+			delete(r.buckets, client)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCounter counts the additions by label values
+type mockCounter struct {
+	lvs    []string
+	counts map[string]float64
+}
+
+func newMockCounter() *mockCounter {
+	return &mockCounter{counts: make(map[string]float64)}
+}
+
+func (c *mockCounter) With(labelValues ...string) metrics.Counter {
+	return &mockCounter{lvs: append(c.lvs, labelValues...), counts: c.counts}
+}
+
+func (c *mockCounter) Add(delta float64) {
+	c.counts[strings.Join(c.lvs, ",")] += delta
+}
+
+func TestRateLimitConfig_methodCost(t *testing.T) {
+	t.Parallel()
+
+	config := &RateLimitConfig{
+		MethodCosts: map[string]float64{
+			"eth_getLogs":        10,
+			"debug_*":            20,
+			"debug_traceBlock*":  50,
+			"debug_traceCall":    30,
+			"eth_blockNumber":    0.5,
+			"txpool_contentFrom": 2,
+		},
+	}
+
+	tests := []struct {
+		method string
+		cost   float64
+	}{
+		{"eth_getLogs", 10},
+		{"eth_chainId", 1},
+		{"eth_blockNumber", 0.5},
+		{"debug_traceTransaction", 20},
+		{"debug_traceBlockByNumber", 50},
+		{"debug_traceCall", 30},
+		{"txpool_content", 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.method, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.cost, config.methodCost(tt.method))
+		})
+	}
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter(&RateLimitConfig{
+		Rate:        2,
+		Burst:       10,
+		MethodCosts: map[string]float64{"eth_getLogs": 5},
+	})
+
+	now := time.Unix(1000, 0)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	// the burst is allowed at once
+	assert.True(t, limiter.allow("a", "eth_getLogs"))
+	assert.True(t, limiter.allow("a", "eth_getLogs"))
+	assert.False(t, limiter.allow("a", "eth_chainId"))
+
+	// the clients have their own buckets
+	assert.True(t, limiter.allow("b", "eth_getLogs"))
+
+	// the buckets are refilled at the rate
+	now = now.Add(time.Second)
+
+	assert.True(t, limiter.allow("a", "eth_chainId"))
+	assert.True(t, limiter.allow("a", "eth_chainId"))
+	assert.False(t, limiter.allow("a", "eth_chainId"))
+
+	// the buckets of the idle clients are removed once full
+	now = now.Add(rateLimiterPruneInterval)
+
+	assert.True(t, limiter.allow("c", "eth_chainId"))
+	assert.Len(t, limiter.buckets, 1)
+}
+
+func TestRateLimiter_AllowCostAboveBurst(t *testing.T) {
+	t.Parallel()
+
+	// the burst defaults to the rate, below the cost of the debug methods
+	limiter := newRateLimiter(&RateLimitConfig{
+		Rate:        10,
+		MethodCosts: map[string]float64{"debug_*": 20},
+	})
+
+	now := time.Unix(1000, 0)
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	// the full bucket is drained
+	assert.True(t, limiter.allow("a", "debug_traceTransaction"))
+	assert.False(t, limiter.allow("a", "eth_chainId"))
+
+	// the bucket has to be full again
+	now = now.Add(500 * time.Millisecond)
+
+	assert.False(t, limiter.allow("a", "debug_traceTransaction"))
+
+	now = now.Add(time.Second)
+
+	assert.True(t, limiter.allow("a", "debug_traceTransaction"))
+}
+
+func TestDispatcher_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	counter := newMockCounter()
+
+	dispatcher := newDispatcher(
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:                 100,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+			rateLimit: &RateLimitConfig{
+				Rate:        1,
+				Burst:       2,
+				MethodCosts: map[string]float64{"web3_sha3": 2},
+			},
+			metrics: &Metrics{RateLimitedRequests: counter},
+		},
+	)
+
+	chainID := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)
+
+	handle := func(body []byte, client string) *SuccessResponse {
+		t.Helper()
+
+		data, err := dispatcher.Handle(body, client)
+		assert.NoError(t, err)
+
+		resp := new(SuccessResponse)
+		assert.NoError(t, json.Unmarshal(data, resp))
+
+		return resp
+	}
+
+	assert.Nil(t, handle(chainID, "ip:127.0.0.1").Error)
+	assert.Nil(t, handle(chainID, "ip:127.0.0.1").Error)
+
+	// the requests above the limit are rejected
+	resp := handle(chainID, "ip:127.0.0.1")
+	assert.Equal(t, &ObjectError{Code: -32005, Message: "rate limit exceeded for the method eth_chainId"}, resp.Error)
+
+	// the requests of the batches are limited one by one
+	data, err := dispatcher.Handle([]byte(`[
+		{"jsonrpc":"2.0","id":1,"method":"web3_sha3","params":["0x00"]},
+		{"jsonrpc":"2.0","id":2,"method":"eth_chainId","params":[]}
+	]`), "key:api-key")
+	assert.NoError(t, err)
+
+	var batch []SuccessResponse
+
+	assert.NoError(t, json.Unmarshal(data, &batch))
+	assert.Len(t, batch, 2)
+	assert.Nil(t, batch[0].Error)
+	assert.Equal(t, -32005, batch[1].Error.Code)
+
+	// the unknown methods are not limited, nor counted
+	resp = handle([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_unknown","params":[]}`), "ip:127.0.0.1")
+	assert.Equal(t, -32601, resp.Error.Code)
+
+	// the local clients are not limited
+	assert.Nil(t, handle(chainID, "").Error)
+
+	assert.Equal(t, map[string]float64{"method,eth_chainId": 2}, counter.counts)
+}
+
+func TestDispatcher_RateLimitWs(t *testing.T) {
+	t.Parallel()
+
+	counter := newMockCounter()
+
+	dispatcher := newDispatcher(
+		hclog.NewNullLogger(),
+		newMockStore(),
+		&dispatcherParams{
+			chainID:                 100,
+			jsonRPCBatchLengthLimit: 20,
+			blockRangeLimit:         1000,
+			rateLimit: &RateLimitConfig{
+				Rate:  1,
+				Burst: 2,
+			},
+			metrics: &Metrics{RateLimitedRequests: counter},
+		},
+	)
+
+	mockConnection, _ := newMockWsConnWithMsgCh()
+
+	handleWs := func(body string) *SuccessResponse {
+		t.Helper()
+
+		data, err := dispatcher.HandleWs([]byte(body), mockConnection, "ip:127.0.0.1")
+		assert.NoError(t, err)
+
+		resp := new(SuccessResponse)
+		assert.NoError(t, json.Unmarshal(data, resp))
+
+		return resp
+	}
+
+	subscribe := `{"jsonrpc":"2.0","id":1,"method":"eth_subscribe","params":["newHeads"]}`
+
+	resp := handleWs(subscribe)
+	assert.Nil(t, resp.Error)
+
+	var filterID string
+
+	assert.NoError(t, json.Unmarshal(resp.Result, &filterID))
+
+	// the unsubscriptions are charged as well
+	assert.Nil(t, handleWs(`{"jsonrpc":"2.0","id":2,"method":"eth_unsubscribe","params":["`+filterID+`"]}`).Error)
+
+	// the subscriptions above the limit are rejected
+	resp = handleWs(subscribe)
+	assert.Equal(t, &ObjectError{Code: -32005, Message: "rate limit exceeded for the method eth_subscribe"}, resp.Error)
+	assert.Equal(t, map[string]float64{"method,eth_subscribe": 1}, counter.counts)
+}
@@ -0,0 +1,465 @@
+package jsonrpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer/paritytracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+const (
+	traceTypeTrace     = "trace"
+	traceTypeStateDiff = "stateDiff"
+)
+
+var (
+	ErrTraceTypeNotSupported = errors.New("trace type not supported")
+)
+
+// traceStore provides the replay of the transactions needed for the trace endpoint
+type traceStore interface {
+	// Header returns the current header of the chain (genesis if empty)
+	Header() *types.Header
+
+	// GetBlockByHash gets a block using the provided hash
+	GetBlockByHash(hash types.Hash, full bool) (*types.Block, bool)
+
+	// GetBlockByNumber returns a block using the provided number
+	GetBlockByNumber(num uint64, full bool) (*types.Block, bool)
+
+	// ReadTxLookup returns a block hash in which a given txn was mined
+	ReadTxLookup(txnHash types.Hash) (types.Hash, bool)
+
+	// TraceBlock replays the transactions of the block on top of its parent,
+	// each traced by the tracer at the same index
+	TraceBlock(block *types.Block, tracers []tracer.Tracer) error
+
+	// TraceTxn replays the transactions of the block on top of its parent
+	// up to the one with the given hash, which is traced
+	TraceTxn(block *types.Block, txHash types.Hash, tracer tracer.Tracer) error
+
+	// TraceBlockStateDiff replays the transactions of the block like TraceBlock,
+	// and returns the state changed by each of them
+	TraceBlockStateDiff(block *types.Block, tracers []tracer.Tracer) ([]state.StateDiff, error)
+}
+
+// Trace is the parity compatible trace jsonrpc endpoint, used by the indexers
+// to find the internal calls of the transactions
+type Trace struct {
+	store traceStore
+
+	// eth resolves the block numbers like the eth endpoint
+	eth *Eth
+
+	// blockRangeLimit is the maximum range of blocks of trace_filter, 0 if unlimited
+	blockRangeLimit uint64
+}
+
+// localizedTrace is a trace with the block and the transaction it belongs to
+type localizedTrace struct {
+	*paritytracer.Trace
+	BlockHash           types.Hash `json:"blockHash"`
+	BlockNumber         uint64     `json:"blockNumber"`
+	TransactionHash     types.Hash `json:"transactionHash"`
+	TransactionPosition uint64     `json:"transactionPosition"`
+}
+
+// TraceFilter is the filter of trace_filter, the traces matching all of its fields are returned
+type TraceFilter struct {
+	FromBlock *BlockNumber `json:"fromBlock"`
+	ToBlock   *BlockNumber `json:"toBlock"`
+
+	// FromAddress and ToAddress match any of their addresses, or any address if empty
+	FromAddress []types.Address `json:"fromAddress"`
+	ToAddress   []types.Address `json:"toAddress"`
+
+	// After is the number of the matching traces to skip
+	After *uint64 `json:"after"`
+	// Count is the maximum number of traces to return
+	Count *uint64 `json:"count"`
+}
+
+// replayTrace is the replay of a transaction of a block
+type replayTrace struct {
+	Output          argBytes                       `json:"output"`
+	StateDiff       map[types.Address]*accountDiff `json:"stateDiff"`
+	Trace           []*paritytracer.Trace          `json:"trace"`
+	VMTrace         interface{}                    `json:"vmTrace"`
+	TransactionHash types.Hash                     `json:"transactionHash"`
+}
+
+// accountDiff is the change of an account in the parity format
+type accountDiff struct {
+	Balance interface{}                `json:"balance"`
+	Nonce   interface{}                `json:"nonce"`
+	Code    interface{}                `json:"code"`
+	Storage map[types.Hash]interface{} `json:"storage"`
+}
+
+// Block returns the traces of the transactions of the block
+func (t *Trace) Block(number BlockNumber) (interface{}, error) {
+	num, err := GetNumericBlockNumber(number, t.eth)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	return t.traceBlock(block)
+}
+
+// Transaction returns the traces of the transaction
+func (t *Trace) Transaction(hash types.Hash) (interface{}, error) {
+	blockHash, ok := t.store.ReadTxLookup(hash)
+	if !ok {
+		return nil, ErrTxnNotFound
+	}
+
+	block, ok := t.store.GetBlockByHash(blockHash, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	for i, txn := range block.Transactions {
+		if txn.Hash != hash {
+			continue
+		}
+
+		tr := paritytracer.NewParityTracer()
+
+		stop, err := startTraceTimeout(nil, tr)
+		if err != nil {
+			return nil, err
+		}
+		defer stop()
+
+		if err := t.store.TraceTxn(block, hash, tr); err != nil {
+			return nil, err
+		}
+
+		txTrace, err := getTxTrace(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		return localizeTraces(block, i, txTrace.Traces), nil
+	}
+
+	return nil, ErrTxnNotFound
+}
+
+// Filter returns the traces of the range of blocks matching the filter
+func (t *Trace) Filter(filter *TraceFilter) (interface{}, error) {
+	from, err := t.resolveFilterBlock(filter.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := t.resolveFilterBlock(filter.ToBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	if to < from {
+		return nil, ErrIncorrectBlockRange
+	}
+
+	// the genesis block has no transactions to trace
+	if from == 0 {
+		from = 1
+	}
+
+	// if not disabled, avoid handling large block ranges
+	if t.blockRangeLimit != 0 && to-from > t.blockRangeLimit {
+		return nil, ErrBlockRangeTooHigh
+	}
+
+	var skip, count uint64 = 0, ^uint64(0)
+
+	if filter.After != nil {
+		skip = *filter.After
+	}
+
+	if filter.Count != nil {
+		count = *filter.Count
+	}
+
+	traces := make([]*localizedTrace, 0)
+
+	for i := from; i <= to && count > 0; i++ {
+		block, ok := t.store.GetBlockByNumber(i, true)
+		if !ok {
+			break
+		}
+
+		blockTraces, err := t.traceBlock(block)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trace := range blockTraces {
+			if !filter.match(trace.Trace) {
+				continue
+			}
+
+			if skip > 0 {
+				skip--
+
+				continue
+			}
+
+			traces = append(traces, trace)
+
+			if count--; count == 0 {
+				break
+			}
+		}
+	}
+
+	return traces, nil
+}
+
+// ReplayBlockTransactions replays the transactions of the block, and returns
+// the requested types of traces of each of them
+func (t *Trace) ReplayBlockTransactions(number BlockNumber, traceTypes []string) (interface{}, error) {
+	var withTrace, withStateDiff bool
+
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case traceTypeTrace:
+			withTrace = true
+		case traceTypeStateDiff:
+			withStateDiff = true
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrTraceTypeNotSupported, traceType)
+		}
+	}
+
+	num, err := GetNumericBlockNumber(number, t.eth)
+	if err != nil {
+		return nil, err
+	}
+
+	block, ok := t.store.GetBlockByNumber(num, true)
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	txTraces, diffs, err := t.replayBlock(block, withStateDiff)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*replayTrace, len(block.Transactions))
+
+	for i, txn := range block.Transactions {
+		results[i] = &replayTrace{
+			Output:          argBytes(txTraces[i].Output),
+			TransactionHash: txn.Hash,
+		}
+
+		if withTrace {
+			results[i].Trace = txTraces[i].Traces
+		}
+
+		if withStateDiff {
+			results[i].StateDiff = toParityStateDiff(diffs[i])
+		}
+	}
+
+	return results, nil
+}
+
+// resolveFilterBlock returns the number of the block of the filter, the latest one if not set
+func (t *Trace) resolveFilterBlock(number *BlockNumber) (uint64, error) {
+	if number == nil {
+		return t.store.Header().Number, nil
+	}
+
+	return GetNumericBlockNumber(*number, t.eth)
+}
+
+// traceBlock returns the traces of the transactions of the block, in order
+func (t *Trace) traceBlock(block *types.Block) ([]*localizedTrace, error) {
+	traces := make([]*localizedTrace, 0)
+
+	if len(block.Transactions) == 0 {
+		return traces, nil
+	}
+
+	if block.Number() == 0 {
+		return nil, ErrTraceGenesisBlock
+	}
+
+	txTraces, _, err := t.replayBlock(block, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, txTrace := range txTraces {
+		traces = append(traces, localizeTraces(block, i, txTrace.Traces)...)
+	}
+
+	return traces, nil
+}
+
+// replayBlock replays the transactions of the block with the parity tracer,
+// the state diffs are only returned if requested
+func (t *Trace) replayBlock(
+	block *types.Block,
+	withStateDiff bool,
+) ([]*paritytracer.TxTrace, []state.StateDiff, error) {
+	tracers := make([]tracer.Tracer, len(block.Transactions))
+	for i := range tracers {
+		tracers[i] = paritytracer.NewParityTracer()
+	}
+
+	stop, err := startTraceTimeout(nil, tracers...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer stop()
+
+	var diffs []state.StateDiff
+
+	if withStateDiff {
+		diffs, err = t.store.TraceBlockStateDiff(block, tracers)
+	} else {
+		err = t.store.TraceBlock(block, tracers)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	txTraces := make([]*paritytracer.TxTrace, len(tracers))
+
+	for i, tr := range tracers {
+		if txTraces[i], err = getTxTrace(tr); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return txTraces, diffs, nil
+}
+
+func getTxTrace(tr tracer.Tracer) (*paritytracer.TxTrace, error) {
+	res, err := tr.GetResult()
+	if err != nil {
+		return nil, err
+	}
+
+	txTrace, ok := res.(*paritytracer.TxTrace)
+	if !ok {
+		return nil, fmt.Errorf("unexpected trace result %T", res)
+	}
+
+	return txTrace, nil
+}
+
+func localizeTraces(block *types.Block, index int, traces []*paritytracer.Trace) []*localizedTrace {
+	localized := make([]*localizedTrace, len(traces))
+
+	for i, trace := range traces {
+		localized[i] = &localizedTrace{
+			Trace:               trace,
+			BlockHash:           block.Hash(),
+			BlockNumber:         block.Number(),
+			TransactionHash:     block.Transactions[index].Hash,
+			TransactionPosition: uint64(index),
+		}
+	}
+
+	return localized
+}
+
+// match returns true if the trace is sent from and to one of the addresses of the filter
+func (f *TraceFilter) match(trace *paritytracer.Trace) bool {
+	var from, to *types.Address
+
+	switch trace.Type {
+	case paritytracer.TraceTypeCall:
+		from, to = trace.Action.From, trace.Action.To
+	case paritytracer.TraceTypeCreate:
+		from = trace.Action.From
+		if trace.Result != nil {
+			to = trace.Result.Address
+		}
+	case paritytracer.TraceTypeSuicide:
+		from, to = trace.Action.Address, trace.Action.RefundAddress
+	}
+
+	return matchAddress(f.FromAddress, from) && matchAddress(f.ToAddress, to)
+}
+
+func matchAddress(addresses []types.Address, addr *types.Address) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+
+	if addr == nil {
+		return false
+	}
+
+	for _, a := range addresses {
+		if a == *addr {
+			return true
+		}
+	}
+
+	return false
+}
+
+// toParityStateDiff converts the state diff to the parity format
+func toParityStateDiff(diff state.StateDiff) map[types.Address]*accountDiff {
+	res := make(map[types.Address]*accountDiff, len(diff))
+
+	for addr, account := range diff {
+		changed := func(before, after string) interface{} {
+			return newDiffValue(account.Existed, account.Exists, before, after)
+		}
+
+		res[addr] = &accountDiff{
+			Balance: changed(hex.EncodeBig(account.BalanceBefore), hex.EncodeBig(account.BalanceAfter)),
+			Nonce:   changed(hex.EncodeUint64(account.NonceBefore), hex.EncodeUint64(account.NonceAfter)),
+			Code:    changed(hex.EncodeToHex(account.CodeBefore), hex.EncodeToHex(account.CodeAfter)),
+			Storage: make(map[types.Hash]interface{}, len(account.Storage)),
+		}
+
+		for key, slot := range account.Storage {
+			res[addr].Storage[key] = changed(slot.Before.String(), slot.After.String())
+		}
+	}
+
+	return res
+}
+
+// newDiffValue returns the change of a value in the parity format: "=" if unchanged,
+// {"+": after} if born, {"-": before} if died or {"*": {"from": before, "to": after}} if changed
+func newDiffValue(existed, exists bool, before, after string) interface{} {
+	switch {
+	case !existed && exists:
+		return map[string]string{"+": after}
+	case existed && !exists:
+		return map[string]string{"-": before}
+	case before == after:
+		return "="
+	default:
+		return map[string]map[string]string{"*": {"from": before, "to": after}}
+	}
+}
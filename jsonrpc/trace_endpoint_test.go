@@ -0,0 +1,328 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer/paritytracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTraceStore struct {
+	*mockDebugStore
+}
+
+func (m *mockTraceStore) TraceBlockStateDiff(block *types.Block, tracers []tracer.Tracer) ([]state.StateDiff, error) {
+	if err := m.TraceBlock(block, tracers); err != nil {
+		return nil, err
+	}
+
+	diffs := make([]state.StateDiff, len(block.Transactions))
+
+	// the sender pays the value to the recipient, which is created
+	for i, txn := range block.Transactions {
+		diffs[i] = state.StateDiff{
+			txn.From: {
+				Existed:       true,
+				Exists:        true,
+				BalanceBefore: big.NewInt(10),
+				BalanceAfter:  big.NewInt(9),
+				NonceBefore:   uint64(i),
+				NonceAfter:    uint64(i + 1),
+			},
+			*txn.To: {
+				Exists:        true,
+				BalanceBefore: big.NewInt(0),
+				BalanceAfter:  big.NewInt(1),
+				Storage: map[types.Hash]state.StorageDiff{
+					hash1: {After: hash2},
+				},
+			},
+		}
+	}
+
+	return diffs, nil
+}
+
+func newTestTraceEndpoint(store *mockTraceStore, blockRangeLimit uint64) *Trace {
+	return &Trace{
+		store:           store,
+		eth:             newTestEthEndpoint(store.mockBlockStore),
+		blockRangeLimit: blockRangeLimit,
+	}
+}
+
+func TestTrace_Block(t *testing.T) {
+	t.Parallel()
+
+	store := &mockTraceStore{newMockDebugStore()}
+	endpoint := newTestTraceEndpoint(store, 0)
+
+	res, err := endpoint.Block(LatestBlockNumber)
+	assert.NoError(t, err)
+
+	traces, ok := res.([]*localizedTrace)
+	assert.True(t, ok)
+	assert.Len(t, traces, 2)
+
+	for i, to := range []types.Address{addr1, addr2} {
+		to := to
+		txn := store.blocks[1].Transactions[i]
+
+		assert.Equal(t, &localizedTrace{
+			Trace: &paritytracer.Trace{
+				Action: &paritytracer.Action{
+					CallType: "call",
+					From:     &txn.From,
+					To:       &to,
+					Gas:      hex.EncodeUint64(txn.Gas - 21000),
+					Input:    "0x",
+					Value:    "0x1",
+				},
+				Result: &paritytracer.Result{
+					GasUsed: "0x0",
+					Output:  "0x",
+				},
+				TraceAddress: []int{},
+				Type:         paritytracer.TraceTypeCall,
+			},
+			BlockHash:           hash2,
+			BlockNumber:         1,
+			TransactionHash:     txn.Hash,
+			TransactionPosition: uint64(i),
+		}, traces[i])
+	}
+
+	// the genesis block has no transactions
+	res, err = endpoint.Block(0)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+
+	_, err = endpoint.Block(2)
+	assert.ErrorIs(t, err, ErrBlockNotFound)
+}
+
+func TestTrace_Transaction(t *testing.T) {
+	t.Parallel()
+
+	store := &mockTraceStore{newMockDebugStore()}
+	endpoint := newTestTraceEndpoint(store, 0)
+
+	res, err := endpoint.Transaction(hash4)
+	assert.NoError(t, err)
+
+	// the transactions before the traced one are replayed
+	assert.Equal(t, []types.Hash{hash3, hash4}, store.replayed)
+	assert.Equal(t, []types.Hash{hash4}, store.traced)
+
+	traces, ok := res.([]*localizedTrace)
+	assert.True(t, ok)
+	assert.Len(t, traces, 1)
+	assert.Equal(t, hash4, traces[0].TransactionHash)
+	assert.Equal(t, uint64(1), traces[0].TransactionPosition)
+	assert.Equal(t, &addr2, traces[0].Action.To)
+
+	_, err = endpoint.Transaction(types.StringToHash("5"))
+	assert.ErrorIs(t, err, ErrTxnNotFound)
+}
+
+func TestTrace_Filter(t *testing.T) {
+	t.Parallel()
+
+	store := &mockTraceStore{newMockDebugStore()}
+
+	// a block with a single transaction to addr1
+	block := newTestBlock(2, types.StringToHash("7"))
+	block.Transactions = []*types.Transaction{
+		newTestDebugTxn(types.StringToHash("6"), addr1, 40000),
+	}
+
+	store.add(block)
+
+	blockNumber := func(n BlockNumber) *BlockNumber {
+		return &n
+	}
+
+	uint64Ptr := func(n uint64) *uint64 {
+		return &n
+	}
+
+	tests := []struct {
+		name            string
+		filter          *TraceFilter
+		blockRangeLimit uint64
+		txHashes        []types.Hash
+		err             error
+	}{
+		{
+			name:     "from the earliest block",
+			filter:   &TraceFilter{FromBlock: blockNumber(EarliestBlockNumber)},
+			txHashes: []types.Hash{hash3, hash4, types.StringToHash("6")},
+		},
+		{
+			name:     "latest block by default",
+			filter:   &TraceFilter{},
+			txHashes: []types.Hash{types.StringToHash("6")},
+		},
+		{
+			name: "to address",
+			filter: &TraceFilter{
+				FromBlock: blockNumber(1),
+				ToAddress: []types.Address{addr1},
+			},
+			txHashes: []types.Hash{hash3, types.StringToHash("6")},
+		},
+		{
+			name: "from and to addresses",
+			filter: &TraceFilter{
+				FromBlock:   blockNumber(1),
+				FromAddress: []types.Address{addr0},
+				ToAddress:   []types.Address{addr2, types.StringToAddress("3")},
+			},
+			txHashes: []types.Hash{hash4},
+		},
+		{
+			name: "no matching address",
+			filter: &TraceFilter{
+				FromBlock:   blockNumber(1),
+				FromAddress: []types.Address{addr1},
+			},
+			txHashes: []types.Hash{},
+		},
+		{
+			name: "after and count",
+			filter: &TraceFilter{
+				FromBlock: blockNumber(1),
+				After:     uint64Ptr(1),
+				Count:     uint64Ptr(1),
+			},
+			txHashes: []types.Hash{hash4},
+		},
+		{
+			name: "range within the limit",
+			filter: &TraceFilter{
+				FromBlock: blockNumber(1),
+				ToBlock:   blockNumber(2),
+			},
+			blockRangeLimit: 1,
+			txHashes:        []types.Hash{hash3, hash4, types.StringToHash("6")},
+		},
+		{
+			name: "incorrect range",
+			filter: &TraceFilter{
+				FromBlock: blockNumber(2),
+				ToBlock:   blockNumber(1),
+			},
+			err: ErrIncorrectBlockRange,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint := newTestTraceEndpoint(&mockTraceStore{
+				&mockDebugStore{mockBlockStore: store.mockBlockStore},
+			}, tt.blockRangeLimit)
+
+			res, err := endpoint.Filter(tt.filter)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+
+			traces, ok := res.([]*localizedTrace)
+			assert.True(t, ok)
+
+			txHashes := make([]types.Hash, len(traces))
+			for i, trace := range traces {
+				txHashes[i] = trace.TransactionHash
+			}
+
+			assert.Equal(t, tt.txHashes, txHashes)
+		})
+	}
+}
+
+func TestTrace_Filter_BlockRangeLimit(t *testing.T) {
+	t.Parallel()
+
+	store := &mockTraceStore{newMockDebugStore()}
+	store.add(newTestBlock(2, types.StringToHash("7")), newTestBlock(3, types.StringToHash("6")))
+
+	endpoint := newTestTraceEndpoint(store, 1)
+
+	from, to := BlockNumber(1), BlockNumber(3)
+
+	_, err := endpoint.Filter(&TraceFilter{FromBlock: &from, ToBlock: &to})
+	assert.ErrorIs(t, err, ErrBlockRangeTooHigh)
+	assert.Empty(t, store.replayed)
+}
+
+func TestTrace_ReplayBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	store := &mockTraceStore{newMockDebugStore()}
+	endpoint := newTestTraceEndpoint(store, 0)
+
+	res, err := endpoint.ReplayBlockTransactions(1, []string{"trace", "stateDiff"})
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(res)
+	assert.NoError(t, err)
+
+	var results []struct {
+		Output          string                     `json:"output"`
+		StateDiff       map[string]json.RawMessage `json:"stateDiff"`
+		Trace           []json.RawMessage          `json:"trace"`
+		VMTrace         interface{}                `json:"vmTrace"`
+		TransactionHash types.Hash                 `json:"transactionHash"`
+	}
+
+	assert.NoError(t, json.Unmarshal(data, &results))
+	assert.Len(t, results, 2)
+
+	assert.Equal(t, "0x", results[0].Output)
+	assert.Equal(t, hash3, results[0].TransactionHash)
+	assert.Len(t, results[0].Trace, 1)
+	assert.Nil(t, results[0].VMTrace)
+
+	assert.JSONEq(t, `{
+		"balance": {"*": {"from": "0xa", "to": "0x9"}},
+		"nonce": {"*": {"from": "0x0", "to": "0x1"}},
+		"code": "=",
+		"storage": {}
+	}`, string(results[0].StateDiff[addr0.String()]))
+
+	assert.JSONEq(t, `{
+		"balance": {"+": "0x1"},
+		"nonce": {"+": "0x0"},
+		"code": {"+": "0x"},
+		"storage": {"`+hash1.String()+`": {"+": "`+hash2.String()+`"}}
+	}`, string(results[0].StateDiff[addr1.String()]))
+
+	// only the requested traces are returned
+	res, err = endpoint.ReplayBlockTransactions(1, []string{"stateDiff"})
+	assert.NoError(t, err)
+
+	replays, ok := res.([]*replayTrace)
+	assert.True(t, ok)
+	assert.Nil(t, replays[0].Trace)
+	assert.NotNil(t, replays[0].StateDiff)
+
+	_, err = endpoint.ReplayBlockTransactions(1, []string{"vmTrace"})
+	assert.ErrorIs(t, err, ErrTraceTypeNotSupported)
+
+	_, err = endpoint.ReplayBlockTransactions(0, []string{"trace"})
+	assert.ErrorIs(t, err, ErrTraceGenesisBlock)
+}
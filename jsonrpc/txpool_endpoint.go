@@ -55,7 +55,7 @@ type txpoolTransaction struct {
 func toTxPoolTransaction(t *types.Transaction) *txpoolTransaction {
 	return &txpoolTransaction{
 		Nonce:       argUint64(t.Nonce),
-		GasPrice:    argBig(*t.GasPrice),
+		GasPrice:    argBig(*t.GetGasFeeCap()),
 		Gas:         argUint64(t.Gas),
 		To:          t.To,
 		Value:       argBig(*t.Value),
@@ -120,7 +120,7 @@ func (t *TxPool) Inspect() (interface{}, error) {
 		for _, tx := range txs {
 			nonceStr := strconv.FormatUint(tx.Nonce, 10)
 			pendingRPCTxs[addr.String()][nonceStr] = fmt.Sprintf(
-				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GasPrice,
+				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GetGasFeeCap(),
 			)
 		}
 	}
@@ -133,7 +133,7 @@ func (t *TxPool) Inspect() (interface{}, error) {
 		for _, tx := range txs {
 			nonceStr := strconv.FormatUint(tx.Nonce, 10)
 			queuedRPCTxs[addr.String()][nonceStr] = fmt.Sprintf(
-				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GasPrice,
+				"%d wei + %d gas x %d wei", tx.Value, tx.Gas, tx.GetGasFeeCap(),
 			)
 		}
 	}
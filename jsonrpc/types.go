@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/helper/progress"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 )
 
@@ -15,20 +16,25 @@ type transactionOrHash interface {
 }
 
 type transaction struct {
-	Nonce       argUint64      `json:"nonce"`
-	GasPrice    argBig         `json:"gasPrice"`
-	Gas         argUint64      `json:"gas"`
-	To          *types.Address `json:"to"`
-	Value       argBig         `json:"value"`
-	Input       argBytes       `json:"input"`
-	V           argBig         `json:"v"`
-	R           argBig         `json:"r"`
-	S           argBig         `json:"s"`
-	Hash        types.Hash     `json:"hash"`
-	From        types.Address  `json:"from"`
-	BlockHash   *types.Hash    `json:"blockHash"`
-	BlockNumber *argUint64     `json:"blockNumber"`
-	TxIndex     *argUint64     `json:"transactionIndex"`
+	Type        argUint64           `json:"type"`
+	ChainID     *argBig             `json:"chainId,omitempty"`
+	Nonce       argUint64           `json:"nonce"`
+	GasPrice    argBig              `json:"gasPrice"`
+	GasTipCap   *argBig             `json:"maxPriorityFeePerGas,omitempty"`
+	GasFeeCap   *argBig             `json:"maxFeePerGas,omitempty"`
+	Gas         argUint64           `json:"gas"`
+	To          *types.Address      `json:"to"`
+	Value       argBig              `json:"value"`
+	Input       argBytes            `json:"input"`
+	AccessList  *types.TxAccessList `json:"accessList,omitempty"`
+	V           argBig              `json:"v"`
+	R           argBig              `json:"r"`
+	S           argBig              `json:"s"`
+	Hash        types.Hash          `json:"hash"`
+	From        types.Address       `json:"from"`
+	BlockHash   *types.Hash         `json:"blockHash"`
+	BlockNumber *argUint64          `json:"blockNumber"`
+	TxIndex     *argUint64          `json:"transactionIndex"`
 }
 
 func (t transaction) getHash() types.Hash { return t.Hash }
@@ -46,6 +52,15 @@ func toPendingTransaction(t *types.Transaction) *transaction {
 	return toTransaction(t, nil, nil, nil)
 }
 
+// toSealedTransaction converts a transaction of the block,
+// the dynamic fee transactions paying a gas price depending on the base fee of the block
+func toSealedTransaction(t *types.Transaction, b *types.Block, txIndex int) *transaction {
+	res := toTransaction(t, argUintPtr(b.Number()), argHashPtr(b.Hash()), &txIndex)
+	res.GasPrice = argBig(*t.EffectiveGasPrice(b.Header.BaseFee))
+
+	return res
+}
+
 func toTransaction(
 	t *types.Transaction,
 	blockNumber *argUint64,
@@ -53,8 +68,9 @@ func toTransaction(
 	txIndex *int,
 ) *transaction {
 	res := &transaction{
+		Type:     argUint64(t.Type),
 		Nonce:    argUint64(t.Nonce),
-		GasPrice: argBig(*t.GasPrice),
+		GasPrice: argBig(*t.GetGasFeeCap()),
 		Gas:      argUint64(t.Gas),
 		To:       t.To,
 		Value:    argBig(*t.Value),
@@ -66,6 +82,26 @@ func toTransaction(
 		From:     t.From,
 	}
 
+	// the typed transactions carry their chain ID and access list (EIP-2930)
+	if t.IsTyped() {
+		accessList := t.AccessList
+		if accessList == nil {
+			accessList = types.TxAccessList{}
+		}
+
+		if t.ChainID != nil {
+			res.ChainID = argBigPtr(t.ChainID)
+		}
+
+		res.AccessList = &accessList
+	}
+
+	// the dynamic fee transactions pay up to their fee cap (EIP-1559)
+	if t.Type == types.DynamicFeeTx {
+		res.GasTipCap = argBigPtr(t.GasTipCap)
+		res.GasFeeCap = argBigPtr(t.GasFeeCap)
+	}
+
 	if blockNumber != nil {
 		res.BlockNumber = blockNumber
 	}
@@ -99,6 +135,7 @@ type block struct {
 	ExtraData       argBytes            `json:"extraData"`
 	MixHash         types.Hash          `json:"mixHash"`
 	Nonce           types.Nonce         `json:"nonce"`
+	BaseFee         *argUint64          `json:"baseFeePerGas,omitempty"`
 	Hash            types.Hash          `json:"hash"`
 	Transactions    []transactionOrHash `json:"transactions"`
 	Uncles          []types.Hash        `json:"uncles"`
@@ -129,16 +166,16 @@ func toBlock(b *types.Block, fullTx bool) *block {
 		Uncles:          []types.Hash{},
 	}
 
+	// the blocks from the London fork have a base fee (EIP-1559)
+	if h.BaseFee != 0 {
+		res.BaseFee = argUintPtr(h.BaseFee)
+	}
+
 	for idx, txn := range b.Transactions {
 		if fullTx {
 			res.Transactions = append(
 				res.Transactions,
-				toTransaction(
-					txn,
-					argUintPtr(b.Number()),
-					argHashPtr(b.Hash()),
-					&idx,
-				),
+				toSealedTransaction(txn, b, idx),
 			)
 		} else {
 			res.Transactions = append(
@@ -156,6 +193,7 @@ func toBlock(b *types.Block, fullTx bool) *block {
 }
 
 type receipt struct {
+	Type              argUint64      `json:"type"`
 	Root              types.Hash     `json:"root"`
 	CumulativeGasUsed argUint64      `json:"cumulativeGasUsed"`
 	LogsBloom         types.Bloom    `json:"logsBloom"`
@@ -166,6 +204,7 @@ type receipt struct {
 	BlockHash         types.Hash     `json:"blockHash"`
 	BlockNumber       argUint64      `json:"blockNumber"`
 	GasUsed           argUint64      `json:"gasUsed"`
+	EffectiveGasPrice argBig         `json:"effectiveGasPrice"`
 	ContractAddress   *types.Address `json:"contractAddress"`
 	FromAddr          types.Address  `json:"from"`
 	ToAddr            *types.Address `json:"to"`
@@ -294,14 +333,58 @@ func encodeToHex(b []byte) []byte {
 
 // txnArgs is the transaction argument for the rpc endpoints
 type txnArgs struct {
-	From     *types.Address
-	To       *types.Address
-	Gas      *argUint64
-	GasPrice *argBytes
-	Value    *argBytes
-	Data     *argBytes
-	Input    *argBytes
-	Nonce    *argUint64
+	From                 *types.Address
+	To                   *types.Address
+	Gas                  *argUint64
+	GasPrice             *argBytes
+	MaxFeePerGas         *argBytes
+	MaxPriorityFeePerGas *argBytes
+	Value                *argBytes
+	Data                 *argBytes
+	Input                *argBytes
+	Nonce                *argUint64
+	Type                 *argUint64
+	AccessList           *types.TxAccessList
+}
+
+// accountProofResult is the merkle proof of an account and of the slots of its storage (EIP-1186)
+type accountProofResult struct {
+	Address      types.Address         `json:"address"`
+	AccountProof []argBytes            `json:"accountProof"`
+	Balance      argBig                `json:"balance"`
+	CodeHash     types.Hash            `json:"codeHash"`
+	Nonce        argUint64             `json:"nonce"`
+	StorageHash  types.Hash            `json:"storageHash"`
+	StorageProof []*storageProofResult `json:"storageProof"`
+}
+
+// storageProofResult is the merkle proof of a storage slot
+type storageProofResult struct {
+	Key   types.Hash `json:"key"`
+	Value argBig     `json:"value"`
+	Proof []argBytes `json:"proof"`
+}
+
+// bundleArgs is the bundle of transactions of eth_sendBundle, included atomically in the target block
+type bundleArgs struct {
+	Txs          []argBytes `json:"txs"`
+	BlockNumber  argUint64  `json:"blockNumber"`
+	MinTimestamp *uint64    `json:"minTimestamp"`
+	MaxTimestamp *uint64    `json:"maxTimestamp"`
+}
+
+// bundleResult is the result of eth_sendBundle
+type bundleResult struct {
+	BundleHash types.Hash `json:"bundleHash"`
+}
+
+func toArgBytesList(list [][]byte) []argBytes {
+	res := make([]argBytes, len(list))
+	for i, b := range list {
+		res[i] = argBytes(b)
+	}
+
+	return res
 }
 
 type progression struct {
@@ -310,3 +393,12 @@ type progression struct {
 	CurrentBlock  string `json:"currentBlock"`
 	HighestBlock  string `json:"highestBlock"`
 }
+
+func toProgression(p *progress.Progression) *progression {
+	return &progression{
+		Type:          string(p.SyncType),
+		StartingBlock: hex.EncodeUint64(p.StartingBlock),
+		CurrentBlock:  hex.EncodeUint64(p.CurrentBlock),
+		HighestBlock:  hex.EncodeUint64(p.HighestBlock),
+	}
+}
@@ -3,6 +3,7 @@ package network
 import (
 	"context"
 	"reflect"
+	"sync"
 
 	"github.com/hashicorp/go-hclog"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -23,6 +24,11 @@ type Topic struct {
 	topic   *pubsub.Topic
 	typ     reflect.Type
 	closeCh chan struct{}
+
+	closeOnce sync.Once
+
+	subsLock sync.Mutex
+	subs     []*pubsub.Subscription
 }
 
 func (t *Topic) createObj() proto.Message {
@@ -49,11 +55,45 @@ func (t *Topic) Subscribe(handler func(obj interface{}, from peer.ID)) error {
 		return err
 	}
 
+	t.subsLock.Lock()
+	t.subs = append(t.subs, sub)
+	t.subsLock.Unlock()
+
 	go t.readLoop(sub, handler)
 
 	return nil
 }
 
+// ListPeers returns the connected peers subscribed to the topic
+func (t *Topic) ListPeers() []peer.ID {
+	return t.topic.ListPeers()
+}
+
+// Close cancels the subscriptions and leaves the topic, so that it can be joined again.
+// Closing the topic more than once has no effect
+func (t *Topic) Close() error {
+	var err error
+
+	t.closeOnce.Do(func() {
+		// stop the read loops first, so that they don't take the cancellation
+		// of their subscription for a read error
+		close(t.closeCh)
+
+		t.subsLock.Lock()
+		defer t.subsLock.Unlock()
+
+		for _, sub := range t.subs {
+			sub.Cancel()
+		}
+
+		t.subs = nil
+
+		err = t.topic.Close()
+	})
+
+	return err
+}
+
 func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{}, from peer.ID)) {
 	ctx, cancelFn := context.WithCancel(context.Background())
 
@@ -65,6 +105,13 @@ func (t *Topic) readLoop(sub *pubsub.Subscription, handler func(obj interface{},
 	for {
 		msg, err := sub.Next(ctx)
 		if err != nil {
+			select {
+			case <-t.closeCh:
+				// the topic is closed
+				return
+			default:
+			}
+
 			t.logger.Error("failed to get topic", "err", err)
 
 			continue
@@ -90,9 +137,10 @@ func (s *Server) NewTopic(protoID string, obj proto.Message) (*Topic, error) {
 	}
 
 	tt := &Topic{
-		logger: s.logger.Named(protoID),
-		topic:  topic,
-		typ:    reflect.TypeOf(obj).Elem(),
+		logger:  s.logger.Named(protoID),
+		topic:   topic,
+		typ:     reflect.TypeOf(obj).Elem(),
+		closeCh: make(chan struct{}),
 	}
 
 	return tt, nil
@@ -107,3 +107,44 @@ func TestSimpleGossip(t *testing.T) {
 		}
 	}
 }
+
+func TestTopic_Close(t *testing.T) {
+	servers, createErr := createServers(1, nil)
+	if createErr != nil {
+		t.Fatalf("Unable to create servers, %v", createErr)
+	}
+
+	t.Cleanup(func() {
+		closeTestServers(t, servers)
+	})
+
+	topicName := "msg-pub-sub"
+
+	topic, topicErr := servers[0].NewTopic(topicName, &testproto.GenericMessage{})
+	if topicErr != nil {
+		t.Fatalf("Unable to create topic, %v", topicErr)
+	}
+
+	if subscribeErr := topic.Subscribe(func(interface{}, peer.ID) {}); subscribeErr != nil {
+		t.Fatalf("Unable to subscribe to topic, %v", subscribeErr)
+	}
+
+	if closeErr := topic.Close(); closeErr != nil {
+		t.Fatalf("Unable to close topic, %v", closeErr)
+	}
+
+	// closing the topic again has no effect
+	if closeErr := topic.Close(); closeErr != nil {
+		t.Fatalf("Unable to close topic twice, %v", closeErr)
+	}
+
+	// the topic can be joined again once closed
+	topic, topicErr = servers[0].NewTopic(topicName, &testproto.GenericMessage{})
+	if topicErr != nil {
+		t.Fatalf("Unable to join topic again, %v", topicErr)
+	}
+
+	if closeErr := topic.Close(); closeErr != nil {
+		t.Fatalf("Unable to close topic, %v", closeErr)
+	}
+}
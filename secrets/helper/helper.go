@@ -1,8 +1,10 @@
 package helper
 
 import (
+	"crypto/rand"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/Gabulhas/polygon-external-consensus/crypto"
 	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
@@ -206,6 +208,50 @@ func LoadNodeID(secretsManager secrets.SecretsManager) (string, error) {
 	return nodeID.String(), nil
 }
 
+// jsonRPCJWTSecretLength is the length of the generated JSON-RPC JWT secrets
+const jsonRPCJWTSecretLength = 32
+
+// InitJSONRPCJWTSecret creates new random secret and set as the secret signing the JSON-RPC JWT tokens
+func InitJSONRPCJWTSecret(secretsManager secrets.SecretsManager) ([]byte, error) {
+	if secretsManager.HasSecret(secrets.JSONRPCJWTSecret) {
+		return nil, fmt.Errorf(`secrets "%s" has been already initialized`, secrets.JSONRPCJWTSecret)
+	}
+
+	secret := make([]byte, jsonRPCJWTSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	// Write the hex encoded secret to the secrets manager storage
+	if setErr := secretsManager.SetSecret(
+		secrets.JSONRPCJWTSecret,
+		[]byte(hex.EncodeToHex(secret)),
+	); setErr != nil {
+		return nil, setErr
+	}
+
+	return secret, nil
+}
+
+// LoadJSONRPCJWTSecret loads the hex encoded secret signing the JSON-RPC JWT tokens by SecretsManager
+func LoadJSONRPCJWTSecret(secretsManager secrets.SecretsManager) ([]byte, error) {
+	encodedSecret, err := secretsManager.GetSecret(secrets.JSONRPCJWTSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := hex.DecodeHex(strings.TrimSpace(string(encodedSecret)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s secret: %w", secrets.JSONRPCJWTSecret, err)
+	}
+
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("empty %s secret", secrets.JSONRPCJWTSecret)
+	}
+
+	return secret, nil
+}
+
 // GetCloudSecretsManager returns the cloud secrets manager from the provided config
 func InitCloudSecretsManager(secretsConfig *secrets.SecretsManagerConfig) (secrets.SecretsManager, error) {
 	var secretsManager secrets.SecretsManager
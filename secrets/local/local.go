@@ -91,6 +91,12 @@ func (l *LocalSecretsManager) Setup() error {
 		secrets.NetworkKeyLocal,
 	)
 
+	// baseDir/jsonrpc-jwt.secret
+	l.secretPathMap[secrets.JSONRPCJWTSecret] = filepath.Join(
+		l.path,
+		secrets.JSONRPCJWTLocal,
+	)
+
 	return nil
 }
 
@@ -31,6 +31,9 @@ const (
 
 	// NetworkKey is the libp2p private key secret used for networking
 	NetworkKey = "network-key"
+
+	// JSONRPCJWTSecret is the secret signing the JWT tokens of the JSON-RPC requests
+	JSONRPCJWTSecret = "jsonrpc-jwt-secret"
 )
 
 // Define constant file names for the local StorageManager
@@ -38,6 +41,7 @@ const (
 	ValidatorKeyLocal    = "validator.key"
 	ValidatorBLSKeyLocal = "validator-bls.key"
 	NetworkKeyLocal      = "libp2p.key"
+	JSONRPCJWTLocal      = "jsonrpc-jwt.secret"
 )
 
 // Define constant folder names for the local StorageManager
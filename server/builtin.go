@@ -2,6 +2,7 @@ package server
 
 import (
 	"github.com/Gabulhas/polygon-external-consensus/consensus"
+	consensusClique "github.com/Gabulhas/polygon-external-consensus/consensus/clique"
 	consensusDev "github.com/Gabulhas/polygon-external-consensus/consensus/dev"
 	consensusDummy "github.com/Gabulhas/polygon-external-consensus/consensus/dummy"
 	consensusExternal "github.com/Gabulhas/polygon-external-consensus/consensus/external"
@@ -20,6 +21,7 @@ const (
 	IBFTConsensus     ConsensusType = "ibft"
 	DummyConsensus    ConsensusType = "dummy"
 	ExternalConsensus ConsensusType = "external"
+	CliqueConsensus   ConsensusType = "clique"
 )
 
 var consensusBackends = map[ConsensusType]consensus.Factory{
@@ -27,6 +29,7 @@ var consensusBackends = map[ConsensusType]consensus.Factory{
 	IBFTConsensus:     consensusIBFT.Factory,
 	DummyConsensus:    consensusDummy.Factory,
 	ExternalConsensus: consensusExternal.Factory,
+	CliqueConsensus:   consensusClique.Factory,
 }
 
 // secretsManagerBackends defines the SecretManager factories for different
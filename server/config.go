@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/Gabulhas/polygon-external-consensus/chain"
+	"github.com/Gabulhas/polygon-external-consensus/jsonrpc"
 	"github.com/Gabulhas/polygon-external-consensus/network"
 	"github.com/Gabulhas/polygon-external-consensus/secrets"
 )
@@ -23,6 +24,11 @@ type Config struct {
 
 	PriceLimit         uint64
 	MaxAccountEnqueued uint64
+	MaxAccountPromoted uint64
+	PriceBump          uint64
+	TxJournal          string
+	TxJournalRotate    uint64
+	PrivateTxLifetime  uint64
 	MaxSlots           uint64
 	BlockTime          uint64
 
@@ -52,4 +58,16 @@ type JSONRPC struct {
 	AccessControlAllowOrigin []string
 	BatchLengthLimit         uint64
 	BlockRangeLimit          uint64
+	IPCPath                  string
+	Auth                     *JSONRPCAuth
+	RateLimit                *jsonrpc.RateLimitConfig
+	EnableDebug              bool
+}
+
+// JSONRPCAuth holds the authentication config of the JSON-RPC server
+type JSONRPCAuth struct {
+	// JWT enables the JWT tokens, signed with the secret of the secrets manager
+	JWT        bool
+	JWTMethods *jsonrpc.MethodRules
+	APIKeys    map[string]*jsonrpc.MethodRules
 }
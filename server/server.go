@@ -15,6 +15,9 @@ import (
 	"github.com/Gabulhas/polygon-external-consensus/blockchain"
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/consensus"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/sealing"
+	sealingProto "github.com/Gabulhas/polygon-external-consensus/consensus/sealing/proto"
+	"github.com/Gabulhas/polygon-external-consensus/consensus/switcher"
 	"github.com/Gabulhas/polygon-external-consensus/crypto"
 	"github.com/Gabulhas/polygon-external-consensus/helper/common"
 	configHelper "github.com/Gabulhas/polygon-external-consensus/helper/config"
@@ -23,12 +26,14 @@ import (
 	"github.com/Gabulhas/polygon-external-consensus/jsonrpc"
 	"github.com/Gabulhas/polygon-external-consensus/network"
 	"github.com/Gabulhas/polygon-external-consensus/secrets"
+	secretsHelper "github.com/Gabulhas/polygon-external-consensus/secrets/helper"
 	"github.com/Gabulhas/polygon-external-consensus/server/proto"
 	"github.com/Gabulhas/polygon-external-consensus/state"
 	itrie "github.com/Gabulhas/polygon-external-consensus/state/immutable-trie"
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime/evm"
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime/precompiled"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
 	"github.com/Gabulhas/polygon-external-consensus/txpool"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/hashicorp/go-hclog"
@@ -193,8 +198,8 @@ func NewServer(config *Config) (*Server, error) {
 	genesisRoot := m.executor.WriteGenesis(config.Chain.Genesis.Alloc)
 	config.Chain.Genesis.StateRoot = genesisRoot
 
-	// use the eip155 signer
-	signer := crypto.NewEIP155Signer(uint64(m.config.Chain.Params.ChainID))
+	// use the london signer, the typed transactions are enabled by the forks
+	signer := crypto.NewLondonSigner(uint64(m.config.Chain.Params.ChainID))
 
 	// blockchain object
 	m.blockchain, err = blockchain.NewBlockchain(logger, m.config.DataDir, config.Chain, nil, m.executor, signer)
@@ -227,7 +232,12 @@ func NewServer(config *Config) (*Server, error) {
 				MaxSlots:            m.config.MaxSlots,
 				PriceLimit:          m.config.PriceLimit,
 				MaxAccountEnqueued:  m.config.MaxAccountEnqueued,
+				MaxAccountPromoted:  m.config.MaxAccountPromoted,
+				PriceBump:           m.config.PriceBump,
 				DeploymentWhitelist: deploymentWhitelist,
+				Journal:             m.config.TxJournal,
+				JournalRotate:       time.Duration(m.config.TxJournalRotate) * time.Second,
+				PrivateTxLifetime:   m.config.PrivateTxLifetime,
 			},
 		)
 		if err != nil {
@@ -388,49 +398,93 @@ func (s *Server) setupSecretsManager() error {
 
 // setupConsensus sets up the consensus mechanism
 func (s *Server) setupConsensus() error {
-	engineName := s.config.Chain.Params.GetEngine()
-	engine, ok := consensusBackends[ConsensusType(engineName)]
-
-	if !ok {
-		return fmt.Errorf("consensus engine '%s' not found", engineName)
+	schedule, err := s.config.Chain.Params.GetEngineSchedule()
+	if err != nil {
+		return err
 	}
 
-	engineConfig, ok := s.config.Chain.Params.Engine[engineName].(map[string]interface{})
-	if !ok {
-		engineConfig = map[string]interface{}{}
+	if len(schedule) == 1 {
+		factory, params, err := s.consensusFactory(schedule[0])
+		if err != nil {
+			return err
+		}
+
+		if s.consensus, err = factory(params); err != nil {
+			return err
+		}
+	} else if err := s.setupConsensusSwitcher(schedule); err != nil {
+		return err
 	}
 
-	config := &consensus.Config{
-		Params: s.config.Chain.Params,
-		Config: engineConfig,
-		Path:   filepath.Join(s.config.DataDir, "consensus"),
+	// the sealing policy of the engine can be changed at runtime
+	if sealer, ok := s.consensus.(sealing.Sealer); ok {
+		sealingProto.RegisterSealingOperatorServer(s.grpcServer, sealing.NewOperator(sealer))
 	}
 
-	consensus, err := engine(
-		&consensus.Params{
-			Context:        context.Background(),
-			Config:         config,
-			TxPool:         s.txpool,
-			Network:        s.network,
-			Blockchain:     s.blockchain,
-			Executor:       s.executor,
-			Grpc:           s.grpcServer,
-			Logger:         s.logger,
-			Metrics:        s.serverMetrics.consensus,
-			SecretsManager: s.secretsManager,
-			BlockTime:      s.config.BlockTime,
-		},
-	)
+	return nil
+}
+
+// setupConsensusSwitcher sets up the consensus engines scheduled by block height
+func (s *Server) setupConsensusSwitcher(schedule []*chain.EngineTransition) error {
+	engines := make([]*switcher.Engine, 0, len(schedule))
+
+	for _, transition := range schedule {
+		factory, params, err := s.consensusFactory(transition)
+		if err != nil {
+			return err
+		}
+
+		engine, err := switcher.NewEngine(transition.Name, transition.From, factory, params)
+		if err != nil {
+			return fmt.Errorf("failed to create consensus engine '%s': %w", transition.Name, err)
+		}
+
+		engines = append(engines, engine)
+	}
 
+	consensusSwitcher, err := switcher.NewSwitcher(s.logger, s.blockchain, engines)
 	if err != nil {
 		return err
 	}
 
-	s.consensus = consensus
+	// the blocks are hashed as their engine requires
+	types.HeaderHash = consensusSwitcher.HeaderHash
+
+	s.consensus = consensusSwitcher
 
 	return nil
 }
 
+// consensusFactory returns the factory of the engine and its parameters
+func (s *Server) consensusFactory(
+	transition *chain.EngineTransition,
+) (consensus.Factory, *consensus.Params, error) {
+	engine, ok := consensusBackends[ConsensusType(transition.Name)]
+	if !ok {
+		return nil, nil, fmt.Errorf("consensus engine '%s' not found", transition.Name)
+	}
+
+	config := &consensus.Config{
+		Params: s.config.Chain.Params,
+		Config: transition.Config,
+		Path:   filepath.Join(s.config.DataDir, "consensus"),
+	}
+
+	return engine, &consensus.Params{
+		Context:        context.Background(),
+		Config:         config,
+		TxPool:         s.txpool,
+		Network:        s.network,
+		Blockchain:     s.blockchain,
+		Executor:       s.executor,
+		Grpc:           s.grpcServer,
+		Logger:         s.logger,
+		Metrics:        s.serverMetrics.consensus,
+		SecretsManager: s.secretsManager,
+		BlockTime:      s.config.BlockTime,
+	}, nil
+}
+
 type jsonRPCHub struct {
 	state              state.State
 	restoreProgression *progress.ProgressionWrapper
@@ -466,6 +520,22 @@ func (j *jsonRPCHub) getState(root types.Hash, slot []byte) ([]byte, error) {
 	return result, nil
 }
 
+// GetProof returns the merkle proof of the key in the trie with the given root
+func (j *jsonRPCHub) GetProof(root types.Hash, key []byte) ([][]byte, error) {
+	snap, err := j.state.NewSnapshotAt(root)
+	if err != nil {
+		return nil, err
+	}
+
+	trie, ok := snap.(*itrie.Trie)
+	if !ok {
+		return nil, errors.New("the state doesn't support proofs")
+	}
+
+	// the values in the trie are the hashed objects of the keys
+	return trie.Prove(keccak.Keccak256(nil, key))
+}
+
 func (j *jsonRPCHub) GetAccount(root types.Hash, addr types.Address) (*state.Account, error) {
 	obj, err := j.getState(root, addr.Bytes())
 	if err != nil {
@@ -515,20 +585,153 @@ func (j *jsonRPCHub) ApplyTxn(
 	header *types.Header,
 	txn *types.Transaction,
 ) (result *runtime.ExecutionResult, err error) {
+	transition, err := j.beginTxn(header, txn)
+	if err != nil {
+		return
+	}
+
+	result, err = transition.Apply(txn)
+
+	return
+}
+
+// ApplyTxnWithAccessList applies the transaction on top of the header,
+// and returns the accounts and storage slots it accessed
+func (j *jsonRPCHub) ApplyTxnWithAccessList(
+	header *types.Header,
+	txn *types.Transaction,
+) (*runtime.ExecutionResult, types.TxAccessList, error) {
+	transition, err := j.beginTxn(header, txn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := transition.Apply(txn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, transition.AccessList(txn), nil
+}
+
+// beginTxn begins a transition on top of the header, credited to its block creator.
+// The transactions not paying for gas are applied without the base fee, since they can't pay it
+func (j *jsonRPCHub) beginTxn(header *types.Header, txn *types.Transaction) (*state.Transition, error) {
+	if feeCap := txn.GetGasFeeCap(); feeCap == nil || feeCap.Sign() == 0 {
+		header = header.Copy()
+		header.BaseFee = 0
+	}
+
 	blockCreator, err := j.GetConsensus().GetBlockCreator(header)
 	if err != nil {
 		return nil, err
 	}
 
-	transition, err := j.BeginTxn(header.StateRoot, header, blockCreator)
+	return j.BeginTxn(header.StateRoot, header, blockCreator)
+}
+
+// TraceBlock replays the transactions of the block on top of its parent,
+// each traced by the tracer at the same index
+func (j *jsonRPCHub) TraceBlock(block *types.Block, tracers []tracer.Tracer) error {
+	if len(tracers) != len(block.Transactions) {
+		return errors.New("the number of tracers doesn't match the number of transactions")
+	}
 
+	transition, err := j.beginBlockTxn(block)
 	if err != nil {
-		return
+		return err
 	}
 
-	result, err = transition.Apply(txn)
+	for i, txn := range block.Transactions {
+		transition.SetTracer(tracers[i])
 
-	return
+		if err := transition.Write(txn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TraceTxn replays the transactions of the block on top of its parent
+// up to the one with the given hash, which is traced
+func (j *jsonRPCHub) TraceTxn(block *types.Block, txHash types.Hash, tr tracer.Tracer) error {
+	transition, err := j.beginBlockTxn(block)
+	if err != nil {
+		return err
+	}
+
+	for _, txn := range block.Transactions {
+		if txn.Hash == txHash {
+			transition.SetTracer(tr)
+
+			return transition.Write(txn)
+		}
+
+		if err := transition.Write(txn); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("transaction %s not found in block %d", txHash, block.Number())
+}
+
+// TraceBlockStateDiff replays the transactions of the block like TraceBlock,
+// and returns the state changed by each of them
+func (j *jsonRPCHub) TraceBlockStateDiff(block *types.Block, tracers []tracer.Tracer) ([]state.StateDiff, error) {
+	if len(tracers) != len(block.Transactions) {
+		return nil, errors.New("the number of tracers doesn't match the number of transactions")
+	}
+
+	transition, err := j.beginBlockTxn(block)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]state.StateDiff, len(block.Transactions))
+
+	for i, txn := range block.Transactions {
+		transition.SetTracer(tracers[i])
+
+		prev := transition.Txn().Copy()
+
+		if err := transition.Write(txn); err != nil {
+			return nil, err
+		}
+
+		diffs[i] = transition.Txn().Diff(prev)
+	}
+
+	return diffs, nil
+}
+
+// TraceCall traces the transaction applied on top of the header
+func (j *jsonRPCHub) TraceCall(txn *types.Transaction, header *types.Header, tr tracer.Tracer) error {
+	transition, err := j.beginTxn(header, txn)
+	if err != nil {
+		return err
+	}
+
+	transition.SetTracer(tr)
+
+	_, err = transition.Apply(txn)
+
+	return err
+}
+
+// beginBlockTxn begins a transition on top of the parent of the block, to replay its transactions
+func (j *jsonRPCHub) beginBlockTxn(block *types.Block) (*state.Transition, error) {
+	parent, ok := j.GetHeaderByHash(block.ParentHash())
+	if !ok {
+		return nil, blockchain.ErrParentNotFound
+	}
+
+	blockCreator, err := j.GetConsensus().GetBlockCreator(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.BeginTxn(parent.StateRoot, block.Header, blockCreator)
 }
 
 func (j *jsonRPCHub) GetSyncProgression() *progress.Progression {
@@ -568,6 +771,24 @@ func (s *Server) setupJSONRPC() error {
 		PriceLimit:               s.config.PriceLimit,
 		BatchLengthLimit:         s.config.JSONRPC.BatchLengthLimit,
 		BlockRangeLimit:          s.config.JSONRPC.BlockRangeLimit,
+		IPCPath:                  s.config.JSONRPC.IPCPath,
+		RateLimit:                s.config.JSONRPC.RateLimit,
+		Metrics:                  s.serverMetrics.jsonrpc,
+		EnableDebug:              s.config.JSONRPC.EnableDebug,
+	}
+
+	// the chain can be controlled through the endpoints only with the dev consensus
+	if devStore, ok := s.consensus.(jsonrpc.DevStore); ok {
+		conf.DevStore = devStore
+	}
+
+	if s.config.JSONRPC.Auth != nil {
+		auth, err := s.setupJSONRPCAuth()
+		if err != nil {
+			return err
+		}
+
+		conf.Auth = auth
 	}
 
 	srv, err := jsonrpc.NewJSONRPC(s.logger, conf)
@@ -580,6 +801,38 @@ func (s *Server) setupJSONRPC() error {
 	return nil
 }
 
+// setupJSONRPCAuth sets up the authentication of the JSON-RPC server,
+// the JWT secret is generated if it's not in the secrets manager yet
+func (s *Server) setupJSONRPCAuth() (*jsonrpc.AuthConfig, error) {
+	authConfig := s.config.JSONRPC.Auth
+
+	auth := &jsonrpc.AuthConfig{
+		JWTMethods: authConfig.JWTMethods,
+		APIKeys:    authConfig.APIKeys,
+	}
+
+	if !authConfig.JWT {
+		return auth, nil
+	}
+
+	if !s.secretsManager.HasSecret(secrets.JSONRPCJWTSecret) {
+		if _, err := secretsHelper.InitJSONRPCJWTSecret(s.secretsManager); err != nil {
+			return nil, fmt.Errorf("unable to generate JSON-RPC JWT secret: %w", err)
+		}
+
+		s.logger.Info("generated JSON-RPC JWT secret", "name", secrets.JSONRPCJWTSecret)
+	}
+
+	secret, err := secretsHelper.LoadJSONRPCJWTSecret(s.secretsManager)
+	if err != nil {
+		return nil, err
+	}
+
+	auth.JWTSecret = secret
+
+	return auth, nil
+}
+
 // setupGRPC sets up the grpc server and listens on tcp
 func (s *Server) setupGRPC() error {
 	proto.RegisterSystemServer(s.grpcServer, &systemService{server: s})
@@ -627,6 +880,13 @@ func (s *Server) Close() {
 		s.logger.Error("failed to close consensus", "err", err.Error())
 	}
 
+	// Close the JSON-RPC servers
+	if s.jsonrpcServer != nil {
+		if err := s.jsonrpcServer.Close(); err != nil {
+			s.logger.Error("failed to close JSON-RPC server", "err", err.Error())
+		}
+	}
+
 	// Close the state storage
 	if err := s.stateStorage.Close(); err != nil {
 		s.logger.Error("failed to close storage for trie", "err", err.Error())
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/Gabulhas/polygon-external-consensus/consensus"
+	"github.com/Gabulhas/polygon-external-consensus/jsonrpc"
 	"github.com/Gabulhas/polygon-external-consensus/network"
 	"github.com/Gabulhas/polygon-external-consensus/txpool"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -16,6 +17,7 @@ type serverMetrics struct {
 	consensus *consensus.Metrics
 	network   *network.Metrics
 	txpool    *txpool.Metrics
+	jsonrpc   *jsonrpc.Metrics
 }
 
 // metricProvider serverMetric instance for the given ChainID and nameSpace
@@ -25,6 +27,7 @@ func metricProvider(nameSpace string, chainID string, metricsRequired bool) *ser
 			consensus: consensus.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 			network:   network.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 			txpool:    txpool.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
+			jsonrpc:   jsonrpc.GetPrometheusMetrics(nameSpace, "chain_id", chainID),
 		}
 	}
 
@@ -32,6 +35,7 @@ func metricProvider(nameSpace string, chainID string, metricsRequired bool) *ser
 		consensus: consensus.NilMetrics(),
 		network:   network.NilMetrics(),
 		txpool:    txpool.NilMetrics(),
+		jsonrpc:   jsonrpc.NilMetrics(),
 	}
 }
 
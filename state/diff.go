@@ -0,0 +1,110 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+// StorageDiff is the change of a storage slot
+type StorageDiff struct {
+	Before types.Hash
+	After  types.Hash
+}
+
+// AccountDiff is the change of an account between two states
+type AccountDiff struct {
+	// Existed and Exists tell if the account exists before and after the change
+	Existed bool
+	Exists  bool
+
+	BalanceBefore *big.Int
+	BalanceAfter  *big.Int
+	NonceBefore   uint64
+	NonceAfter    uint64
+
+	// CodeBefore and CodeAfter are only set if the code changed
+	CodeBefore []byte
+	CodeAfter  []byte
+
+	// Storage has the changed slots only
+	Storage map[types.Hash]StorageDiff
+}
+
+// StateDiff is the set of the changed accounts
+type StateDiff map[types.Address]*AccountDiff
+
+// Diff returns the accounts changed since the given copy of the txn.
+// The changes are found in the pending objects of the txn, the ones
+// committed in between (before Byzantium) are not part of the diff
+func (txn *Txn) Diff(prev *Txn) StateDiff {
+	diff := StateDiff{}
+
+	txn.txn.Root().Walk(func(k []byte, v interface{}) bool {
+		obj, ok := v.(*StateObject)
+		if !ok || len(k) != types.AddressLength {
+			return false
+		}
+
+		// the objects are replaced on every change
+		if prevObj, ok := prev.txn.Get(k); ok && prevObj == v {
+			return false
+		}
+
+		addr := types.BytesToAddress(k)
+
+		account := &AccountDiff{
+			Existed:       prev.Exist(addr),
+			Exists:        txn.Exist(addr),
+			BalanceBefore: prev.GetBalance(addr),
+			BalanceAfter:  txn.GetBalance(addr),
+			NonceBefore:   prev.GetNonce(addr),
+			NonceAfter:    txn.GetNonce(addr),
+			Storage:       map[types.Hash]StorageDiff{},
+		}
+
+		if prev.codeHash(addr) != txn.codeHash(addr) {
+			account.CodeBefore = prev.GetCode(addr)
+			account.CodeAfter = txn.GetCode(addr)
+		}
+
+		if obj.Txn != nil {
+			obj.Txn.Root().Walk(func(k []byte, _ interface{}) bool {
+				key := types.BytesToHash(k)
+
+				before, after := prev.GetState(addr, key), txn.GetState(addr, key)
+				if before != after {
+					account.Storage[key] = StorageDiff{Before: before, After: after}
+				}
+
+				return false
+			})
+		}
+
+		if !account.unchanged() {
+			diff[addr] = account
+		}
+
+		return false
+	})
+
+	return diff
+}
+
+// codeHash returns the hash of the code of the account, the one of the empty code if it doesn't exist
+func (txn *Txn) codeHash(addr types.Address) types.Hash {
+	if hash := txn.GetCodeHash(addr); hash != emptyHash {
+		return hash
+	}
+
+	return emptyCodeHashTwo
+}
+
+func (a *AccountDiff) unchanged() bool {
+	return a.Existed == a.Exists &&
+		a.BalanceBefore.Cmp(a.BalanceAfter) == 0 &&
+		a.NonceBefore == a.NonceAfter &&
+		bytes.Equal(a.CodeBefore, a.CodeAfter) &&
+		len(a.Storage) == 0
+}
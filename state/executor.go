@@ -7,12 +7,14 @@ import (
 	"math/big"
 
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime/evm"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
 
 	"github.com/hashicorp/go-hclog"
 
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/crypto"
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/precompiled"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 )
 
@@ -21,6 +23,9 @@ const (
 
 	TxGas                 uint64 = 21000 // Per transaction not creating a contract
 	TxGasContractCreation uint64 = 53000 // Per transaction that creates a contract
+
+	TxAccessListAddressGas    uint64 = 2400 // Per account in the access list of the transaction
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage slot in the access list of the transaction
 )
 
 var emptyCodeHashTwo = types.BytesToHash(crypto.Keccak256(nil))
@@ -156,6 +161,7 @@ func (e *Executor) BeginTxn(
 		Difficulty: types.BytesToHash(new(big.Int).SetUint64(header.Difficulty).Bytes()),
 		GasLimit:   int64(header.GasLimit),
 		ChainID:    int64(e.config.ChainID),
+		BaseFee:    types.BytesToHash(new(big.Int).SetUint64(header.BaseFee).Bytes()),
 	}
 
 	txn := &Transition{
@@ -167,6 +173,9 @@ func (e *Executor) BeginTxn(
 		auxState: e.state,
 		config:   config,
 		gasPool:  uint64(env2.GasLimit),
+		baseFee:  header.BaseFee,
+
+		baseFeeCollector: e.config.BaseFeeCollector,
 
 		receipts: []*types.Receipt{},
 		totalGas: 0,
@@ -191,9 +200,16 @@ type Transition struct {
 	ctx     runtime.TxContext
 	gasPool uint64
 
+	// the base fee of the block, and the account collecting it, burned if not set (EIP-1559)
+	baseFee          uint64
+	baseFeeCollector *types.Address
+
 	// result
 	receipts []*types.Receipt
 	totalGas uint64
+
+	// tracer of the transactions, nil if they are not traced
+	tracer tracer.Tracer
 }
 
 func NewTransition(config chain.ForksInTime, radix *Txn) *Transition {
@@ -208,6 +224,16 @@ func NewTransition(config chain.ForksInTime, radix *Txn) *Transition {
 	}
 }
 
+// SetTracer sets the tracer of the next transactions, nil stops the tracing
+func (t *Transition) SetTracer(tr tracer.Tracer) {
+	t.tracer = tr
+}
+
+// GetTracer returns the tracer of the transactions
+func (t *Transition) GetTracer() tracer.Tracer {
+	return t.tracer
+}
+
 func (t *Transition) TotalGas() uint64 {
 	return t.totalGas
 }
@@ -232,6 +258,7 @@ func (t *Transition) WriteFailedReceipt(txn *types.Transaction) error {
 	}
 
 	receipt := &types.Receipt{
+		TransactionType:   txn.Type,
 		CumulativeGasUsed: t.totalGas,
 		TxHash:            txn.Hash,
 		Logs:              t.state.Logs(),
@@ -278,6 +305,7 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	var root []byte
 
 	receipt := &types.Receipt{
+		TransactionType:   txn.Type,
 		CumulativeGasUsed: t.totalGas,
 		TxHash:            txn.Hash,
 		GasUsed:           result.GasUsed,
@@ -313,6 +341,38 @@ func (t *Transition) Write(txn *types.Transaction) error {
 	return nil
 }
 
+// TransitionSnapshot is a point of the transition to revert to,
+// discarding the transactions written afterwards
+type TransitionSnapshot struct {
+	state    *Txn
+	id       int
+	receipts int
+	totalGas uint64
+	gasPool  uint64
+}
+
+// Snapshot takes a snapshot of the transition at this point
+func (t *Transition) Snapshot() *TransitionSnapshot {
+	return &TransitionSnapshot{
+		state:    t.state,
+		id:       t.state.Snapshot(),
+		receipts: len(t.receipts),
+		totalGas: t.totalGas,
+		gasPool:  t.gasPool,
+	}
+}
+
+// RevertToSnapshot reverts the state, receipts and gas of the transition to the snapshot
+func (t *Transition) RevertToSnapshot(snapshot *TransitionSnapshot) {
+	// the state is replaced on each transaction before Byzantium
+	t.state = snapshot.state
+	t.state.RevertToSnapshot(snapshot.id)
+
+	t.receipts = t.receipts[:snapshot.receipts]
+	t.totalGas = snapshot.totalGas
+	t.gasPool = snapshot.gasPool
+}
+
 // Commit commits the final result
 func (t *Transition) Commit() (Snapshot, types.Hash) {
 	objs := t.state.Commit(t.config.EIP155)
@@ -369,10 +429,16 @@ func (t *Transition) ContextPtr() *runtime.TxContext {
 	return &t.ctx
 }
 
-func (t *Transition) subGasLimitPrice(msg *types.Transaction) error {
+func (t *Transition) subGasLimitPrice(msg *types.Transaction, gasPrice *big.Int) error {
+	gas := new(big.Int).SetUint64(msg.Gas)
+
+	// the caller must be able to pay the gas at the fee cap
+	if maxGasCost := new(big.Int).Mul(msg.GetGasFeeCap(), gas); t.state.GetBalance(msg.From).Cmp(maxGasCost) < 0 {
+		return ErrNotEnoughFundsForGas
+	}
+
 	// deduct the upfront max gas cost
-	upfrontGasCost := new(big.Int).Set(msg.GasPrice)
-	upfrontGasCost.Mul(upfrontGasCost, new(big.Int).SetUint64(msg.Gas))
+	upfrontGasCost := new(big.Int).Mul(gasPrice, gas)
 
 	if err := t.state.SubBalance(msg.From, upfrontGasCost); err != nil {
 		if errors.Is(err, runtime.ErrNotEnoughFunds) {
@@ -405,6 +471,8 @@ var (
 	ErrIntrinsicGasOverflow  = fmt.Errorf("overflow in intrinsic gas calculation")
 	ErrNotEnoughIntrinsicGas = fmt.Errorf("not enough gas supplied for intrinsic gas costs")
 	ErrNotEnoughFunds        = fmt.Errorf("not enough funds for transfer with given value")
+	ErrTipAboveFeeCap        = fmt.Errorf("max priority fee per gas higher than max fee per gas")
+	ErrFeeCapTooLow          = fmt.Errorf("max fee per gas less than block base fee")
 )
 
 type TransitionApplicationError struct {
@@ -445,13 +513,27 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 	// 6. caller has enough balance to cover asset transfer for **topmost** call
 	txn := t.state
 
+	// typed transactions are only valid once their fork is enabled
+	if !TxTypeEnabled(t.config, msg.Type) {
+		return nil, NewTransitionApplicationError(types.ErrTxTypeNotSupported, false)
+	}
+
+	// the transaction must pay the base fee (EIP-1559)
+	if t.config.London {
+		if err := t.checkFeeCaps(msg); err != nil {
+			return nil, err
+		}
+	}
+
 	// 1. the nonce of the message caller is correct
 	if err := t.nonceCheck(msg); err != nil {
 		return nil, NewTransitionApplicationError(err, true)
 	}
 
+	gasPrice := msg.EffectiveGasPrice(t.baseFee)
+
 	// 2. caller has enough balance to cover transaction fee(gaslimit * gasprice)
-	if err := t.subGasLimitPrice(msg); err != nil {
+	if err := t.subGasLimitPrice(msg, gasPrice); err != nil {
 		return nil, NewTransitionApplicationError(err, true)
 	}
 
@@ -460,8 +542,13 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, NewGasLimitReachedTransitionApplicationError(err)
 	}
 
+	// the initcode of a contract creation is limited (EIP-3860)
+	if t.config.Shanghai && msg.IsContractCreation() && len(msg.Input) > runtime.MaxInitCodeSize {
+		return nil, NewTransitionApplicationError(runtime.ErrMaxInitCodeSizeExceeded, false)
+	}
+
 	// 4. there is no overflow when calculating intrinsic gas
-	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul)
+	intrinsicGasCost, err := TransactionGasCost(msg, t.config.Homestead, t.config.Istanbul, t.config.Shanghai)
 	if err != nil {
 		return nil, NewTransitionApplicationError(err, false)
 	}
@@ -478,13 +565,23 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		return nil, NewTransitionApplicationError(ErrNotEnoughFunds, true)
 	}
 
-	gasPrice := new(big.Int).Set(msg.GasPrice)
 	value := new(big.Int).Set(msg.Value)
 
 	// Set the specific transaction fields in the context
 	t.ctx.GasPrice = types.BytesToHash(gasPrice.Bytes())
 	t.ctx.Origin = msg.From
 
+	if t.config.Berlin {
+		t.prepareAccessList(msg)
+	}
+
+	// the transient storage only lasts for the transaction (EIP-1153)
+	txn.ClearTransientStorage()
+
+	if t.tracer != nil {
+		t.tracer.CaptureTxStart(msg.Gas)
+	}
+
 	var result *runtime.ExecutionResult
 	if msg.IsContractCreation() {
 		result = t.Create2(msg.From, msg.Input, value, gasLeft)
@@ -493,23 +590,125 @@ func (t *Transition) apply(msg *types.Transaction) (*runtime.ExecutionResult, er
 		result = t.Call2(msg.From, *msg.To, msg.Input, value, gasLeft)
 	}
 
+	refundQuotient := runtime.RefundQuotient
+	if t.config.London {
+		refundQuotient = runtime.RefundQuotientEIP3529
+	}
+
 	refund := txn.GetRefund()
-	result.UpdateGasUsed(msg.Gas, refund)
+	result.UpdateGasUsed(msg.Gas, refund, refundQuotient)
+
+	if t.tracer != nil {
+		t.tracer.CaptureTxEnd(result.GasLeft)
+	}
 
 	// refund the sender
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(result.GasLeft), gasPrice)
 	txn.AddBalance(msg.From, remaining)
 
-	// pay the coinbase
-	coinbaseFee := new(big.Int).Mul(new(big.Int).SetUint64(result.GasUsed), gasPrice)
+	gasUsed := new(big.Int).SetUint64(result.GasUsed)
+
+	// pay the coinbase, the base fee is burned or collected
+	coinbaseFee := new(big.Int).Sub(gasPrice, new(big.Int).SetUint64(t.baseFee))
+	coinbaseFee.Mul(coinbaseFee, gasUsed)
 	txn.AddBalance(t.ctx.Coinbase, coinbaseFee)
 
+	if t.baseFee != 0 && t.baseFeeCollector != nil {
+		baseFee := new(big.Int).SetUint64(t.baseFee)
+		txn.AddBalance(*t.baseFeeCollector, baseFee.Mul(baseFee, gasUsed))
+	}
+
 	// return gas to the pool
 	t.addGasPool(result.GasLeft)
 
 	return result, nil
 }
 
+// TxTypeEnabled checks if the transaction type is enabled by the forks
+func TxTypeEnabled(forks chain.ForksInTime, txType types.TxType) bool {
+	switch txType {
+	case types.LegacyTx:
+		return true
+	case types.AccessListTx:
+		return forks.Berlin
+	case types.DynamicFeeTx:
+		return forks.London
+	default:
+		return false
+	}
+}
+
+// checkFeeCaps checks the transaction pays at least the base fee of the block (EIP-1559)
+func (t *Transition) checkFeeCaps(msg *types.Transaction) error {
+	if msg.GetGasFeeCap().Cmp(msg.GetGasTipCap()) < 0 {
+		return NewTransitionApplicationError(ErrTipAboveFeeCap, false)
+	}
+
+	// the base fee may decrease in the following blocks
+	if msg.GetGasFeeCap().Cmp(new(big.Int).SetUint64(t.baseFee)) < 0 {
+		return NewTransitionApplicationError(ErrFeeCapTooLow, true)
+	}
+
+	return nil
+}
+
+// implicitAccessList returns the accounts warmed up before the execution of the message,
+// the sender, the recipient or the created contract, and the precompiled contracts (EIP-2929).
+// The coinbase is warm too after Shanghai (EIP-3651)
+func (t *Transition) implicitAccessList(msg *types.Transaction) []types.Address {
+	to := crypto.CreateAddress(msg.From, msg.Nonce)
+	if msg.To != nil {
+		to = *msg.To
+	}
+
+	addrs := append([]types.Address{msg.From, to}, precompiled.Addresses(&t.config)...)
+
+	if t.config.Shanghai {
+		addrs = append(addrs, t.ctx.Coinbase)
+	}
+
+	return addrs
+}
+
+// prepareAccessList resets the access list with the accounts warmed up by default,
+// and the accounts and storage slots of the access list of the message (EIP-2930)
+func (t *Transition) prepareAccessList(msg *types.Transaction) {
+	t.state.ClearAccessList()
+
+	for _, addr := range t.implicitAccessList(msg) {
+		t.state.AddAddressToAccessList(addr)
+	}
+
+	for _, tuple := range msg.AccessList {
+		t.state.AddAddressToAccessList(tuple.Address)
+
+		for _, key := range tuple.StorageKeys {
+			t.state.AddSlotToAccessList(tuple.Address, key)
+		}
+	}
+}
+
+// AccessList returns the accounts and storage slots accessed by the last applied message.
+// The accounts warmed up by default are left out, unless their storage was accessed
+func (t *Transition) AccessList(msg *types.Transaction) types.TxAccessList {
+	implicit := map[types.Address]struct{}{}
+	for _, addr := range t.implicitAccessList(msg) {
+		implicit[addr] = struct{}{}
+	}
+
+	list := types.TxAccessList{}
+
+	for _, tuple := range t.state.AccessList() {
+		if _, ok := implicit[tuple.Address]; ok && len(tuple.StorageKeys) == 0 {
+			continue
+		}
+
+		list = append(list, tuple)
+	}
+
+	return list
+}
+
 func (t *Transition) Create2(
 	caller types.Address,
 	code []byte,
@@ -568,7 +767,7 @@ func (t *Transition) applyCall(
 	c *runtime.Contract,
 	callType runtime.CallType,
 	host runtime.Host,
-) *runtime.ExecutionResult {
+) (result *runtime.ExecutionResult) {
 	if c.Depth > int(1024)+1 {
 		return &runtime.ExecutionResult{
 			GasLeft: c.Gas,
@@ -576,6 +775,14 @@ func (t *Transition) applyCall(
 		}
 	}
 
+	if t.tracer != nil {
+		t.captureCallStart(c, callType)
+
+		defer func() {
+			t.captureCallEnd(c, result)
+		}()
+	}
+
 	snapshot := t.state.Snapshot()
 	t.state.TouchAccount(c.Address)
 
@@ -589,7 +796,7 @@ func (t *Transition) applyCall(
 		}
 	}
 
-	result := t.run(c, host)
+	result = t.run(c, host)
 	if result.Failed() {
 		t.state.RevertToSnapshot(snapshot)
 	}
@@ -597,6 +804,16 @@ func (t *Transition) applyCall(
 	return result
 }
 
+// captureCallStart passes the call frame being entered to the tracer
+func (t *Transition) captureCallStart(c *runtime.Contract, callType runtime.CallType) {
+	t.tracer.CaptureEnter(callType.String(), c.Caller, c.Address, c.Input, c.Gas, c.Value)
+}
+
+// captureCallEnd passes the result of the call frame to the tracer
+func (t *Transition) captureCallEnd(c *runtime.Contract, result *runtime.ExecutionResult) {
+	t.tracer.CaptureExit(result.ReturnValue, c.Gas-result.GasLeft, result.Err)
+}
+
 var emptyHash types.Hash
 
 func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
@@ -614,7 +831,7 @@ func (t *Transition) hasCodeOrNonce(addr types.Address) bool {
 	return false
 }
 
-func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtime.ExecutionResult {
+func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) (result *runtime.ExecutionResult) {
 	gasLimit := c.Gas
 
 	if c.Depth > int(1024)+1 {
@@ -624,9 +841,22 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
+	if t.tracer != nil {
+		t.captureCallStart(c, c.Type)
+
+		defer func() {
+			t.captureCallEnd(c, result)
+		}()
+	}
+
 	// Increment the nonce of the caller
 	t.state.IncrNonce(c.Caller)
 
+	// the created account is warm even if the creation fails (EIP-2929)
+	if t.config.Berlin {
+		t.state.AddAddressToAccessList(c.Address)
+	}
+
 	// Check if there if there is a collision and the address already exists
 	if t.hasCodeOrNonce(c.Address) {
 		return &runtime.ExecutionResult{
@@ -652,7 +882,7 @@ func (t *Transition) applyCreate(c *runtime.Contract, host runtime.Host) *runtim
 		}
 	}
 
-	result := t.run(c, host)
+	result = t.run(c, host)
 
 	if result.Failed() {
 		t.state.RevertToSnapshot(snapshot)
@@ -745,8 +975,33 @@ func (t *Transition) GetNonce(addr types.Address) uint64 {
 	return t.state.GetNonce(addr)
 }
 
+func (t *Transition) AddressInAccessList(addr types.Address) bool {
+	return t.state.AddressInAccessList(addr)
+}
+
+func (t *Transition) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	return t.state.SlotInAccessList(addr, slot)
+}
+
+func (t *Transition) AddAddressToAccessList(addr types.Address) {
+	t.state.AddAddressToAccessList(addr)
+}
+
+func (t *Transition) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	t.state.AddSlotToAccessList(addr, slot)
+}
+
+func (t *Transition) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return t.state.GetTransientState(addr, key)
+}
+
+func (t *Transition) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	t.state.SetTransientState(addr, key, value)
+}
+
 func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address) {
-	if !t.state.HasSuicided(addr) {
+	// the refund is removed after London (EIP-3529)
+	if !t.config.London && !t.state.HasSuicided(addr) {
 		t.state.AddRefund(24000)
 	}
 
@@ -755,7 +1010,7 @@ func (t *Transition) Selfdestruct(addr types.Address, beneficiary types.Address)
 }
 
 func (t *Transition) Callx(c *runtime.Contract, h runtime.Host) *runtime.ExecutionResult {
-	if c.Type == runtime.Create {
+	if c.Type == runtime.Create || c.Type == runtime.Create2 {
 		return t.applyCreate(c, h)
 	}
 
@@ -793,7 +1048,31 @@ func (t *Transition) SetCodeDirectly(addr types.Address, code []byte) error {
 	return nil
 }
 
-func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (uint64, error) {
+// SetBalanceDirectly sets the balance of the account with the specified address
+// NOTE: SetBalanceDirectly changes the world state without a transaction
+func (t *Transition) SetBalanceDirectly(addr types.Address, balance *big.Int) error {
+	if !t.AccountExists(addr) {
+		return fmt.Errorf("account doesn't exist at %s", addr)
+	}
+
+	t.state.SetBalance(addr, balance)
+
+	return nil
+}
+
+// SetStorageDirectly sets the storage slot of the account with the specified address
+// NOTE: SetStorageDirectly changes the world state without a transaction
+func (t *Transition) SetStorageDirectly(addr types.Address, key, value types.Hash) error {
+	if !t.AccountExists(addr) {
+		return fmt.Errorf("account doesn't exist at %s", addr)
+	}
+
+	t.state.SetStorage(addr, key, value, &t.config)
+
+	return nil
+}
+
+func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul, isShanghai bool) (uint64, error) {
 	cost := uint64(0)
 
 	// Contract creation is only paid on the homestead fork
@@ -831,6 +1110,33 @@ func TransactionGasCost(msg *types.Transaction, isHomestead, isIstanbul bool) (u
 		}
 
 		cost += zeros * 4
+
+		// the words of the initcode are paid upfront (EIP-3860)
+		if msg.IsContractCreation() && isShanghai {
+			words := (uint64(len(payload)) + 31) / 32
+			if (math.MaxUint64-cost)/runtime.InitCodeWordGas < words {
+				return 0, ErrIntrinsicGasOverflow
+			}
+
+			cost += words * runtime.InitCodeWordGas
+		}
+	}
+
+	// the accounts and storage slots of the access list are paid upfront (EIP-2930)
+	if len(msg.AccessList) > 0 {
+		addresses := uint64(len(msg.AccessList))
+		if (math.MaxUint64-cost)/TxAccessListAddressGas < addresses {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += addresses * TxAccessListAddressGas
+
+		keys := uint64(msg.AccessList.StorageKeys())
+		if (math.MaxUint64-cost)/TxAccessListStorageKeyGas < keys {
+			return 0, ErrIntrinsicGasOverflow
+		}
+
+		cost += keys * TxAccessListStorageKeyGas
 	}
 
 	return cost, nil
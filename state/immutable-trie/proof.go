@@ -0,0 +1,157 @@
+package itrie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/umbracle/fastrlp"
+)
+
+var (
+	ErrProofNodeNotFound = errors.New("proof node not found")
+	ErrInvalidProofNode  = errors.New("invalid proof node")
+)
+
+// Prove returns the merkle proof of the key, which is the list of the encoded nodes
+// on the path from the root to the value of the key. If the key is not in the trie,
+// the proof shows its absence
+func (t *Trie) Prove(key []byte) ([][]byte, error) {
+	root, err := t.Txn().Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	proof := [][]byte{}
+
+	_, err = walkProof(root, key, func(hash []byte) ([]byte, bool) {
+		data, ok := t.storage.Get(hash)
+		if ok {
+			proof = append(proof, data)
+		}
+
+		return data, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// VerifyProof checks the merkle proof of the key against the root of the trie,
+// and returns the value of the key, nil if the proof shows it is not in the trie
+func VerifyProof(root types.Hash, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[types.Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[types.BytesToHash(hashit(node))] = node
+	}
+
+	return walkProof(root.Bytes(), key, func(hash []byte) ([]byte, bool) {
+		node, ok := nodes[types.BytesToHash(hash)]
+
+		return node, ok
+	})
+}
+
+// walkProof follows the path of the key from the root, getting the encoded nodes
+// referenced by hash with get. It returns the value of the key, nil if it is not in the trie
+func walkProof(root, key []byte, get func(hash []byte) ([]byte, bool)) ([]byte, error) {
+	if bytes.Equal(root, emptyRoot) {
+		return nil, nil
+	}
+
+	p := parserPool.Get()
+	defer parserPool.Put(p)
+
+	path := bytesToHexNibbles(key)
+	hash := root
+
+	for {
+		data, ok := get(hash)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrProofNodeNotFound, hex.EncodeToHex(hash))
+		}
+
+		v, err := p.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProofNode, err)
+		}
+
+		var value []byte
+
+		if hash, value, err = walkNode(v, &path); err != nil || hash == nil {
+			return value, err
+		}
+	}
+}
+
+// walkNode follows the path through the node and its embedded children. It returns
+// either the hash of the next node of the path or the value of the key, both nil
+// if the key is not in the trie
+func walkNode(v *fastrlp.Value, path *[]byte) ([]byte, []byte, error) {
+	for {
+		if v.Type() != fastrlp.TypeArray {
+			return nil, nil, ErrInvalidProofNode
+		}
+
+		switch v.Elems() {
+		case 2:
+			if v.Get(0).Type() != fastrlp.TypeBytes {
+				return nil, nil, ErrInvalidProofNode
+			}
+
+			key := decodeCompact(v.Get(0).Raw())
+			if !bytes.HasPrefix(*path, key) {
+				return nil, nil, nil
+			}
+
+			*path = (*path)[len(key):]
+			v = v.Get(1)
+
+			if hasTerminator(key) {
+				return nil, copyValue(v), nil
+			}
+
+		case 17:
+			if len(*path) == 0 {
+				return nil, nil, ErrInvalidProofNode
+			}
+
+			idx := (*path)[0]
+			*path = (*path)[1:]
+			v = v.Get(int(idx))
+
+			if idx == 16 {
+				return nil, copyValue(v), nil
+			}
+
+		default:
+			return nil, nil, ErrInvalidProofNode
+		}
+
+		// the children smaller than a hash are embedded in the node
+		if v.Type() == fastrlp.TypeArray {
+			continue
+		}
+
+		switch ref := v.Raw(); len(ref) {
+		case 0:
+			return nil, nil, nil
+		case types.HashLength:
+			return append([]byte{}, ref...), nil, nil
+		default:
+			return nil, nil, ErrInvalidProofNode
+		}
+	}
+}
+
+func copyValue(v *fastrlp.Value) []byte {
+	if v.Type() != fastrlp.TypeBytes || len(v.Raw()) == 0 {
+		return nil
+	}
+
+	return append([]byte{}, v.Raw()...)
+}
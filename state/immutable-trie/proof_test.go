@@ -0,0 +1,178 @@
+package itrie
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// buildTrie commits the entries to a trie written in a memory storage
+func buildTrie(t *testing.T, entries map[string][]byte) (*State, *Trie, types.Hash) {
+	t.Helper()
+
+	storage := NewMemoryStorage()
+	batch := storage.Batch()
+
+	txn := NewTrie().Txn()
+	txn.storage = storage
+	txn.batch = batch
+
+	for k, v := range entries {
+		txn.Insert([]byte(k), v)
+	}
+
+	root, err := txn.Hash()
+	assert.NoError(t, err)
+
+	batch.Write()
+
+	return NewState(storage), txn.Commit(), types.BytesToHash(root)
+}
+
+func randomEntries(n, keySize int) map[string][]byte {
+	//nolint:gosec
+	r := rand.New(rand.NewSource(int64(n)))
+
+	entries := make(map[string][]byte, n)
+
+	for len(entries) < n {
+		key := make([]byte, keySize)
+		r.Read(key)
+
+		value := make([]byte, 1+r.Intn(40))
+		r.Read(value)
+
+		entries[string(key)] = value
+	}
+
+	return entries
+}
+
+func TestTrie_Prove(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries map[string][]byte
+	}{
+		{
+			name:    "single entry",
+			entries: randomEntries(1, 32),
+		},
+		{
+			name:    "hashed keys",
+			entries: randomEntries(200, 32),
+		},
+		{
+			// the nodes smaller than a hash are embedded in their parents
+			name: "embedded nodes",
+			entries: map[string][]byte{
+				"\x01":     {0x1},
+				"\x02":     {0x2},
+				"\x12":     {0x3},
+				"\x12\x34": {0x4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			st, trie, root := buildTrie(t, tt.entries)
+			assert.Equal(t, root, trie.Hash())
+
+			// the trie read back from the storage proves the same
+			snap, err := st.NewSnapshotAt(root)
+			assert.NoError(t, err)
+
+			stored, ok := snap.(*Trie)
+			assert.True(t, ok)
+
+			for k, v := range tt.entries {
+				proof, err := trie.Prove([]byte(k))
+				assert.NoError(t, err)
+				assert.NotEmpty(t, proof)
+
+				value, err := VerifyProof(root, []byte(k), proof)
+				assert.NoError(t, err)
+				assert.Equal(t, v, value)
+
+				storedProof, err := stored.Prove([]byte(k))
+				assert.NoError(t, err)
+				assert.Equal(t, proof, storedProof)
+			}
+		})
+	}
+}
+
+func TestTrie_Prove_Absence(t *testing.T) {
+	t.Parallel()
+
+	entries := randomEntries(50, 32)
+	_, trie, root := buildTrie(t, entries)
+
+	missing := bytes.Repeat([]byte{0xff}, 32)
+
+	proof, err := trie.Prove(missing)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, proof)
+
+	value, err := VerifyProof(root, missing, proof)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+
+	// the empty trie proves the absence of any key
+	emptyTrie := NewTrie()
+	emptyTrie.storage = NewMemoryStorage()
+
+	proof, err = emptyTrie.Prove(missing)
+	assert.NoError(t, err)
+	assert.Empty(t, proof)
+
+	value, err = VerifyProof(types.EmptyRootHash, missing, proof)
+	assert.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestVerifyProof_Invalid(t *testing.T) {
+	t.Parallel()
+
+	entries := randomEntries(50, 32)
+	_, trie, root := buildTrie(t, entries)
+
+	var key []byte
+	for k := range entries {
+		key = []byte(k)
+
+		break
+	}
+
+	proof, err := trie.Prove(key)
+	assert.NoError(t, err)
+	assert.Greater(t, len(proof), 1)
+
+	// a node of the path is missing
+	_, err = VerifyProof(root, key, proof[:len(proof)-1])
+	assert.ErrorIs(t, err, ErrProofNodeNotFound)
+
+	// the proof is not of the root
+	_, err = VerifyProof(types.StringToHash("1"), key, proof)
+	assert.ErrorIs(t, err, ErrProofNodeNotFound)
+
+	// a node is modified, so it is not referenced by its hash anymore
+	tampered := make([][]byte, len(proof))
+	copy(tampered, proof)
+
+	last := append([]byte{}, proof[len(proof)-1]...)
+	last[len(last)-1] ^= 0xff
+	tampered[len(tampered)-1] = last
+
+	_, err = VerifyProof(root, key, tampered)
+	assert.ErrorIs(t, err, ErrProofNodeNotFound)
+}
@@ -38,6 +38,7 @@ func init() {
 	register(SMOD, handler{opSMod, 2, 5})
 	register(EXP, handler{opExp, 2, 10})
 
+	register(PUSH0, handler{opPush0, 0, 2})
 	registerRange(PUSH1, PUSH32, opPush, 3)
 	registerRange(DUP1, DUP16, opDup, 3)
 	registerRange(SWAP1, SWAP16, opSwap, 3)
@@ -81,10 +82,13 @@ func init() {
 	register(MLOAD, handler{opMload, 1, 3})
 	register(MSTORE, handler{opMStore, 2, 3})
 	register(MSTORE8, handler{opMStore8, 2, 3})
+	register(MCOPY, handler{opMCopy, 3, 3})
 
 	// store
 	register(SLOAD, handler{opSload, 1, 0})
 	register(SSTORE, handler{opSStore, 2, 0})
+	register(TLOAD, handler{opTLoad, 1, 100})
+	register(TSTORE, handler{opTStore, 2, 100})
 
 	register(SHA3, handler{opSha3, 2, 30})
 
@@ -106,6 +110,7 @@ func init() {
 	register(GASPRICE, handler{opGasPrice, 0, 2})
 	register(RETURNDATASIZE, handler{opReturnDataSize, 0, 2})
 	register(CHAINID, handler{opChainID, 0, 2})
+	register(BASEFEE, handler{opBaseFee, 0, 2})
 	register(PC, handler{opPC, 0, 2})
 	register(MSIZE, handler{opMSize, 0, 2})
 	register(GAS, handler{opGas, 0, 2})
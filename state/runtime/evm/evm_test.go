@@ -6,6 +6,7 @@ import (
 
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -87,6 +88,34 @@ func (m *mockHost) GetNonce(addr types.Address) uint64 {
 	panic("Not implemented in tests")
 }
 
+func (m *mockHost) AddressInAccessList(addr types.Address) bool {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) AddAddressToAccessList(addr types.Address) {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	panic("Not implemented in tests")
+}
+
+func (m *mockHost) GetTracer() tracer.Tracer {
+	return nil
+}
+
 func TestRun(t *testing.T) {
 	t.Parallel()
 
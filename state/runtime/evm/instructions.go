@@ -459,13 +459,73 @@ func opMStore8(c *state) {
 	c.memory[offset.Uint64()] = byte(val.Uint64() & 0xff)
 }
 
+func opMCopy(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	dst := c.pop()
+	src := c.pop()
+	length := c.pop()
+
+	// the memory is expanded to cover both the source and the destination (EIP-5656)
+	if !c.checkMemory(src, length) || !c.checkMemory(dst, length) {
+		return
+	}
+
+	size := length.Uint64()
+	if !c.consumeGas(((size + 31) / 32) * copyGas) {
+		return
+	}
+
+	if size != 0 {
+		d, s := dst.Uint64(), src.Uint64()
+		copy(c.memory[d:d+size], c.memory[s:s+size])
+	}
+}
+
+// --- access list (eip-2929) ---
+
+const (
+	coldAccountAccessCost uint64 = 2600
+	coldSloadCost         uint64 = 2100
+	warmStorageReadCost   uint64 = 100
+)
+
+// accessAddressGas warms up the account and returns the gas cost of the access
+func (c *state) accessAddressGas(addr types.Address) uint64 {
+	if c.host.AddressInAccessList(addr) {
+		return warmStorageReadCost
+	}
+
+	c.host.AddAddressToAccessList(addr)
+
+	return coldAccountAccessCost
+}
+
+// accessSlotGas warms up the storage slot of the contract and returns the gas cost of the access
+func (c *state) accessSlotGas(slot types.Hash) uint64 {
+	if _, ok := c.host.SlotInAccessList(c.msg.Address, slot); ok {
+		return warmStorageReadCost
+	}
+
+	c.host.AddSlotToAccessList(c.msg.Address, slot)
+
+	return coldSloadCost
+}
+
 // --- storage ---
 
 func opSload(c *state) {
 	loc := c.top()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.accessSlotGas(bigToHash(loc))
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 800
 	} else if c.config.EIP150 {
@@ -500,38 +560,43 @@ func opSStore(c *state) {
 
 	legacyGasMetering := !c.config.Istanbul && (c.config.Petersburg || !c.config.Constantinople)
 
-	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
 	cost := uint64(0)
 
+	if c.config.Berlin {
+		// eip-2929, the access of a cold slot is charged on top
+		if _, ok := c.host.SlotInAccessList(c.msg.Address, key); !ok {
+			c.host.AddSlotToAccessList(c.msg.Address, key)
+
+			cost = coldSloadCost
+		}
+	}
+
+	status := c.host.SetStorage(c.msg.Address, key, val, c.config)
+
 	switch status {
-	case runtime.StorageUnchanged:
-		if c.config.Istanbul {
+	case runtime.StorageUnchanged, runtime.StorageModifiedAgain:
+		if c.config.Berlin {
+			// eip-2929
+			cost += warmStorageReadCost
+		} else if c.config.Istanbul {
 			// eip-2200
-			cost = 800
+			cost += 800
 		} else if legacyGasMetering {
-			cost = 5000
+			cost += 5000
 		} else {
-			cost = 200
+			cost += 200
 		}
 
-	case runtime.StorageModified:
-		cost = 5000
-
-	case runtime.StorageModifiedAgain:
-		if c.config.Istanbul {
-			// eip-2200
-			cost = 800
-		} else if legacyGasMetering {
-			cost = 5000
+	case runtime.StorageModified, runtime.StorageDeleted:
+		if c.config.Berlin {
+			// eip-2929
+			cost += 5000 - coldSloadCost
 		} else {
-			cost = 200
+			cost += 5000
 		}
 
 	case runtime.StorageAdded:
-		cost = 20000
-
-	case runtime.StorageDeleted:
-		cost = 5000
+		cost += 20000
 	}
 
 	if !c.consumeGas(cost) {
@@ -539,6 +604,40 @@ func opSStore(c *state) {
 	}
 }
 
+// --- transient storage (eip-1153) ---
+
+func opTLoad(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	loc := c.top()
+
+	val := c.host.GetTransientStorage(c.msg.Address, bigToHash(loc))
+	loc.SetBytes(val.Bytes())
+}
+
+func opTStore(c *state) {
+	if !c.config.Cancun {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	if c.inStaticCall() {
+		c.exit(errWriteProtection)
+
+		return
+	}
+
+	key := c.popHash()
+	val := c.popHash()
+
+	c.host.SetTransientStorage(c.msg.Address, key, val)
+}
+
 const sha3WordGas uint64 = 6
 
 func opSha3(c *state) {
@@ -575,7 +674,10 @@ func opBalance(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.accessAddressGas(addr)
+	} else if c.config.Istanbul {
 		// eip-1884
 		gas = 700
 	} else if c.config.EIP150 {
@@ -611,6 +713,16 @@ func opChainID(c *state) {
 	c.push1().SetUint64(uint64(c.host.GetTxContext().ChainID))
 }
 
+func opBaseFee(c *state) {
+	if !c.config.London {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().SetBytes(c.host.GetTxContext().BaseFee.Bytes())
+}
+
 func opOrigin(c *state) {
 	c.push1().SetBytes(c.host.GetTxContext().Origin.Bytes())
 }
@@ -658,7 +770,10 @@ func opExtCodeSize(c *state) {
 	addr, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.accessAddressGas(addr)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -693,7 +808,10 @@ func opExtCodeHash(c *state) {
 	address, _ := c.popAddr()
 
 	var gas uint64
-	if c.config.Istanbul {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.accessAddressGas(address)
+	} else if c.config.Istanbul {
 		gas = 700
 	} else {
 		gas = 400
@@ -767,7 +885,10 @@ func opExtCodeCopy(c *state) {
 	}
 
 	var gas uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gas = c.accessAddressGas(address)
+	} else if c.config.EIP150 {
 		gas = 700
 	} else {
 		gas = 20
@@ -917,6 +1038,13 @@ func opSelfDestruct(c *state) {
 	if c.config.EIP150 {
 		gas = 5000
 
+		// eip-2929
+		if c.config.Berlin && !c.host.AddressInAccessList(address) {
+			c.host.AddAddressToAccessList(address)
+
+			gas += coldAccountAccessCost
+		}
+
 		if c.config.EIP158 {
 			// if empty and transfers value
 			if c.host.Empty(address) && c.host.GetBalance(c.msg.Address).Sign() != 0 {
@@ -959,6 +1087,16 @@ func opJumpi(c *state) {
 func opJumpDest(c *state) {
 }
 
+func opPush0(c *state) {
+	if !c.config.Shanghai {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+
+	c.push1().SetUint64(0)
+}
+
 func opPush(n int) instruction {
 	return func(c *state) {
 		ins := c.code
@@ -1078,6 +1216,9 @@ func opCreate(op OpCode) instruction {
 		}
 
 		contract.Type = runtime.Create
+		if op == CREATE2 {
+			contract.Type = runtime.Create2
+		}
 
 		// Correct call
 		result := c.host.Callx(contract, c.host)
@@ -1208,7 +1349,10 @@ func (c *state) buildCallContract(op OpCode) (*runtime.Contract, uint64, uint64,
 	}
 
 	var gasCost uint64
-	if c.config.EIP150 {
+	if c.config.Berlin {
+		// eip-2929
+		gasCost = c.accessAddressGas(addr)
+	} else if c.config.EIP150 {
 		gasCost = 700
 	} else {
 		gasCost = 40
@@ -1327,6 +1471,20 @@ func (c *state) buildCreateContract(op OpCode) (*runtime.Contract, error) {
 		return nil, nil
 	}
 
+	// the initcode is limited, and each of its words is paid (EIP-3860)
+	if c.config.Shanghai {
+		size := uint64(len(input))
+		if size > runtime.MaxInitCodeSize {
+			c.exit(runtime.ErrMaxInitCodeSizeExceeded)
+
+			return nil, nil
+		}
+
+		if !c.consumeGas(((size + 31) / 32) * runtime.InitCodeWordGas) {
+			return nil, nil
+		}
+	}
+
 	// Consume memory resize gas (TODO, change with get2)
 	if !c.consumeGas(gasCost) {
 		return nil, nil
@@ -99,6 +99,168 @@ func TestMStore(t *testing.T) {
 	assert.Len(t, s.memory, 1024+32)
 }
 
+type mockHostForTxContext struct {
+	mockHost
+	txContext runtime.TxContext
+}
+
+func (m *mockHostForTxContext) GetTxContext() runtime.TxContext {
+	return m.txContext
+}
+
+func TestBaseFee(t *testing.T) {
+	t.Run("pushes the base fee of the block after the london fork", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{London: true}
+		s.host = &mockHostForTxContext{
+			txContext: runtime.TxContext{BaseFee: types.BytesToHash(big.NewInt(1000).Bytes())},
+		}
+
+		opBaseFee(s)
+
+		assert.False(t, s.stop)
+		assert.Equal(t, big.NewInt(1000), s.pop())
+	})
+
+	t.Run("is not available before the london fork", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		opBaseFee(s)
+
+		assert.True(t, s.stop)
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
+
+func TestPush0(t *testing.T) {
+	t.Run("pushes zero after the shanghai fork", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Shanghai: true}
+
+		opPush0(s)
+
+		assert.False(t, s.stop)
+		assert.Equal(t, 1, s.sp)
+		assert.Equal(t, 0, s.pop().Sign())
+	})
+
+	t.Run("is not available before the shanghai fork", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		opPush0(s)
+
+		assert.True(t, s.stop)
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
+
+func TestMCopy(t *testing.T) {
+	s, closeFn := getState()
+	defer closeFn()
+
+	s.config = &chain.ForksInTime{Cancun: true}
+	s.gas = 1000
+
+	// write 0x01..0x20 in the first word
+	s.push(new(big.Int).SetBytes([]byte{
+		1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16,
+		17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32,
+	}))
+	s.push(big.NewInt(0))
+	opMStore(s)
+
+	// copy the word to the second one
+	s.push(big.NewInt(32)) // length
+	s.push(big.NewInt(0))  // source
+	s.push(big.NewInt(32)) // destination
+	opMCopy(s)
+
+	assert.False(t, s.stop)
+	assert.Len(t, s.memory, 64)
+	assert.Equal(t, s.memory[:32], s.memory[32:64])
+
+	// the areas can overlap
+	s.push(big.NewInt(16)) // length
+	s.push(big.NewInt(0))  // source
+	s.push(big.NewInt(8))  // destination
+	opMCopy(s)
+
+	assert.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}, s.memory[:16])
+	assert.Equal(t, []byte{9, 10, 11, 12, 13, 14, 15, 16, 25}, s.memory[16:25])
+}
+
+type mockHostForTransientStorage struct {
+	mockHost
+	storage map[types.Hash]types.Hash
+}
+
+func (m *mockHostForTransientStorage) GetTransientStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHostForTransientStorage) SetTransientStorage(addr types.Address, key types.Hash, value types.Hash) {
+	m.storage[key] = value
+}
+
+func TestTransientStorage(t *testing.T) {
+	t.Run("stores and loads the transient storage after the cancun fork", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Cancun: true}
+		s.msg = &runtime.Contract{Address: addr1}
+		s.host = &mockHostForTransientStorage{storage: map[types.Hash]types.Hash{}}
+
+		s.push(big.NewInt(10)) // value
+		s.push(big.NewInt(1))  // key
+		opTStore(s)
+
+		s.push(big.NewInt(1))
+		opTLoad(s)
+
+		assert.False(t, s.stop)
+		assert.Equal(t, big.NewInt(10), s.pop())
+	})
+
+	t.Run("is read only in static calls", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{Cancun: true}
+		s.msg = &runtime.Contract{Address: addr1, Static: true}
+
+		s.push(big.NewInt(10))
+		s.push(big.NewInt(1))
+		opTStore(s)
+
+		assert.True(t, s.stop)
+		assert.Equal(t, errWriteProtection, s.err)
+	})
+
+	t.Run("is not available before the cancun fork", func(t *testing.T) {
+		s, closeFn := getState()
+		defer closeFn()
+
+		s.config = &chain.ForksInTime{}
+
+		s.push(big.NewInt(1))
+		opTLoad(s)
+
+		assert.True(t, s.stop)
+		assert.Equal(t, errOpCodeNotFound, s.err)
+	})
+}
+
 type mockHostForCreate struct {
 	mockHost
 	nonce       uint64
@@ -352,6 +514,41 @@ func TestCreate(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "should throw ErrMaxInitCodeSizeExceeded when the initcode exceeds the limit after Shanghai",
+			op:   CREATE,
+			contract: &runtime.Contract{
+				Static:  false,
+				Address: addr1,
+			},
+			config: &chain.ForksInTime{
+				Shanghai: true,
+			},
+			initState: &state{
+				gas: 20000,
+				sp:  3,
+				stack: []*big.Int{
+					big.NewInt(runtime.MaxInitCodeSize + 1), // length
+					big.NewInt(0x00),                        // offset
+					big.NewInt(0x00),                        // value
+				},
+				memory: []byte{},
+			},
+			// the memory expansion is paid before the initcode size check
+			resultState: &state{
+				gas: 20000 - 9225,
+				sp:  0,
+				stack: []*big.Int{
+					big.NewInt(runtime.MaxInitCodeSize + 1),
+					big.NewInt(0x00),
+					big.NewInt(0x00),
+				},
+				memory: make([]byte, 1537*32),
+				stop:   true,
+				err:    runtime.ErrMaxInitCodeSizeExceeded,
+			},
+			mockHost: &mockHostForCreate{},
+		},
 	}
 
 	for _, tt := range tests {
@@ -161,6 +161,9 @@ const (
 	// SELFBALANCE returns the balance of the current account
 	SELFBALANCE = 0x47
 
+	// BASEFEE returns the current block's base fee
+	BASEFEE = 0x48
+
 	// POP pops a (u)int256 off the stack and discards it
 	POP = 0x50
 
@@ -197,6 +200,18 @@ const (
 	// JUMPDEST corresponds to a possible jump destination
 	JUMPDEST = 0x5B
 
+	// TLOAD reads a (u)int256 from the transient storage
+	TLOAD = 0x5C
+
+	// TSTORE writes a (u)int256 to the transient storage
+	TSTORE = 0x5D
+
+	// MCOPY copies an area of memory to another one
+	MCOPY = 0x5E
+
+	// PUSH0 pushes the constant 0 onto the stack
+	PUSH0 = 0x5F
+
 	// PUSH1 pushes a 1-byte value onto the stack
 	PUSH1 = 0x60
 
@@ -320,6 +335,10 @@ var opCodeToString = map[OpCode]string{
 	MSIZE:          "MSIZE",
 	GAS:            "GAS",
 	JUMPDEST:       "JUMPDEST",
+	TLOAD:          "TLOAD",
+	TSTORE:         "TSTORE",
+	MCOPY:          "MCOPY",
+	PUSH0:          "PUSH0",
 	CREATE:         "CREATE",
 	CALL:           "CALL",
 	RETURN:         "RETURN",
@@ -331,6 +350,7 @@ var opCodeToString = map[OpCode]string{
 	SELFDESTRUCT:   "SELFDESTRUCT",
 	CHAINID:        "CHAINID",
 	SELFBALANCE:    "SELFBALANCE",
+	BASEFEE:        "BASEFEE",
 }
 
 func opCodesToString(from, to OpCode, str string) {
@@ -2,6 +2,7 @@ package evm
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 )
 
@@ -212,6 +214,8 @@ func (c *state) resetReturnData() {
 func (c *state) Run() ([]byte, error) {
 	var vmerr error
 
+	tr := c.host.GetTracer()
+
 	codeSize := len(c.code)
 	for !c.stop {
 		if c.ip >= codeSize {
@@ -222,32 +226,17 @@ func (c *state) Run() ([]byte, error) {
 
 		op := OpCode(c.code[c.ip])
 
-		inst := dispatchTable[op]
-		if inst.inst == nil {
-			c.exit(errOpCodeNotFound)
+		if tr == nil {
+			c.execute(op)
+		} else {
+			gasCopy := c.gas
 
-			break
+			c.captureState(tr, op)
+			c.execute(op)
+			c.captureExecution(tr, gasCopy)
 		}
-		// check if the depth of the stack is enough for the instruction
-		if c.sp < inst.stack {
-			c.exit(errStackUnderflow)
-
-			break
-		}
-		// consume the gas of the instruction
-		if !c.consumeGas(inst.gas) {
-			c.exit(errOutOfGas)
-
-			break
-		}
-
-		// execute the instruction
-		inst.inst(c)
-
-		// check if stack size exceeds the max size
-		if c.sp > stackSize {
-			c.exit(errStackOverflow)
 
+		if c.err != nil {
 			break
 		}
 		c.ip++
@@ -260,6 +249,68 @@ func (c *state) Run() ([]byte, error) {
 	return c.ret, vmerr
 }
 
+// execute runs a single instruction, the state exits if it fails
+func (c *state) execute(op OpCode) {
+	inst := dispatchTable[op]
+	if inst.inst == nil {
+		c.exit(errOpCodeNotFound)
+
+		return
+	}
+	// check if the depth of the stack is enough for the instruction
+	if c.sp < inst.stack {
+		c.exit(errStackUnderflow)
+
+		return
+	}
+	// consume the gas of the instruction
+	if !c.consumeGas(inst.gas) {
+		c.exit(errOutOfGas)
+
+		return
+	}
+
+	// execute the instruction
+	inst.inst(c)
+
+	// check if stack size exceeds the max size
+	if c.sp > stackSize {
+		c.exit(errStackOverflow)
+	}
+}
+
+// captureState passes the state before the execution of the instruction to the tracer
+func (c *state) captureState(t tracer.Tracer, op OpCode) {
+	name := op.String()
+	if name == "" {
+		name = fmt.Sprintf("opcode %#x not defined", int(op))
+	}
+
+	t.CaptureState(&tracer.Step{
+		PC:         uint64(c.ip),
+		Op:         name,
+		Gas:        c.gas,
+		Depth:      c.msg.Depth,
+		Address:    c.msg.Address,
+		Stack:      c.stack[:c.sp],
+		Memory:     c.memory,
+		ReturnData: c.returnData,
+	}, c.host)
+}
+
+// captureExecution passes the gas cost of the instruction and its error to the tracer
+func (c *state) captureExecution(t tracer.Tracer, gasBefore uint64) {
+	cost := gasBefore - c.gas
+
+	if c.err != nil {
+		t.CaptureFault(cost, c.err)
+
+		return
+	}
+
+	t.CaptureStateEnd(cost)
+}
+
 func (c *state) inStaticCall() bool {
 	return c.msg.Static
 }
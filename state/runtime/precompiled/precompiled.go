@@ -61,14 +61,36 @@ var (
 	nine  = types.StringToAddress("9")
 )
 
-// CanRun implements the runtime interface
-func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.ForksInTime) bool {
-	if _, ok := p.contracts[c.CodeAddress]; !ok {
-		return false
+// addresses of the precompiled contracts
+var addresses = []types.Address{
+	types.StringToAddress("1"),
+	types.StringToAddress("2"),
+	types.StringToAddress("3"),
+	types.StringToAddress("4"),
+	five,
+	six,
+	seven,
+	eight,
+	nine,
+}
+
+// Addresses returns the addresses of the precompiled contracts enabled by the forks
+func Addresses(config *chain.ForksInTime) []types.Address {
+	res := make([]types.Address, 0, len(addresses))
+
+	for _, addr := range addresses {
+		if isEnabled(addr, config) {
+			res = append(res, addr)
+		}
 	}
 
+	return res
+}
+
+// isEnabled checks if the precompiled contract at the address is enabled by the forks
+func isEnabled(addr types.Address, config *chain.ForksInTime) bool {
 	// byzantium precompiles
-	switch c.CodeAddress {
+	switch addr {
 	case five:
 		fallthrough
 	case six:
@@ -80,7 +102,7 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 	}
 
 	// istanbul precompiles
-	switch c.CodeAddress {
+	switch addr {
 	case nine:
 		return config.Istanbul
 	}
@@ -88,6 +110,15 @@ func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.
 	return true
 }
 
+// CanRun implements the runtime interface
+func (p *Precompiled) CanRun(c *runtime.Contract, _ runtime.Host, config *chain.ForksInTime) bool {
+	if _, ok := p.contracts[c.CodeAddress]; !ok {
+		return false
+	}
+
+	return isEnabled(c.CodeAddress, config)
+}
+
 // Name implements the runtime interface
 func (p *Precompiled) Name() string {
 	return "precompiled"
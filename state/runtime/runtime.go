@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/Gabulhas/polygon-external-consensus/chain"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 )
 
@@ -18,6 +19,7 @@ type TxContext struct {
 	GasLimit   int64
 	ChainID    int64
 	Difficulty types.Hash
+	BaseFee    types.Hash
 }
 
 // StorageStatus is the status of the storage access
@@ -69,6 +71,19 @@ type Host interface {
 	Callx(*Contract, Host) *ExecutionResult
 	Empty(addr types.Address) bool
 	GetNonce(addr types.Address) uint64
+
+	// access list of the transaction (EIP-2929)
+	AddressInAccessList(addr types.Address) bool
+	SlotInAccessList(addr types.Address, slot types.Hash) (addressOk bool, slotOk bool)
+	AddAddressToAccessList(addr types.Address)
+	AddSlotToAccessList(addr types.Address, slot types.Hash)
+
+	// transient storage discarded at the end of the transaction (EIP-1153)
+	GetTransientStorage(addr types.Address, key types.Hash) types.Hash
+	SetTransientStorage(addr types.Address, key types.Hash, value types.Hash)
+
+	// tracer of the execution, nil if it is not traced
+	GetTracer() tracer.Tracer
 }
 
 // ExecutionResult includes all output after executing given evm
@@ -84,11 +99,12 @@ func (r *ExecutionResult) Succeeded() bool { return r.Err == nil }
 func (r *ExecutionResult) Failed() bool    { return r.Err != nil }
 func (r *ExecutionResult) Reverted() bool  { return errors.Is(r.Err, ErrExecutionReverted) }
 
-func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64) {
+// UpdateGasUsed sets the gas used by the execution, minus the refund.
+// The refund can go up to the gas used divided by the refund quotient
+func (r *ExecutionResult) UpdateGasUsed(gasLimit uint64, refund uint64, refundQuotient uint64) {
 	r.GasUsed = gasLimit - r.GasLeft
 
-	// Refund can go up to half the gas used
-	if maxRefund := r.GasUsed / 2; refund > maxRefund {
+	if maxRefund := r.GasUsed / refundQuotient; refund > maxRefund {
 		refund = maxRefund
 	}
 
@@ -107,6 +123,21 @@ var (
 	ErrDepth                    = errors.New("max call depth exceeded")
 	ErrExecutionReverted        = errors.New("execution was reverted")
 	ErrCodeStoreOutOfGas        = errors.New("contract creation code storage out of gas")
+	ErrMaxInitCodeSizeExceeded  = errors.New("evm: max initcode size exceeded")
+)
+
+const (
+	// RefundQuotient is the maximum portion of the gas used that can be refunded
+	RefundQuotient uint64 = 2
+
+	// RefundQuotientEIP3529 is the maximum portion of the gas used that can be refunded after London (EIP-3529)
+	RefundQuotientEIP3529 uint64 = 5
+
+	// MaxInitCodeSize is the maximum size of the initcode of a contract creation (EIP-3860)
+	MaxInitCodeSize = 2 * 24576
+
+	// InitCodeWordGas is the gas paid per word of the initcode of a contract creation (EIP-3860)
+	InitCodeWordGas uint64 = 2
 )
 
 type CallType int
@@ -120,6 +151,25 @@ const (
 	Create2
 )
 
+func (t CallType) String() string {
+	switch t {
+	case Call:
+		return "CALL"
+	case CallCode:
+		return "CALLCODE"
+	case DelegateCall:
+		return "DELEGATECALL"
+	case StaticCall:
+		return "STATICCALL"
+	case Create:
+		return "CREATE"
+	case Create2:
+		return "CREATE2"
+	default:
+		panic("BUG: call type not found")
+	}
+}
+
 // Runtime can process contracts
 type Runtime interface {
 	Run(c *Contract, host Host, config *chain.ForksInTime) *ExecutionResult
@@ -175,6 +225,7 @@ func NewContractCreation(
 	code []byte,
 ) *Contract {
 	c := NewContract(depth, origin, from, to, value, gas, code)
+	c.Type = Create
 
 	return c
 }
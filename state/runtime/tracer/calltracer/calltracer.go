@@ -0,0 +1,181 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/umbracle/ethgo/abi"
+)
+
+var _ tracer.Tracer = &CallTracer{}
+
+// Config is the configuration of the call tracer
+type Config struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // do not trace the inner calls
+}
+
+// Frame is a call frame of the transaction
+type Frame struct {
+	Type         string        `json:"type"`
+	From         types.Address `json:"from"`
+	To           types.Address `json:"to"`
+	Value        string        `json:"value,omitempty"`
+	Gas          string        `json:"gas"`
+	GasUsed      string        `json:"gasUsed"`
+	Input        string        `json:"input"`
+	Output       string        `json:"output,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	RevertReason string        `json:"revertReason,omitempty"`
+	Calls        []*Frame      `json:"calls,omitempty"`
+}
+
+// CallTracer builds the tree of the call frames of the transaction
+type CallTracer struct {
+	config Config
+
+	// the frames being executed, the first one is the top call
+	stack []*Frame
+
+	root     *Frame
+	gasLimit uint64
+
+	stopped    uint32
+	reason     error
+	reasonLock sync.Mutex
+}
+
+// NewCallTracer creates a call tracer with the given configuration
+func NewCallTracer(config Config) *CallTracer {
+	return &CallTracer{
+		config: config,
+	}
+}
+
+// Cancel implements the tracer interface
+func (t *CallTracer) Cancel(err error) {
+	t.reasonLock.Lock()
+	defer t.reasonLock.Unlock()
+
+	if t.reason == nil {
+		t.reason = err
+	}
+
+	atomic.StoreUint32(&t.stopped, 1)
+}
+
+func (t *CallTracer) cancelled() bool {
+	return atomic.LoadUint32(&t.stopped) == 1
+}
+
+// CaptureTxStart implements the tracer interface
+func (t *CallTracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+// CaptureTxEnd implements the tracer interface
+func (t *CallTracer) CaptureTxEnd(gasLeft uint64) {
+	// the top call reports the gas of the transaction, intrinsic gas and refund included
+	if t.root != nil {
+		t.root.Gas = hex.EncodeUint64(t.gasLimit)
+		t.root.GasUsed = hex.EncodeUint64(t.gasLimit - gasLeft)
+	}
+}
+
+// CaptureEnter implements the tracer interface
+func (t *CallTracer) CaptureEnter(
+	callType string,
+	from, to types.Address,
+	input []byte,
+	gas uint64,
+	value *big.Int,
+) {
+	if t.cancelled() {
+		return
+	}
+
+	frame := &Frame{
+		Type:  callType,
+		From:  from,
+		To:    to,
+		Gas:   hex.EncodeUint64(gas),
+		Input: hex.EncodeToHex(input),
+	}
+
+	// the value is inherited by the delegated calls and forbidden in the static ones
+	if value != nil && callType != "DELEGATECALL" && callType != "STATICCALL" {
+		frame.Value = hex.EncodeBig(value)
+	}
+
+	if len(t.stack) == 0 {
+		t.root = frame
+	} else {
+		parent := t.stack[len(t.stack)-1]
+		if t.config.OnlyTopCall {
+			// keep the depth of the frames even if they are not recorded
+			frame = parent
+		} else {
+			parent.Calls = append(parent.Calls, frame)
+		}
+	}
+
+	t.stack = append(t.stack, frame)
+}
+
+// CaptureExit implements the tracer interface
+func (t *CallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if t.cancelled() || len(t.stack) == 0 {
+		return
+	}
+
+	frame := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+
+	if t.config.OnlyTopCall && len(t.stack) > 0 {
+		return
+	}
+
+	frame.GasUsed = hex.EncodeUint64(gasUsed)
+
+	if err == nil {
+		frame.Output = hex.EncodeToHex(output)
+
+		return
+	}
+
+	frame.Error = err.Error()
+
+	if errors.Is(err, runtime.ErrExecutionReverted) {
+		frame.Output = hex.EncodeToHex(output)
+
+		if reason, unpackErr := abi.UnpackRevertError(output); unpackErr == nil {
+			frame.RevertReason = reason
+		}
+	}
+}
+
+// CaptureState implements the tracer interface
+func (t *CallTracer) CaptureState(*tracer.Step, tracer.RuntimeHost) {}
+
+// CaptureStateEnd implements the tracer interface
+func (t *CallTracer) CaptureStateEnd(uint64) {}
+
+// CaptureFault implements the tracer interface
+func (t *CallTracer) CaptureFault(uint64, error) {}
+
+// GetResult implements the tracer interface
+func (t *CallTracer) GetResult() (interface{}, error) {
+	t.reasonLock.Lock()
+	defer t.reasonLock.Unlock()
+
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	return t.root, nil
+}
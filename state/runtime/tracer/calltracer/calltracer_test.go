@@ -0,0 +1,100 @@
+package calltracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+)
+
+// revertOutput is the abi encoding of Error("not allowed")
+var revertOutput = hex.MustDecodeHex("0x08c379a0" +
+	"0000000000000000000000000000000000000000000000000000000000000020" +
+	"000000000000000000000000000000000000000000000000000000000000000b" +
+	"6e6f7420616c6c6f776564000000000000000000000000000000000000000000")
+
+// traceCalls runs a transaction calling addr2, which calls addr3 and reverts
+func traceCalls(tr *CallTracer) {
+	tr.CaptureTxStart(100000)
+	tr.CaptureEnter("CALL", addr1, addr2, []byte{0x1}, 79000, big.NewInt(10))
+	tr.CaptureEnter("STATICCALL", addr2, addr3, nil, 5000, big.NewInt(0))
+	tr.CaptureExit([]byte{0x2}, 100, nil)
+	tr.CaptureExit(revertOutput, 6000, runtime.ErrExecutionReverted)
+	tr.CaptureTxEnd(73000)
+}
+
+func TestCallTracer(t *testing.T) {
+	t.Parallel()
+
+	tr := NewCallTracer(Config{})
+	traceCalls(tr)
+
+	res, err := tr.GetResult()
+	assert.NoError(t, err)
+
+	assert.Equal(t, &Frame{
+		Type:         "CALL",
+		From:         addr1,
+		To:           addr2,
+		Value:        "0xa",
+		Gas:          "0x186a0",
+		GasUsed:      "0x6978",
+		Input:        "0x01",
+		Output:       hex.EncodeToHex(revertOutput),
+		Error:        runtime.ErrExecutionReverted.Error(),
+		RevertReason: "not allowed",
+		Calls: []*Frame{
+			{
+				Type:    "STATICCALL",
+				From:    addr2,
+				To:      addr3,
+				Gas:     "0x1388",
+				GasUsed: "0x64",
+				Input:   "0x",
+				Output:  "0x02",
+			},
+		},
+	}, res)
+}
+
+func TestCallTracer_OnlyTopCall(t *testing.T) {
+	t.Parallel()
+
+	tr := NewCallTracer(Config{OnlyTopCall: true})
+	traceCalls(tr)
+
+	res, err := tr.GetResult()
+	assert.NoError(t, err)
+
+	frame, ok := res.(*Frame)
+	assert.True(t, ok)
+
+	assert.Empty(t, frame.Calls)
+	assert.Equal(t, "not allowed", frame.RevertReason)
+	assert.Equal(t, "0x6978", frame.GasUsed)
+}
+
+func TestCallTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	errCancel := errors.New("cancelled")
+
+	tr := NewCallTracer(Config{})
+	tr.Cancel(errCancel)
+
+	traceCalls(tr)
+
+	res, err := tr.GetResult()
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, errCancel)
+}
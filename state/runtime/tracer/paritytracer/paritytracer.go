@@ -0,0 +1,270 @@
+package paritytracer
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+var _ tracer.Tracer = &ParityTracer{}
+
+const (
+	TraceTypeCall    = "call"
+	TraceTypeCreate  = "create"
+	TraceTypeSuicide = "suicide"
+
+	// revertedError is the error of the reverted calls in the parity format
+	revertedError = "Reverted"
+)
+
+// Action is the action of a trace, its fields depend on the type of the trace
+type Action struct {
+	// call
+	CallType string         `json:"callType,omitempty"`
+	To       *types.Address `json:"to,omitempty"`
+	Input    string         `json:"input,omitempty"`
+
+	// create
+	CreationMethod string `json:"creationMethod,omitempty"`
+	Init           string `json:"init,omitempty"`
+
+	// call and create
+	From  *types.Address `json:"from,omitempty"`
+	Gas   string         `json:"gas,omitempty"`
+	Value string         `json:"value,omitempty"`
+
+	// suicide
+	Address       *types.Address `json:"address,omitempty"`
+	RefundAddress *types.Address `json:"refundAddress,omitempty"`
+	Balance       string         `json:"balance,omitempty"`
+}
+
+// Result is the result of a successful call or create
+type Result struct {
+	GasUsed string `json:"gasUsed"`
+
+	// call
+	Output string `json:"output,omitempty"`
+
+	// create
+	Address *types.Address `json:"address,omitempty"`
+	Code    string         `json:"code,omitempty"`
+}
+
+// Trace is a call, create or suicide of the transaction
+type Trace struct {
+	Action       *Action `json:"action"`
+	Result       *Result `json:"result"`
+	Error        string  `json:"error,omitempty"`
+	Subtraces    int     `json:"subtraces"`
+	TraceAddress []int   `json:"traceAddress"`
+	Type         string  `json:"type"`
+}
+
+// TxTrace is the result of the parity tracer
+type TxTrace struct {
+	// Output is the return data of the top call
+	Output []byte
+	// Traces are in the order of execution, a trace comes before its subtraces
+	Traces []*Trace
+}
+
+// frame is a call frame being executed
+type frame struct {
+	trace *Trace
+	// to is the called or created account
+	to types.Address
+}
+
+// ParityTracer records the calls, creates and suicides of the transaction in the parity format
+type ParityTracer struct {
+	traces []*Trace
+	// the call frames being executed, the first one is the top call
+	stack []frame
+	// the suicide captured by the opcode being executed, until it succeeds
+	suicide *Trace
+
+	output []byte
+
+	stopped    uint32
+	reason     error
+	reasonLock sync.Mutex
+}
+
+// NewParityTracer creates a parity tracer
+func NewParityTracer() *ParityTracer {
+	return &ParityTracer{
+		traces: []*Trace{},
+	}
+}
+
+// Cancel implements the tracer interface
+func (t *ParityTracer) Cancel(err error) {
+	t.reasonLock.Lock()
+	defer t.reasonLock.Unlock()
+
+	if t.reason == nil {
+		t.reason = err
+	}
+
+	atomic.StoreUint32(&t.stopped, 1)
+}
+
+func (t *ParityTracer) cancelled() bool {
+	return atomic.LoadUint32(&t.stopped) == 1
+}
+
+// CaptureTxStart implements the tracer interface
+func (t *ParityTracer) CaptureTxStart(uint64) {}
+
+// CaptureTxEnd implements the tracer interface
+func (t *ParityTracer) CaptureTxEnd(uint64) {}
+
+// CaptureEnter implements the tracer interface
+func (t *ParityTracer) CaptureEnter(
+	callType string,
+	from, to types.Address,
+	input []byte,
+	gas uint64,
+	value *big.Int,
+) {
+	if t.cancelled() {
+		return
+	}
+
+	if value == nil {
+		value = big.NewInt(0)
+	}
+
+	action := &Action{
+		From:  &from,
+		Gas:   hex.EncodeUint64(gas),
+		Value: hex.EncodeBig(value),
+	}
+
+	trace := &Trace{
+		Action: action,
+	}
+
+	switch callType {
+	case "CREATE", "CREATE2":
+		trace.Type = TraceTypeCreate
+		action.CreationMethod = strings.ToLower(callType)
+		action.Init = hex.EncodeToHex(input)
+	default:
+		trace.Type = TraceTypeCall
+		action.CallType = strings.ToLower(callType)
+		action.To = &to
+		action.Input = hex.EncodeToHex(input)
+	}
+
+	t.push(trace)
+	t.stack = append(t.stack, frame{trace: trace, to: to})
+}
+
+// CaptureExit implements the tracer interface
+func (t *ParityTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if t.cancelled() || len(t.stack) == 0 {
+		return
+	}
+
+	current := t.stack[len(t.stack)-1]
+	trace := current.trace
+	t.stack = t.stack[:len(t.stack)-1]
+
+	if len(t.stack) == 0 {
+		t.output = append(t.output[:0], output...)
+	}
+
+	if err != nil {
+		if errors.Is(err, runtime.ErrExecutionReverted) {
+			trace.Error = revertedError
+		} else {
+			trace.Error = err.Error()
+		}
+
+		return
+	}
+
+	trace.Result = &Result{
+		GasUsed: hex.EncodeUint64(gasUsed),
+	}
+
+	if trace.Type == TraceTypeCreate {
+		trace.Result.Address = &current.to
+		trace.Result.Code = hex.EncodeToHex(output)
+	} else {
+		trace.Result.Output = hex.EncodeToHex(output)
+	}
+}
+
+// push adds the trace as the next subtrace of the current call frame
+func (t *ParityTracer) push(trace *Trace) {
+	trace.TraceAddress = []int{}
+
+	if len(t.stack) > 0 {
+		parent := t.stack[len(t.stack)-1].trace
+
+		trace.TraceAddress = append(trace.TraceAddress, parent.TraceAddress...)
+		trace.TraceAddress = append(trace.TraceAddress, parent.Subtraces)
+		parent.Subtraces++
+	}
+
+	t.traces = append(t.traces, trace)
+}
+
+// CaptureState implements the tracer interface
+func (t *ParityTracer) CaptureState(step *tracer.Step, host tracer.RuntimeHost) {
+	if t.cancelled() || step.Op != "SELFDESTRUCT" || len(step.Stack) == 0 {
+		return
+	}
+
+	address := step.Address
+	refundAddress := types.BytesToAddress(step.Stack[len(step.Stack)-1].Bytes())
+
+	t.suicide = &Trace{
+		Action: &Action{
+			Address:       &address,
+			RefundAddress: &refundAddress,
+			Balance:       hex.EncodeBig(host.GetBalance(address)),
+		},
+		Type: TraceTypeSuicide,
+	}
+}
+
+// CaptureStateEnd implements the tracer interface
+func (t *ParityTracer) CaptureStateEnd(uint64) {
+	if t.suicide != nil && !t.cancelled() {
+		t.push(t.suicide)
+	}
+
+	t.suicide = nil
+}
+
+// CaptureFault implements the tracer interface
+func (t *ParityTracer) CaptureFault(uint64, error) {
+	// the contract is not destructed if the opcode fails
+	t.suicide = nil
+}
+
+// GetResult implements the tracer interface
+func (t *ParityTracer) GetResult() (interface{}, error) {
+	t.reasonLock.Lock()
+	defer t.reasonLock.Unlock()
+
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	return &TxTrace{
+		Output: t.output,
+		Traces: t.traces,
+	}, nil
+}
@@ -0,0 +1,159 @@
+package paritytracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+	addr3 = types.StringToAddress("3")
+	addr4 = types.StringToAddress("4")
+)
+
+type mockHost struct{}
+
+func (m *mockHost) GetStorage(types.Address, types.Hash) types.Hash {
+	return types.Hash{}
+}
+
+func (m *mockHost) GetBalance(types.Address) *big.Int {
+	return big.NewInt(7)
+}
+
+func getResult(t *testing.T, tr *ParityTracer) *TxTrace {
+	t.Helper()
+
+	res, err := tr.GetResult()
+	assert.NoError(t, err)
+
+	result, ok := res.(*TxTrace)
+	assert.True(t, ok)
+
+	return result
+}
+
+func TestParityTracer(t *testing.T) {
+	t.Parallel()
+
+	tr := NewParityTracer()
+	host := &mockHost{}
+
+	// addr1 calls addr2, which creates addr3 and calls addr4 reverting,
+	// then addr2 self destructs
+	tr.CaptureTxStart(100000)
+	tr.CaptureEnter("CALL", addr1, addr2, []byte{0x1}, 79000, big.NewInt(10))
+
+	tr.CaptureEnter("CREATE2", addr2, addr3, []byte{0x2}, 30000, nil)
+	tr.CaptureExit([]byte{0x3}, 20000, nil)
+
+	tr.CaptureEnter("DELEGATECALL", addr2, addr4, nil, 5000, big.NewInt(10))
+	tr.CaptureExit(nil, 100, runtime.ErrExecutionReverted)
+
+	tr.CaptureState(&tracer.Step{Op: "SELFDESTRUCT", Address: addr2, Stack: []*big.Int{big.NewInt(1)}}, host)
+	tr.CaptureStateEnd(5000)
+
+	tr.CaptureExit([]byte{0x4}, 40000, nil)
+	tr.CaptureTxEnd(39000)
+
+	result := getResult(t, tr)
+
+	assert.Equal(t, []byte{0x4}, result.Output)
+	assert.Equal(t, []*Trace{
+		{
+			Action: &Action{
+				CallType: "call",
+				From:     &addr1,
+				To:       &addr2,
+				Gas:      "0x13498",
+				Input:    "0x01",
+				Value:    "0xa",
+			},
+			Result: &Result{
+				GasUsed: "0x9c40",
+				Output:  "0x04",
+			},
+			Subtraces:    3,
+			TraceAddress: []int{},
+			Type:         TraceTypeCall,
+		},
+		{
+			Action: &Action{
+				CreationMethod: "create2",
+				From:           &addr2,
+				Gas:            "0x7530",
+				Init:           "0x02",
+				Value:          "0x0",
+			},
+			Result: &Result{
+				GasUsed: "0x4e20",
+				Address: &addr3,
+				Code:    "0x03",
+			},
+			TraceAddress: []int{0},
+			Type:         TraceTypeCreate,
+		},
+		{
+			Action: &Action{
+				CallType: "delegatecall",
+				From:     &addr2,
+				To:       &addr4,
+				Gas:      "0x1388",
+				Input:    "0x",
+				Value:    "0xa",
+			},
+			Error:        revertedError,
+			TraceAddress: []int{1},
+			Type:         TraceTypeCall,
+		},
+		{
+			Action: &Action{
+				Address:       &addr2,
+				RefundAddress: &addr1,
+				Balance:       "0x7",
+			},
+			TraceAddress: []int{2},
+			Type:         TraceTypeSuicide,
+		},
+	}, result.Traces)
+}
+
+func TestParityTracer_FailedSelfdestruct(t *testing.T) {
+	t.Parallel()
+
+	tr := NewParityTracer()
+
+	tr.CaptureEnter("STATICCALL", addr1, addr2, nil, 79000, nil)
+	tr.CaptureState(&tracer.Step{Op: "SELFDESTRUCT", Address: addr2, Stack: []*big.Int{big.NewInt(1)}}, &mockHost{})
+	tr.CaptureFault(0, runtime.ErrOutOfGas)
+	tr.CaptureExit(nil, 79000, runtime.ErrOutOfGas)
+
+	result := getResult(t, tr)
+
+	assert.Len(t, result.Traces, 1)
+	assert.Equal(t, 0, result.Traces[0].Subtraces)
+	assert.Nil(t, result.Traces[0].Result)
+	assert.Equal(t, runtime.ErrOutOfGas.Error(), result.Traces[0].Error)
+}
+
+func TestParityTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	errCancel := errors.New("cancelled")
+
+	tr := NewParityTracer()
+	tr.CaptureEnter("CALL", addr1, addr2, nil, 79000, nil)
+	tr.Cancel(errCancel)
+	tr.CaptureExit(nil, 0, nil)
+
+	res, err := tr.GetResult()
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, errCancel)
+}
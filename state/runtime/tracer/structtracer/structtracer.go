@@ -0,0 +1,245 @@
+package structtracer
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+var _ tracer.Tracer = &StructTracer{}
+
+// Config is the configuration of the struct tracer
+type Config struct {
+	EnableMemory     bool // capture the memory of each step
+	DisableStack     bool // do not capture the stack of each step
+	DisableStorage   bool // do not capture the storage read and written by SLOAD and SSTORE
+	EnableReturnData bool // capture the return data of the last call of each step
+}
+
+// StructLog is an opcode executed by the transaction
+type StructLog struct {
+	Pc         uint64            `json:"pc"`
+	Op         string            `json:"op"`
+	Gas        uint64            `json:"gas"`
+	GasCost    uint64            `json:"gasCost"`
+	Depth      int               `json:"depth"`
+	Error      string            `json:"error,omitempty"`
+	Stack      *[]string         `json:"stack,omitempty"`
+	Memory     *[]string         `json:"memory,omitempty"`
+	ReturnData string            `json:"returnData,omitempty"`
+	Storage    map[string]string `json:"storage,omitempty"`
+}
+
+// StructTraceResult is the result of the struct tracer
+type StructTraceResult struct {
+	Failed      bool        `json:"failed"`
+	Gas         uint64      `json:"gas"`
+	ReturnValue string      `json:"returnValue"`
+	StructLogs  []StructLog `json:"structLogs"`
+}
+
+// StructTracer logs every opcode executed by the transaction
+type StructTracer struct {
+	config Config
+
+	logs []StructLog
+	// indexes of the logs of the opcodes being executed, the calls nest them
+	pending []int
+	// storage read and written by each contract
+	storage map[types.Address]map[types.Hash]types.Hash
+
+	depth    int
+	gasLimit uint64
+	gasUsed  uint64
+	output   []byte
+	err      error
+
+	stopped    uint32
+	reason     error
+	reasonLock sync.Mutex
+}
+
+// NewStructTracer creates a struct tracer with the given configuration
+func NewStructTracer(config Config) *StructTracer {
+	return &StructTracer{
+		config:  config,
+		logs:    []StructLog{},
+		storage: make(map[types.Address]map[types.Hash]types.Hash),
+	}
+}
+
+// Cancel implements the tracer interface
+func (t *StructTracer) Cancel(err error) {
+	t.reasonLock.Lock()
+	defer t.reasonLock.Unlock()
+
+	if t.reason == nil {
+		t.reason = err
+	}
+
+	atomic.StoreUint32(&t.stopped, 1)
+}
+
+func (t *StructTracer) cancelled() bool {
+	return atomic.LoadUint32(&t.stopped) == 1
+}
+
+// CaptureTxStart implements the tracer interface
+func (t *StructTracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+// CaptureTxEnd implements the tracer interface
+func (t *StructTracer) CaptureTxEnd(gasLeft uint64) {
+	t.gasUsed = t.gasLimit - gasLeft
+}
+
+// CaptureEnter implements the tracer interface
+func (t *StructTracer) CaptureEnter(string, types.Address, types.Address, []byte, uint64, *big.Int) {
+	t.depth++
+}
+
+// CaptureExit implements the tracer interface
+func (t *StructTracer) CaptureExit(output []byte, _ uint64, err error) {
+	t.depth--
+
+	if t.depth == 0 {
+		t.output = append(t.output[:0], output...)
+		t.err = err
+	}
+}
+
+// CaptureState implements the tracer interface
+func (t *StructTracer) CaptureState(step *tracer.Step, host tracer.RuntimeHost) {
+	if t.cancelled() {
+		return
+	}
+
+	log := StructLog{
+		Pc:    step.PC,
+		Op:    step.Op,
+		Gas:   step.Gas,
+		Depth: step.Depth,
+	}
+
+	if !t.config.DisableStack {
+		stack := make([]string, len(step.Stack))
+		for i, v := range step.Stack {
+			stack[i] = hex.EncodeBig(v)
+		}
+
+		log.Stack = &stack
+	}
+
+	if t.config.EnableMemory {
+		memory := make([]string, 0, (len(step.Memory)+31)/32)
+		for i := 0; i < len(step.Memory); i += 32 {
+			end := i + 32
+			if end > len(step.Memory) {
+				end = len(step.Memory)
+			}
+
+			memory = append(memory, hex.EncodeToString(step.Memory[i:end]))
+		}
+
+		log.Memory = &memory
+	}
+
+	if t.config.EnableReturnData && len(step.ReturnData) > 0 {
+		log.ReturnData = hex.EncodeToHex(step.ReturnData)
+	}
+
+	if !t.config.DisableStorage && t.captureStorage(step, host) {
+		storage := t.storage[step.Address]
+
+		log.Storage = make(map[string]string, len(storage))
+		for k, v := range storage {
+			log.Storage[hex.EncodeToString(k.Bytes())] = hex.EncodeToString(v.Bytes())
+		}
+	}
+
+	t.logs = append(t.logs, log)
+	t.pending = append(t.pending, len(t.logs)-1)
+}
+
+// captureStorage records the slot read by SLOAD or written by SSTORE,
+// it returns false for the other opcodes
+func (t *StructTracer) captureStorage(step *tracer.Step, host tracer.RuntimeHost) bool {
+	sp := len(step.Stack)
+
+	var key, value types.Hash
+
+	switch {
+	case step.Op == "SLOAD" && sp >= 1:
+		key = types.BytesToHash(step.Stack[sp-1].Bytes())
+		value = host.GetStorage(step.Address, key)
+	case step.Op == "SSTORE" && sp >= 2:
+		key = types.BytesToHash(step.Stack[sp-1].Bytes())
+		value = types.BytesToHash(step.Stack[sp-2].Bytes())
+	default:
+		return false
+	}
+
+	storage, ok := t.storage[step.Address]
+	if !ok {
+		storage = make(map[types.Hash]types.Hash)
+		t.storage[step.Address] = storage
+	}
+
+	storage[key] = value
+
+	return true
+}
+
+// CaptureStateEnd implements the tracer interface
+func (t *StructTracer) CaptureStateEnd(cost uint64) {
+	if log := t.popPending(); log != nil {
+		log.GasCost = cost
+	}
+}
+
+// CaptureFault implements the tracer interface
+func (t *StructTracer) CaptureFault(cost uint64, err error) {
+	if log := t.popPending(); log != nil {
+		log.GasCost = cost
+
+		// a revert is the regular way out of a call, not a fault of the opcode
+		if !errors.Is(err, runtime.ErrExecutionReverted) {
+			log.Error = err.Error()
+		}
+	}
+}
+
+func (t *StructTracer) popPending() *StructLog {
+	if t.cancelled() || len(t.pending) == 0 {
+		return nil
+	}
+
+	index := t.pending[len(t.pending)-1]
+	t.pending = t.pending[:len(t.pending)-1]
+
+	return &t.logs[index]
+}
+
+// GetResult implements the tracer interface
+func (t *StructTracer) GetResult() (interface{}, error) {
+	t.reasonLock.Lock()
+	defer t.reasonLock.Unlock()
+
+	if t.reason != nil {
+		return nil, t.reason
+	}
+
+	return &StructTraceResult{
+		Failed:      t.err != nil,
+		Gas:         t.gasUsed,
+		ReturnValue: hex.EncodeToString(t.output),
+		StructLogs:  t.logs,
+	}, nil
+}
@@ -0,0 +1,174 @@
+package structtracer
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	addr1 = types.StringToAddress("1")
+	addr2 = types.StringToAddress("2")
+)
+
+type mockHost struct {
+	storage map[types.Hash]types.Hash
+}
+
+func (m *mockHost) GetStorage(addr types.Address, key types.Hash) types.Hash {
+	return m.storage[key]
+}
+
+func (m *mockHost) GetBalance(addr types.Address) *big.Int {
+	return big.NewInt(0)
+}
+
+func getResult(t *testing.T, tr *StructTracer) *StructTraceResult {
+	t.Helper()
+
+	res, err := tr.GetResult()
+	assert.NoError(t, err)
+
+	result, ok := res.(*StructTraceResult)
+	assert.True(t, ok)
+
+	return result
+}
+
+func TestStructTracer_Result(t *testing.T) {
+	t.Parallel()
+
+	tr := NewStructTracer(Config{})
+	host := &mockHost{}
+
+	tr.CaptureTxStart(100000)
+	tr.CaptureEnter("CALL", addr1, addr2, nil, 79000, big.NewInt(0))
+
+	tr.CaptureState(&tracer.Step{PC: 0, Op: "CALL", Gas: 79000, Depth: 1, Stack: []*big.Int{big.NewInt(1)}}, host)
+	tr.CaptureEnter("CALL", addr2, addr1, nil, 1000, big.NewInt(0))
+	tr.CaptureState(&tracer.Step{PC: 0, Op: "STOP", Gas: 1000, Depth: 2}, host)
+	tr.CaptureStateEnd(0)
+	tr.CaptureExit(nil, 0, nil)
+	tr.CaptureStateEnd(2700)
+
+	tr.CaptureState(&tracer.Step{PC: 1, Op: "REVERT", Gas: 76300, Depth: 1}, host)
+	tr.CaptureFault(0, runtime.ErrExecutionReverted)
+	tr.CaptureExit([]byte{0x1}, 2700, runtime.ErrExecutionReverted)
+	tr.CaptureTxEnd(76300)
+
+	result := getResult(t, tr)
+
+	assert.True(t, result.Failed)
+	assert.Equal(t, uint64(23700), result.Gas)
+	assert.Equal(t, "01", result.ReturnValue)
+
+	assert.Len(t, result.StructLogs, 3)
+
+	// the call is logged before its nested steps, with its cost once it returns
+	assert.Equal(t, "CALL", result.StructLogs[0].Op)
+	assert.Equal(t, uint64(2700), result.StructLogs[0].GasCost)
+	assert.Equal(t, []string{"0x1"}, *result.StructLogs[0].Stack)
+	assert.Equal(t, 2, result.StructLogs[1].Depth)
+
+	// the revert is not a fault of the opcode
+	assert.Empty(t, result.StructLogs[2].Error)
+}
+
+func TestStructTracer_Fault(t *testing.T) {
+	t.Parallel()
+
+	tr := NewStructTracer(Config{DisableStack: true})
+
+	tr.CaptureState(&tracer.Step{Op: "ADD", Gas: 2, Depth: 1}, &mockHost{})
+	tr.CaptureFault(0, runtime.ErrStackUnderflow)
+
+	result := getResult(t, tr)
+
+	assert.Len(t, result.StructLogs, 1)
+	assert.Equal(t, runtime.ErrStackUnderflow.Error(), result.StructLogs[0].Error)
+	assert.Nil(t, result.StructLogs[0].Stack)
+}
+
+func TestStructTracer_Storage(t *testing.T) {
+	t.Parallel()
+
+	key1 := types.StringToHash("1")
+	key2 := types.StringToHash("2")
+
+	host := &mockHost{
+		storage: map[types.Hash]types.Hash{
+			key1: types.StringToHash("3"),
+		},
+	}
+
+	tests := []struct {
+		name            string
+		config          Config
+		expectedStorage []map[string]string
+	}{
+		{
+			name:   "should capture the slots read and written",
+			config: Config{},
+			expectedStorage: []map[string]string{
+				{
+					"0000000000000000000000000000000000000000000000000000000000000001": "0000000000000000000000000000000000000000000000000000000000000003",
+				},
+				{
+					"0000000000000000000000000000000000000000000000000000000000000001": "0000000000000000000000000000000000000000000000000000000000000003",
+					"0000000000000000000000000000000000000000000000000000000000000002": "0000000000000000000000000000000000000000000000000000000000000004",
+				},
+				nil,
+			},
+		},
+		{
+			name:            "should not capture the storage if disabled",
+			config:          Config{DisableStorage: true},
+			expectedStorage: []map[string]string{nil, nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr := NewStructTracer(tt.config)
+
+			tr.CaptureState(&tracer.Step{Op: "SLOAD", Address: addr1, Stack: []*big.Int{new(big.Int).SetBytes(key1.Bytes())}}, host)
+			tr.CaptureStateEnd(2100)
+			tr.CaptureState(&tracer.Step{Op: "SSTORE", Address: addr1, Stack: []*big.Int{big.NewInt(4), new(big.Int).SetBytes(key2.Bytes())}}, host)
+			tr.CaptureStateEnd(20000)
+			tr.CaptureState(&tracer.Step{Op: "STOP", Address: addr1}, host)
+			tr.CaptureStateEnd(0)
+
+			result := getResult(t, tr)
+
+			for i, log := range result.StructLogs {
+				assert.Equal(t, tt.expectedStorage[i], log.Storage)
+			}
+		})
+	}
+}
+
+func TestStructTracer_Cancel(t *testing.T) {
+	t.Parallel()
+
+	errCancel := errors.New("cancelled")
+
+	tr := NewStructTracer(Config{})
+	tr.Cancel(errCancel)
+
+	tr.CaptureState(&tracer.Step{Op: "STOP"}, &mockHost{})
+	tr.CaptureStateEnd(0)
+
+	res, err := tr.GetResult()
+	assert.Nil(t, res)
+	assert.ErrorIs(t, err, errCancel)
+	assert.Len(t, tr.logs, 0)
+}
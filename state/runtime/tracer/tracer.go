@@ -0,0 +1,54 @@
+package tracer
+
+import (
+	"math/big"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+// RuntimeHost is the part of the execution host the tracers can read from
+type RuntimeHost interface {
+	GetStorage(addr types.Address, key types.Hash) types.Hash
+	GetBalance(addr types.Address) *big.Int
+}
+
+// Step is the state of the interpreter before the execution of an opcode.
+// The stack, memory and return data are only valid during the capture,
+// tracers keeping them must copy them
+type Step struct {
+	PC         uint64
+	Op         string
+	Gas        uint64
+	Depth      int
+	Address    types.Address
+	Stack      []*big.Int
+	Memory     []byte
+	ReturnData []byte
+}
+
+// Tracer receives the events of the execution of a transaction
+type Tracer interface {
+	// CaptureTxStart is called before the execution of the transaction
+	CaptureTxStart(gasLimit uint64)
+	// CaptureTxEnd is called after the execution of the transaction with the gas left after the refund
+	CaptureTxEnd(gasLeft uint64)
+
+	// CaptureEnter is called when a call frame is entered
+	CaptureEnter(callType string, from, to types.Address, input []byte, gas uint64, value *big.Int)
+	// CaptureExit is called when the last entered call frame exits
+	CaptureExit(output []byte, gasUsed uint64, err error)
+
+	// CaptureState is called before the execution of each opcode.
+	// It is followed by CaptureStateEnd if the opcode succeeds or CaptureFault if it fails
+	CaptureState(step *Step, host RuntimeHost)
+	// CaptureStateEnd is called after the execution of the opcode with its gas cost,
+	// the steps of the call frames it opens are captured in between
+	CaptureStateEnd(cost uint64)
+	// CaptureFault is called instead of CaptureStateEnd when the opcode fails
+	CaptureFault(cost uint64, err error)
+
+	// GetResult returns the result of the tracing
+	GetResult() (interface{}, error)
+	// Cancel stops the tracing, the result is the given error
+	Cancel(err error)
+}
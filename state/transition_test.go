@@ -4,7 +4,10 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer/calltracer"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime/tracer/structtracer"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/hashicorp/go-hclog"
 	"github.com/stretchr/testify/assert"
@@ -74,7 +77,7 @@ func TestSubGasLimitPrice(t *testing.T) {
 				GasPrice: big.NewInt(tt.gasPrice),
 			}
 
-			err := transition.subGasLimitPrice(msg)
+			err := transition.subGasLimitPrice(msg, msg.GasPrice)
 
 			assert.Equal(t, tt.expectedErr, err)
 			if err == nil {
@@ -159,3 +162,213 @@ func TestTransfer(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionGasCost_InitCode(t *testing.T) {
+	t.Parallel()
+
+	msg := &types.Transaction{
+		Input: make([]byte, 33),
+	}
+
+	// the creation of a contract pays for the zero bytes of the initcode
+	cost, err := TransactionGasCost(msg, true, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, TxGasContractCreation+33*4, cost)
+
+	// and for its two words after Shanghai (EIP-3860)
+	cost, err = TransactionGasCost(msg, true, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, TxGasContractCreation+33*4+2*runtime.InitCodeWordGas, cost)
+
+	// the calls do not pay for the words of the input
+	msg.To = &addr1
+
+	cost, err = TransactionGasCost(msg, true, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, TxGas+33*4, cost)
+}
+
+func TestSelfdestruct_Refund(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		config         chain.ForksInTime
+		expectedRefund uint64
+	}{
+		{
+			name:           "should refund the self destruct before London",
+			config:         chain.ForksInTime{Istanbul: true},
+			expectedRefund: 24000,
+		},
+		{
+			name:           "should not refund the self destruct after London",
+			config:         chain.ForksInTime{Istanbul: true, London: true},
+			expectedRefund: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			transition := newTestTransition(map[types.Address]*PreState{
+				addr1: {Balance: 100},
+			})
+			transition.config = tt.config
+
+			transition.Selfdestruct(addr1, addr2)
+
+			assert.Equal(t, tt.expectedRefund, transition.state.GetRefund())
+			assert.Equal(t, big.NewInt(100), transition.state.GetBalance(addr2))
+		})
+	}
+}
+
+func TestTransition_Tracer(t *testing.T) {
+	t.Parallel()
+
+	addr3 := types.StringToAddress("3")
+
+	// calls addr3 without arguments and stops
+	code := []byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x73}
+	code = append(code, addr3.Bytes()...)
+	code = append(code, 0x61, 0xff, 0xff, 0xf1, 0x00)
+
+	newTracedTransition := func() *Transition {
+		config := chain.ForksInTime{
+			Homestead:      true,
+			Byzantium:      true,
+			Constantinople: true,
+			Petersburg:     true,
+			Istanbul:       true,
+			EIP150:         true,
+			EIP158:         true,
+			EIP155:         true,
+		}
+
+		transition := NewTransition(config, newTestTxn(defaultPreState))
+		transition.state.SetCode(addr2, code)
+		transition.state.SetCode(addr3, []byte{0x00})
+
+		return transition
+	}
+
+	t.Run("should log the steps of the nested calls in order", func(t *testing.T) {
+		t.Parallel()
+
+		tracer := structtracer.NewStructTracer(structtracer.Config{})
+
+		transition := newTracedTransition()
+		transition.SetTracer(tracer)
+
+		result := transition.Call2(addr1, addr2, nil, big.NewInt(0), 1000000)
+		assert.NoError(t, result.Err)
+
+		res, err := tracer.GetResult()
+		assert.NoError(t, err)
+
+		logs := res.(*structtracer.StructTraceResult).StructLogs //nolint:forcetypeassert
+		ops := make([]string, len(logs))
+
+		for i, log := range logs {
+			ops[i] = log.Op
+		}
+
+		assert.Equal(t, []string{
+			"PUSH1", "PUSH1", "PUSH1", "PUSH1", "PUSH1", "PUSH20", "PUSH2", "CALL", "STOP", "STOP",
+		}, ops)
+
+		assert.Equal(t, uint64(3), logs[0].GasCost)
+		assert.Equal(t, 1, logs[7].Depth)
+		assert.Equal(t, 2, logs[8].Depth)
+		assert.Equal(t, 1, logs[9].Depth)
+
+		// the cost of the call is settled once the nested call returns
+		assert.Equal(t, logs[7].Gas-logs[9].Gas, logs[7].GasCost)
+		assert.Len(t, *logs[7].Stack, 7)
+	})
+
+	t.Run("should build the tree of the call frames", func(t *testing.T) {
+		t.Parallel()
+
+		tracer := calltracer.NewCallTracer(calltracer.Config{})
+
+		transition := newTracedTransition()
+		transition.SetTracer(tracer)
+
+		result := transition.Call2(addr1, addr2, nil, big.NewInt(0), 1000000)
+		assert.NoError(t, result.Err)
+
+		res, err := tracer.GetResult()
+		assert.NoError(t, err)
+
+		root := res.(*calltracer.Frame) //nolint:forcetypeassert
+		assert.Equal(t, "CALL", root.Type)
+		assert.Equal(t, addr1, root.From)
+		assert.Equal(t, addr2, root.To)
+
+		assert.Len(t, root.Calls, 1)
+		assert.Equal(t, "CALL", root.Calls[0].Type)
+		assert.Equal(t, addr2, root.Calls[0].From)
+		assert.Equal(t, addr3, root.Calls[0].To)
+		assert.Equal(t, "0xffff", root.Calls[0].Gas)
+	})
+
+	t.Run("should trace the contract creations", func(t *testing.T) {
+		t.Parallel()
+
+		tracer := calltracer.NewCallTracer(calltracer.Config{})
+
+		transition := newTracedTransition()
+		transition.SetTracer(tracer)
+
+		// returns a single byte of code
+		initCode := []byte{0x60, 0x01, 0x60, 0x00, 0xf3}
+
+		result := transition.Create2(addr1, initCode, big.NewInt(0), 1000000)
+		assert.NoError(t, result.Err)
+
+		res, err := tracer.GetResult()
+		assert.NoError(t, err)
+
+		root := res.(*calltracer.Frame) //nolint:forcetypeassert
+		assert.Equal(t, "CREATE", root.Type)
+		assert.Equal(t, "0x00", root.Output)
+		assert.Empty(t, root.Error)
+	})
+}
+
+func TestTransition_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	transition := newTestTransition(map[types.Address]*PreState{
+		addr1: {
+			Nonce:   0,
+			Balance: 1000,
+		},
+	})
+
+	transition.state.AddBalance(addr1, big.NewInt(100))
+	transition.receipts = append(transition.receipts, &types.Receipt{GasUsed: 21000})
+	transition.totalGas = 21000
+	transition.gasPool = 100000
+
+	snapshot := transition.Snapshot()
+
+	transition.state.AddBalance(addr1, big.NewInt(100))
+	transition.state.IncrNonce(addr1)
+	transition.receipts = append(transition.receipts, &types.Receipt{GasUsed: 30000})
+	transition.totalGas = 51000
+	transition.gasPool = 70000
+
+	transition.RevertToSnapshot(snapshot)
+
+	assert.Equal(t, big.NewInt(1100), transition.GetBalance(addr1))
+	assert.Equal(t, uint64(0), transition.GetNonce(addr1))
+	assert.Len(t, transition.Receipts(), 1)
+	assert.Equal(t, uint64(21000), transition.TotalGas())
+	assert.Equal(t, uint64(100000), transition.gasPool)
+}
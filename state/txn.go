@@ -21,6 +21,12 @@ var (
 
 	// refundIndex is the index of the refund
 	refundIndex = types.BytesToHash([]byte{3}).Bytes()
+
+	// accessListIndex is the prefix of the access list entries in the trie
+	accessListIndex = types.BytesToHash([]byte{4}).Bytes()
+
+	// transientStorageIndex is the prefix of the transient storage entries in the trie
+	transientStorageIndex = types.BytesToHash([]byte{5}).Bytes()
 )
 
 // Txn is a reference of the state
@@ -64,6 +70,18 @@ func (txn *Txn) hashit(src []byte) []byte {
 	return txn.hash.Read()
 }
 
+// Copy returns a copy of the txn, which is not affected by the later changes of the txn
+func (txn *Txn) Copy() *Txn {
+	return &Txn{
+		snapshot:  txn.snapshot,
+		state:     txn.state,
+		snapshots: []*iradix.Tree{},
+		txn:       txn.txn.CommitOnly().Txn(),
+		codeCache: txn.codeCache,
+		hash:      keccak.NewKeccak256(),
+	}
+}
+
 // Snapshot takes a snapshot at this point in time
 func (txn *Txn) Snapshot() int {
 	t := txn.txn.CommitOnly()
@@ -267,6 +285,12 @@ func (txn *Txn) SetStorage(
 
 	legacyGasMetering := !config.Istanbul && (config.Petersburg || !config.Constantinople)
 
+	// the refund of a cleared slot is reduced after London (EIP-3529)
+	clearsRefund := uint64(15000)
+	if config.London {
+		clearsRefund = 4800
+	}
+
 	if legacyGasMetering {
 		if oldValue == zeroHash {
 			return runtime.StorageAdded
@@ -285,7 +309,7 @@ func (txn *Txn) SetStorage(
 		}
 
 		if value == zeroHash { // delete slot (2.1.2b)
-			txn.AddRefund(15000)
+			txn.AddRefund(clearsRefund)
 
 			return runtime.StorageDeleted
 		}
@@ -295,22 +319,26 @@ func (txn *Txn) SetStorage(
 
 	if original != zeroHash { // Storage slot was populated before this transaction started
 		if current == zeroHash { // recreate slot (2.2.1.1)
-			txn.SubRefund(15000)
+			txn.SubRefund(clearsRefund)
 		} else if value == zeroHash { // delete slot (2.2.1.2)
-			txn.AddRefund(15000)
+			txn.AddRefund(clearsRefund)
 		}
 	}
 
 	if original == value {
 		if original == zeroHash { // reset to original nonexistent slot (2.2.2.1)
 			// Storage was used as memory (allocation and deallocation occurred within the same contract)
-			if config.Istanbul {
+			if config.Berlin {
+				txn.AddRefund(19900)
+			} else if config.Istanbul {
 				txn.AddRefund(19200)
 			} else {
 				txn.AddRefund(19800)
 			}
 		} else { // reset to original existing slot (2.2.2.2)
-			if config.Istanbul {
+			if config.Berlin {
+				txn.AddRefund(2800)
+			} else if config.Istanbul {
 				txn.AddRefund(4200)
 			} else {
 				txn.AddRefund(4800)
@@ -497,6 +525,107 @@ func (txn *Txn) GetRefund() uint64 {
 	return data.(uint64)
 }
 
+// Access list
+
+// accessListKey returns the key of the account, or of its storage slot if set, in the access list
+func accessListKey(addr types.Address, slot *types.Hash) []byte {
+	key := append(append([]byte{}, accessListIndex...), addr.Bytes()...)
+
+	if slot != nil {
+		key = append(key, slot.Bytes()...)
+	}
+
+	return key
+}
+
+// AddAddressToAccessList adds the account to the access list of the transaction (EIP-2929).
+// The access list is reverted with the snapshots
+func (txn *Txn) AddAddressToAccessList(addr types.Address) {
+	txn.txn.Insert(accessListKey(addr, nil), struct{}{})
+}
+
+// AddSlotToAccessList adds the storage slot, and its account, to the access list of the transaction
+func (txn *Txn) AddSlotToAccessList(addr types.Address, slot types.Hash) {
+	txn.AddAddressToAccessList(addr)
+	txn.txn.Insert(accessListKey(addr, &slot), struct{}{})
+}
+
+// AddressInAccessList checks if the account is in the access list
+func (txn *Txn) AddressInAccessList(addr types.Address) bool {
+	_, ok := txn.txn.Get(accessListKey(addr, nil))
+
+	return ok
+}
+
+// SlotInAccessList checks if the account and the storage slot are in the access list
+func (txn *Txn) SlotInAccessList(addr types.Address, slot types.Hash) (bool, bool) {
+	_, slotOk := txn.txn.Get(accessListKey(addr, &slot))
+
+	return txn.AddressInAccessList(addr), slotOk
+}
+
+// AccessList returns the accounts and storage slots in the access list
+func (txn *Txn) AccessList() types.TxAccessList {
+	list := types.TxAccessList{}
+
+	txn.txn.Root().WalkPrefix(accessListIndex, func(k []byte, _ interface{}) bool {
+		entry := k[len(accessListIndex):]
+		addr := types.BytesToAddress(entry[:types.AddressLength])
+
+		if len(entry) == types.AddressLength {
+			list = append(list, types.AccessTuple{Address: addr, StorageKeys: []types.Hash{}})
+		} else {
+			// the slots follow the account in the walk order
+			tuple := &list[len(list)-1]
+			tuple.StorageKeys = append(tuple.StorageKeys, types.BytesToHash(entry[types.AddressLength:]))
+		}
+
+		return false
+	})
+
+	return list
+}
+
+// ClearAccessList removes all the entries of the access list
+func (txn *Txn) ClearAccessList() {
+	txn.txn.DeletePrefix(accessListIndex)
+}
+
+// Transient storage
+
+// transientStorageKey returns the key of the storage slot of the account in the transient storage
+func transientStorageKey(addr types.Address, key types.Hash) []byte {
+	return append(append(append([]byte{}, transientStorageIndex...), addr.Bytes()...), key.Bytes()...)
+}
+
+// GetTransientState returns the value of the slot in the transient storage of the account (EIP-1153)
+func (txn *Txn) GetTransientState(addr types.Address, key types.Hash) types.Hash {
+	val, ok := txn.txn.Get(transientStorageKey(addr, key))
+	if !ok {
+		return types.Hash{}
+	}
+
+	//nolint:forcetypeassert
+	return val.(types.Hash)
+}
+
+// SetTransientState sets the value of the slot in the transient storage of the account.
+// The transient storage is reverted with the snapshots
+func (txn *Txn) SetTransientState(addr types.Address, key, value types.Hash) {
+	if value == zeroHash {
+		txn.txn.Delete(transientStorageKey(addr, key))
+
+		return
+	}
+
+	txn.txn.Insert(transientStorageKey(addr, key), value)
+}
+
+// ClearTransientStorage removes all the entries of the transient storage
+func (txn *Txn) ClearTransientStorage() {
+	txn.txn.DeletePrefix(transientStorageIndex)
+}
+
 // GetCommittedState returns the state of the address in the trie
 func (txn *Txn) GetCommittedState(addr types.Address, key types.Hash) types.Hash {
 	obj, ok := txn.getStateObject(addr)
@@ -7,7 +7,9 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/helper/hex"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/umbracle/fastrlp"
@@ -145,3 +147,147 @@ func hashit(k []byte) []byte {
 
 	return h.Sum(nil)
 }
+
+func TestAccessList(t *testing.T) {
+	t.Parallel()
+
+	txn := newTestTxn(defaultPreState)
+
+	txn.AddAddressToAccessList(addr1)
+	assert.True(t, txn.AddressInAccessList(addr1))
+	assert.False(t, txn.AddressInAccessList(addr2))
+
+	// the access list is reverted with the snapshot
+	ss := txn.Snapshot()
+	txn.AddSlotToAccessList(addr2, hash1)
+
+	addrOk, slotOk := txn.SlotInAccessList(addr2, hash1)
+	assert.True(t, addrOk)
+	assert.True(t, slotOk)
+
+	assert.Equal(t, types.TxAccessList{
+		{Address: addr1, StorageKeys: []types.Hash{}},
+		{Address: addr2, StorageKeys: []types.Hash{hash1}},
+	}, txn.AccessList())
+
+	txn.RevertToSnapshot(ss)
+	assert.False(t, txn.AddressInAccessList(addr2))
+
+	txn.ClearAccessList()
+	assert.False(t, txn.AddressInAccessList(addr1))
+	assert.Empty(t, txn.AccessList())
+}
+
+func TestTransientStorage(t *testing.T) {
+	t.Parallel()
+
+	txn := newTestTxn(defaultPreState)
+
+	txn.SetTransientState(addr1, hash1, hash2)
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash1))
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr2, hash1))
+
+	// the transient storage is reverted with the snapshot
+	ss := txn.Snapshot()
+	txn.SetTransientState(addr1, hash1, types.Hash{})
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr1, hash1))
+
+	txn.RevertToSnapshot(ss)
+	assert.Equal(t, hash2, txn.GetTransientState(addr1, hash1))
+
+	// the transient storage is not committed
+	assert.Len(t, txn.Commit(false), 0)
+
+	txn.ClearTransientStorage()
+	assert.Equal(t, types.Hash{}, txn.GetTransientState(addr1, hash1))
+}
+
+func TestSetStorage_ClearsRefund(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		config         *chain.ForksInTime
+		expectedRefund uint64
+	}{
+		{
+			name:           "should refund the cleared slot before London",
+			config:         &chain.ForksInTime{Constantinople: true, Petersburg: true, Istanbul: true},
+			expectedRefund: 15000,
+		},
+		{
+			name:           "should reduce the refund of the cleared slot after London",
+			config:         &chain.ForksInTime{Constantinople: true, Petersburg: true, Istanbul: true, London: true},
+			expectedRefund: 4800,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// the mock snapshot stores the slots by the hash of their key
+			txn := newTestTxn(map[types.Address]*PreState{
+				addr1: {
+					State: map[types.Hash]types.Hash{
+						types.BytesToHash(hashit(hash1.Bytes())): hash1,
+					},
+				},
+			})
+
+			assert.Equal(t, runtime.StorageDeleted, txn.SetStorage(addr1, hash1, types.Hash{}, tt.config))
+			assert.Equal(t, tt.expectedRefund, txn.GetRefund())
+		})
+	}
+}
+
+func TestTxn_Diff(t *testing.T) {
+	t.Parallel()
+
+	addr3 := types.StringToAddress("3")
+
+	txn := newTestTxn(map[types.Address]*PreState{
+		addr1: {Balance: 100, Nonce: 1},
+		addr3: {Balance: 5},
+	})
+
+	// the changes before the copy are not part of the diff
+	txn.AddBalance(addr1, big.NewInt(10))
+
+	prev := txn.Copy()
+
+	assert.NoError(t, txn.SubBalance(addr1, big.NewInt(30)))
+	txn.IncrNonce(addr1)
+	txn.SetState(addr1, hash1, hash2)
+	txn.SetCode(addr2, []byte{0x1})
+
+	// the account is rewritten without changes
+	txn.AddBalance(addr3, big.NewInt(0))
+
+	// the copy is not affected by the changes
+	assert.Equal(t, big.NewInt(110), prev.GetBalance(addr1))
+
+	diff := txn.Diff(prev)
+
+	assert.Len(t, diff, 2)
+
+	assert.Equal(t, &AccountDiff{
+		Existed:       true,
+		Exists:        true,
+		BalanceBefore: big.NewInt(110),
+		BalanceAfter:  big.NewInt(80),
+		NonceBefore:   1,
+		NonceAfter:    2,
+		Storage: map[types.Hash]StorageDiff{
+			hash1: {Before: types.Hash{}, After: hash2},
+		},
+	}, diff[addr1])
+
+	assert.False(t, diff[addr2].Existed)
+	assert.True(t, diff[addr2].Exists)
+	assert.Empty(t, diff[addr2].CodeBefore)
+	assert.Equal(t, []byte{0x1}, diff[addr2].CodeAfter)
+	assert.Empty(t, diff[addr2].Storage)
+}
@@ -74,6 +74,12 @@ func (m *syncPeerClient) Close() {
 		m.subscription = nil
 	}
 
+	if m.topic != nil {
+		if err := m.topic.Close(); err != nil {
+			m.logger.Error("failed to close the status topic", "err", err)
+		}
+	}
+
 	close(m.peerStatusUpdateCh)
 	close(m.peerConnectionUpdateCh)
 }
@@ -264,8 +270,8 @@ func (m *syncPeerClient) GetBlocks(
 	// input channel
 	streamBlockCh, streamErrorCh := blockStreamToChannel(stream)
 
-	// output channel
-	blockCh := make(chan *types.Block, 1)
+	// output channel, buffered so the received blocks can be verified in batches
+	blockCh := make(chan *types.Block, maxBlockBatchSize)
 
 	go func() {
 		defer cancel()
@@ -15,6 +15,9 @@ import (
 const (
 	syncerName  = "syncer"
 	syncerProto = "/syncer/0.2"
+
+	// maximum number of received blocks whose headers are verified in a single batch
+	maxBlockBatchSize = 1024
 )
 
 var (
@@ -233,24 +236,66 @@ func (s *syncer) bulkSyncWithPeer(peerID peer.ID, newBlockCallback func(*types.B
 				return lastReceivedNumber, shouldTerminate, nil
 			}
 
-			// safe check
-			if block.Number() == 0 {
-				continue
-			}
+			batch := collectBlockBatch(block, blockCh)
 
-			if err := s.blockchain.VerifyFinalizedBlock(block); err != nil {
-				return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
+			// verify the headers of the already received blocks at once,
+			// the verification results are reused by the consensus for each block
+			if err := s.blockchain.VerifyHeaders(blocksToHeaders(batch)); err != nil {
+				s.logger.Debug("failed to verify headers in batch", "err", err)
 			}
 
-			if err := s.blockchain.WriteBlock(block, syncerName); err != nil {
-				return lastReceivedNumber, false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
-			}
+			for _, block := range batch {
+				// safe check
+				if block.Number() == 0 {
+					continue
+				}
 
-			shouldTerminate = newBlockCallback(block)
+				if err := s.blockchain.VerifyFinalizedBlock(block); err != nil {
+					return lastReceivedNumber, false, fmt.Errorf("unable to verify block, %w", err)
+				}
 
-			lastReceivedNumber = block.Number()
+				if err := s.blockchain.WriteBlock(block, syncerName); err != nil {
+					return lastReceivedNumber, false, fmt.Errorf("failed to write block while bulk syncing: %w", err)
+				}
+
+				shouldTerminate = newBlockCallback(block)
+
+				lastReceivedNumber = block.Number()
+			}
 		case <-time.After(s.blockTimeout):
 			return lastReceivedNumber, shouldTerminate, errTimeout
 		}
 	}
 }
+
+// collectBlockBatch returns the given block followed by the blocks
+// already received in the channel, without waiting for new ones
+func collectBlockBatch(first *types.Block, blockCh <-chan *types.Block) []*types.Block {
+	batch := []*types.Block{first}
+
+	for len(batch) < maxBlockBatchSize {
+		select {
+		case block, ok := <-blockCh:
+			if !ok {
+				return batch
+			}
+
+			batch = append(batch, block)
+		default:
+			return batch
+		}
+	}
+
+	return batch
+}
+
+// blocksToHeaders returns the headers of the given blocks
+func blocksToHeaders(blocks []*types.Block) []*types.Header {
+	headers := make([]*types.Header, len(blocks))
+
+	for i, block := range blocks {
+		headers[i] = block.Header
+	}
+
+	return headers
+}
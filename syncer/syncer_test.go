@@ -40,6 +40,7 @@ type mockBlockchain struct {
 	headerHandler               func() *types.Header
 	getBlockByNumberHandler     func(uint64, bool) (*types.Block, bool)
 	verifyFinalizedBlockHandler func(*types.Block) error
+	verifyHeadersHandler        func([]*types.Header) error
 	writeBlockHandler           func(*types.Block) error
 }
 
@@ -59,6 +60,14 @@ func (m *mockBlockchain) VerifyFinalizedBlock(b *types.Block) error {
 	return m.verifyFinalizedBlockHandler(b)
 }
 
+func (m *mockBlockchain) VerifyHeaders(headers []*types.Header) error {
+	if m.verifyHeadersHandler != nil {
+		return m.verifyHeadersHandler(headers)
+	}
+
+	return nil
+}
+
 func (m *mockBlockchain) WriteBlock(b *types.Block, s string) error {
 	return m.writeBlockHandler(b)
 }
@@ -24,6 +24,8 @@ type Blockchain interface {
 	GetBlockByNumber(uint64, bool) (*types.Block, bool)
 	// VerifyFinalizedBlock verifies finalized block
 	VerifyFinalizedBlock(*types.Block) error
+	// VerifyHeaders verifies a batch of headers ahead of their blocks
+	VerifyHeaders([]*types.Header) error
 	// WriteBlock writes a given block to chain
 	WriteBlock(*types.Block, string) error
 }
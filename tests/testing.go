@@ -35,6 +35,7 @@ type env struct {
 	GasLimit   string `json:"currentGasLimit"`
 	Number     string `json:"currentNumber"`
 	Timestamp  string `json:"currentTimestamp"`
+	BaseFee    string `json:"currentBaseFee"`
 }
 
 func remove0xPrefix(str string) string {
@@ -139,13 +140,20 @@ func (e *env) ToHeader(t *testing.T) *types.Header {
 
 	miner := stringToAddressT(t, e.Coinbase)
 
-	return &types.Header{
+	header := &types.Header{
 		Miner:      miner[:],
 		Difficulty: stringToUint64T(t, e.Difficulty),
 		GasLimit:   stringToUint64T(t, e.GasLimit),
 		Number:     stringToUint64T(t, e.Number),
 		Timestamp:  stringToUint64T(t, e.Timestamp),
 	}
+
+	// the base fee is only set by the tests of the forks after London
+	if e.BaseFee != "" {
+		header.BaseFee = stringToUint64T(t, e.BaseFee)
+	}
+
+	return header
 }
 
 func (e *env) ToEnv(t *testing.T) runtime.TxContext {
@@ -284,13 +292,16 @@ func (p *postEntry) UnmarshalJSON(input []byte) error {
 }
 
 type stTransaction struct {
-	Data     []string       `json:"data"`
-	GasLimit []uint64       `json:"gasLimit"`
-	Value    []*big.Int     `json:"value"`
-	GasPrice *big.Int       `json:"gasPrice"`
-	Nonce    uint64         `json:"nonce"`
-	From     types.Address  `json:"secretKey"`
-	To       *types.Address `json:"to"`
+	Data        []string             `json:"data"`
+	GasLimit    []uint64             `json:"gasLimit"`
+	Value       []*big.Int           `json:"value"`
+	GasPrice    *big.Int             `json:"gasPrice"`
+	GasTipCap   *big.Int             `json:"maxPriorityFeePerGas"`
+	GasFeeCap   *big.Int             `json:"maxFeePerGas"`
+	AccessLists []types.TxAccessList `json:"accessLists"`
+	Nonce       uint64               `json:"nonce"`
+	From        types.Address        `json:"secretKey"`
+	To          *types.Address       `json:"to"`
 }
 
 func (t *stTransaction) At(i indexes) (*types.Transaction, error) {
@@ -307,12 +318,25 @@ func (t *stTransaction) At(i indexes) (*types.Transaction, error) {
 	}
 
 	msg := &types.Transaction{
-		To:       t.To,
-		Nonce:    t.Nonce,
-		Value:    new(big.Int).Set(t.Value[i.Value]),
-		Gas:      t.GasLimit[i.Gas],
-		GasPrice: new(big.Int).Set(t.GasPrice),
-		Input:    hex.MustDecodeHex(t.Data[i.Data]),
+		To:    t.To,
+		Nonce: t.Nonce,
+		Value: new(big.Int).Set(t.Value[i.Value]),
+		Gas:   t.GasLimit[i.Gas],
+		Input: hex.MustDecodeHex(t.Data[i.Data]),
+	}
+
+	// the access lists are given per data entry, and some entries may be empty
+	if len(t.AccessLists) > i.Data && t.AccessLists[i.Data] != nil {
+		msg.Type = types.AccessListTx
+		msg.AccessList = t.AccessLists[i.Data]
+	}
+
+	if t.GasFeeCap != nil {
+		msg.Type = types.DynamicFeeTx
+		msg.GasTipCap = new(big.Int).Set(t.GasTipCap)
+		msg.GasFeeCap = new(big.Int).Set(t.GasFeeCap)
+	} else {
+		msg.GasPrice = new(big.Int).Set(t.GasPrice)
 	}
 
 	msg.From = t.From
@@ -322,13 +346,16 @@ func (t *stTransaction) At(i indexes) (*types.Transaction, error) {
 
 func (t *stTransaction) UnmarshalJSON(input []byte) error {
 	type txUnmarshall struct {
-		Data      []string `json:"data"`
-		GasLimit  []string `json:"gasLimit"`
-		Value     []string `json:"value"`
-		GasPrice  string   `json:"gasPrice"`
-		Nonce     string   `json:"nonce"`
-		SecretKey string   `json:"secretKey"`
-		To        string   `json:"to"`
+		Data                 []string             `json:"data"`
+		GasLimit             []string             `json:"gasLimit"`
+		Value                []string             `json:"value"`
+		GasPrice             string               `json:"gasPrice"`
+		MaxPriorityFeePerGas string               `json:"maxPriorityFeePerGas"`
+		MaxFeePerGas         string               `json:"maxFeePerGas"`
+		AccessLists          []types.TxAccessList `json:"accessLists"`
+		Nonce                string               `json:"nonce"`
+		SecretKey            string               `json:"secretKey"`
+		To                   string               `json:"to"`
 	}
 
 	var dec txUnmarshall
@@ -364,8 +391,18 @@ func (t *stTransaction) UnmarshalJSON(input []byte) error {
 		t.Value = append(t.Value, value)
 	}
 
-	t.GasPrice, err = stringToBigInt(dec.GasPrice)
-	if err != nil {
+	t.AccessLists = dec.AccessLists
+
+	// the dynamic fee transactions set the fee caps instead of the gas price
+	if dec.MaxFeePerGas != "" {
+		if t.GasFeeCap, err = stringToBigInt(dec.MaxFeePerGas); err != nil {
+			return err
+		}
+
+		if t.GasTipCap, err = stringToBigInt(dec.MaxPriorityFeePerGas); err != nil {
+			return err
+		}
+	} else if t.GasPrice, err = stringToBigInt(dec.GasPrice); err != nil {
 		return err
 	}
 
@@ -440,6 +477,56 @@ var Forks = map[string]*chain.Forks{
 		Petersburg:     chain.NewFork(0),
 		Istanbul:       chain.NewFork(0),
 	},
+	"Berlin": {
+		Homestead:      chain.NewFork(0),
+		EIP150:         chain.NewFork(0),
+		EIP155:         chain.NewFork(0),
+		EIP158:         chain.NewFork(0),
+		Byzantium:      chain.NewFork(0),
+		Constantinople: chain.NewFork(0),
+		Petersburg:     chain.NewFork(0),
+		Istanbul:       chain.NewFork(0),
+		Berlin:         chain.NewFork(0),
+	},
+	"London": {
+		Homestead:      chain.NewFork(0),
+		EIP150:         chain.NewFork(0),
+		EIP155:         chain.NewFork(0),
+		EIP158:         chain.NewFork(0),
+		Byzantium:      chain.NewFork(0),
+		Constantinople: chain.NewFork(0),
+		Petersburg:     chain.NewFork(0),
+		Istanbul:       chain.NewFork(0),
+		Berlin:         chain.NewFork(0),
+		London:         chain.NewFork(0),
+	},
+	"Shanghai": {
+		Homestead:      chain.NewFork(0),
+		EIP150:         chain.NewFork(0),
+		EIP155:         chain.NewFork(0),
+		EIP158:         chain.NewFork(0),
+		Byzantium:      chain.NewFork(0),
+		Constantinople: chain.NewFork(0),
+		Petersburg:     chain.NewFork(0),
+		Istanbul:       chain.NewFork(0),
+		Berlin:         chain.NewFork(0),
+		London:         chain.NewFork(0),
+		Shanghai:       chain.NewFork(0),
+	},
+	"Cancun": {
+		Homestead:      chain.NewFork(0),
+		EIP150:         chain.NewFork(0),
+		EIP155:         chain.NewFork(0),
+		EIP158:         chain.NewFork(0),
+		Byzantium:      chain.NewFork(0),
+		Constantinople: chain.NewFork(0),
+		Petersburg:     chain.NewFork(0),
+		Istanbul:       chain.NewFork(0),
+		Berlin:         chain.NewFork(0),
+		London:         chain.NewFork(0),
+		Shanghai:       chain.NewFork(0),
+		Cancun:         chain.NewFork(0),
+	},
 	"FrontierToHomesteadAt5": {
 		Homestead: chain.NewFork(5),
 	},
@@ -15,6 +15,7 @@ type accountsMap struct {
 	count uint64
 
 	maxEnqueuedLimit uint64
+	maxPromotedLimit uint64
 }
 
 // Intializes an account for the given address.
@@ -27,8 +28,9 @@ func (m *accountsMap) initOnce(addr types.Address, nonce uint64) *account {
 		newAccount.enqueued = newAccountQueue()
 		newAccount.promoted = newAccountQueue()
 
-		//	set the limit for enqueued txs
+		//	set the limits for enqueued and promoted txs
 		newAccount.maxEnqueued = m.maxEnqueuedLimit
+		newAccount.maxPromoted = m.maxPromotedLimit
 
 		// set the nonce
 		newAccount.setNonce(nonce)
@@ -162,6 +164,9 @@ type account struct {
 
 	//	maximum number of enqueued transactions
 	maxEnqueued uint64
+
+	//	maximum number of promoted transactions, unlimited if 0
+	maxPromoted uint64
 }
 
 // getNonce returns the next expected nonce for this account.
@@ -192,7 +197,8 @@ func (a *account) incrementDemotions() {
 // reset aligns the account with the new nonce
 // by pruning all transactions with nonce lesser than new.
 // After pruning, a promotion may be signaled if the first
-// enqueued transaction matches the new nonce.
+// enqueued transaction matches the new nonce, or was held
+// back by the promoted limit which now has room for it.
 func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 	prunedPromoted,
 	prunedEnqueued []*types.Transaction,
@@ -204,7 +210,18 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 	prunedPromoted = a.promoted.prune(nonce)
 
 	if nonce <= a.getNonce() {
-		// only the promoted queue needed pruning
+		// only the promoted queue needed pruning. The txs held back
+		// by the promoted limit are promoted once it has room for them,
+		// even if the written txs were already popped from the queue
+		if a.maxPromoted != 0 && a.promoted.length() < a.maxPromoted {
+			a.enqueued.lock(true)
+			defer a.enqueued.unlock()
+
+			if first := a.enqueued.peek(); first != nil && first.Nonce == a.getNonce() {
+				promoteCh <- promoteRequest{account: first.From}
+			}
+		}
+
 		return
 	}
 
@@ -229,23 +246,65 @@ func (a *account) reset(nonce uint64, promoteCh chan<- promoteRequest) (
 }
 
 // enqueue attempts tp push the transaction onto the enqueued queue.
-func (a *account) enqueue(tx *types.Transaction) error {
+// If a transaction with the same nonce is already in one of the queues,
+// it is replaced instead, provided the new one is priced at least priceBump percent higher.
+// Returns the replaced transaction (if any), and whether it was promoted.
+func (a *account) enqueue(tx *types.Transaction, priceBump uint64) (
+	replaced *types.Transaction,
+	promoted bool,
+	err error,
+) {
+	a.promoted.lock(true)
 	a.enqueued.lock(true)
-	defer a.enqueued.unlock()
+
+	defer func() {
+		a.enqueued.unlock()
+		a.promoted.unlock()
+	}()
+
+	// replace the tx with the same nonce
+	for _, queue := range []*accountQueue{a.promoted, a.enqueued} {
+		old := queue.get(tx.Nonce)
+		if old == nil {
+			continue
+		}
+
+		if !isReplacement(old, tx, priceBump) {
+			return nil, false, ErrReplacementUnderpriced
+		}
+
+		return queue.replace(tx), queue == a.promoted, nil
+	}
 
 	if a.enqueued.length() == a.maxEnqueued {
-		return ErrMaxEnqueuedLimitReached
+		return nil, false, ErrMaxEnqueuedLimitReached
 	}
 
 	// reject low nonce tx
 	if tx.Nonce < a.getNonce() {
-		return ErrNonceTooLow
+		return nil, false, ErrNonceTooLow
 	}
 
 	// enqueue tx
 	a.enqueued.push(tx)
 
-	return nil
+	return nil, false, nil
+}
+
+// getTxWithNonce returns the transaction with the given nonce
+// from either of the queues, or nil if there is none.
+func (a *account) getTxWithNonce(nonce uint64) *types.Transaction {
+	a.promoted.lock(false)
+	defer a.promoted.unlock()
+
+	if tx := a.promoted.get(nonce); tx != nil {
+		return tx
+	}
+
+	a.enqueued.lock(false)
+	defer a.enqueued.unlock()
+
+	return a.enqueued.get(nonce)
 }
 
 // Promote moves eligible transactions from enqueued to promoted.
@@ -279,6 +338,11 @@ func (a *account) promote() (promoted []*types.Transaction, pruned []*types.Tran
 			break
 		}
 
+		// hold back the txs above the promoted limit
+		if a.maxPromoted != 0 && a.promoted.length() >= a.maxPromoted {
+			break
+		}
+
 		// pop from enqueued
 		tx = a.enqueued.pop()
 
@@ -0,0 +1,240 @@
+package txpool
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Gabulhas/polygon-external-consensus/helper/keccak"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+const (
+	// maxBundles is the maximum number of bundles kept by the pool
+	maxBundles = 1024
+
+	// maxBundleTxs is the maximum number of transactions of a bundle
+	maxBundleTxs = 64
+
+	// maxBundleBlocksAhead is the maximum number of blocks a bundle can target ahead of the head
+	maxBundleBlocksAhead = 25
+)
+
+var (
+	ErrEmptyBundle             = errors.New("bundle has no transactions")
+	ErrBundleTooLarge          = errors.New("bundle has too many transactions")
+	ErrBundleOutdated          = errors.New("bundle targets an already sealed block")
+	ErrBundleTooFarAhead       = errors.New("bundle targets a block too far ahead")
+	ErrInvalidBundleTimestamps = errors.New("bundle min timestamp is above its max timestamp")
+	ErrBundlePoolFull          = errors.New("bundle pool is full")
+	ErrBundleAlreadyKnown      = errors.New("bundle already known")
+)
+
+// Bundle is an ordered list of transactions, included atomically in the target block
+// only if all of them succeed. The bundles are kept apart from the other transactions
+// of the pool, and are not gossiped
+type Bundle struct {
+	// Hash is the hash of the hashes of the transactions
+	Hash types.Hash
+
+	// Txs are the transactions, in their order of inclusion
+	Txs []*types.Transaction
+
+	// BlockNumber is the number of the block the bundle targets
+	BlockNumber uint64
+
+	// MinTimestamp and MaxTimestamp are the range of the timestamps
+	// of the block the bundle is included in, unbounded if 0
+	MinTimestamp uint64
+	MaxTimestamp uint64
+}
+
+func newBundle(txs []*types.Transaction, blockNumber, minTimestamp, maxTimestamp uint64) *Bundle {
+	hashes := make([]byte, 0, len(txs)*types.HashLength)
+	for _, tx := range txs {
+		hashes = append(hashes, tx.Hash.Bytes()...)
+	}
+
+	return &Bundle{
+		Hash:         types.BytesToHash(keccak.Keccak256(nil, hashes)),
+		Txs:          txs,
+		BlockNumber:  blockNumber,
+		MinTimestamp: minTimestamp,
+		MaxTimestamp: maxTimestamp,
+	}
+}
+
+// Admits checks if the bundle can be included in the block with the given number and timestamp
+func (b *Bundle) Admits(number, timestamp uint64) bool {
+	if b.BlockNumber != number {
+		return false
+	}
+
+	if b.MinTimestamp != 0 && timestamp < b.MinTimestamp {
+		return false
+	}
+
+	if b.MaxTimestamp != 0 && timestamp > b.MaxTimestamp {
+		return false
+	}
+
+	return true
+}
+
+// bundleMap keeps the bundles in their order of arrival
+type bundleMap struct {
+	sync.Mutex
+
+	bundles []*Bundle
+}
+
+// add adds the bundle, unless it is already known. If the map is full, the bundle
+// targeting the furthest block is evicted, unless it targets the same block or an earlier one
+func (m *bundleMap) add(bundle *Bundle) error {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, known := range m.bundles {
+		if known.Hash == bundle.Hash && known.BlockNumber == bundle.BlockNumber {
+			return ErrBundleAlreadyKnown
+		}
+	}
+
+	if len(m.bundles) >= maxBundles {
+		furthest := m.furthest()
+		if m.bundles[furthest].BlockNumber <= bundle.BlockNumber {
+			return ErrBundlePoolFull
+		}
+
+		m.bundles = append(m.bundles[:furthest], m.bundles[furthest+1:]...)
+	}
+
+	m.bundles = append(m.bundles, bundle)
+
+	return nil
+}
+
+// furthest returns the index of the bundle targeting the furthest block,
+// the latest one to arrive if there are several
+func (m *bundleMap) furthest() (index int) {
+	for i, bundle := range m.bundles {
+		if bundle.BlockNumber >= m.bundles[index].BlockNumber {
+			index = i
+		}
+	}
+
+	return
+}
+
+// admitted returns the bundles admitted by the block with the given number and timestamp
+func (m *bundleMap) admitted(number, timestamp uint64) (bundles []*Bundle) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, bundle := range m.bundles {
+		if bundle.Admits(number, timestamp) {
+			bundles = append(bundles, bundle)
+		}
+	}
+
+	return
+}
+
+// prune removes the bundles targeting the blocks up to the given number
+func (m *bundleMap) prune(number uint64) (pruned int) {
+	m.Lock()
+	defer m.Unlock()
+
+	kept := m.bundles[:0]
+
+	for _, bundle := range m.bundles {
+		if bundle.BlockNumber > number {
+			kept = append(kept, bundle)
+		}
+	}
+
+	pruned = len(m.bundles) - len(kept)
+
+	// clear the references of the pruned bundles
+	for i := len(kept); i < len(m.bundles); i++ {
+		m.bundles[i] = nil
+	}
+
+	m.bundles = kept
+
+	return
+}
+
+// AddBundle adds a bundle of transactions (sent from the json-RPC endpoint), targeting the block
+// with the given number, and returns its hash. The transactions are validated against
+// the current state, but they are only executed when the target block is built
+func (p *TxPool) AddBundle(
+	txs []*types.Transaction,
+	blockNumber,
+	minTimestamp,
+	maxTimestamp uint64,
+) (types.Hash, error) {
+	if len(txs) == 0 {
+		return types.ZeroHash, ErrEmptyBundle
+	}
+
+	if len(txs) > maxBundleTxs {
+		return types.ZeroHash, ErrBundleTooLarge
+	}
+
+	head := p.store.Header().Number
+
+	if blockNumber <= head {
+		return types.ZeroHash, ErrBundleOutdated
+	}
+
+	if blockNumber > head+maxBundleBlocksAhead {
+		return types.ZeroHash, ErrBundleTooFarAhead
+	}
+
+	if maxTimestamp != 0 && minTimestamp > maxTimestamp {
+		return types.ZeroHash, ErrInvalidBundleTimestamps
+	}
+
+	for _, tx := range txs {
+		tx.ComputeHash()
+
+		if err := p.validateTx(tx); err != nil {
+			return types.ZeroHash, fmt.Errorf("invalid bundle transaction %s, %w", tx.Hash, err)
+		}
+	}
+
+	bundle := newBundle(txs, blockNumber, minTimestamp, maxTimestamp)
+
+	if err := p.bundles.add(bundle); err != nil {
+		return types.ZeroHash, err
+	}
+
+	p.logger.Debug("add bundle",
+		"hash", bundle.Hash.String(),
+		"txs", len(txs),
+		"block", blockNumber,
+	)
+
+	return bundle.Hash, nil
+}
+
+// HasBundles checks if there are bundles targeting the block with the given number
+func (p *TxPool) HasBundles(number uint64) bool {
+	p.bundles.Lock()
+	defer p.bundles.Unlock()
+
+	for _, bundle := range p.bundles.bundles {
+		if bundle.BlockNumber == number {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Bundles returns the bundles which can be included in the block with the given number
+// and timestamp, in their order of arrival. They are kept until the target block is sealed
+func (p *TxPool) Bundles(number, timestamp uint64) []*Bundle {
+	return p.bundles.admitted(number, timestamp)
+}
@@ -0,0 +1,165 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddBundle(t *testing.T) {
+	t.Parallel()
+
+	// the head of the mock store is the block 0
+	setupPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		return pool
+	}
+
+	t.Run("invalid bundles are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		tooLarge := make([]*types.Transaction, maxBundleTxs+1)
+		for i := range tooLarge {
+			tooLarge[i] = newTx(addr1, uint64(i), 1)
+		}
+
+		underpriced := newTx(addr1, 0, 1)
+		underpriced.GasPrice.SetUint64(0)
+
+		tests := []struct {
+			name         string
+			txs          []*types.Transaction
+			blockNumber  uint64
+			minTimestamp uint64
+			maxTimestamp uint64
+			err          error
+		}{
+			{"empty bundle", nil, 1, 0, 0, ErrEmptyBundle},
+			{"too many txs", tooLarge, 1, 0, 0, ErrBundleTooLarge},
+			{"outdated block", []*types.Transaction{newTx(addr1, 0, 1)}, 0, 0, 0, ErrBundleOutdated},
+			{"too far ahead", []*types.Transaction{newTx(addr1, 0, 1)}, maxBundleBlocksAhead + 1, 0, 0, ErrBundleTooFarAhead},
+			{"invalid timestamps", []*types.Transaction{newTx(addr1, 0, 1)}, 1, 20, 10, ErrInvalidBundleTimestamps},
+			{"invalid tx", []*types.Transaction{newTx(addr1, 0, 1), underpriced}, 1, 0, 0, ErrUnderpriced},
+		}
+
+		for _, tt := range tests {
+			_, err := pool.AddBundle(tt.txs, tt.blockNumber, tt.minTimestamp, tt.maxTimestamp)
+			assert.ErrorIs(t, err, tt.err, tt.name)
+		}
+
+		assert.Empty(t, pool.bundles.bundles)
+	})
+
+	t.Run("bundles are kept apart from the pool", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		txs := []*types.Transaction{newTx(addr1, 0, 1), newTx(addr2, 0, 1)}
+
+		hash, err := pool.AddBundle(txs, 1, 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, newBundle(txs, 1, 0, 0).Hash, hash)
+
+		_, err = pool.AddBundle(txs, 1, 0, 0)
+		assert.ErrorIs(t, err, ErrBundleAlreadyKnown)
+
+		// the same txs can target another block
+		_, err = pool.AddBundle(txs, 2, 0, 0)
+		assert.NoError(t, err)
+
+		assert.Equal(t, uint64(0), pool.Length())
+		assert.Equal(t, uint64(0), pool.gauge.read())
+
+		_, exists := pool.index.get(txs[0].Hash)
+		assert.False(t, exists)
+	})
+
+	t.Run("bundles are returned for their target block", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		unbounded, err := pool.AddBundle([]*types.Transaction{newTx(addr1, 0, 1)}, 1, 0, 0)
+		assert.NoError(t, err)
+
+		bounded, err := pool.AddBundle([]*types.Transaction{newTx(addr2, 0, 1)}, 1, 100, 200)
+		assert.NoError(t, err)
+
+		_, err = pool.AddBundle([]*types.Transaction{newTx(addr3, 0, 1)}, 2, 0, 0)
+		assert.NoError(t, err)
+
+		hashes := func(bundles []*Bundle) (hashes []types.Hash) {
+			for _, bundle := range bundles {
+				hashes = append(hashes, bundle.Hash)
+			}
+
+			return
+		}
+
+		assert.Equal(t, []types.Hash{unbounded}, hashes(pool.Bundles(1, 99)))
+		assert.Equal(t, []types.Hash{unbounded, bounded}, hashes(pool.Bundles(1, 100)))
+		assert.Equal(t, []types.Hash{unbounded, bounded}, hashes(pool.Bundles(1, 200)))
+		assert.Equal(t, []types.Hash{unbounded}, hashes(pool.Bundles(1, 201)))
+
+		assert.True(t, pool.HasBundles(2))
+		assert.False(t, pool.HasBundles(3))
+	})
+
+	t.Run("the furthest bundles are evicted from the full pool", func(t *testing.T) {
+		t.Parallel()
+
+		pool := setupPool(t)
+
+		for i := 0; i < maxBundles; i++ {
+			assert.NoError(t, pool.bundles.add(newBundle([]*types.Transaction{
+				newTx(addr1, uint64(i), 1).ComputeHash(),
+			}, maxBundleBlocksAhead, 0, 0)))
+		}
+
+		furthest := pool.bundles.bundles[maxBundles-1]
+
+		// the bundles targeting the furthest block are not evicted for each other
+		_, err := pool.AddBundle([]*types.Transaction{newTx(addr2, 0, 1)}, maxBundleBlocksAhead, 0, 0)
+		assert.ErrorIs(t, err, ErrBundlePoolFull)
+
+		// the bundles targeting an earlier block evict the latest one of the furthest block
+		next, err := pool.AddBundle([]*types.Transaction{newTx(addr2, 0, 1)}, 1, 0, 0)
+		assert.NoError(t, err)
+
+		assert.Len(t, pool.bundles.bundles, maxBundles)
+		assert.NotContains(t, pool.bundles.bundles, furthest)
+		assert.Equal(t, next, pool.bundles.bundles[maxBundles-1].Hash)
+	})
+
+	t.Run("bundles of the sealed blocks are pruned", func(t *testing.T) {
+		t.Parallel()
+
+		header := mockHeader.Copy()
+
+		pool, err := newTestPool(defaultMockStore{DefaultHeader: header})
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		_, err = pool.AddBundle([]*types.Transaction{newTx(addr1, 0, 1)}, 1, 0, 0)
+		assert.NoError(t, err)
+
+		next, err := pool.AddBundle([]*types.Transaction{newTx(addr2, 0, 1)}, 2, 0, 0)
+		assert.NoError(t, err)
+
+		header.Number = 1
+		pool.ResetWithHeaders()
+
+		assert.False(t, pool.HasBundles(1))
+		assert.Len(t, pool.bundles.bundles, 1)
+		assert.Equal(t, next, pool.bundles.bundles[0].Hash)
+	})
+}
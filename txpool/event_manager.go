@@ -82,6 +82,9 @@ func (em *eventManager) Close() {
 		subscription.close()
 	}
 
+	// the subscriptions are already closed if they are cancelled later
+	em.subscriptions = make(map[subscriptionID]*eventSubscription)
+
 	atomic.StoreInt64(&em.numSubscriptions, 0)
 }
 
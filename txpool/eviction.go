@@ -0,0 +1,247 @@
+package txpool
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/Gabulhas/polygon-external-consensus/txpool/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+// evictionCandidate holds the transactions of an account that can be evicted,
+// sorted by descending nonce so that the account is evicted from its tail
+type evictionCandidate struct {
+	addr types.Address
+	txs  []*types.Transaction
+}
+
+// evictionQueue is a min heap of the candidates by the price of their next transaction
+type evictionQueue []*evictionCandidate
+
+/* Queue methods required by the heap interface */
+
+func (q *evictionQueue) Len() int {
+	return len(*q)
+}
+
+func (q *evictionQueue) Swap(i, j int) {
+	(*q)[i], (*q)[j] = (*q)[j], (*q)[i]
+}
+
+func (q *evictionQueue) Less(i, j int) bool {
+	return (*q)[i].txs[0].GetGasFeeCap().Cmp((*q)[j].txs[0].GetGasFeeCap()) < 0
+}
+
+func (q *evictionQueue) Push(x interface{}) {
+	candidate, ok := x.(*evictionCandidate)
+	if !ok {
+		return
+	}
+
+	*q = append(*q, candidate)
+}
+
+func (q *evictionQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[0 : n-1]
+
+	return x
+}
+
+// evict frees the slots required by the transaction in the full pool, by evicting
+// the transactions priced lower than it: the enqueued ones first, and then the promoted ones,
+// each account from its highest nonce. The local transactions, and the ones
+// of their accounts with a lower nonce, are protected.
+// Returns false, without evicting anything, if not enough slots can be freed.
+func (p *TxPool) evict(tx *types.Transaction) bool {
+	p.evictionLock.Lock()
+	defer p.evictionLock.Unlock()
+
+	height, slots := p.gauge.read(), slotsRequired(tx)
+	if height+slots <= p.gauge.max {
+		// freed in the meantime
+		return true
+	}
+
+	var (
+		required = height + slots - p.gauge.max
+		freed    uint64
+	)
+
+	// evictCheapest plans the eviction of the cheapest transactions
+	// of the candidates, until enough slots are freed
+	evictCheapest := func(candidates evictionQueue) (evicted []*types.Transaction) {
+		heap.Init(&candidates)
+
+		for freed < required && candidates.Len() > 0 {
+			candidate := candidates[0]
+
+			next := candidate.txs[0]
+			if next.GetGasFeeCap().Cmp(tx.GetGasFeeCap()) >= 0 {
+				// the other candidates are not cheaper
+				break
+			}
+
+			evicted = append(evicted, next)
+			freed += slotsRequired(next)
+
+			if candidate.txs = candidate.txs[1:]; len(candidate.txs) == 0 {
+				heap.Pop(&candidates)
+			} else {
+				heap.Fix(&candidates, 0)
+			}
+		}
+
+		return evicted
+	}
+
+	enqueuedCandidates, promotedCandidates := p.evictionCandidates(tx.From)
+
+	evictedEnqueued := evictCheapest(enqueuedCandidates)
+
+	// the promoted transactions of an account are evicted
+	// only once its enqueued ones are, to not leave a nonce gap
+	remaining := make(map[types.Address]struct{})
+
+	for _, candidate := range enqueuedCandidates {
+		if len(candidate.txs) != 0 {
+			remaining[candidate.addr] = struct{}{}
+		}
+	}
+
+	eligible := make(evictionQueue, 0, len(promotedCandidates))
+
+	for _, candidate := range promotedCandidates {
+		if _, ok := remaining[candidate.addr]; !ok {
+			eligible = append(eligible, candidate)
+		}
+	}
+
+	evictedPromoted := evictCheapest(eligible)
+
+	if freed < required {
+		return false
+	}
+
+	p.evictTxs(evictedEnqueued, evictedPromoted)
+
+	return true
+}
+
+// evictionCandidates returns the evictable enqueued and promoted
+// transactions of the accounts, except the given one. The transactions
+// are evictable down to the highest local one of their account
+func (p *TxPool) evictionCandidates(except types.Address) (enqueued, promoted evictionQueue) {
+	// evictable returns the txs sorted by descending nonce, above the highest local one
+	evictable := func(txs []*types.Transaction) []*types.Transaction {
+		sorted := make([]*types.Transaction, len(txs))
+		copy(sorted, txs)
+
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i].Nonce > sorted[j].Nonce
+		})
+
+		for i, tx := range sorted {
+			if p.index.isLocal(tx.Hash) {
+				return sorted[:i]
+			}
+		}
+
+		return sorted
+	}
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account, _ := value.(*account)
+
+		if addr == except {
+			return true
+		}
+
+		account.promoted.lock(false)
+		defer account.promoted.unlock()
+
+		account.enqueued.lock(false)
+		defer account.enqueued.unlock()
+
+		evictableEnqueued := evictable(account.enqueued.queue)
+		if len(evictableEnqueued) != 0 {
+			enqueued = append(enqueued, &evictionCandidate{
+				addr: addr,
+				txs:  evictableEnqueued,
+			})
+		}
+
+		// the promoted txs have lower nonces than the local enqueued ones
+		if len(evictableEnqueued) != int(account.enqueued.length()) {
+			return true
+		}
+
+		if evictablePromoted := evictable(account.promoted.queue); len(evictablePromoted) != 0 {
+			promoted = append(promoted, &evictionCandidate{
+				addr: addr,
+				txs:  evictablePromoted,
+			})
+		}
+
+		return true
+	})
+
+	return
+}
+
+// evictTxs removes the given transactions from the queues of their accounts,
+// skipping the ones no longer in them. The next nonce of the accounts
+// is rolled back to their lowest evicted promoted transaction
+func (p *TxPool) evictTxs(enqueued, promoted []*types.Transaction) {
+	var evictedEnqueued, evictedPromoted []*types.Transaction
+
+	for _, tx := range enqueued {
+		account := p.accounts.get(tx.From)
+
+		account.enqueued.lock(true)
+
+		if account.enqueued.remove(tx) {
+			evictedEnqueued = append(evictedEnqueued, tx)
+		}
+
+		account.enqueued.unlock()
+	}
+
+	// the promoted transactions are in descending nonce order for each account
+	for _, tx := range promoted {
+		account := p.accounts.get(tx.From)
+
+		account.promoted.lock(true)
+
+		if account.promoted.remove(tx) {
+			evictedPromoted = append(evictedPromoted, tx)
+
+			if tx.Nonce < account.getNonce() {
+				account.setNonce(tx.Nonce)
+			}
+		}
+
+		account.promoted.unlock()
+	}
+
+	evicted := append(evictedEnqueued, evictedPromoted...)
+	if len(evicted) == 0 {
+		return
+	}
+
+	p.index.remove(evicted...)
+	p.gauge.decrease(slotsRequired(evicted...))
+
+	p.metrics.PendingTxs.Add(float64(-1 * len(evictedPromoted)))
+	p.metrics.EvictedTxs.With("queue", "enqueued").Add(float64(len(evictedEnqueued)))
+	p.metrics.EvictedTxs.With("queue", "promoted").Add(float64(len(evictedPromoted)))
+
+	p.eventManager.signalEvent(proto.EventType_DROPPED, toHash(evicted...)...)
+	p.logger.Debug("evicted txs",
+		"enqueued", len(evictedEnqueued),
+		"promoted", len(evictedPromoted),
+	)
+}
@@ -0,0 +1,362 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvict(t *testing.T) {
+	t.Parallel()
+
+	// returns a new tx of 1 slot with the given gas price
+	newPricedTx := func(addr types.Address, nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx
+	}
+
+	// adds the tx to the pool, and promotes it if it has the expected nonce
+	addTx := func(t *testing.T, pool *TxPool, origin txOrigin, tx *types.Transaction) {
+		t.Helper()
+
+		go func() {
+			assert.NoError(t, pool.addTx(origin, tx))
+		}()
+
+		req := <-pool.enqueueReqCh
+
+		if tx.Nonce != pool.accounts.get(tx.From).getNonce() {
+			pool.handleEnqueueRequest(req)
+
+			return
+		}
+
+		go pool.handleEnqueueRequest(req)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	setupPool := func(t *testing.T, maxSlots uint64) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPoolWithSlots(maxSlots)
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		return pool
+	}
+
+	assertTxExists := func(t *testing.T, pool *TxPool, tx *types.Transaction, shouldExist bool) {
+		t.Helper()
+
+		_, exists := pool.index.get(tx.Hash)
+		assert.Equal(t, shouldExist, exists)
+	}
+
+	t.Run(
+		"evict cheapest enqueued tx first",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 3)
+
+			enqueued1, enqueued2 := newPricedTx(addr1, 5, 1), newPricedTx(addr1, 6, 2)
+			promoted := newPricedTx(addr2, 0, 1)
+
+			addTx(t, pool, gossip, enqueued1)
+			addTx(t, pool, gossip, enqueued2)
+			addTx(t, pool, gossip, promoted)
+
+			assert.Equal(t, uint64(3), pool.gauge.read())
+
+			// the highest nonce of the account is evicted, although it's not the cheapest
+			tx := newPricedTx(addr3, 0, 10)
+			addTx(t, pool, gossip, tx)
+
+			assert.Equal(t, uint64(3), pool.gauge.read())
+			assertTxExists(t, pool, enqueued1, true)
+			assertTxExists(t, pool, enqueued2, false)
+			assertTxExists(t, pool, promoted, true)
+			assertTxExists(t, pool, tx, true)
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		},
+	)
+
+	t.Run(
+		"evict promoted tail",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 3)
+
+			promoted1, promoted2 := newPricedTx(addr1, 0, 1), newPricedTx(addr1, 1, 1)
+			expensive := newPricedTx(addr2, 0, 20)
+
+			addTx(t, pool, gossip, promoted1)
+			addTx(t, pool, gossip, promoted2)
+			addTx(t, pool, gossip, expensive)
+
+			assert.Equal(t, uint64(2), pool.accounts.get(addr1).getNonce())
+
+			tx := newPricedTx(addr3, 0, 10)
+			addTx(t, pool, gossip, tx)
+
+			assert.Equal(t, uint64(3), pool.gauge.read())
+			assertTxExists(t, pool, promoted1, true)
+			assertTxExists(t, pool, promoted2, false)
+			assertTxExists(t, pool, expensive, true)
+
+			// the evicted nonce can be sent again
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+		},
+	)
+
+	t.Run(
+		"keep promoted txs of accounts with enqueued txs left",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 3)
+
+			promoted := newPricedTx(addr1, 0, 1)
+			enqueued := newPricedTx(addr1, 5, 20)
+			expensive := newPricedTx(addr2, 0, 20)
+
+			addTx(t, pool, gossip, promoted)
+			addTx(t, pool, gossip, enqueued)
+			addTx(t, pool, gossip, expensive)
+
+			assert.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr3, 0, 10)), ErrTxPoolOverflow)
+
+			assert.Equal(t, uint64(3), pool.gauge.read())
+			assertTxExists(t, pool, promoted, true)
+			assertTxExists(t, pool, enqueued, true)
+		},
+	)
+
+	t.Run(
+		"protect local txs",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 2)
+
+			localTx, remoteTx := newPricedTx(addr1, 0, 1), newPricedTx(addr2, 0, 5)
+
+			addTx(t, pool, local, localTx)
+			addTx(t, pool, gossip, remoteTx)
+
+			assert.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr3, 0, 3)), ErrTxPoolOverflow)
+
+			assertTxExists(t, pool, localTx, true)
+			assertTxExists(t, pool, remoteTx, true)
+			assert.Equal(t, uint64(2), pool.gauge.read())
+		},
+	)
+
+	t.Run(
+		"evict remote txs of local accounts",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 3)
+
+			localTx := newPricedTx(addr1, 0, 1)
+			remoteTx, remoteEnqueued := newPricedTx(addr1, 1, 1), newPricedTx(addr1, 5, 1)
+
+			addTx(t, pool, local, localTx)
+			addTx(t, pool, gossip, remoteTx)
+			addTx(t, pool, gossip, remoteEnqueued)
+
+			// the remote txs are not protected by the local one of their account
+			addTx(t, pool, gossip, newPricedTx(addr2, 0, 5))
+			addTx(t, pool, gossip, newPricedTx(addr3, 0, 5))
+
+			assertTxExists(t, pool, localTx, true)
+			assertTxExists(t, pool, remoteTx, false)
+			assertTxExists(t, pool, remoteEnqueued, false)
+
+			// the local tx is no longer protected once it leaves the pool
+			pool.index.remove(localTx)
+			assert.False(t, pool.index.isLocal(localTx.Hash))
+		},
+	)
+
+	t.Run(
+		"protect remote txs below local ones",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 2)
+
+			remoteTx, localTx := newPricedTx(addr1, 0, 1), newPricedTx(addr1, 1, 1)
+
+			addTx(t, pool, gossip, remoteTx)
+			addTx(t, pool, local, localTx)
+
+			assert.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr2, 0, 5)), ErrTxPoolOverflow)
+
+			assertTxExists(t, pool, remoteTx, true)
+			assertTxExists(t, pool, localTx, true)
+		},
+	)
+
+	t.Run(
+		"reject tx not pricier than the evictable ones",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 2)
+
+			cheap, expensive := newPricedTx(addr1, 0, 1), newPricedTx(addr2, 0, 5)
+
+			addTx(t, pool, gossip, cheap)
+			addTx(t, pool, gossip, expensive)
+
+			// 2 slots are required, but only the cheap tx can be evicted
+			tx := newTx(addr3, 0, 2)
+			tx.GasPrice = big.NewInt(3)
+
+			assert.ErrorIs(t, pool.addTx(gossip, tx), ErrTxPoolOverflow)
+			assert.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr3, 0, 1)), ErrTxPoolOverflow)
+
+			assertTxExists(t, pool, cheap, true)
+			assertTxExists(t, pool, expensive, true)
+			assert.Equal(t, uint64(2), pool.gauge.read())
+		},
+	)
+
+	t.Run(
+		"rejected txs evict nothing",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 2)
+
+			cheap, expensive := newPricedTx(addr1, 0, 1), newPricedTx(addr2, 0, 10)
+
+			addTx(t, pool, gossip, cheap)
+			addTx(t, pool, gossip, expensive)
+
+			// the known tx is priced higher than the cheap one
+			assert.ErrorIs(t, pool.addTx(gossip, expensive), ErrAlreadyKnown)
+
+			// the replacement isn't priced higher than the replaced tx
+			assert.ErrorIs(t, pool.addTx(gossip, newPricedTx(addr2, 0, 10)), ErrReplacementUnderpriced)
+
+			assertTxExists(t, pool, cheap, true)
+			assertTxExists(t, pool, expensive, true)
+			assert.Equal(t, uint64(2), pool.gauge.read())
+		},
+	)
+
+	t.Run(
+		"replacements evict nothing",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t, 2)
+
+			cheap, expensive := newPricedTx(addr1, 0, 1), newPricedTx(addr2, 0, 10)
+
+			addTx(t, pool, gossip, cheap)
+			addTx(t, pool, gossip, expensive)
+
+			// the replacement takes the slot of the replaced tx
+			replacement := newPricedTx(addr2, 0, 20)
+			addTx(t, pool, gossip, replacement)
+
+			assertTxExists(t, pool, cheap, true)
+			assertTxExists(t, pool, expensive, false)
+			assertTxExists(t, pool, replacement, true)
+			assert.Equal(t, uint64(2), pool.gauge.read())
+		},
+	)
+}
+
+func TestPromoteHandler_MaxPromoted(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.accounts.maxPromotedLimit = 1
+
+	tx0, tx1 := newTx(addr1, 0, 1), newTx(addr1, 1, 1)
+
+	// enqueue the higher nonce first, so both are promoted at once
+	go func() {
+		assert.NoError(t, pool.addTx(local, tx1))
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	go func() {
+		assert.NoError(t, pool.addTx(local, tx0))
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	// the second tx is held back
+	account := pool.accounts.get(addr1)
+	assert.Equal(t, uint64(1), account.promoted.length())
+	assert.Equal(t, uint64(1), account.enqueued.length())
+	assert.Equal(t, uint64(1), account.getNonce())
+
+	// the first tx is written, the second one is promoted
+	go pool.resetAccounts(map[types.Address]uint64{addr1: 1})
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	assert.Equal(t, uint64(1), account.promoted.length())
+	assert.Equal(t, uint64(0), account.enqueued.length())
+	assert.Equal(t, uint64(2), account.getNonce())
+	assert.Equal(t, tx1, account.promoted.peek())
+}
+
+func TestPromoteHandler_MaxPromoted_Sealed(t *testing.T) {
+	t.Parallel()
+
+	pool, err := newTestPool()
+	assert.NoError(t, err)
+	pool.SetSigner(&mockSigner{})
+
+	pool.accounts.maxPromotedLimit = 1
+
+	tx0, tx1 := newTx(addr1, 0, 1), newTx(addr1, 1, 1)
+
+	// enqueue the higher nonce first, so both are promoted at once
+	go func() {
+		assert.NoError(t, pool.addTx(local, tx1))
+	}()
+	pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+	go func() {
+		assert.NoError(t, pool.addTx(local, tx0))
+	}()
+	go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	// the first tx is written in a block, the second one is held back
+	pool.Prepare(0)
+
+	tx := pool.Peek()
+	assert.Equal(t, tx0, tx)
+	pool.Pop(tx)
+
+	account := pool.accounts.get(addr1)
+	assert.Equal(t, uint64(0), account.promoted.length())
+	assert.Equal(t, uint64(1), account.enqueued.length())
+
+	// the block is sealed, the second tx is promoted although nothing is pruned
+	go pool.resetAccounts(map[types.Address]uint64{addr1: 1})
+	pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+	assert.Equal(t, uint64(1), account.promoted.length())
+	assert.Equal(t, uint64(0), account.enqueued.length())
+	assert.Equal(t, uint64(2), account.getNonce())
+	assert.Equal(t, tx1, account.promoted.peek())
+}
@@ -0,0 +1,187 @@
+package txpool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+var (
+	errNoActiveJournal = errors.New("no active journal")
+)
+
+// journal is the on-disk log of the local transactions, replayed into the pool on start.
+// The transactions are stored as their RLP encoding, prefixed by its length
+type journal struct {
+	sync.Mutex
+
+	path   string
+	writer *os.File
+	closed bool
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load reads the transactions of the journal, and passes them to the add function.
+// A truncated last entry, written partially before a crash, is ignored
+func (j *journal) load(add func(*types.Transaction) error) (loaded, dropped int, err error) {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+
+	for {
+		var size uint32
+		if err := binary.Read(reader, binary.BigEndian, &size); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return loaded, dropped, nil
+			}
+
+			return loaded, dropped, err
+		}
+
+		if size > txMaxSize {
+			return loaded, dropped, fmt.Errorf("journal entry of %d bytes exceeds the max tx size", size)
+		}
+
+		raw := make([]byte, size)
+		if _, err := io.ReadFull(reader, raw); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return loaded, dropped, nil
+			}
+
+			return loaded, dropped, err
+		}
+
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalRLP(raw); err != nil {
+			return loaded, dropped, fmt.Errorf("unable to decode journal entry, %w", err)
+		}
+
+		loaded++
+
+		// the txs included or replaced meanwhile are rejected by the pool
+		if err := add(tx); err != nil {
+			dropped++
+		}
+	}
+}
+
+// insert appends the transaction to the journal
+func (j *journal) insert(tx *types.Transaction) error {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.writer == nil {
+		return errNoActiveJournal
+	}
+
+	return writeJournalEntry(j.writer, tx)
+}
+
+// rotate rewrites the journal with the given transactions only,
+// and opens it to append the new ones
+func (j *journal) rotate(txs []*types.Transaction) error {
+	j.Lock()
+	defer j.Unlock()
+
+	if j.closed {
+		return errNoActiveJournal
+	}
+
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+
+		j.writer = nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0755); err != nil {
+		return err
+	}
+
+	// write the txs to a new file, replacing the journal once complete
+	replacement, err := os.OpenFile(j.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(replacement)
+
+	for _, tx := range txs {
+		if err := writeJournalEntry(writer, tx); err != nil {
+			replacement.Close()
+
+			return err
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		replacement.Close()
+
+		return err
+	}
+
+	if err := replacement.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(j.path+".new", j.path); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	j.writer = file
+
+	return nil
+}
+
+// close closes the journal, the transactions inserted afterwards are not written
+func (j *journal) close() error {
+	j.Lock()
+	defer j.Unlock()
+
+	j.closed = true
+
+	if j.writer == nil {
+		return nil
+	}
+
+	err := j.writer.Close()
+	j.writer = nil
+
+	return err
+}
+
+func writeJournalEntry(writer io.Writer, tx *types.Transaction) error {
+	raw := tx.MarshalRLP()
+
+	entry := make([]byte, 4, 4+len(raw))
+	binary.BigEndian.PutUint32(entry, uint32(len(raw)))
+
+	_, err := writer.Write(append(entry, raw...))
+
+	return err
+}
@@ -0,0 +1,331 @@
+package txpool
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Gabulhas/polygon-external-consensus/network"
+	"github.com/Gabulhas/polygon-external-consensus/txpool/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// loadJournal returns the hashes of the txs of the journal
+func loadJournal(t *testing.T, j *journal) []types.Hash {
+	t.Helper()
+
+	hashes := []types.Hash{}
+
+	_, _, err := j.load(func(tx *types.Transaction) error {
+		hashes = append(hashes, tx.ComputeHash().Hash)
+
+		return nil
+	})
+	assert.NoError(t, err)
+
+	return hashes
+}
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+
+	newJournalTx := func(nonce uint64) *types.Transaction {
+		return newTx(addr1, nonce, 1).ComputeHash()
+	}
+
+	t.Run(
+		"missing journal loads nothing",
+		func(t *testing.T) {
+			t.Parallel()
+
+			j := newJournal(filepath.Join(t.TempDir(), "transactions.rlp"))
+
+			assert.Empty(t, loadJournal(t, j))
+		},
+	)
+
+	t.Run(
+		"insert requires the journal to be rotated",
+		func(t *testing.T) {
+			t.Parallel()
+
+			j := newJournal(filepath.Join(t.TempDir(), "transactions.rlp"))
+
+			assert.ErrorIs(t, j.insert(newJournalTx(0)), errNoActiveJournal)
+		},
+	)
+
+	t.Run(
+		"load returns the rotated and inserted txs",
+		func(t *testing.T) {
+			t.Parallel()
+
+			tx0, tx1, tx2 := newJournalTx(0), newJournalTx(1), newJournalTx(2)
+
+			j := newJournal(filepath.Join(t.TempDir(), "transactions.rlp"))
+
+			assert.NoError(t, j.rotate([]*types.Transaction{tx0, tx1}))
+			assert.NoError(t, j.insert(tx2))
+			assert.NoError(t, j.close())
+
+			assert.Equal(t, []types.Hash{tx0.Hash, tx1.Hash, tx2.Hash}, loadJournal(t, j))
+
+			// closed journal is not written anymore
+			assert.ErrorIs(t, j.insert(tx2), errNoActiveJournal)
+			assert.ErrorIs(t, j.rotate(nil), errNoActiveJournal)
+		},
+	)
+
+	t.Run(
+		"rotate drops the txs no longer given",
+		func(t *testing.T) {
+			t.Parallel()
+
+			tx0, tx1 := newJournalTx(0), newJournalTx(1)
+
+			j := newJournal(filepath.Join(t.TempDir(), "transactions.rlp"))
+
+			assert.NoError(t, j.rotate([]*types.Transaction{tx0}))
+			assert.NoError(t, j.insert(tx1))
+			assert.NoError(t, j.rotate([]*types.Transaction{tx1}))
+			assert.NoError(t, j.close())
+
+			assert.Equal(t, []types.Hash{tx1.Hash}, loadJournal(t, j))
+		},
+	)
+
+	t.Run(
+		"truncated last entry is ignored",
+		func(t *testing.T) {
+			t.Parallel()
+
+			tx0, tx1 := newJournalTx(0), newJournalTx(1)
+
+			path := filepath.Join(t.TempDir(), "transactions.rlp")
+			j := newJournal(path)
+
+			assert.NoError(t, j.rotate([]*types.Transaction{tx0, tx1}))
+			assert.NoError(t, j.close())
+
+			info, err := os.Stat(path)
+			assert.NoError(t, err)
+			assert.NoError(t, os.Truncate(path, info.Size()-10))
+
+			assert.Equal(t, []types.Hash{tx0.Hash}, loadJournal(t, j))
+		},
+	)
+
+	t.Run(
+		"rejected txs are dropped",
+		func(t *testing.T) {
+			t.Parallel()
+
+			tx0, tx1 := newJournalTx(0), newJournalTx(1)
+
+			j := newJournal(filepath.Join(t.TempDir(), "transactions.rlp"))
+
+			assert.NoError(t, j.rotate([]*types.Transaction{tx0, tx1}))
+			assert.NoError(t, j.close())
+
+			loaded, dropped, err := j.load(func(tx *types.Transaction) error {
+				if tx.Nonce == 0 {
+					return ErrNonceTooLow
+				}
+
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 2, loaded)
+			assert.Equal(t, 1, dropped)
+		},
+	)
+}
+
+func TestJournal_Replay(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	newJournaledPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			nil,
+			nilMetrics,
+			&Config{
+				PriceLimit:          defaultPriceLimit,
+				MaxSlots:            defaultMaxSlots,
+				MaxAccountEnqueued:  defaultMaxAccountEnqueued,
+				DeploymentWhitelist: []types.Address{},
+				Journal:             path,
+				JournalRotate:       time.Hour,
+			},
+		)
+		assert.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+		pool.Start()
+
+		return pool
+	}
+
+	eoa := new(eoa).create(t)
+
+	newSignedTx := func(nonce uint64) *types.Transaction {
+		return eoa.signTx(&types.Transaction{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(1),
+			Gas:      validGasLimit,
+			Value:    big.NewInt(1),
+		}, signerEIP155)
+	}
+
+	tx0, tx1 := newSignedTx(0), newSignedTx(1)
+
+	pool := newJournaledPool(t)
+
+	assert.NoError(t, pool.AddTx(tx0))
+	assert.NoError(t, pool.AddTx(tx1))
+
+	// the private txs are not journaled
+	privateTx := newSignedTx(2)
+	assert.NoError(t, pool.AddPrivateTx(privateTx))
+
+	// the gossiped txs of the local accounts are not journaled
+	remoteTx := newSignedTx(3)
+	assert.NoError(t, pool.addTx(gossip, remoteTx))
+
+	// the gossiped txs are not journaled
+	gossipTx := newTx(addr1, 0, 1)
+	gossipTx.ComputeHash()
+
+	pool.SetSigner(&mockSigner{})
+	assert.NoError(t, pool.addTx(gossip, gossipTx))
+
+	pool.Close()
+
+	// the local txs are replayed into the new pool
+	pool = newJournaledPool(t)
+	defer pool.Close()
+
+	assert.Eventually(t, func() bool {
+		return pool.accounts.exists(eoa.Address) && pool.accounts.get(eoa.Address).getNonce() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, exists := pool.index.get(tx0.Hash)
+	assert.True(t, exists)
+
+	_, exists = pool.index.get(tx1.Hash)
+	assert.True(t, exists)
+
+	_, exists = pool.index.get(gossipTx.Hash)
+	assert.False(t, exists)
+
+	_, exists = pool.index.get(privateTx.Hash)
+	assert.False(t, exists)
+
+	_, exists = pool.index.get(remoteTx.Hash)
+	assert.False(t, exists)
+
+	// the journal is rotated on start
+	assert.ElementsMatch(t, []types.Hash{tx0.Hash, tx1.Hash}, loadJournal(t, newJournal(path)))
+}
+
+func TestJournal_ReplayGossip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+
+	newJournaledPool := func(t *testing.T, server *network.Server) *TxPool {
+		t.Helper()
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: mockHeader},
+			nil,
+			server,
+			nilMetrics,
+			&Config{
+				PriceLimit:          defaultPriceLimit,
+				MaxSlots:            defaultMaxSlots,
+				MaxAccountEnqueued:  defaultMaxAccountEnqueued,
+				DeploymentWhitelist: []types.Address{},
+				Journal:             path,
+			},
+		)
+		assert.NoError(t, err)
+
+		pool.SetSigner(signerEIP155)
+		pool.Start()
+
+		return pool
+	}
+
+	eoa := new(eoa).create(t)
+
+	tx := eoa.signTx(&types.Transaction{
+		Nonce:    0,
+		GasPrice: big.NewInt(1),
+		Gas:      validGasLimit,
+		Value:    big.NewInt(1),
+	}, signerEIP155)
+
+	// the tx is journaled by an offline node
+	pool := newJournaledPool(t, nil)
+	assert.NoError(t, pool.AddTx(tx))
+	pool.Close()
+
+	servers := make([]*network.Server, 2)
+
+	for i := range servers {
+		server, err := network.CreateServer(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			_ = server.Close()
+		})
+
+		servers[i] = server
+	}
+
+	if errs := network.MeshJoin(servers...); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+
+	topic, err := servers[1].NewTopic(topicNameV1, &proto.Txn{})
+	assert.NoError(t, err)
+
+	receivedCh := make(chan types.Hash, 1)
+
+	assert.NoError(t, topic.Subscribe(func(obj interface{}, _ peer.ID) {
+		msg, _ := obj.(*proto.Txn)
+
+		received := new(types.Transaction)
+		if err := received.UnmarshalRLP(msg.Raw.Value); err == nil {
+			receivedCh <- received.Hash
+		}
+	}))
+
+	// the replayed tx is broadcast to the peers once restarted
+	pool = newJournaledPool(t, servers[0])
+	defer pool.Close()
+
+	select {
+	case hash := <-receivedCh:
+		assert.Equal(t, tx.Hash, hash)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the replayed tx was not broadcast")
+	}
+}
@@ -10,6 +10,10 @@ import (
 type lookupMap struct {
 	sync.RWMutex
 	all map[types.Hash]*types.Transaction
+
+	// locals are the hashes of the local transactions,
+	// which are protected from eviction and journaled
+	locals map[types.Hash]struct{}
 }
 
 // add inserts the given transaction into the map. Returns false
@@ -34,9 +38,31 @@ func (m *lookupMap) remove(txs ...*types.Transaction) {
 
 	for _, tx := range txs {
 		delete(m.all, tx.Hash)
+		delete(m.locals, tx.Hash)
+	}
+}
+
+// markLocal marks the transaction with the given hash as local,
+// until it is removed from the map. [thread-safe]
+func (m *lookupMap) markLocal(hash types.Hash) {
+	m.Lock()
+	defer m.Unlock()
+
+	if _, exists := m.all[hash]; exists {
+		m.locals[hash] = struct{}{}
 	}
 }
 
+// isLocal checks if the transaction with the given hash is local. [thread-safe]
+func (m *lookupMap) isLocal(hash types.Hash) bool {
+	m.RLock()
+	defer m.RUnlock()
+
+	_, ok := m.locals[hash]
+
+	return ok
+}
+
 // get returns the transaction associated with the given hash. [thread-safe]
 func (m *lookupMap) get(hash types.Hash) (*types.Transaction, bool) {
 	m.RLock()
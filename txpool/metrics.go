@@ -11,6 +11,9 @@ import (
 type Metrics struct {
 	// Pending transactions
 	PendingTxs metrics.Gauge
+
+	// Transactions evicted from the full pool, labeled by queue
+	EvictedTxs metrics.Counter
 }
 
 // GetPrometheusMetrics return the txpool metrics instance
@@ -28,6 +31,12 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 			Name:      "pending_transactions",
 			Help:      "Pending transactions in the pool",
 		}, labels).With(labelsWithValues...),
+		EvictedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "txpool",
+			Name:      "evicted_transactions",
+			Help:      "Transactions evicted from the full pool",
+		}, append(labels, "queue")).With(labelsWithValues...),
 	}
 }
 
@@ -35,5 +44,6 @@ func GetPrometheusMetrics(namespace string, labelsWithValues ...string) *Metrics
 func NilMetrics() *Metrics {
 	return &Metrics{
 		PendingTxs: discard.NewGauge(),
+		EvictedTxs: discard.NewCounter(),
 	}
 }
@@ -38,7 +38,12 @@ func (p *TxPool) AddTxn(ctx context.Context, raw *proto.AddTxnReq) (*proto.AddTx
 		txn.From = from
 	}
 
-	if err := p.AddTx(txn); err != nil {
+	addTx := p.AddTx
+	if raw.Private {
+		addTx = p.AddPrivateTx
+	}
+
+	if err := addTx(txn); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,83 @@
+package txpool
+
+import (
+	"sort"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+// PendingTxs is a snapshot of the promoted transactions of the pool, iterated
+// by the tip they pay, and in nonce order for each account. Iterating the snapshot
+// doesn't modify the pool, the transactions are only removed once included in a block
+type PendingTxs struct {
+	byAccount map[types.Address][]*types.Transaction
+	heads     *pricedQueue
+}
+
+// Pending returns a snapshot of the promoted transactions of the pool,
+// sorted by the tip they pay on top of the given base fee. (EIP-1559)
+// The private transactions are included, as they are meant to be built in a block
+func (p *TxPool) Pending(baseFee uint64) *PendingTxs {
+	pending := &PendingTxs{
+		byAccount: make(map[types.Address][]*types.Transaction),
+		heads:     newPricedQueue(),
+	}
+
+	pending.heads.queue.baseFee = baseFee
+
+	p.accounts.Range(func(key, value interface{}) bool {
+		addr, _ := key.(types.Address)
+		account := p.accounts.get(addr)
+
+		account.promoted.lock(false)
+		defer account.promoted.unlock()
+
+		if account.promoted.length() == 0 {
+			return true
+		}
+
+		// copy the underlying heap, which keeps changing in the pool
+		txs := make([]*types.Transaction, len(account.promoted.queue))
+		copy(txs, account.promoted.queue)
+
+		sort.Slice(txs, func(i, j int) bool {
+			return txs[i].Nonce < txs[j].Nonce
+		})
+
+		pending.byAccount[addr] = txs[1:]
+		pending.heads.push(txs[0])
+
+		return true
+	})
+
+	return pending
+}
+
+// Peek returns the best-price transaction, or nil if there are none left
+func (s *PendingTxs) Peek() *types.Transaction {
+	return s.heads.queue.Peek()
+}
+
+// Shift replaces the peeked transaction with the following one of its account, if any
+func (s *PendingTxs) Shift() {
+	head := s.heads.pop()
+	if head == nil {
+		return
+	}
+
+	if txs := s.byAccount[head.From]; len(txs) != 0 {
+		s.byAccount[head.From] = txs[1:]
+		s.heads.push(txs[0])
+	}
+}
+
+// Skip removes the peeked transaction along with the following ones of its account,
+// which can't be executed without it
+func (s *PendingTxs) Skip() {
+	head := s.heads.pop()
+	if head == nil {
+		return
+	}
+
+	delete(s.byAccount, head.From)
+}
@@ -0,0 +1,99 @@
+package txpool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPending(t *testing.T) {
+	t.Parallel()
+
+	// returns a new tx of 1 slot with the given gas price
+	newPricedTx := func(addr types.Address, nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx.ComputeHash()
+	}
+
+	setupPool := func(t *testing.T, txs ...*types.Transaction) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		for _, tx := range txs {
+			pool.createAccountOnce(tx.From)
+			pool.accounts.get(tx.From).promoted.push(tx)
+		}
+
+		return pool
+	}
+
+	// peeks the txs of the snapshot, shifting or skipping each one
+	iterate := func(pending *PendingTxs, skip func(*types.Transaction) bool) (peeked []*types.Transaction) {
+		for tx := pending.Peek(); tx != nil; tx = pending.Peek() {
+			peeked = append(peeked, tx)
+
+			if skip(tx) {
+				pending.Skip()
+			} else {
+				pending.Shift()
+			}
+		}
+
+		return
+	}
+
+	t.Run("txs are sorted by price and nonce", func(t *testing.T) {
+		t.Parallel()
+
+		cheap0, cheap1 := newPricedTx(addr1, 0, 1), newPricedTx(addr1, 1, 20)
+		expensive0, expensive1 := newPricedTx(addr2, 0, 10), newPricedTx(addr2, 1, 5)
+
+		pool := setupPool(t, cheap1, cheap0, expensive1, expensive0)
+
+		peeked := iterate(pool.Pending(0), func(*types.Transaction) bool {
+			return false
+		})
+
+		assert.Equal(t, []*types.Transaction{expensive0, expensive1, cheap0, cheap1}, peeked)
+	})
+
+	t.Run("skipping a tx skips the following ones of its account", func(t *testing.T) {
+		t.Parallel()
+
+		skipped0, skipped1 := newPricedTx(addr1, 0, 10), newPricedTx(addr1, 1, 10)
+		other := newPricedTx(addr2, 0, 1)
+
+		pool := setupPool(t, skipped0, skipped1, other)
+
+		peeked := iterate(pool.Pending(0), func(tx *types.Transaction) bool {
+			return tx == skipped0
+		})
+
+		assert.Equal(t, []*types.Transaction{skipped0, other}, peeked)
+	})
+
+	t.Run("the pool is left untouched", func(t *testing.T) {
+		t.Parallel()
+
+		txs := []*types.Transaction{newPricedTx(addr1, 0, 1), newPricedTx(addr1, 1, 1)}
+
+		pool := setupPool(t, txs...)
+
+		for i := 0; i < 2; i++ {
+			peeked := iterate(pool.Pending(0), func(*types.Transaction) bool {
+				return false
+			})
+
+			assert.Equal(t, txs, peeked)
+		}
+
+		assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+	})
+}
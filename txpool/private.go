@@ -0,0 +1,181 @@
+package txpool
+
+import (
+	"math"
+	"sync"
+
+	"github.com/Gabulhas/polygon-external-consensus/txpool/proto"
+	"github.com/Gabulhas/polygon-external-consensus/types"
+)
+
+// privateTxs keeps track of the private transactions in the pool,
+// with the block number they expire at if not included until then
+type privateTxs struct {
+	sync.Mutex
+
+	expiries map[types.Hash]uint64
+}
+
+func newPrivateTxs() *privateTxs {
+	return &privateTxs{expiries: make(map[types.Hash]uint64)}
+}
+
+// add tracks the private transaction until the given block number
+func (m *privateTxs) add(hash types.Hash, expiry uint64) {
+	m.Lock()
+	defer m.Unlock()
+
+	m.expiries[hash] = expiry
+}
+
+// contains checks if the transaction is a tracked private one
+func (m *privateTxs) contains(hash types.Hash) bool {
+	m.Lock()
+	defer m.Unlock()
+
+	_, ok := m.expiries[hash]
+
+	return ok
+}
+
+// remove stops tracking the given transactions, once included
+func (m *privateTxs) remove(txs ...*types.Transaction) {
+	m.Lock()
+	defer m.Unlock()
+
+	for _, tx := range txs {
+		delete(m.expiries, tx.Hash)
+	}
+}
+
+// expired stops tracking and returns the transactions expired at the given block number
+func (m *privateTxs) expired(number uint64) (hashes []types.Hash) {
+	m.Lock()
+	defer m.Unlock()
+
+	for hash, expiry := range m.expiries {
+		if expiry <= number {
+			hashes = append(hashes, hash)
+			delete(m.expiries, hash)
+		}
+	}
+
+	return
+}
+
+// AddPrivateTx adds a new transaction to the pool (sent from json-RPC/gRPC endpoints)
+// without broadcasting it to the network, so that it stays on this node until included.
+// It is dropped if it's not included within the private tx lifetime.
+func (p *TxPool) AddPrivateTx(tx *types.Transaction) error {
+	if err := p.addTx(private, tx); err != nil {
+		p.logger.Error("failed to add private tx", "err", err)
+
+		return err
+	}
+
+	return nil
+}
+
+// privateTxExpiry returns the block number the private transactions added now expire at
+func (p *TxPool) privateTxExpiry() uint64 {
+	if p.privateTxLifetime == 0 {
+		return math.MaxUint64
+	}
+
+	return p.store.Header().Number + p.privateTxLifetime
+}
+
+// IsPrivateTx checks if the transaction with the given hash is a private one of the pool,
+// which is not exposed as a pending transaction
+func (p *TxPool) IsPrivateTx(txHash types.Hash) bool {
+	return p.private.contains(txHash)
+}
+
+// dropExpiredPrivateTxs drops the private transactions
+// not included until the given block number
+func (p *TxPool) dropExpiredPrivateTxs(number uint64) {
+	expired := make(map[types.Address][]*types.Transaction)
+
+	for _, hash := range p.private.expired(number) {
+		// skip the txs already removed from the pool
+		if tx, ok := p.index.get(hash); ok {
+			expired[tx.From] = append(expired[tx.From], tx)
+		}
+	}
+
+	for addr, txs := range expired {
+		account := p.accounts.get(addr)
+		if account == nil {
+			continue
+		}
+
+		dropped, droppedPromoted, demoted := account.dropTxs(txs)
+		if len(dropped) == 0 {
+			continue
+		}
+
+		p.index.remove(dropped...)
+		p.gauge.decrease(slotsRequired(dropped...))
+		p.metrics.PendingTxs.Add(float64(-1 * (droppedPromoted + len(demoted))))
+
+		p.eventManager.signalEvent(proto.EventType_DROPPED, toHash(dropped...)...)
+		p.eventManager.signalEvent(proto.EventType_DEMOTED, toHash(demoted...)...)
+
+		p.logger.Debug("dropped expired private txs",
+			"account", addr.String(),
+			"dropped", len(dropped),
+			"demoted", len(demoted),
+		)
+	}
+}
+
+// dropTxs removes the given transactions from the queues of the account.
+// The promoted transactions following the lowest removed one are no longer
+// executable, so they are demoted to the enqueued ones
+func (a *account) dropTxs(txs []*types.Transaction) (
+	dropped []*types.Transaction,
+	droppedPromoted int,
+	demoted []*types.Transaction,
+) {
+	a.promoted.lock(true)
+	defer a.promoted.unlock()
+
+	a.enqueued.lock(true)
+	defer a.enqueued.unlock()
+
+	lowestNonce := uint64(math.MaxUint64)
+
+	for _, tx := range txs {
+		if a.enqueued.remove(tx) {
+			dropped = append(dropped, tx)
+
+			continue
+		}
+
+		if a.promoted.remove(tx) {
+			dropped = append(dropped, tx)
+			droppedPromoted++
+
+			if tx.Nonce < lowestNonce {
+				lowestNonce = tx.Nonce
+			}
+		}
+	}
+
+	if droppedPromoted == 0 {
+		return
+	}
+
+	for _, tx := range append([]*types.Transaction(nil), a.promoted.queue...) {
+		if tx.Nonce > lowestNonce {
+			a.promoted.remove(tx)
+			a.enqueued.push(tx)
+
+			demoted = append(demoted, tx)
+		}
+	}
+
+	a.setNonce(lowestNonce)
+
+	return
+}
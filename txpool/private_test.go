@@ -0,0 +1,180 @@
+package txpool
+
+import (
+	"testing"
+
+	"github.com/Gabulhas/polygon-external-consensus/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivateTxs_Expiry(t *testing.T) {
+	t.Parallel()
+
+	// adds the tx to the pool, and promotes it if it has the expected nonce
+	addTx := func(t *testing.T, pool *TxPool, add func(*types.Transaction) error, tx *types.Transaction) {
+		t.Helper()
+
+		go func() {
+			assert.NoError(t, add(tx))
+		}()
+
+		req := <-pool.enqueueReqCh
+
+		if tx.Nonce != pool.accounts.get(tx.From).getNonce() {
+			pool.handleEnqueueRequest(req)
+
+			return
+		}
+
+		go pool.handleEnqueueRequest(req)
+		pool.handlePromoteRequest(<-pool.promoteReqCh)
+	}
+
+	// returns a new pool whose head block number can be set
+	setupPool := func(t *testing.T, lifetime uint64) (*TxPool, *types.Header) {
+		t.Helper()
+
+		header := mockHeader.Copy()
+		header.Number = 10
+
+		pool, err := NewTxPool(
+			hclog.NewNullLogger(),
+			forks.At(0),
+			defaultMockStore{DefaultHeader: header},
+			nil,
+			nil,
+			nilMetrics,
+			&Config{
+				PriceLimit:          defaultPriceLimit,
+				MaxSlots:            defaultMaxSlots,
+				MaxAccountEnqueued:  defaultMaxAccountEnqueued,
+				DeploymentWhitelist: []types.Address{},
+				PrivateTxLifetime:   lifetime,
+			},
+		)
+		assert.NoError(t, err)
+
+		pool.SetSigner(&mockSigner{})
+
+		return pool, header
+	}
+
+	assertTxExists := func(t *testing.T, pool *TxPool, tx *types.Transaction, shouldExist bool) {
+		t.Helper()
+
+		_, exists := pool.index.get(tx.Hash)
+		assert.Equal(t, shouldExist, exists)
+	}
+
+	t.Run(
+		"expired private tx is dropped and the following ones demoted",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, header := setupPool(t, 2)
+
+			privateTx, publicTx := newTx(addr1, 0, 1), newTx(addr1, 1, 1)
+
+			addTx(t, pool, pool.AddPrivateTx, privateTx)
+			addTx(t, pool, pool.AddTx, publicTx)
+
+			assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+
+			// not expired yet
+			header.Number = 11
+			pool.ResetWithHeaders()
+
+			assertTxExists(t, pool, privateTx, true)
+			assert.Equal(t, uint64(2), pool.accounts.get(addr1).promoted.length())
+
+			header.Number = 12
+			pool.ResetWithHeaders()
+
+			assertTxExists(t, pool, privateTx, false)
+			assertTxExists(t, pool, publicTx, true)
+
+			account := pool.accounts.get(addr1)
+			assert.Equal(t, uint64(0), account.getNonce())
+			assert.Equal(t, uint64(0), account.promoted.length())
+			assert.Equal(t, uint64(1), account.enqueued.length())
+			assert.Equal(t, slotsRequired(publicTx), pool.gauge.read())
+		},
+	)
+
+	t.Run(
+		"expired enqueued private tx is dropped",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, header := setupPool(t, 2)
+
+			promotedTx, privateTx := newTx(addr1, 0, 1), newTx(addr1, 5, 1)
+
+			addTx(t, pool, pool.AddTx, promotedTx)
+			addTx(t, pool, pool.AddPrivateTx, privateTx)
+
+			header.Number = 12
+			pool.ResetWithHeaders()
+
+			assertTxExists(t, pool, privateTx, false)
+			assertTxExists(t, pool, promotedTx, true)
+
+			account := pool.accounts.get(addr1)
+			assert.Equal(t, uint64(1), account.getNonce())
+			assert.Equal(t, uint64(1), account.promoted.length())
+			assert.Equal(t, uint64(0), account.enqueued.length())
+		},
+	)
+
+	t.Run(
+		"private tx is kept until included without lifetime",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, header := setupPool(t, 0)
+
+			privateTx := newTx(addr1, 0, 1)
+
+			addTx(t, pool, pool.AddPrivateTx, privateTx)
+
+			header.Number = 1000
+			pool.ResetWithHeaders()
+
+			assertTxExists(t, pool, privateTx, true)
+			assert.True(t, pool.private.contains(privateTx.Hash))
+
+			// the included txs are no longer tracked
+			pool.private.remove(privateTx)
+			assert.False(t, pool.private.contains(privateTx.Hash))
+		},
+	)
+
+	t.Run(
+		"private txs are not exposed as pending txs",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool, _ := setupPool(t, 0)
+
+			privateTx, publicTx, enqueuedTx := newTx(addr1, 0, 1), newTx(addr2, 0, 1), newTx(addr1, 5, 1)
+
+			addTx(t, pool, pool.AddPrivateTx, privateTx)
+			addTx(t, pool, pool.AddTx, publicTx)
+			addTx(t, pool, pool.AddPrivateTx, enqueuedTx)
+
+			assert.True(t, pool.IsPrivateTx(privateTx.Hash))
+			assert.False(t, pool.IsPrivateTx(publicTx.Hash))
+
+			_, found := pool.GetPendingTx(privateTx.Hash)
+			assert.False(t, found)
+
+			_, found = pool.GetPendingTx(publicTx.Hash)
+			assert.True(t, found)
+
+			promoted, enqueued := pool.GetTxs(true)
+			assert.Equal(t, map[types.Address][]*types.Transaction{addr2: {publicTx}}, promoted)
+			assert.Empty(t, enqueued)
+		},
+	)
+}
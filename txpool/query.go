@@ -29,7 +29,13 @@ func (p *TxPool) GetCapacity() (uint64, uint64) {
 }
 
 // GetPendingTx returns the transaction by hash in the TxPool (pending txn) [Thread-safe]
+//
+// -> The private transactions are not returned, as they are not exposed outside of the node
 func (p *TxPool) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
+	if p.private.contains(txHash) {
+		return nil, false
+	}
+
 	tx, ok := p.index.get(txHash)
 	if !ok {
 		return nil, false
@@ -38,11 +44,33 @@ func (p *TxPool) GetPendingTx(txHash types.Hash) (*types.Transaction, bool) {
 	return tx, true
 }
 
-// GetTxs gets pending and queued transactions
+// GetTxs gets pending and queued transactions, except the private ones
 func (p *TxPool) GetTxs(inclQueued bool) (
 	allPromoted, allEnqueued map[types.Address][]*types.Transaction,
 ) {
 	allPromoted, allEnqueued = p.accounts.allTxs(inclQueued)
 
+	p.excludePrivateTxs(allPromoted)
+	p.excludePrivateTxs(allEnqueued)
+
 	return
 }
+
+// excludePrivateTxs removes the private transactions from the given ones of each account
+func (p *TxPool) excludePrivateTxs(all map[types.Address][]*types.Transaction) {
+	for addr, txs := range all {
+		public := make([]*types.Transaction, 0, len(txs))
+
+		for _, tx := range txs {
+			if !p.private.contains(tx.Hash) {
+				public = append(public, tx)
+			}
+		}
+
+		if len(public) == 0 {
+			delete(all, addr)
+		} else {
+			all[addr] = public
+		}
+	}
+}
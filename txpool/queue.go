@@ -72,6 +72,46 @@ func (q *accountQueue) clear() (removed []*types.Transaction) {
 	return
 }
 
+// get returns the transaction of the queue with the given nonce, or nil.
+func (q *accountQueue) get(nonce uint64) *types.Transaction {
+	for _, tx := range q.queue {
+		if tx.Nonce == nonce {
+			return tx
+		}
+	}
+
+	return nil
+}
+
+// replace swaps the transaction of the queue with the same nonce
+// for the given one, and returns it, or nil if there is none.
+func (q *accountQueue) replace(tx *types.Transaction) *types.Transaction {
+	for i, old := range q.queue {
+		if old.Nonce == tx.Nonce {
+			q.queue[i] = tx
+			heap.Fix(&q.queue, i)
+
+			return old
+		}
+	}
+
+	return nil
+}
+
+// remove removes the given transaction from the queue,
+// returning false if it is not in the queue.
+func (q *accountQueue) remove(tx *types.Transaction) bool {
+	for i, queued := range q.queue {
+		if queued.Hash == tx.Hash {
+			heap.Remove(&q.queue, i)
+
+			return true
+		}
+	}
+
+	return false
+}
+
 // push pushes the given transactions onto the queue.
 func (q *accountQueue) push(tx *types.Transaction) {
 	heap.Push(&q.queue, tx)
@@ -129,7 +169,7 @@ func (q *minNonceQueue) Swap(i, j int) {
 func (q *minNonceQueue) Less(i, j int) bool {
 	// The higher gas price Tx comes first if the nonces are same
 	if (*q)[i].Nonce == (*q)[j].Nonce {
-		return (*q)[i].GasPrice.Cmp((*q)[j].GasPrice) > 0
+		return (*q)[i].GetGasFeeCap().Cmp((*q)[j].GetGasFeeCap()) > 0
 	}
 
 	return (*q)[i].Nonce < (*q)[j].Nonce
@@ -159,7 +199,9 @@ type pricedQueue struct {
 
 func newPricedQueue() *pricedQueue {
 	q := pricedQueue{
-		queue: make(maxPriceQueue, 0),
+		queue: maxPriceQueue{
+			txs: make([]*types.Transaction, 0),
+		},
 	}
 
 	heap.Init(&q.queue)
@@ -169,7 +211,7 @@ func newPricedQueue() *pricedQueue {
 
 // clear empties the underlying queue.
 func (q *pricedQueue) clear() {
-	q.queue = q.queue[:0]
+	q.queue.txs = q.queue.txs[:0]
 }
 
 // Pushes the given transactions onto the queue.
@@ -197,8 +239,11 @@ func (q *pricedQueue) length() uint64 {
 	return uint64(q.queue.Len())
 }
 
-// transactions sorted by gas price (descending)
-type maxPriceQueue []*types.Transaction
+// transactions sorted by the tip paid on top of the base fee (descending)
+type maxPriceQueue struct {
+	baseFee uint64
+	txs     []*types.Transaction
+}
 
 /* Queue methods required by the heap interface */
 
@@ -207,19 +252,19 @@ func (q *maxPriceQueue) Peek() *types.Transaction {
 		return nil
 	}
 
-	return (*q)[0]
+	return q.txs[0]
 }
 
 func (q *maxPriceQueue) Len() int {
-	return len(*q)
+	return len(q.txs)
 }
 
 func (q *maxPriceQueue) Swap(i, j int) {
-	(*q)[i], (*q)[j] = (*q)[j], (*q)[i]
+	q.txs[i], q.txs[j] = q.txs[j], q.txs[i]
 }
 
 func (q *maxPriceQueue) Less(i, j int) bool {
-	return (*q)[i].GasPrice.Uint64() > (*q)[j].GasPrice.Uint64()
+	return q.txs[i].EffectiveTip(q.baseFee).Cmp(q.txs[j].EffectiveTip(q.baseFee)) > 0
 }
 
 func (q *maxPriceQueue) Push(x interface{}) {
@@ -228,14 +273,13 @@ func (q *maxPriceQueue) Push(x interface{}) {
 		return
 	}
 
-	*q = append(*q, transaction)
+	q.txs = append(q.txs, transaction)
 }
 
 func (q *maxPriceQueue) Pop() interface{} {
-	old := q
-	n := len(*old)
-	x := (*old)[n-1]
-	*q = (*old)[0 : n-1]
+	n := len(q.txs)
+	x := q.txs[n-1]
+	q.txs = q.txs[0 : n-1]
 
 	return x
 }
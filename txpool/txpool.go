@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/ptypes/any"
@@ -16,6 +17,7 @@ import (
 	"github.com/Gabulhas/polygon-external-consensus/chain"
 	"github.com/Gabulhas/polygon-external-consensus/network"
 	"github.com/Gabulhas/polygon-external-consensus/state"
+	"github.com/Gabulhas/polygon-external-consensus/state/runtime"
 	"github.com/Gabulhas/polygon-external-consensus/txpool/proto"
 	"github.com/Gabulhas/polygon-external-consensus/types"
 )
@@ -33,6 +35,9 @@ const (
 	maxAccountSkips = uint64(10)
 
 	pruningCooldown = 5000 * time.Millisecond
+
+	// interval of the checks for peers to broadcast the replayed journal to
+	journalPublishInterval = time.Second
 )
 
 // errors
@@ -52,15 +57,20 @@ var (
 	ErrMaxEnqueuedLimitReached = errors.New("maximum number of enqueued transactions reached")
 	ErrRejectFutureTx          = errors.New("rejected future tx due to low slots")
 	ErrSmartContractRestricted = errors.New("smart contract deployment restricted")
+	ErrTxTypeNotSupported      = errors.New("transaction type not supported")
+	ErrTipAboveFeeCap          = errors.New("max priority fee per gas higher than max fee per gas")
+	ErrInitCodeTooLarge        = errors.New("max initcode size exceeded")
+	ErrReplacementUnderpriced  = errors.New("replacement transaction underpriced")
 )
 
 // indicates origin of a transaction
 type txOrigin int
 
 const (
-	local  txOrigin = iota // json-RPC/gRPC endpoints
-	gossip                 // gossip protocol
-	reorg                  // legacy code
+	local   txOrigin = iota // json-RPC/gRPC endpoints
+	gossip                  // gossip protocol
+	reorg                   // legacy code
+	private                 // json-RPC/gRPC endpoints, not gossiped
 )
 
 func (o txOrigin) String() (s string) {
@@ -71,6 +81,8 @@ func (o txOrigin) String() (s string) {
 		s = "gossip"
 	case reorg:
 		s = "reorg"
+	case private:
+		s = "private"
 	}
 
 	return
@@ -92,7 +104,20 @@ type Config struct {
 	PriceLimit          uint64
 	MaxSlots            uint64
 	MaxAccountEnqueued  uint64
+	MaxAccountPromoted  uint64
+	PriceBump           uint64
 	DeploymentWhitelist []types.Address
+
+	// Journal is the path of the journal of the local transactions, disabled if empty
+	Journal string
+
+	// JournalRotate is the interval of the rewrites of the journal
+	// dropping the transactions no longer in the pool
+	JournalRotate time.Duration
+
+	// PrivateTxLifetime is the number of blocks the private transactions
+	// are kept in the pool, until included. They are kept until included if 0
+	PrivateTxLifetime uint64
 }
 
 /* All requests are passed to the main loop
@@ -141,7 +166,7 @@ type TxPool struct {
 	// map of all accounts registered by the pool
 	accounts accountsMap
 
-	// all the primaries sorted by max gas price
+	// all the primaries sorted by max effective tip
 	executables *pricedQueue
 
 	// lookup map keeping track of all
@@ -154,9 +179,16 @@ type TxPool struct {
 	// gauge for measuring pool capacity
 	gauge slotGauge
 
+	// evictionLock serializes the evictions of the full pool
+	evictionLock sync.Mutex
+
 	// priceLimit is a lower threshold for gas price
 	priceLimit uint64
 
+	// priceBump is the minimum percentage a transaction
+	// must be priced above the one with the same nonce to replace it
+	priceBump uint64
+
 	// channels on which the pool's event loop
 	// does dispatching/handling requests.
 	enqueueReqCh chan enqueueRequest
@@ -166,6 +198,18 @@ type TxPool struct {
 	// shutdown channel
 	shutdownCh chan struct{}
 
+	// bundles of transactions, kept apart from the queues
+	bundles bundleMap
+
+	// private transactions, not gossiped, with their expiry
+	private           *privateTxs
+	privateTxLifetime uint64
+
+	// journal of the local transactions, nil if disabled
+	journal           *journal
+	journalRotate     time.Duration
+	journalShutdownCh chan struct{}
+
 	// flag indicating if the current node is a sealer,
 	// and should therefore gossip transactions
 	sealing atomic.Bool
@@ -234,10 +278,20 @@ func NewTxPool(
 		store:       store,
 		metrics:     metrics,
 		executables: newPricedQueue(),
-		accounts:    accountsMap{maxEnqueuedLimit: config.MaxAccountEnqueued},
-		index:       lookupMap{all: make(map[types.Hash]*types.Transaction)},
-		gauge:       slotGauge{height: 0, max: config.MaxSlots},
-		priceLimit:  config.PriceLimit,
+		accounts: accountsMap{
+			maxEnqueuedLimit: config.MaxAccountEnqueued,
+			maxPromotedLimit: config.MaxAccountPromoted,
+		},
+		index: lookupMap{
+			all:    make(map[types.Hash]*types.Transaction),
+			locals: make(map[types.Hash]struct{}),
+		},
+		gauge:      slotGauge{height: 0, max: config.MaxSlots},
+		priceLimit: config.PriceLimit,
+		priceBump:  config.PriceBump,
+
+		private:           newPrivateTxs(),
+		privateTxLifetime: config.PrivateTxLifetime,
 
 		//	main loop channels
 		enqueueReqCh: make(chan enqueueRequest),
@@ -249,6 +303,12 @@ func NewTxPool(
 	// Attach the event manager
 	pool.eventManager = newEventManager(pool.logger)
 
+	if config.Journal != "" {
+		pool.journal = newJournal(config.Journal)
+		pool.journalRotate = config.JournalRotate
+		pool.journalShutdownCh = make(chan struct{})
+	}
+
 	if network != nil {
 		// subscribe to the gossip protocol
 		topic, err := network.NewTopic(topicNameV1, &proto.Txn{})
@@ -309,12 +369,120 @@ func (p *TxPool) Start() {
 			}
 		}
 	}()
+
+	if p.journal != nil {
+		p.startJournal()
+	}
+}
+
+// startJournal replays the journal of the local transactions into the pool,
+// and runs the handler rotating it periodically
+func (p *TxPool) startJournal() {
+	var accepted []*types.Transaction
+
+	loaded, dropped, err := p.journal.load(func(tx *types.Transaction) error {
+		if err := p.addTx(local, tx); err != nil {
+			return err
+		}
+
+		accepted = append(accepted, tx)
+
+		return nil
+	})
+	if err != nil {
+		p.logger.Error("failed to load the transaction journal", "err", err)
+	}
+
+	p.logger.Info("loaded the transaction journal", "loaded", loaded, "dropped", dropped)
+
+	if err := p.journal.rotate(accepted); err != nil {
+		p.logger.Error("failed to rotate the transaction journal", "err", err)
+	}
+
+	// the replayed txs are broadcast like the ones added through AddTx,
+	// they are never private as those are not journaled
+	if p.topic != nil && len(accepted) != 0 {
+		go p.publishOnceConnected(accepted)
+	}
+
+	if p.journalRotate <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(p.journalRotate)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-p.journalShutdownCh:
+				return
+			case <-ticker.C:
+				if err := p.journal.rotate(p.journaledTxs()); err != nil {
+					p.logger.Error("failed to rotate the transaction journal", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// publishOnceConnected broadcasts the transactions once there are peers subscribed to the topic,
+// as the pool is started along with the node, before connecting to the other nodes
+func (p *TxPool) publishOnceConnected(txs []*types.Transaction) {
+	ticker := time.NewTicker(journalPublishInterval)
+	defer ticker.Stop()
+
+	for len(p.topic.ListPeers()) == 0 {
+		select {
+		case <-p.journalShutdownCh:
+			return
+		case <-ticker.C:
+		}
+	}
+
+	for _, tx := range txs {
+		p.publish(tx)
+	}
+}
+
+// journaledTxs returns the local transactions to keep in the journal,
+// which are all of them except the private ones
+func (p *TxPool) journaledTxs() (txs []*types.Transaction) {
+	p.accounts.Range(func(key, value interface{}) bool {
+		account, _ := value.(*account)
+
+		account.promoted.lock(false)
+		defer account.promoted.unlock()
+
+		account.enqueued.lock(false)
+		defer account.enqueued.unlock()
+
+		for _, queue := range []*accountQueue{account.promoted, account.enqueued} {
+			for _, tx := range queue.queue {
+				if p.index.isLocal(tx.Hash) && !p.private.contains(tx.Hash) {
+					txs = append(txs, tx)
+				}
+			}
+		}
+
+		return true
+	})
+
+	return
 }
 
 // Close shuts down the pool's main loop.
 func (p *TxPool) Close() {
 	p.eventManager.Close()
 	p.shutdownCh <- struct{}{}
+
+	if p.journal != nil {
+		close(p.journalShutdownCh)
+
+		if err := p.journal.close(); err != nil {
+			p.logger.Error("failed to close the transaction journal", "err", err)
+		}
+	}
 }
 
 // SetSigner sets the signer the pool will use
@@ -342,31 +510,41 @@ func (p *TxPool) AddTx(tx *types.Transaction) error {
 		return err
 	}
 
-	// broadcast the transaction only if a topic
-	// subscription is present
-	if p.topic != nil {
-		tx := &proto.Txn{
-			Raw: &any.Any{
-				Value: tx.MarshalRLP(),
-			},
-		}
+	p.publish(tx)
 
-		if err := p.topic.Publish(tx); err != nil {
-			p.logger.Error("failed to topic tx", "err", err)
-		}
+	return nil
+}
+
+// publish broadcasts the transaction only if a topic
+// subscription is present
+func (p *TxPool) publish(tx *types.Transaction) {
+	if p.topic == nil {
+		return
 	}
 
-	return nil
+	msg := &proto.Txn{
+		Raw: &any.Any{
+			Value: tx.MarshalRLP(),
+		},
+	}
+
+	if err := p.topic.Publish(msg); err != nil {
+		p.logger.Error("failed to topic tx", "err", err)
+	}
 }
 
 // Prepare generates all the transactions
 // ready for execution. (primaries)
-func (p *TxPool) Prepare() {
+// The transactions are sorted by the tip they pay
+// on top of the base fee of the block. (EIP-1559)
+func (p *TxPool) Prepare(baseFee uint64) {
 	// clear from previous round
 	if p.executables.length() != 0 {
 		p.executables.clear()
 	}
 
+	p.executables.queue.baseFee = baseFee
+
 	// fetch primary from each account
 	primaries := p.accounts.getPrimaries()
 
@@ -399,14 +577,22 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 	account.promoted.lock(true)
 	defer account.promoted.unlock()
 
-	// pop the top most promoted tx
-	account.promoted.pop()
+	// pop the top most promoted tx, which is a replacement
+	// of the given one if it was replaced after being peeked
+	popped := account.promoted.pop()
+	if popped == nil {
+		return
+	}
+
+	if popped.Hash != tx.Hash {
+		p.index.remove(popped)
+	}
 
 	// successfully popping an account resets its demotions count to 0
 	account.resetDemotions()
 
 	// update state
-	p.gauge.decrease(slotsRequired(tx))
+	p.gauge.decrease(slotsRequired(popped))
 
 	// update metrics
 	p.metrics.PendingTxs.Add(-1)
@@ -422,6 +608,9 @@ func (p *TxPool) Pop(tx *types.Transaction) {
 func (p *TxPool) Drop(tx *types.Transaction) {
 	// fetch associated account
 	account := p.accounts.get(tx.From)
+	if account == nil {
+		return
+	}
 
 	account.promoted.lock(true)
 	account.enqueued.lock(true)
@@ -535,6 +724,7 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 
 		// remove mined txs from the lookup map
 		p.index.remove(block.Transactions...)
+		p.private.remove(block.Transactions...)
 
 		// Extract latest nonces
 		for _, tx := range block.Transactions {
@@ -579,6 +769,16 @@ func (p *TxPool) processEvent(event *blockchain.Event) {
 	// reset accounts with the new state
 	p.resetAccounts(stateNonces)
 
+	latestNumber := p.store.Header().Number
+
+	// drop the private txs not included in time
+	p.dropExpiredPrivateTxs(latestNumber)
+
+	// drop the bundles targeting the sealed blocks
+	if pruned := p.bundles.prune(latestNumber); pruned != 0 {
+		p.logger.Debug("pruned bundles", "num", pruned)
+	}
+
 	if !p.getSealing() {
 		// only non-validator cleanup inactive accounts
 		p.updateAccountSkipsCounts(stateNonces)
@@ -593,6 +793,16 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrOversizedData
 	}
 
+	// Check if the transaction type is enabled by the forks
+	if !state.TxTypeEnabled(p.forks, tx.Type) {
+		return ErrTxTypeNotSupported
+	}
+
+	// Check if the priority fee is covered by the fee cap
+	if tx.GetGasFeeCap().Cmp(tx.GetGasTipCap()) < 0 {
+		return ErrTipAboveFeeCap
+	}
+
 	// Check if the transaction has a strictly positive value
 	if tx.Value.Sign() < 0 {
 		return ErrNegativeValue
@@ -623,6 +833,11 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 		return ErrSmartContractRestricted
 	}
 
+	// Check the size of the initcode of the contract creation (EIP-3860)
+	if p.forks.Shanghai && tx.IsContractCreation() && len(tx.Input) > runtime.MaxInitCodeSize {
+		return ErrInitCodeTooLarge
+	}
+
 	// Reject underpriced transactions
 	if tx.IsUnderpriced(p.priceLimit) {
 		return ErrUnderpriced
@@ -647,7 +862,7 @@ func (p *TxPool) validateTx(tx *types.Transaction) error {
 	}
 
 	// Make sure the transaction has more gas than the basic transaction fee
-	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul)
+	intrinsicGas, err := state.TransactionGasCost(tx, p.forks.Homestead, p.forks.Istanbul, p.forks.Shanghai)
 	if err != nil {
 		return err
 	}
@@ -726,12 +941,34 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 		}
 	}
 
-	// check for overflow
-	if p.gauge.read()+slotsRequired(tx) > p.gauge.max {
-		return ErrTxPoolOverflow
+	tx.ComputeHash()
+
+	// the known txs are rejected before making room for them
+	if _, ok := p.index.get(tx.Hash); ok {
+		return ErrAlreadyKnown
 	}
 
-	tx.ComputeHash()
+	// check the replacement of the tx with the same nonce early,
+	// so that the underpriced ones are rejected to the sender
+	var replaced *types.Transaction
+
+	if account := p.accounts.get(tx.From); account != nil {
+		replaced = account.getTxWithNonce(tx.Nonce)
+
+		if replaced != nil && replaced.Hash != tx.Hash && !isReplacement(replaced, tx, p.priceBump) {
+			return ErrReplacementUnderpriced
+		}
+	}
+
+	// check for overflow, making room for the tx by evicting cheaper ones
+	// if the pool is full, unless it replaces a pooled tx freeing its slots
+	if replaced != nil {
+		if p.gauge.read()+slotsRequired(tx) > p.gauge.max+slotsRequired(replaced) {
+			return ErrTxPoolOverflow
+		}
+	} else if p.gauge.read()+slotsRequired(tx) > p.gauge.max && !p.evict(tx) {
+		return ErrTxPoolOverflow
+	}
 
 	// add to index
 	if ok := p.index.add(tx); !ok {
@@ -741,6 +978,25 @@ func (p *TxPool) addTx(origin txOrigin, tx *types.Transaction) error {
 	// initialize account for this address once
 	p.createAccountOnce(tx.From)
 
+	// the local txs are protected from eviction,
+	// and journaled to survive restarts,
+	// unless private as they expire anyway
+	if origin == local || origin == private {
+		p.index.markLocal(tx.Hash)
+
+		// the private txs are tracked before being enqueued,
+		// so that they are never exposed as pending txs
+		if origin == private {
+			p.private.add(tx.Hash, p.privateTxExpiry())
+		}
+
+		if origin == local && p.journal != nil {
+			if err := p.journal.insert(tx); err != nil && !errors.Is(err, errNoActiveJournal) {
+				p.logger.Error("failed to journal the local tx", "hash", tx.Hash, "err", err)
+			}
+		}
+	}
+
 	// send request [BLOCKING]
 	p.enqueueReqCh <- enqueueRequest{tx: tx}
 	p.eventManager.signalEvent(proto.EventType_ADDED, tx.Hash)
@@ -760,10 +1016,12 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 	account := p.accounts.get(addr)
 
 	// enqueue tx
-	if err := account.enqueue(tx); err != nil {
+	replaced, promoted, err := account.enqueue(tx, p.priceBump)
+	if err != nil {
 		p.logger.Error("enqueue request", "err", err)
 
 		p.index.remove(tx)
+		p.private.remove(tx)
 
 		return
 	}
@@ -772,6 +1030,22 @@ func (p *TxPool) handleEnqueueRequest(req enqueueRequest) {
 
 	p.gauge.increase(slotsRequired(tx))
 
+	if replaced != nil {
+		p.logger.Debug("replaced tx", "old", replaced.Hash.String(), "new", tx.Hash.String())
+
+		p.index.remove(replaced)
+		p.gauge.decrease(slotsRequired(replaced))
+
+		p.eventManager.signalEvent(proto.EventType_REPLACED, replaced.Hash)
+	}
+
+	if promoted {
+		// the tx took the place of a promoted one
+		p.eventManager.signalEvent(proto.EventType_PROMOTED, tx.Hash)
+
+		return
+	}
+
 	p.eventManager.signalEvent(proto.EventType_ENQUEUED, tx.Hash)
 
 	if tx.Nonce > account.getNonce() {
@@ -959,6 +1233,36 @@ func (p *TxPool) Length() uint64 {
 	return p.accounts.promoted()
 }
 
+// SubscribeTxEvents registers a new listener for the given types of events,
+// returning the events channel and the function cancelling the subscription
+func (p *TxPool) SubscribeTxEvents(eventTypes ...proto.EventType) (<-chan *proto.TxPoolEvent, func()) {
+	subscription := p.eventManager.subscribe(eventTypes)
+
+	return subscription.subscriptionChannel, func() {
+		p.eventManager.cancelSubscription(subscription.subscriptionID)
+	}
+}
+
+// isReplacement checks if the transaction is priced at least priceBump percent
+// higher than the old one, for both its fee cap and tip cap
+func isReplacement(old, tx *types.Transaction, priceBump uint64) bool {
+	bumped := func(price *big.Int) *big.Int {
+		threshold := new(big.Int).Mul(price, new(big.Int).SetUint64(100+priceBump))
+
+		return threshold.Div(threshold, big.NewInt(100))
+	}
+
+	oldFeeCap, oldTipCap := old.GetGasFeeCap(), old.GetGasTipCap()
+	feeCap, tipCap := tx.GetGasFeeCap(), tx.GetGasTipCap()
+
+	// the price must increase, even if the bump rounds down to nothing
+	if feeCap.Cmp(oldFeeCap) <= 0 || tipCap.Cmp(oldTipCap) <= 0 {
+		return false
+	}
+
+	return feeCap.Cmp(bumped(oldFeeCap)) >= 0 && tipCap.Cmp(bumped(oldTipCap)) >= 0
+}
+
 // toHash returns the hash(es) of given transaction(s)
 func toHash(txs ...*types.Transaction) (hashes []types.Hash) {
 	for _, tx := range txs {
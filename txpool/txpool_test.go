@@ -862,7 +862,7 @@ func TestPromoteHandler(t *testing.T) {
 	})
 
 	t.Run(
-		"promote handler discards cheaper tx",
+		"promote handler promotes the replacement of cheaper tx",
 		func(t *testing.T) {
 			t.Parallel()
 
@@ -928,16 +928,19 @@ func TestPromoteHandler(t *testing.T) {
 			promReq1 := handleEnqueueRequest(enqTx1)
 			promReq2 := handleEnqueueRequest(enqTx2)
 
+			// the second Tx replaces the first Tx
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).getNonce())
-			assert.Equal(t, uint64(2), pool.accounts.get(addr1).enqueued.length())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
 			assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+			assertTxExists(t, tx1, false)
+			assertTxExists(t, tx2, true)
 			assert.Equal(
 				t,
-				slotsRequired(tx1)+slotsRequired(tx2),
+				slotsRequired(tx2),
 				pool.gauge.read(),
 			)
 
-			// promote the second Tx and remove the first Tx
+			// promote the second Tx
 			pool.handlePromoteRequest(promReq1)
 
 			assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
@@ -1399,7 +1402,7 @@ func TestPop(t *testing.T) {
 	assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
 
 	// pop the tx
-	pool.Prepare()
+	pool.Prepare(0)
 	tx := pool.Peek()
 	pool.Pop(tx)
 
@@ -1427,7 +1430,7 @@ func TestDrop(t *testing.T) {
 	assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
 
 	// pop the tx
-	pool.Prepare()
+	pool.Prepare(0)
 	tx := pool.Peek()
 	pool.Drop(tx)
 
@@ -1460,7 +1463,7 @@ func TestDemote(t *testing.T) {
 		assert.Equal(t, uint64(0), pool.accounts.get(addr1).Demotions())
 
 		// call demote
-		pool.Prepare()
+		pool.Prepare(0)
 		tx := pool.Peek()
 		pool.Demote(tx)
 
@@ -1496,7 +1499,7 @@ func TestDemote(t *testing.T) {
 		pool.accounts.get(addr1).demotions = maxAccountDemotions
 
 		// call demote
-		pool.Prepare()
+		pool.Prepare(0)
 		tx := pool.Peek()
 		pool.Demote(tx)
 
@@ -1510,6 +1513,204 @@ func TestDemote(t *testing.T) {
 	})
 }
 
+func TestReplaceTx(t *testing.T) {
+	t.Parallel()
+
+	// returns a new tx with the given gas price
+	newPricedTx := func(nonce, gasPrice uint64) *types.Transaction {
+		tx := newTx(addr1, nonce, 1)
+		tx.GasPrice = new(big.Int).SetUint64(gasPrice)
+
+		return tx
+	}
+
+	setupPool := func(t *testing.T) *TxPool {
+		t.Helper()
+
+		pool, err := newTestPool()
+		assert.NoError(t, err)
+		pool.SetSigner(&mockSigner{})
+
+		pool.priceBump = 10
+
+		return pool
+	}
+
+	t.Run(
+		"reject underpriced replacement",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t)
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, newPricedTx(5, 100)))
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			// same price, and price below the bump
+			assert.ErrorIs(t, pool.addTx(local, newPricedTx(5, 100)), ErrReplacementUnderpriced)
+			assert.ErrorIs(t, pool.addTx(local, newPricedTx(5, 109)), ErrReplacementUnderpriced)
+
+			assert.Equal(t, uint64(1), pool.gauge.read())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+		},
+	)
+
+	t.Run(
+		"replace enqueued tx",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t)
+
+			replacedSubscription := pool.eventManager.subscribe(
+				[]proto.EventType{
+					proto.EventType_REPLACED,
+				})
+			defer pool.eventManager.cancelSubscription(replacedSubscription.subscriptionID)
+
+			oldTx, replacementTx := newPricedTx(5, 100), newPricedTx(5, 110)
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, oldTx))
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, replacementTx))
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			assert.Equal(t, uint64(1), pool.gauge.read())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).enqueued.length())
+			assert.Equal(t, replacementTx, pool.accounts.get(addr1).enqueued.peek())
+
+			_, found := pool.index.get(oldTx.Hash)
+			assert.False(t, found)
+
+			_, found = pool.index.get(replacementTx.Hash)
+			assert.True(t, found)
+
+			ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancelFn()
+
+			events := waitForEvents(ctx, replacedSubscription, 1)
+			assert.Len(t, events, 1)
+			assert.Equal(t, oldTx.Hash.String(), events[0].TxHash)
+		},
+	)
+
+	t.Run(
+		"replace promoted tx",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t)
+
+			oldTx, replacementTx := newPricedTx(0, 100), newPricedTx(0, 200)
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, oldTx))
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+
+			// the replacement of a promoted tx doesn't signal a promotion
+			go func() {
+				assert.NoError(t, pool.addTx(local, replacementTx))
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			assert.Equal(t, uint64(1), pool.gauge.read())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).getNonce())
+			assert.Equal(t, uint64(0), pool.accounts.get(addr1).enqueued.length())
+			assert.Equal(t, uint64(1), pool.accounts.get(addr1).promoted.length())
+
+			pool.Prepare(0)
+			assert.Equal(t, replacementTx, pool.Peek())
+		},
+	)
+
+	t.Run(
+		"pop tx replaced after being peeked",
+		func(t *testing.T) {
+			t.Parallel()
+
+			pool := setupPool(t)
+
+			oldTx, replacementTx := newPricedTx(0, 100), newPricedTx(0, 200)
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, oldTx))
+			}()
+			go pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+			pool.handlePromoteRequest(<-pool.promoteReqCh)
+
+			pool.Prepare(0)
+			tx := pool.Peek()
+
+			go func() {
+				assert.NoError(t, pool.addTx(local, replacementTx))
+			}()
+			pool.handleEnqueueRequest(<-pool.enqueueReqCh)
+
+			pool.Pop(tx)
+
+			assert.Equal(t, uint64(0), pool.gauge.read())
+			assert.Equal(t, uint64(0), pool.accounts.get(addr1).promoted.length())
+
+			_, found := pool.index.get(replacementTx.Hash)
+			assert.False(t, found)
+		},
+	)
+}
+
+func TestIsReplacement(t *testing.T) {
+	t.Parallel()
+
+	legacyTx := func(gasPrice int64) *types.Transaction {
+		return &types.Transaction{Type: types.LegacyTx, GasPrice: big.NewInt(gasPrice)}
+	}
+
+	dynamicFeeTx := func(feeCap, tipCap int64) *types.Transaction {
+		return &types.Transaction{
+			Type:      types.DynamicFeeTx,
+			GasFeeCap: big.NewInt(feeCap),
+			GasTipCap: big.NewInt(tipCap),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		old, tx     *types.Transaction
+		priceBump   uint64
+		replacement bool
+	}{
+		{"legacy bumped", legacyTx(100), legacyTx(110), 10, true},
+		{"legacy not bumped enough", legacyTx(100), legacyTx(109), 10, false},
+		{"legacy same price", legacyTx(1), legacyTx(1), 10, false},
+		{"legacy bump rounded down", legacyTx(1), legacyTx(2), 10, true},
+		{"legacy no bump", legacyTx(100), legacyTx(101), 0, true},
+		{"dynamic fee bumped", dynamicFeeTx(100, 10), dynamicFeeTx(110, 11), 10, true},
+		{"dynamic fee tip not bumped", dynamicFeeTx(100, 10), dynamicFeeTx(200, 10), 10, false},
+		{"dynamic fee cap not bumped", dynamicFeeTx(100, 10), dynamicFeeTx(105, 20), 10, false},
+		{"dynamic fee replacing legacy", legacyTx(100), dynamicFeeTx(110, 110), 10, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.replacement, isReplacement(tt.old, tt.tx, tt.priceBump))
+		})
+	}
+}
+
 func Test_updateAccountSkipsCounts(t *testing.T) {
 	t.Parallel()
 
@@ -2241,6 +2442,60 @@ func TestExecutablesOrder(t *testing.T) {
 	}
 }
 
+func TestExecutablesOrder_EffectiveTip(t *testing.T) {
+	t.Parallel()
+
+	legacyTx := newTx(addr1, 0, 1)
+	legacyTx.GasPrice.SetUint64(20)
+
+	highTipTx := newTx(addr2, 0, 1)
+	highTipTx.Type = types.DynamicFeeTx
+	highTipTx.GasPrice = nil
+	highTipTx.GasTipCap = big.NewInt(15)
+	highTipTx.GasFeeCap = big.NewInt(30)
+
+	cappedTx := newTx(addr3, 0, 1)
+	cappedTx.Type = types.DynamicFeeTx
+	cappedTx.GasPrice = nil
+	cappedTx.GasTipCap = big.NewInt(16)
+	cappedTx.GasFeeCap = big.NewInt(12)
+
+	testCases := []struct {
+		name          string
+		baseFee       uint64
+		expectedOrder []*types.Transaction
+	}{
+		{
+			name:          "ordered by the effective gas price without a base fee",
+			baseFee:       0,
+			expectedOrder: []*types.Transaction{legacyTx, highTipTx, cappedTx},
+		},
+		{
+			name:          "ordered by the tip paid on top of the base fee",
+			baseFee:       10,
+			expectedOrder: []*types.Transaction{highTipTx, legacyTx, cappedTx},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			queue := newPricedQueue()
+			queue.queue.baseFee = test.baseFee
+
+			for _, tx := range []*types.Transaction{cappedTx, legacyTx, highTipTx} {
+				queue.push(tx)
+			}
+
+			for _, expected := range test.expectedOrder {
+				assert.Same(t, expected, queue.pop())
+			}
+		})
+	}
+}
+
 type status int
 
 // Status of a transaction resulted
@@ -2419,7 +2674,7 @@ func TestRecovery(t *testing.T) {
 			assert.Len(t, waitForEvents(ctx, promoteSubscription, totalTx), totalTx)
 
 			func() {
-				pool.Prepare()
+				pool.Prepare(0)
 				for {
 					tx := pool.Peek()
 					if tx == nil {
@@ -29,6 +29,7 @@ type Header struct {
 	ExtraData    []byte `json:"extraData"`
 	MixHash      Hash   `json:"mixHash"`
 	Nonce        Nonce  `json:"nonce"`
+	BaseFee      uint64 `json:"baseFeePerGas"`
 	Hash         Hash   `json:"hash"`
 }
 
@@ -49,6 +50,7 @@ type headerJSON struct {
 	ExtraData    string `json:"extraData"`
 	MixHash      Hash   `json:"mixHash"`
 	Nonce        Nonce  `json:"nonce"`
+	BaseFee      string `json:"baseFeePerGas,omitempty"`
 	Hash         Hash   `json:"hash"`
 }
 
@@ -74,6 +76,10 @@ func (h *Header) MarshalJSON() ([]byte, error) {
 	header.Timestamp = hex.EncodeUint64(h.Timestamp)
 	header.ExtraData = hex.EncodeToHex(h.ExtraData)
 
+	if h.BaseFee != 0 {
+		header.BaseFee = hex.EncodeUint64(h.BaseFee)
+	}
+
 	return json.Marshal(&header)
 }
 
@@ -123,6 +129,12 @@ func (h *Header) UnmarshalJSON(input []byte) error {
 		return err
 	}
 
+	if header.BaseFee != "" {
+		if h.BaseFee, err = hex.DecodeUint64(header.BaseFee); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -204,6 +216,7 @@ func (h *Header) Copy() *Header {
 		GasLimit:     h.GasLimit,
 		GasUsed:      h.GasUsed,
 		Timestamp:    h.Timestamp,
+		BaseFee:      h.BaseFee,
 	}
 
 	newHeader.Miner = make([]byte, len(h.Miner))
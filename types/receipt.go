@@ -22,6 +22,7 @@ type Receipts []*Receipt
 
 type Receipt struct {
 	// consensus fields
+	TransactionType   TxType
 	Root              Hash
 	CumulativeGasUsed uint64
 	LogsBloom         Bloom
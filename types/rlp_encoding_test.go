@@ -129,3 +129,125 @@ func TestRLPUnmarshal_Header_ComputeHash(t *testing.T) {
 	assert.NoError(t, h2.UnmarshalRLP(data))
 	assert.Equal(t, h.Hash, h2.Hash)
 }
+
+func TestRLPMarshall_And_Unmarshall_AccessListTransaction(t *testing.T) {
+	t.Parallel()
+
+	addrTo := StringToAddress("11")
+	txn := &Transaction{
+		Type:     AccessListTx,
+		ChainID:  big.NewInt(100),
+		Nonce:    1,
+		GasPrice: big.NewInt(11),
+		Gas:      11,
+		To:       &addrTo,
+		Value:    big.NewInt(1),
+		Input:    []byte{1, 2},
+		AccessList: TxAccessList{
+			{Address: addrTo, StorageKeys: []Hash{StringToHash("1"), StringToHash("2")}},
+			{Address: StringToAddress("12"), StorageKeys: []Hash{}},
+		},
+		V: big.NewInt(1),
+		S: big.NewInt(26),
+		R: big.NewInt(27),
+	}
+
+	// the envelope is the type byte followed by the payload
+	envelope := txn.MarshalRLP()
+	assert.Equal(t, byte(AccessListTx), envelope[0])
+
+	unmarshalledTxn := new(Transaction)
+	assert.NoError(t, unmarshalledTxn.UnmarshalRLP(envelope))
+
+	txn.ComputeHash()
+	assert.Equal(t, txn, unmarshalledTxn)
+
+	// the typed transactions are byte strings in the block body
+	block := &Block{
+		Header:       &Header{},
+		Transactions: []*Transaction{txn},
+	}
+
+	unmarshalledBlock := new(Block)
+	assert.NoError(t, unmarshalledBlock.UnmarshalRLP(block.MarshalRLP()))
+	assert.Equal(t, txn, unmarshalledBlock.Transactions[0])
+}
+
+func TestRLPMarshall_And_Unmarshall_DynamicFeeTransaction(t *testing.T) {
+	t.Parallel()
+
+	addrTo := StringToAddress("11")
+	txn := &Transaction{
+		Type:      DynamicFeeTx,
+		ChainID:   big.NewInt(100),
+		Nonce:     1,
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(20),
+		Gas:       11,
+		To:        &addrTo,
+		Value:     big.NewInt(1),
+		Input:     []byte{1, 2},
+		AccessList: TxAccessList{
+			{Address: addrTo, StorageKeys: []Hash{StringToHash("1")}},
+		},
+		V: big.NewInt(1),
+		S: big.NewInt(26),
+		R: big.NewInt(27),
+	}
+
+	envelope := txn.MarshalRLP()
+	assert.Equal(t, byte(DynamicFeeTx), envelope[0])
+
+	unmarshalledTxn := new(Transaction)
+	assert.NoError(t, unmarshalledTxn.UnmarshalRLP(envelope))
+
+	txn.ComputeHash()
+	assert.Equal(t, txn, unmarshalledTxn)
+	assert.Nil(t, unmarshalledTxn.GasPrice)
+}
+
+func TestRLPMarshall_And_Unmarshall_HeaderBaseFee(t *testing.T) {
+	t.Parallel()
+
+	header := &Header{Number: 1, GasLimit: 100, BaseFee: 7}
+	header.ComputeHash()
+
+	unmarshalledHeader := new(Header)
+	assert.NoError(t, unmarshalledHeader.UnmarshalRLP(header.MarshalRLP()))
+	assert.Equal(t, header, unmarshalledHeader)
+
+	// the base fee is part of the header hash
+	withoutBaseFee := header.Copy()
+	withoutBaseFee.BaseFee = 0
+	withoutBaseFee.ComputeHash()
+	assert.NotEqual(t, header.Hash, withoutBaseFee.Hash)
+}
+
+func TestRLPUnmarshal_UnsupportedTransactionType(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorIs(t, new(Transaction).UnmarshalRLP([]byte{0x03, 0xc0}), ErrTxTypeNotSupported)
+}
+
+func TestRLPMarshall_And_Unmarshall_TypedReceipt(t *testing.T) {
+	t.Parallel()
+
+	receipt := &Receipt{
+		TransactionType:   AccessListTx,
+		CumulativeGasUsed: 10,
+		Logs: []*Log{
+			{Address: StringToAddress("11"), Topics: []Hash{StringToHash("10")}, Data: []byte{1}},
+		},
+	}
+	receipt.SetStatus(ReceiptSuccess)
+
+	envelope := receipt.MarshalRLP()
+	assert.Equal(t, byte(AccessListTx), envelope[0])
+
+	unmarshalledReceipt := new(Receipt)
+	assert.NoError(t, unmarshalledReceipt.UnmarshalRLP(envelope))
+	assert.Equal(t, receipt.TransactionType, unmarshalledReceipt.TransactionType)
+	assert.Equal(t, receipt.CumulativeGasUsed, unmarshalledReceipt.CumulativeGasUsed)
+	assert.Equal(t, receipt.Status, unmarshalledReceipt.Status)
+	assert.Equal(t, receipt.Logs, unmarshalledReceipt.Logs)
+}
@@ -83,6 +83,11 @@ func (h *Header) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 	vv.Set(arena.NewBytes(h.MixHash.Bytes()))
 	vv.Set(arena.NewCopyBytes(h.Nonce[:]))
 
+	// the base fee is only part of the headers from the London fork (EIP-1559)
+	if h.BaseFee != 0 {
+		vv.Set(arena.NewUint(h.BaseFee))
+	}
+
 	return vv
 }
 
@@ -103,12 +108,29 @@ func (r *Receipt) MarshalRLP() []byte {
 	return r.MarshalRLPTo(nil)
 }
 
+// MarshalRLPTo marshals the receipt to its canonical encoding, the RLP list
+// of a legacy receipt or the envelope of the receipt of a typed transaction (EIP-2718)
 func (r *Receipt) MarshalRLPTo(dst []byte) []byte {
-	return MarshalRLPTo(r.MarshalRLPWith, dst)
+	if r.TransactionType == LegacyTx {
+		return MarshalRLPTo(r.MarshalRLPWith, dst)
+	}
+
+	dst = append(dst, byte(r.TransactionType))
+
+	return MarshalRLPTo(r.marshalPayloadWith, dst)
 }
 
-// MarshalRLPWith marshals a receipt with a specific fastrlp.Arena
+// MarshalRLPWith marshals a receipt with a specific fastrlp.Arena.
+// The receipts of typed transactions are marshaled as a byte string of their envelope
 func (r *Receipt) MarshalRLPWith(a *fastrlp.Arena) *fastrlp.Value {
+	if r.TransactionType != LegacyTx {
+		return a.NewCopyBytes(r.MarshalRLPTo(nil))
+	}
+
+	return r.marshalPayloadWith(a)
+}
+
+func (r *Receipt) marshalPayloadWith(a *fastrlp.Arena) *fastrlp.Value {
 	vv := a.NewArray()
 
 	if r.Status != nil {
@@ -159,12 +181,25 @@ func (t *Transaction) MarshalRLP() []byte {
 	return t.MarshalRLPTo(nil)
 }
 
+// MarshalRLPTo marshals the transaction to its canonical encoding, the RLP list
+// of a legacy transaction or the envelope of a typed transaction (EIP-2718)
 func (t *Transaction) MarshalRLPTo(dst []byte) []byte {
-	return MarshalRLPTo(t.MarshalRLPWith, dst)
+	if !t.IsTyped() {
+		return MarshalRLPTo(t.MarshalRLPWith, dst)
+	}
+
+	dst = append(dst, byte(t.Type))
+
+	return MarshalRLPTo(t.marshalPayloadWith, dst)
 }
 
-// MarshalRLPWith marshals the transaction to RLP with a specific fastrlp.Arena
+// MarshalRLPWith marshals the transaction to RLP with a specific fastrlp.Arena.
+// Typed transactions are marshaled as a byte string of their envelope, as in the block bodies
 func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	if t.IsTyped() {
+		return arena.NewCopyBytes(t.MarshalRLPTo(nil))
+	}
+
 	vv := arena.NewArray()
 
 	vv.Set(arena.NewUint(t.Nonce))
@@ -188,3 +223,59 @@ func (t *Transaction) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
 
 	return vv
 }
+
+// marshalPayloadWith marshals the payload of the typed transaction envelope,
+// the access list transaction (EIP-2930) or the dynamic fee transaction (EIP-1559)
+func (t *Transaction) marshalPayloadWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	vv.Set(arena.NewBigInt(t.ChainID))
+	vv.Set(arena.NewUint(t.Nonce))
+
+	if t.Type == DynamicFeeTx {
+		vv.Set(arena.NewBigInt(t.GasTipCap))
+		vv.Set(arena.NewBigInt(t.GasFeeCap))
+	} else {
+		vv.Set(arena.NewBigInt(t.GasPrice))
+	}
+
+	vv.Set(arena.NewUint(t.Gas))
+
+	// Address may be empty
+	if t.To != nil {
+		vv.Set(arena.NewBytes((*t.To).Bytes()))
+	} else {
+		vv.Set(arena.NewNull())
+	}
+
+	vv.Set(arena.NewBigInt(t.Value))
+	vv.Set(arena.NewCopyBytes(t.Input))
+	vv.Set(t.AccessList.MarshalRLPWith(arena))
+
+	// signature values
+	vv.Set(arena.NewBigInt(t.V))
+	vv.Set(arena.NewBigInt(t.R))
+	vv.Set(arena.NewBigInt(t.S))
+
+	return vv
+}
+
+// MarshalRLPWith marshals the access list to RLP with a specific fastrlp.Arena
+func (al TxAccessList) MarshalRLPWith(arena *fastrlp.Arena) *fastrlp.Value {
+	vv := arena.NewArray()
+
+	for _, tuple := range al {
+		v := arena.NewArray()
+		v.Set(arena.NewCopyBytes(tuple.Address.Bytes()))
+
+		keys := arena.NewArray()
+		for _, key := range tuple.StorageKeys {
+			keys.Set(arena.NewCopyBytes(key.Bytes()))
+		}
+
+		v.Set(keys)
+		vv.Set(v)
+	}
+
+	return vv
+}
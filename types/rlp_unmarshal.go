@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/Gabulhas/polygon-external-consensus/helper/keccak"
 	"github.com/umbracle/fastrlp"
 )
 
@@ -165,6 +166,14 @@ func (h *Header) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 
 	h.SetNonce(nonce)
 
+	// baseFee
+	h.BaseFee = 0
+	if len(elems) > 15 {
+		if h.BaseFee, err = elems[15].GetUint64(); err != nil {
+			return err
+		}
+	}
+
 	// compute the hash after the decoding
 	h.ComputeHash()
 
@@ -194,11 +203,43 @@ func (r *Receipts) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 }
 
 func (r *Receipt) UnmarshalRLP(input []byte) error {
+	if isTypedEnvelope(input) {
+		return r.unmarshalEnvelope(input)
+	}
+
 	return UnmarshalRlp(r.UnmarshalRLPFrom, input)
 }
 
 // UnmarshalRLP unmarshals a Receipt in RLP format
 func (r *Receipt) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	if v.Type() == fastrlp.TypeBytes {
+		// the receipts of typed transactions are wrapped in a byte string
+		envelope, err := v.Bytes()
+		if err != nil {
+			return err
+		}
+
+		return r.unmarshalEnvelope(envelope)
+	}
+
+	r.TransactionType = LegacyTx
+
+	return r.unmarshalPayloadFrom(p, v)
+}
+
+// unmarshalEnvelope unmarshals the receipt of a typed transaction (EIP-2718)
+func (r *Receipt) unmarshalEnvelope(input []byte) error {
+	txType, err := envelopeType(input)
+	if err != nil {
+		return err
+	}
+
+	r.TransactionType = txType
+
+	return UnmarshalRlp(r.unmarshalPayloadFrom, input[1:])
+}
+
+func (r *Receipt) unmarshalPayloadFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 	elems, err := v.GetElems()
 	if err != nil {
 		return err
@@ -288,12 +329,48 @@ func (l *Log) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
 	return nil
 }
 
+// isTypedEnvelope checks if the input is the envelope of a typed transaction,
+// or of its receipt (EIP-2718). The envelope starts with the type, the RLP lists with 0xc0 or above
+func isTypedEnvelope(input []byte) bool {
+	return len(input) > 0 && input[0] <= 0x7f
+}
+
+// envelopeType returns the transaction type of the envelope, if supported
+func envelopeType(input []byte) (TxType, error) {
+	if len(input) == 0 {
+		return LegacyTx, fmt.Errorf("%w: empty envelope", ErrTxTypeNotSupported)
+	}
+
+	switch txType := TxType(input[0]); txType {
+	case AccessListTx, DynamicFeeTx:
+		return txType, nil
+	default:
+		return LegacyTx, fmt.Errorf("%w: %d", ErrTxTypeNotSupported, txType)
+	}
+}
+
+// UnmarshalRLP unmarshals the canonical encoding of a transaction,
+// either the RLP list of a legacy transaction or the envelope of a typed transaction
 func (t *Transaction) UnmarshalRLP(input []byte) error {
+	if isTypedEnvelope(input) {
+		return t.unmarshalEnvelope(input)
+	}
+
 	return UnmarshalRlp(t.UnmarshalRLPFrom, input)
 }
 
 // UnmarshalRLPFrom unmarshals a Transaction in RLP format
 func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	if v.Type() == fastrlp.TypeBytes {
+		// typed transactions are wrapped in a byte string
+		envelope, err := v.Bytes()
+		if err != nil {
+			return err
+		}
+
+		return t.unmarshalEnvelope(envelope)
+	}
+
 	elems, err := v.GetElems()
 	if err != nil {
 		return err
@@ -305,6 +382,12 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 
 	p.Hash(t.Hash[:0], v)
 
+	t.Type = LegacyTx
+	t.ChainID = nil
+	t.GasTipCap = nil
+	t.GasFeeCap = nil
+	t.AccessList = nil
+
 	// nonce
 	if t.Nonce, err = elems[0].GetUint64(); err != nil {
 		return err
@@ -337,22 +420,168 @@ func (t *Transaction) UnmarshalRLPFrom(p *fastrlp.Parser, v *fastrlp.Value) erro
 		return err
 	}
 
+	return t.unmarshalSignatureFrom(elems[6:9])
+}
+
+// unmarshalEnvelope unmarshals a typed transaction (EIP-2718), the hash covers the whole envelope
+func (t *Transaction) unmarshalEnvelope(input []byte) error {
+	txType, err := envelopeType(input)
+	if err != nil {
+		return err
+	}
+
+	t.Type = txType
+
+	if err := UnmarshalRlp(t.unmarshalPayloadFrom, input[1:]); err != nil {
+		return err
+	}
+
+	keccak.Keccak256(t.Hash[:0], input)
+
+	return nil
+}
+
+// unmarshalPayloadFrom unmarshals the payload of the typed transaction envelope,
+// the access list transaction (EIP-2930) or the dynamic fee transaction (EIP-1559)
+func (t *Transaction) unmarshalPayloadFrom(p *fastrlp.Parser, v *fastrlp.Value) error {
+	elems, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	// the dynamic fee transactions replace the gas price with the priority fee and the fee cap
+	expected := 11
+	if t.Type == DynamicFeeTx {
+		expected = 12
+	}
+
+	if len(elems) < expected {
+		return fmt.Errorf(
+			"incorrect number of elements to decode transaction, expected %d but found %d",
+			expected,
+			len(elems),
+		)
+	}
+
+	// chainID
+	t.ChainID = new(big.Int)
+	if err := elems[0].GetBigInt(t.ChainID); err != nil {
+		return err
+	}
+	// nonce
+	if t.Nonce, err = elems[1].GetUint64(); err != nil {
+		return err
+	}
+
+	if t.Type == DynamicFeeTx {
+		t.GasPrice = nil
+		// gasTipCap
+		t.GasTipCap = new(big.Int)
+		if err := elems[2].GetBigInt(t.GasTipCap); err != nil {
+			return err
+		}
+		// gasFeeCap
+		t.GasFeeCap = new(big.Int)
+		if err := elems[3].GetBigInt(t.GasFeeCap); err != nil {
+			return err
+		}
+
+		elems = elems[1:]
+	} else {
+		t.GasTipCap = nil
+		t.GasFeeCap = nil
+		// gasPrice
+		t.GasPrice = new(big.Int)
+		if err := elems[2].GetBigInt(t.GasPrice); err != nil {
+			return err
+		}
+	}
+	// gas
+	if t.Gas, err = elems[3].GetUint64(); err != nil {
+		return err
+	}
+	// to
+	if vv, _ := elems[4].Bytes(); len(vv) == 20 {
+		// address
+		addr := BytesToAddress(vv)
+		t.To = &addr
+	} else {
+		// reset To
+		t.To = nil
+	}
+	// value
+	t.Value = new(big.Int)
+	if err := elems[5].GetBigInt(t.Value); err != nil {
+		return err
+	}
+	// input
+	if t.Input, err = elems[6].GetBytes(t.Input[:0]); err != nil {
+		return err
+	}
+	// access list
+	if err := t.AccessList.unmarshalRLPFrom(p, elems[7]); err != nil {
+		return err
+	}
+
+	return t.unmarshalSignatureFrom(elems[8:11])
+}
+
+// unmarshalSignatureFrom unmarshals the V, R and S signature values
+func (t *Transaction) unmarshalSignatureFrom(elems []*fastrlp.Value) error {
 	// V
 	t.V = new(big.Int)
-	if err = elems[6].GetBigInt(t.V); err != nil {
+	if err := elems[0].GetBigInt(t.V); err != nil {
 		return err
 	}
-
 	// R
 	t.R = new(big.Int)
-	if err = elems[7].GetBigInt(t.R); err != nil {
+	if err := elems[1].GetBigInt(t.R); err != nil {
 		return err
 	}
 	// S
 	t.S = new(big.Int)
-	if err = elems[8].GetBigInt(t.S); err != nil {
+	if err := elems[2].GetBigInt(t.S); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (al *TxAccessList) unmarshalRLPFrom(_ *fastrlp.Parser, v *fastrlp.Value) error {
+	tuples, err := v.GetElems()
+	if err != nil {
+		return err
+	}
+
+	*al = make(TxAccessList, len(tuples))
+
+	for i, tuple := range tuples {
+		elems, err := tuple.GetElems()
+		if err != nil {
+			return err
+		}
+
+		if len(elems) < 2 {
+			return fmt.Errorf("incorrect number of elements to decode access tuple, expected 2 but found %d", len(elems))
+		}
+
+		if err := elems[0].GetAddr((*al)[i].Address[:]); err != nil {
+			return err
+		}
+
+		keys, err := elems[1].GetElems()
+		if err != nil {
+			return err
+		}
+
+		(*al)[i].StorageKeys = make([]Hash, len(keys))
+
+		for j, key := range keys {
+			if err := key.GetHash((*al)[i].StorageKeys[j][:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -1,24 +1,87 @@
 package types
 
 import (
+	"errors"
 	"math/big"
 	"sync/atomic"
 
 	"github.com/Gabulhas/polygon-external-consensus/helper/keccak"
 )
 
+var (
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+)
+
+// TxType is the type of the transaction envelope (EIP-2718)
+type TxType byte
+
+const (
+	// LegacyTx is the untyped transaction, encoded as a plain RLP list
+	LegacyTx TxType = 0x0
+
+	// AccessListTx is the transaction with an access list (EIP-2930)
+	AccessListTx TxType = 0x01
+
+	// DynamicFeeTx is the transaction with a fee cap and a priority fee (EIP-1559)
+	DynamicFeeTx TxType = 0x02
+)
+
+// AccessTuple is an account and the storage slots of the account accessed by a transaction
+type AccessTuple struct {
+	Address     Address `json:"address"`
+	StorageKeys []Hash  `json:"storageKeys"`
+}
+
+// TxAccessList is the list of the accounts and storage slots a transaction
+// accesses, warmed up before the execution (EIP-2930)
+type TxAccessList []AccessTuple
+
+// StorageKeys returns the number of storage slots in the access list
+func (al TxAccessList) StorageKeys() int {
+	keys := 0
+
+	for _, tuple := range al {
+		keys += len(tuple.StorageKeys)
+	}
+
+	return keys
+}
+
+// Copy returns a deep copy of the access list
+func (al TxAccessList) Copy() TxAccessList {
+	if al == nil {
+		return nil
+	}
+
+	res := make(TxAccessList, len(al))
+
+	for i, tuple := range al {
+		res[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append([]Hash{}, tuple.StorageKeys...),
+		}
+	}
+
+	return res
+}
+
 type Transaction struct {
-	Nonce    uint64
-	GasPrice *big.Int
-	Gas      uint64
-	To       *Address
-	Value    *big.Int
-	Input    []byte
-	V        *big.Int
-	R        *big.Int
-	S        *big.Int
-	Hash     Hash
-	From     Address
+	Type       TxType
+	ChainID    *big.Int
+	Nonce      uint64
+	GasPrice   *big.Int
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Gas        uint64
+	To         *Address
+	Value      *big.Int
+	Input      []byte
+	AccessList TxAccessList
+	V          *big.Int
+	R          *big.Int
+	S          *big.Int
+	Hash       Hash
+	From       Address
 
 	// Cache
 	size atomic.Value
@@ -29,8 +92,20 @@ func (t *Transaction) IsContractCreation() bool {
 	return t.To == nil
 }
 
-// ComputeHash computes the hash of the transaction
+// IsTyped checks if the transaction is wrapped in a typed envelope (EIP-2718)
+func (t *Transaction) IsTyped() bool {
+	return t.Type != LegacyTx
+}
+
+// ComputeHash computes the hash of the transaction.
+// The hash of the typed transactions covers their envelope
 func (t *Transaction) ComputeHash() *Transaction {
+	if t.IsTyped() {
+		keccak.Keccak256(t.Hash[:0], t.MarshalRLP())
+
+		return t
+	}
+
 	ar := marshalArenaPool.Get()
 	hash := keccak.DefaultKeccakPool.Get()
 
@@ -52,11 +127,25 @@ func (t *Transaction) Copy() *Transaction {
 		tt.GasPrice.Set(t.GasPrice)
 	}
 
+	if t.GasTipCap != nil {
+		tt.GasTipCap = new(big.Int).Set(t.GasTipCap)
+	}
+
+	if t.GasFeeCap != nil {
+		tt.GasFeeCap = new(big.Int).Set(t.GasFeeCap)
+	}
+
 	tt.Value = new(big.Int)
 	if t.Value != nil {
 		tt.Value.Set(t.Value)
 	}
 
+	if t.ChainID != nil {
+		tt.ChainID = new(big.Int).Set(t.ChainID)
+	}
+
+	tt.AccessList = t.AccessList.Copy()
+
 	if t.R != nil {
 		tt.R = new(big.Int)
 		tt.R = big.NewInt(0).SetBits(t.R.Bits())
@@ -73,9 +162,9 @@ func (t *Transaction) Copy() *Transaction {
 	return tt
 }
 
-// Cost returns gas * gasPrice + value
+// Cost returns gas * gasFeeCap + value, the most the transaction can cost
 func (t *Transaction) Cost() *big.Int {
-	total := new(big.Int).Mul(t.GasPrice, new(big.Int).SetUint64(t.Gas))
+	total := new(big.Int).Mul(t.GetGasFeeCap(), new(big.Int).SetUint64(t.Gas))
 	total.Add(total, t.Value)
 
 	return total
@@ -102,5 +191,46 @@ func (t *Transaction) ExceedsBlockGasLimit(blockGasLimit uint64) bool {
 }
 
 func (t *Transaction) IsUnderpriced(priceLimit uint64) bool {
-	return t.GasPrice.Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
+	return t.GetGasTipCap().Cmp(big.NewInt(0).SetUint64(priceLimit)) < 0
+}
+
+// GetGasFeeCap returns the most the transaction pays per gas,
+// the fee cap of the dynamic fee transactions or the gas price of the others
+func (t *Transaction) GetGasFeeCap() *big.Int {
+	if t.Type == DynamicFeeTx {
+		return t.GasFeeCap
+	}
+
+	return t.GasPrice
+}
+
+// GetGasTipCap returns the most the transaction pays per gas to the block creator,
+// the priority fee of the dynamic fee transactions or the gas price of the others
+func (t *Transaction) GetGasTipCap() *big.Int {
+	if t.Type == DynamicFeeTx {
+		return t.GasTipCap
+	}
+
+	return t.GasPrice
+}
+
+// EffectiveGasPrice returns the gas price paid by the transaction in a block with the given base fee.
+// The dynamic fee transactions pay the base fee and the priority fee, up to their fee cap (EIP-1559)
+func (t *Transaction) EffectiveGasPrice(baseFee uint64) *big.Int {
+	if t.Type != DynamicFeeTx {
+		return new(big.Int).Set(t.GasPrice)
+	}
+
+	price := new(big.Int).Add(t.GasTipCap, new(big.Int).SetUint64(baseFee))
+	if price.Cmp(t.GasFeeCap) > 0 {
+		price.Set(t.GasFeeCap)
+	}
+
+	return price
+}
+
+// EffectiveTip returns the price per gas paid to the block creator in a block with the given base fee,
+// negative if the transaction can't pay the base fee
+func (t *Transaction) EffectiveTip(baseFee uint64) *big.Int {
+	return new(big.Int).Sub(t.EffectiveGasPrice(baseFee), new(big.Int).SetUint64(baseFee))
 }
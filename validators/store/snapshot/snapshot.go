@@ -41,6 +41,7 @@ var (
 	ErrCandidateNotExistInSet       = errors.New("cannot remove a validator if they're not in the snapshot")
 	ErrAlreadyVoted                 = errors.New("already voted for this address")
 	ErrMultipleVotesBySameValidator = errors.New("more than one proposal per validator per address found")
+	ErrCandidateNotFound            = errors.New("candidate not found")
 )
 
 type SnapshotValidatorStore struct {
@@ -365,6 +366,22 @@ func (s *SnapshotValidatorStore) Propose(candidate validators.Validator, auth bo
 	)
 }
 
+// Discard removes the candidate with the given address from the candidates
+func (s *SnapshotValidatorStore) Discard(candidateAddr types.Address) error {
+	s.candidatesLock.Lock()
+	defer s.candidatesLock.Unlock()
+
+	for idx, c := range s.candidates {
+		if c.Validator.Addr() == candidateAddr {
+			s.candidates = append(s.candidates[:idx], s.candidates[idx+1:]...)
+
+			return nil
+		}
+	}
+
+	return ErrCandidateNotFound
+}
+
 // AddCandidate adds new candidate to candidate list
 // unsafe against concurrent access
 func (s *SnapshotValidatorStore) addCandidate(
@@ -1807,6 +1807,42 @@ func TestSnapshotValidatorStorePropose(t *testing.T) {
 	}
 }
 
+func TestSnapshotValidatorStoreDiscard(t *testing.T) {
+	t.Parallel()
+
+	snapshotStore := newTestSnapshotValidatorStore(
+		nil,
+		nil,
+		0,
+		nil,
+		[]*store.Candidate{
+			{
+				Validator: ecdsaValidator1,
+				Authorize: true,
+			},
+			{
+				Validator: ecdsaValidator2,
+				Authorize: false,
+			},
+		},
+		0,
+	)
+
+	assert.NoError(t, snapshotStore.Discard(ecdsaValidator1.Address))
+	assert.ErrorIs(t, snapshotStore.Discard(ecdsaValidator1.Address), ErrCandidateNotFound)
+
+	assert.Equal(
+		t,
+		[]*store.Candidate{
+			{
+				Validator: ecdsaValidator2,
+				Authorize: false,
+			},
+		},
+		snapshotStore.Candidates(),
+	)
+}
+
 func TestSnapshotValidatorStore_addCandidate(t *testing.T) {
 	t.Parallel()
 